@@ -145,26 +145,32 @@ var (
 	}
 )
 
-// Client queries for DNS records
+// Client queries for DNS records. Every lookup reports the DNSSEC status of
+// its answer.
 type Client interface {
-	LookupTXT(context.Context, string) (txts []string, err error)
-	LookupHost(context.Context, string) ([]net.IP, error)
-	LookupCAA(context.Context, string) ([]*dns.CAA, string, error)
+	LookupTXT(context.Context, string) (txts []string, status DNSSECStatus, err error)
+	LookupHost(context.Context, string) ([]net.IP, DNSSECStatus, error)
+	LookupCAA(context.Context, string) ([]*dns.CAA, string, DNSSECStatus, error)
 }
 
 // impl represents a client that talks to an external resolver
 type impl struct {
 	dnsClient                exchanger
+	transports               map[string]exchanger // by server address, see WithServers
+	readTimeout              time.Duration
 	servers                  ServerProvider
 	allowRestrictedAddresses bool
 	maxTries                 int
 	clk                      clock.Clock
 	log                      blog.Logger
+	// validator is set if answers are validated in-process, see WithDNSSEC.
+	validator *validator
 
 	queryTime         *prometheus.HistogramVec
 	totalLookupTime   *prometheus.HistogramVec
 	timeoutCounter    *prometheus.CounterVec
 	idMismatchCounter *prometheus.CounterVec
+	connections       *prometheus.CounterVec
 }
 
 var _ Client = &impl{}
@@ -228,10 +234,18 @@ func New(
 		},
 		[]string{"qtype", "resolver"},
 	)
-	stats.MustRegister(queryTime, totalLookupTime, timeoutCounter, idMismatchCounter)
+	connections := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "dns_connections",
+			Help: "Counter of connections used for DNS over TLS and DNS over HTTPS queries, by resolver, transport, and whether they were new or reused",
+		},
+		[]string{"resolver", "transport", "type"},
+	)
+	stats.MustRegister(queryTime, totalLookupTime, timeoutCounter, idMismatchCounter, connections)
 
 	return &impl{
 		dnsClient:                dnsClient,
+		readTimeout:              readTimeout,
 		servers:                  servers,
 		allowRestrictedAddresses: false,
 		maxTries:                 maxTries,
@@ -240,6 +254,7 @@ func New(
 		totalLookupTime:          totalLookupTime,
 		timeoutCounter:           timeoutCounter,
 		idMismatchCounter:        idMismatchCounter,
+		connections:              connections,
 		log:                      log,
 	}
 }
@@ -261,8 +276,8 @@ func NewTest(
 
 // exchangeOne performs a single DNS exchange with a randomly chosen server
 // out of the server list, returning the response, time, and error (if any).
-// We assume that the upstream resolver requests and validates DNSSEC records
-// itself.
+// Unless DNSSEC validation is enabled we assume that the upstream resolver
+// requests and validates DNSSEC records itself.
 func (dnsClient *impl) exchangeOne(ctx context.Context, hostname string, qtype uint16) (resp *dns.Msg, err error) {
 	m := new(dns.Msg)
 	// Set question type
@@ -278,6 +293,13 @@ func (dnsClient *impl) exchangeOne(ctx context.Context, hostname string, qtype u
 	// This happens sometimes when there are a very large number of CAA records
 	// present.
 	m.SetEdns0(4096, false)
+	if dnsClient.validator != nil {
+		// Ask for the signatures needed to validate the answer ourselves, and
+		// for the resolver to return answers which fail its own validation so
+		// that we can tell them apart from other failures.
+		m.SetEdns0(4096, true)
+		m.CheckingDisabled = true
+	}
 
 	servers, err := dnsClient.servers.Addrs()
 	if err != nil {
@@ -287,7 +309,6 @@ func (dnsClient *impl) exchangeOne(ctx context.Context, hostname string, qtype u
 	chosenServer := servers[chosenServerIndex]
 
 	start := dnsClient.clk.Now()
-	client := dnsClient.dnsClient
 	qtypeStr := dns.TypeToString[qtype]
 	tries := 1
 	defer func() {
@@ -305,6 +326,9 @@ func (dnsClient *impl) exchangeOne(ctx context.Context, hostname string, qtype u
 		}).Observe(dnsClient.clk.Since(start).Seconds())
 	}()
 	for {
+		// Each server may use its own transport, so the exchanger must be
+		// chosen again whenever a retry moves on to another server.
+		client := dnsClient.exchangerFor(chosenServer)
 		ch := make(chan dnsResp, 1)
 
 		go func() {
@@ -384,23 +408,38 @@ func (dnsClient *impl) exchangeOne(ctx context.Context, hostname string, qtype u
 
 }
 
+// exchangerFor returns the exchanger used to query the given server.
+func (dnsClient *impl) exchangerFor(server string) exchanger {
+	if ex, ok := dnsClient.transports[server]; ok {
+		return ex
+	}
+	return dnsClient.dnsClient
+}
+
 type dnsResp struct {
 	m   *dns.Msg
 	err error
 }
 
 // LookupTXT sends a DNS query to find all TXT records associated with
-// the provided hostname which it returns along with the returned
-// DNS authority section.
-func (dnsClient *impl) LookupTXT(ctx context.Context, hostname string) ([]string, error) {
+// the provided hostname which it returns along with the DNSSEC status of the
+// answer.
+func (dnsClient *impl) LookupTXT(ctx context.Context, hostname string) ([]string, DNSSECStatus, error) {
 	var txt []string
 	dnsType := dns.TypeTXT
 	r, err := dnsClient.exchangeOne(ctx, hostname, dnsType)
 	if err != nil {
-		return nil, &Error{dnsType, hostname, err, -1}
+		return nil, "", &Error{dnsType, hostname, err, -1}
+	}
+	if r.Rcode != dns.RcodeSuccess && r.Rcode != dns.RcodeNameError {
+		return nil, "", &Error{dnsType, hostname, nil, r.Rcode}
+	}
+	status, err := dnsClient.dnssecStatus(ctx, hostname, dnsType, r)
+	if err != nil {
+		return nil, status, err
 	}
 	if r.Rcode != dns.RcodeSuccess {
-		return nil, &Error{dnsType, hostname, nil, r.Rcode}
+		return nil, status, &Error{dnsType, hostname, nil, r.Rcode}
 	}
 
 	for _, answer := range r.Answer {
@@ -411,7 +450,7 @@ func (dnsClient *impl) LookupTXT(ctx context.Context, hostname string) ([]string
 		}
 	}
 
-	return txt, err
+	return txt, status, nil
 }
 
 func isPrivateV4(ip net.IP) bool {
@@ -432,15 +471,22 @@ func isPrivateV6(ip net.IP) bool {
 	return false
 }
 
-func (dnsClient *impl) lookupIP(ctx context.Context, hostname string, ipType uint16) ([]dns.RR, error) {
+func (dnsClient *impl) lookupIP(ctx context.Context, hostname string, ipType uint16) ([]dns.RR, DNSSECStatus, error) {
 	resp, err := dnsClient.exchangeOne(ctx, hostname, ipType)
 	if err != nil {
-		return nil, &Error{ipType, hostname, err, -1}
+		return nil, "", &Error{ipType, hostname, err, -1}
+	}
+	if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		return nil, "", &Error{ipType, hostname, nil, resp.Rcode}
+	}
+	status, err := dnsClient.dnssecStatus(ctx, hostname, ipType, resp)
+	if err != nil {
+		return nil, status, err
 	}
 	if resp.Rcode != dns.RcodeSuccess {
-		return nil, &Error{ipType, hostname, nil, resp.Rcode}
+		return nil, status, &Error{ipType, hostname, nil, resp.Rcode}
 	}
-	return resp.Answer, nil
+	return resp.Answer, status, nil
 }
 
 // LookupHost sends a DNS query to find all A and AAAA records associated with
@@ -448,26 +494,41 @@ func (dnsClient *impl) lookupIP(ctx context.Context, hostname string, ipType uin
 // chase CNAME/DNAME aliases and return relevant records.  It will retry
 // requests in the case of temporary network errors. It can return net package,
 // context.Canceled, and context.DeadlineExceeded errors, all wrapped in the
-// DNSError type.
-func (dnsClient *impl) LookupHost(ctx context.Context, hostname string) ([]net.IP, error) {
+// DNSError type. The DNSSEC status returned is the worse of the A and AAAA
+// answers', and a bogus answer to either fails the lookup.
+func (dnsClient *impl) LookupHost(ctx context.Context, hostname string) ([]net.IP, DNSSECStatus, error) {
 	var recordsA, recordsAAAA []dns.RR
+	var statusA, statusAAAA DNSSECStatus
 	var errA, errAAAA error
 	var wg sync.WaitGroup
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		recordsA, errA = dnsClient.lookupIP(ctx, hostname, dns.TypeA)
+		recordsA, statusA, errA = dnsClient.lookupIP(ctx, hostname, dns.TypeA)
 	}()
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		recordsAAAA, errAAAA = dnsClient.lookupIP(ctx, hostname, dns.TypeAAAA)
+		recordsAAAA, statusAAAA, errAAAA = dnsClient.lookupIP(ctx, hostname, dns.TypeAAAA)
 	}()
 	wg.Wait()
 
+	if errors.Is(errA, ErrDNSSECBogus) {
+		return nil, DNSSECBogus, errA
+	}
+	if errors.Is(errAAAA, ErrDNSSECBogus) {
+		return nil, DNSSECBogus, errAAAA
+	}
 	if errA != nil && errAAAA != nil {
-		return nil, errA
+		return nil, "", errA
+	}
+	var status DNSSECStatus
+	if errA == nil {
+		status = statusA
+	}
+	if errAAAA == nil {
+		status = worse(status, statusAAAA)
 	}
 
 	var addrs []net.IP
@@ -487,22 +548,30 @@ func (dnsClient *impl) LookupHost(ctx context.Context, hostname string) ([]net.I
 		}
 	}
 
-	return addrs, nil
+	return addrs, status, nil
 }
 
 // LookupCAA sends a DNS query to find all CAA records associated with
 // the provided hostname and the complete dig-style RR `response`. This
 // response is quite verbose, however it's only populated when the CAA
-// response is non-empty.
-func (dnsClient *impl) LookupCAA(ctx context.Context, hostname string) ([]*dns.CAA, string, error) {
+// response is non-empty. The DNSSEC status of the answer is returned too.
+func (dnsClient *impl) LookupCAA(ctx context.Context, hostname string) ([]*dns.CAA, string, DNSSECStatus, error) {
 	dnsType := dns.TypeCAA
 	r, err := dnsClient.exchangeOne(ctx, hostname, dnsType)
 	if err != nil {
-		return nil, "", &Error{dnsType, hostname, err, -1}
+		return nil, "", "", &Error{dnsType, hostname, err, -1}
 	}
 
 	if r.Rcode == dns.RcodeServerFailure {
-		return nil, "", &Error{dnsType, hostname, nil, r.Rcode}
+		return nil, "", "", &Error{dnsType, hostname, nil, r.Rcode}
+	}
+
+	var status DNSSECStatus
+	if r.Rcode == dns.RcodeSuccess || r.Rcode == dns.RcodeNameError {
+		status, err = dnsClient.dnssecStatus(ctx, hostname, dnsType, r)
+		if err != nil {
+			return nil, "", status, err
+		}
 	}
 
 	var CAAs []*dns.CAA
@@ -515,7 +584,7 @@ func (dnsClient *impl) LookupCAA(ctx context.Context, hostname string) ([]*dns.C
 	if len(CAAs) > 0 {
 		response = r.String()
 	}
-	return CAAs, response, nil
+	return CAAs, response, status, nil
 }
 
 // logDNSError logs the provided err result from making a query for hostname to
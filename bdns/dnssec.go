@@ -0,0 +1,837 @@
+package bdns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+)
+
+// DNSSECStatus describes how far the answer to a lookup can be trusted.
+type DNSSECStatus string
+
+const (
+	// DNSSECSecure answers were validated along an unbroken chain of
+	// signatures from a trust anchor, or carried the AD bit from the resolver
+	// when in-process validation isn't enabled.
+	DNSSECSecure = DNSSECStatus("secure")
+	// DNSSECInsecure answers are provably, or, without in-process validation,
+	// apparently, not signed.
+	DNSSECInsecure = DNSSECStatus("insecure")
+	// DNSSECBogus answers should have been signed but failed validation. They
+	// are always returned with an error wrapping ErrDNSSECBogus.
+	DNSSECBogus = DNSSECStatus("bogus")
+)
+
+// ErrDNSSECBogus is wrapped by the errors returned for lookups whose answers
+// fail DNSSEC validation.
+var ErrDNSSECBogus = errors.New("DNSSEC validation failed")
+
+func bogus(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrDNSSECBogus, fmt.Sprintf(format, a...))
+}
+
+// worse returns the less trustworthy of two statuses.
+func worse(a, b DNSSECStatus) DNSSECStatus {
+	rank := map[DNSSECStatus]int{DNSSECSecure: 0, DNSSECInsecure: 1, DNSSECBogus: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+const (
+	// validatorCacheTTL caps how long validated keys and delegations are
+	// cached, whatever their TTL.
+	validatorCacheTTL = time.Hour
+	// maxValidatorCacheEntries caps the number of zones whose keys, and the
+	// number of names whose delegations, are cached. Lookups can be made for
+	// names in any zone, so without a cap the caches would grow without bound.
+	maxValidatorCacheEntries = 10000
+	// maxNSEC3Iterations is the most NSEC3 hash iterations a denial of
+	// existence may use. Zones using more are treated as insecure, as
+	// recommended by RFC 9276.
+	maxNSEC3Iterations = 150
+	// maxCNAMEs is the longest chain of aliases which will be followed in an
+	// answer.
+	maxCNAMEs = 8
+)
+
+// supportedAlgorithms are the DNSKEY algorithms which signatures can be
+// verified with. Zones signed only with others are treated as insecure.
+var supportedAlgorithms = map[uint8]bool{
+	dns.RSASHA1:          true,
+	dns.RSASHA1NSEC3SHA1: true,
+	dns.RSASHA256:        true,
+	dns.RSASHA512:        true,
+	dns.ECDSAP256SHA256:  true,
+	dns.ECDSAP384SHA384:  true,
+	dns.ED25519:          true,
+}
+
+// supportedDigests are the DS digest types which keys can be matched with.
+var supportedDigests = map[uint8]bool{
+	dns.SHA1:   true,
+	dns.SHA256: true,
+	dns.SHA384: true,
+}
+
+// LoadTrustAnchors reads DNSSEC trust anchors from a file in zone file
+// format. The file may contain DS records, and DNSKEY records with the SEP
+// flag set, which are converted to SHA-256 DS records.
+func LoadTrustAnchors(filename string) ([]*dns.DS, error) {
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var anchors []*dns.DS
+	zp := dns.NewZoneParser(strings.NewReader(string(contents)), "", filename)
+	for rr, ok := zp.Next(); ok; rr, ok = zp.Next() {
+		switch rr := rr.(type) {
+		case *dns.DS:
+			anchors = append(anchors, rr)
+		case *dns.DNSKEY:
+			if rr.Flags&dns.SEP != 0 {
+				anchors = append(anchors, rr.ToDS(dns.SHA256))
+			}
+		}
+	}
+	if err := zp.Err(); err != nil {
+		return nil, err
+	}
+	if len(anchors) == 0 {
+		return nil, fmt.Errorf("no trust anchors found in %s", filename)
+	}
+	return anchors, nil
+}
+
+// WithDNSSEC enables in-process DNSSEC validation of every lookup made by a
+// Client returned from New or NewTest, starting from the given trust anchors.
+// Answers for names beneath none of the anchors are insecure.
+func WithDNSSEC(client Client, trustAnchors []*dns.DS) Client {
+	c := client.(*impl)
+	c.validator = newValidator(trustAnchors, c.clk, c.query)
+	return c
+}
+
+// query makes a single query on behalf of the validator. Responses with an
+// RCODE other than NOERROR or NXDOMAIN are returned as errors.
+func (dnsClient *impl) query(ctx context.Context, name string, qtype uint16) (*dns.Msg, error) {
+	resp, err := dnsClient.exchangeOne(ctx, name, qtype)
+	if err != nil {
+		return nil, &Error{qtype, name, err, -1}
+	}
+	if resp.Rcode != dns.RcodeSuccess && resp.Rcode != dns.RcodeNameError {
+		return nil, &Error{qtype, name, nil, resp.Rcode}
+	}
+	return resp, nil
+}
+
+// dnssecStatus returns the status of an answer received for the given name
+// and type. The answer is validated if in-process validation is enabled, and
+// otherwise the status is taken from the resolver's AD bit. If validation
+// fails the status is DNSSECBogus, and if validation couldn't be completed it
+// is empty.
+func (dnsClient *impl) dnssecStatus(ctx context.Context, hostname string, qtype uint16, resp *dns.Msg) (DNSSECStatus, error) {
+	if dnsClient.validator == nil {
+		if resp.AuthenticatedData {
+			return DNSSECSecure, nil
+		}
+		return DNSSECInsecure, nil
+	}
+	status, err := dnsClient.validator.validate(ctx, hostname, qtype, resp)
+	if err != nil {
+		if errors.Is(err, ErrDNSSECBogus) {
+			status = DNSSECBogus
+		}
+		var dnsErr *Error
+		if errors.As(err, &dnsErr) {
+			return status, err
+		}
+		return status, &Error{qtype, hostname, err, -1}
+	}
+	return status, nil
+}
+
+// validator verifies the DNSSEC chain of trust for answers, querying for the
+// DS and DNSKEY records it needs along the way.
+type validator struct {
+	anchors map[string][]*dns.DS
+	query   func(ctx context.Context, name string, qtype uint16) (*dns.Msg, error)
+	clk     clock.Clock
+
+	sync.Mutex
+	keys        map[string]cachedKeys
+	delegations map[string]cachedDelegation
+}
+
+// cachedKeys are the validated DNSKEYs for a zone, or none if the zone is
+// insecure.
+type cachedKeys struct {
+	keys    []*dns.DNSKEY
+	status  DNSSECStatus
+	expires time.Time
+}
+
+// delegation describes what the parent side of a name says about it: whether
+// it is a zone cut, and if so the DS records for the child zone.
+type delegation struct {
+	ds     []*dns.DS
+	cut    bool
+	status DNSSECStatus
+}
+
+type cachedDelegation struct {
+	delegation
+	expires time.Time
+}
+
+func newValidator(
+	trustAnchors []*dns.DS,
+	clk clock.Clock,
+	query func(context.Context, string, uint16) (*dns.Msg, error),
+) *validator {
+	anchors := make(map[string][]*dns.DS)
+	for _, ds := range trustAnchors {
+		zone := canonicalName(ds.Hdr.Name)
+		anchors[zone] = append(anchors[zone], ds)
+	}
+	return &validator{
+		anchors:     anchors,
+		query:       query,
+		clk:         clk,
+		keys:        make(map[string]cachedKeys),
+		delegations: make(map[string]cachedDelegation),
+	}
+}
+
+func canonicalName(name string) string {
+	return strings.ToLower(dns.Fqdn(name))
+}
+
+// parentName returns the name with its first label removed.
+func parentName(name string) string {
+	labels := dns.Split(name)
+	if len(labels) < 2 {
+		return "."
+	}
+	return name[labels[1]:]
+}
+
+// cacheExpiry returns when data with the given TTL should leave the cache.
+func (v *validator) cacheExpiry(ttl uint32) time.Time {
+	d := time.Duration(ttl) * time.Second
+	if d > validatorCacheTTL {
+		d = validatorCacheTTL
+	}
+	return v.clk.Now().Add(d)
+}
+
+// closestAnchor returns the longest trust anchor zone at or above name, or ""
+// if there is none.
+func (v *validator) closestAnchor(name string) string {
+	var closest string
+	for zone := range v.anchors {
+		if dns.IsSubDomain(zone, name) && len(zone) > len(closest) {
+			closest = zone
+		}
+	}
+	return closest
+}
+
+type rrsetKey struct {
+	name   string
+	rrtype uint16
+}
+
+// splitRRsets groups the records in a message section into RRsets, along
+// with the signatures covering each of them.
+func splitRRsets(rrs []dns.RR) ([]rrsetKey, map[rrsetKey][]dns.RR, map[rrsetKey][]*dns.RRSIG) {
+	var order []rrsetKey
+	sets := make(map[rrsetKey][]dns.RR)
+	sigs := make(map[rrsetKey][]*dns.RRSIG)
+	for _, rr := range rrs {
+		name := canonicalName(rr.Header().Name)
+		if sig, ok := rr.(*dns.RRSIG); ok {
+			key := rrsetKey{name, sig.TypeCovered}
+			sigs[key] = append(sigs[key], sig)
+			continue
+		}
+		key := rrsetKey{name, rr.Header().Rrtype}
+		if _, ok := sets[key]; !ok {
+			order = append(order, key)
+		}
+		sets[key] = append(sets[key], rr)
+	}
+	return order, sets, sigs
+}
+
+func hasType(types []uint16, rrtype uint16) bool {
+	for _, t := range types {
+		if t == rrtype {
+			return true
+		}
+	}
+	return false
+}
+
+// validate returns the status of a NOERROR or NXDOMAIN response to a query for
+// the given name and type. Bogus responses are returned as errors wrapping
+// ErrDNSSECBogus.
+func (v *validator) validate(ctx context.Context, qname string, qtype uint16, resp *dns.Msg) (DNSSECStatus, error) {
+	qname = canonicalName(qname)
+	order, sets, sigs := splitRRsets(resp.Answer)
+	status := DNSSECSecure
+	for _, key := range order {
+		if key.rrtype == dns.TypeCNAME && len(sigs[key]) == 0 && synthesized(key.name, order) {
+			// CNAMEs synthesized from a DNAME aren't signed, but the DNAME
+			// they were synthesized from is.
+			continue
+		}
+		rrsetStatus, err := v.verifyRRset(ctx, resp, key, sets[key], sigs[key], "")
+		if err != nil {
+			return "", err
+		}
+		status = worse(status, rrsetStatus)
+	}
+
+	// Follow any aliases to find the name which the answer is really for.
+	target := qname
+	for i := 0; i < maxCNAMEs; i++ {
+		cnames, ok := sets[rrsetKey{target, dns.TypeCNAME}]
+		if !ok || qtype == dns.TypeCNAME {
+			break
+		}
+		target = canonicalName(cnames[0].(*dns.CNAME).Target)
+	}
+	if _, ok := sets[rrsetKey{target, qtype}]; !ok {
+		denialStatus, _, err := v.verifyDenial(ctx, resp, target, qtype, "")
+		if err != nil {
+			return "", err
+		}
+		status = worse(status, denialStatus)
+	}
+	return status, nil
+}
+
+// synthesized returns true if a CNAME at name could have been synthesized
+// from one of the DNAMEs in an answer.
+func synthesized(name string, order []rrsetKey) bool {
+	for _, key := range order {
+		if key.rrtype == dns.TypeDNAME && key.name != name && dns.IsSubDomain(key.name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyRRset returns the status of an RRset given the signatures covering it.
+// If below is set, the RRset belongs to the parent side of the zone cut at
+// below, so must be signed by a zone above it. If the RRset was expanded from
+// a wildcard, resp must hold the proof that the name doesn't otherwise exist;
+// if resp is nil, expansions are bogus.
+func (v *validator) verifyRRset(ctx context.Context, resp *dns.Msg, key rrsetKey, rrset []dns.RR, sigs []*dns.RRSIG, below string) (DNSSECStatus, error) {
+	if len(sigs) == 0 {
+		// Unsigned data is only acceptable beneath an insecure delegation.
+		statusName := key.name
+		if below != "" {
+			statusName = parentName(below)
+		}
+		status, err := v.nameStatus(ctx, statusName)
+		if err != nil {
+			return "", err
+		}
+		if status == DNSSECSecure {
+			return "", bogus("no signature for %s %s", dns.TypeToString[key.rrtype], key.name)
+		}
+		return status, nil
+	}
+	lastErr := bogus("no usable signature for %s %s", dns.TypeToString[key.rrtype], key.name)
+	for _, sig := range sigs {
+		signer := canonicalName(sig.SignerName)
+		if !dns.IsSubDomain(signer, key.name) {
+			continue
+		}
+		if below != "" && (signer == below || !dns.IsSubDomain(signer, below)) {
+			continue
+		}
+		if int(sig.Labels) > dns.CountLabel(key.name) {
+			lastErr = bogus("signature for %s %s has more labels than its owner", dns.TypeToString[key.rrtype], key.name)
+			continue
+		}
+		keys, status, err := v.zoneKeys(ctx, signer)
+		if err != nil {
+			if errors.Is(err, ErrDNSSECBogus) {
+				lastErr = err
+				continue
+			}
+			return "", err
+		}
+		if status == DNSSECInsecure {
+			return DNSSECInsecure, nil
+		}
+		if v.verifySig(sig, keys, rrset) {
+			if int(sig.Labels) < dns.CountLabel(key.name) {
+				return v.verifyExpansion(ctx, resp, key, sig, below)
+			}
+			return DNSSECSecure, nil
+		}
+		lastErr = bogus("invalid signature for %s %s by %s", dns.TypeToString[key.rrtype], key.name, signer)
+	}
+	return "", lastErr
+}
+
+// verifyExpansion returns the status of an RRset whose signature shows it was
+// expanded from a wildcard. The expansion is only valid if the response also
+// proves that the next closer name, the one beneath the wildcard's parent
+// leading to the owner, doesn't exist (RFC 4035 Section 5.3.4). Otherwise a
+// signed expansion could be replayed over a name which does exist.
+func (v *validator) verifyExpansion(ctx context.Context, resp *dns.Msg, key rrsetKey, sig *dns.RRSIG, below string) (DNSSECStatus, error) {
+	labels := dns.Split(key.name)
+	n := len(labels) - int(sig.Labels)
+	nextCloser := key.name[labels[n-1]:]
+	encloser := "."
+	if n < len(labels) {
+		encloser = key.name[labels[n]:]
+	}
+	if resp == nil {
+		return "", bogus("%s %s must not be a wildcard expansion", dns.TypeToString[key.rrtype], key.name)
+	}
+
+	order, sets, sigs := splitRRsets(resp.Ns)
+	for _, nsKey := range order {
+		if nsKey.rrtype != dns.TypeNSEC && nsKey.rrtype != dns.TypeNSEC3 {
+			continue
+		}
+		status, err := v.verifyRRset(ctx, nil, nsKey, sets[nsKey], sigs[nsKey], below)
+		if err != nil {
+			return "", err
+		}
+		if status != DNSSECSecure {
+			continue
+		}
+		for _, rr := range sets[nsKey] {
+			switch rr := rr.(type) {
+			case *dns.NSEC:
+				if nsecCovers(rr, key.name) && nsecEncloser(rr, key.name) == encloser {
+					return DNSSECSecure, nil
+				}
+			case *dns.NSEC3:
+				if !rr.Cover(nextCloser) {
+					continue
+				}
+				if rr.Iterations > maxNSEC3Iterations || rr.Flags&1 == 1 {
+					return DNSSECInsecure, nil
+				}
+				return DNSSECSecure, nil
+			}
+		}
+	}
+	return "", bogus("no proof that %s does not exist for wildcard expansion %s %s",
+		nextCloser, dns.TypeToString[key.rrtype], key.name)
+}
+
+// verifySig returns true if the signature is currently valid and was made over
+// the RRset by one of the given zone keys.
+func (v *validator) verifySig(sig *dns.RRSIG, keys []*dns.DNSKEY, rrset []dns.RR) bool {
+	if !sig.ValidityPeriod(v.clk.Now()) {
+		return false
+	}
+	for _, key := range keys {
+		if key.Flags&dns.ZONE == 0 || key.Algorithm != sig.Algorithm || key.KeyTag() != sig.KeyTag {
+			continue
+		}
+		if sig.Verify(key, rrset) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// zoneKeys returns the validated DNSKEYs for a zone, or no keys and
+// DNSSECInsecure if the zone is provably unsigned.
+func (v *validator) zoneKeys(ctx context.Context, zone string) ([]*dns.DNSKEY, DNSSECStatus, error) {
+	v.Lock()
+	cached, ok := v.keys[zone]
+	v.Unlock()
+	if ok && v.clk.Now().Before(cached.expires) {
+		return cached.keys, cached.status, nil
+	}
+
+	ds, ok := v.anchors[zone]
+	if !ok {
+		if v.closestAnchor(zone) == "" {
+			return nil, DNSSECInsecure, nil
+		}
+		d, err := v.delegation(ctx, zone)
+		if err != nil {
+			return nil, "", err
+		}
+		if d.status == DNSSECInsecure {
+			v.cacheKeys(zone, cachedKeys{status: DNSSECInsecure, expires: v.cacheExpiry(uint32(validatorCacheTTL.Seconds()))})
+			return nil, DNSSECInsecure, nil
+		}
+		if !d.cut {
+			return nil, "", bogus("signer %s is not a zone", zone)
+		}
+		ds = d.ds
+	}
+
+	resp, err := v.query(ctx, zone, dns.TypeDNSKEY)
+	if err != nil {
+		return nil, "", err
+	}
+	key := rrsetKey{zone, dns.TypeDNSKEY}
+	_, sets, sigs := splitRRsets(resp.Answer)
+	rrset := sets[key]
+	var keys []*dns.DNSKEY
+	for _, rr := range rrset {
+		keys = append(keys, rr.(*dns.DNSKEY))
+	}
+
+	// Find the keys which the DS records vouch for.
+	var supported bool
+	var trusted []*dns.DNSKEY
+	for _, d := range ds {
+		if !supportedAlgorithms[d.Algorithm] || !supportedDigests[d.DigestType] {
+			continue
+		}
+		supported = true
+		for _, k := range keys {
+			if k.Algorithm != d.Algorithm || k.KeyTag() != d.KeyTag {
+				continue
+			}
+			if kds := k.ToDS(d.DigestType); kds != nil && strings.EqualFold(kds.Digest, d.Digest) {
+				trusted = append(trusted, k)
+			}
+		}
+	}
+	if !supported {
+		// A zone whose DS records all use unsupported algorithms is treated as
+		// unsigned (RFC 4035 Section 5.2).
+		v.cacheKeys(zone, cachedKeys{status: DNSSECInsecure, expires: v.cacheExpiry(uint32(validatorCacheTTL.Seconds()))})
+		return nil, DNSSECInsecure, nil
+	}
+	for _, sig := range sigs[key] {
+		if int(sig.Labels) != dns.CountLabel(zone) {
+			// A zone's keys can't come from a wildcard.
+			continue
+		}
+		if v.verifySig(sig, trusted, rrset) {
+			v.cacheKeys(zone, cachedKeys{keys: keys, status: DNSSECSecure, expires: v.cacheExpiry(rrset[0].Header().Ttl)})
+			return keys, DNSSECSecure, nil
+		}
+	}
+	return nil, "", bogus("no valid signature on the DNSKEY records for %s", zone)
+}
+
+func (v *validator) cacheKeys(zone string, keys cachedKeys) {
+	v.Lock()
+	defer v.Unlock()
+	if _, ok := v.keys[zone]; !ok && len(v.keys) >= maxValidatorCacheEntries {
+		now := v.clk.Now()
+		for name, cached := range v.keys {
+			if !now.Before(cached.expires) {
+				delete(v.keys, name)
+			}
+		}
+		// If nothing had expired, make room by evicting an arbitrary entry.
+		for name := range v.keys {
+			if len(v.keys) < maxValidatorCacheEntries {
+				break
+			}
+			delete(v.keys, name)
+		}
+	}
+	v.keys[zone] = keys
+}
+
+func (v *validator) cacheDelegation(name string, d cachedDelegation) {
+	v.Lock()
+	defer v.Unlock()
+	if _, ok := v.delegations[name]; !ok && len(v.delegations) >= maxValidatorCacheEntries {
+		now := v.clk.Now()
+		for n, cached := range v.delegations {
+			if !now.Before(cached.expires) {
+				delete(v.delegations, n)
+			}
+		}
+		for n := range v.delegations {
+			if len(v.delegations) < maxValidatorCacheEntries {
+				break
+			}
+			delete(v.delegations, n)
+		}
+	}
+	v.delegations[name] = d
+}
+
+// delegation queries for the DS records at a name beneath a trust anchor, and
+// returns what they show about it.
+func (v *validator) delegation(ctx context.Context, name string) (delegation, error) {
+	v.Lock()
+	cached, ok := v.delegations[name]
+	v.Unlock()
+	if ok && v.clk.Now().Before(cached.expires) {
+		return cached.delegation, nil
+	}
+
+	resp, err := v.query(ctx, name, dns.TypeDS)
+	if err != nil {
+		return delegation{}, err
+	}
+	_, sets, sigs := splitRRsets(resp.Answer)
+	var d delegation
+	ttl := uint32(validatorCacheTTL.Seconds())
+	dsKey := rrsetKey{name, dns.TypeDS}
+	cnameKey := rrsetKey{name, dns.TypeCNAME}
+	if rrset, ok := sets[dsKey]; ok {
+		d.cut = true
+		d.status, err = v.verifyRRset(ctx, nil, dsKey, rrset, sigs[dsKey], name)
+		if err != nil {
+			return delegation{}, err
+		}
+		if d.status == DNSSECSecure {
+			for _, rr := range rrset {
+				d.ds = append(d.ds, rr.(*dns.DS))
+			}
+			ttl = rrset[0].Header().Ttl
+		}
+	} else if rrset, ok := sets[cnameKey]; ok {
+		// An alias can't be a zone cut, so its status is that of the zone
+		// it is in.
+		d.status, err = v.verifyRRset(ctx, resp, cnameKey, rrset, sigs[cnameKey], name)
+		if err != nil {
+			return delegation{}, err
+		}
+	} else {
+		var types []uint16
+		d.status, types, err = v.verifyDenial(ctx, resp, name, dns.TypeDS, name)
+		if err != nil {
+			return delegation{}, err
+		}
+		d.cut = hasType(types, dns.TypeNS)
+		if d.cut {
+			// A provably unsigned delegation.
+			d.status = DNSSECInsecure
+		}
+	}
+
+	v.cacheDelegation(name, cachedDelegation{d, v.cacheExpiry(ttl)})
+	return d, nil
+}
+
+// nameStatus returns DNSSECInsecure if there is an insecure delegation
+// between name and the closest trust anchor above it, and DNSSECSecure
+// otherwise.
+func (v *validator) nameStatus(ctx context.Context, name string) (DNSSECStatus, error) {
+	anchor := v.closestAnchor(name)
+	if anchor == "" {
+		return DNSSECInsecure, nil
+	}
+	labels := dns.Split(name)
+	for i := len(labels) - dns.CountLabel(anchor) - 1; i >= 0; i-- {
+		d, err := v.delegation(ctx, name[labels[i]:])
+		if err != nil {
+			return "", err
+		}
+		if d.status == DNSSECInsecure {
+			return DNSSECInsecure, nil
+		}
+	}
+	return DNSSECSecure, nil
+}
+
+// verifyDenial returns the status of the proof in a response's authority
+// section that name has no records of type qtype, or doesn't exist at all,
+// along with the types which the proof shows name does have. If below is set
+// the proof is for a DS query at the zone cut below.
+func (v *validator) verifyDenial(ctx context.Context, resp *dns.Msg, name string, qtype uint16, below string) (DNSSECStatus, []uint16, error) {
+	order, sets, sigs := splitRRsets(resp.Ns)
+	var nsecs []*dns.NSEC
+	var nsec3s []*dns.NSEC3
+	for _, key := range order {
+		if key.rrtype != dns.TypeNSEC && key.rrtype != dns.TypeNSEC3 {
+			continue
+		}
+		status, err := v.verifyRRset(ctx, nil, key, sets[key], sigs[key], below)
+		if err != nil {
+			return "", nil, err
+		}
+		if status == DNSSECInsecure {
+			return DNSSECInsecure, nil, nil
+		}
+		for _, rr := range sets[key] {
+			switch rr := rr.(type) {
+			case *dns.NSEC:
+				nsecs = append(nsecs, rr)
+			case *dns.NSEC3:
+				if rr.Iterations > maxNSEC3Iterations {
+					return DNSSECInsecure, nil, nil
+				}
+				nsec3s = append(nsec3s, rr)
+			}
+		}
+	}
+
+	if len(nsecs) == 0 && len(nsec3s) == 0 {
+		// Without a proof the denial is only acceptable in an insecure zone.
+		statusName := name
+		if below != "" {
+			statusName = parentName(below)
+		}
+		status, err := v.nameStatus(ctx, statusName)
+		if err != nil {
+			return "", nil, err
+		}
+		if status == DNSSECSecure {
+			return "", nil, bogus("no proof that %s %s does not exist", dns.TypeToString[qtype], name)
+		}
+		return status, nil, nil
+	}
+
+	if resp.Rcode != dns.RcodeNameError {
+		// The name exists, so a record for it must show the type doesn't.
+		for _, nsec := range nsecs {
+			if canonicalName(nsec.Hdr.Name) == name {
+				return nodata(nsec.TypeBitMap, name, qtype)
+			}
+		}
+		for _, nsec3 := range nsec3s {
+			if nsec3.Match(name) {
+				return nodata(nsec3.TypeBitMap, name, qtype)
+			}
+		}
+		// Or the name is an empty non-terminal, with nothing but names
+		// beneath it.
+		for _, nsec := range nsecs {
+			next := canonicalName(nsec.NextDomain)
+			if nsecCovers(nsec, name) && next != name && dns.IsSubDomain(name, next) {
+				return DNSSECSecure, nil, nil
+			}
+		}
+		// Or there is no DS for a delegation in an opt-out span.
+		if qtype == dns.TypeDS {
+			if _, optOut, ok := closestEncloser(name, nsec3s); ok && optOut {
+				return DNSSECInsecure, nil, nil
+			}
+		}
+		return "", nil, bogus("no proof that %s %s does not exist", dns.TypeToString[qtype], name)
+	}
+
+	// The name doesn't exist, so the proof must cover both it and the
+	// wildcard which could have matched it.
+	for _, nsec := range nsecs {
+		if !nsecCovers(nsec, name) {
+			continue
+		}
+		encloser := nsecEncloser(nsec, name)
+		wildcard := "*." + encloser
+		if encloser == "." {
+			wildcard = "*."
+		}
+		for _, w := range nsecs {
+			if nsecCovers(w, wildcard) {
+				return DNSSECSecure, nil, nil
+			}
+		}
+	}
+	if encloser, optOut, ok := closestEncloser(name, nsec3s); ok {
+		if optOut {
+			return DNSSECInsecure, nil, nil
+		}
+		for _, nsec3 := range nsec3s {
+			if nsec3.Cover("*." + encloser) {
+				return DNSSECSecure, nil, nil
+			}
+		}
+	}
+	return "", nil, bogus("no proof that %s does not exist", name)
+}
+
+// nodata returns the result of a record showing which types exist at a name.
+func nodata(types []uint16, name string, qtype uint16) (DNSSECStatus, []uint16, error) {
+	if hasType(types, qtype) || hasType(types, dns.TypeCNAME) {
+		return "", nil, bogus("denial of existence for %s %s shows that it exists", dns.TypeToString[qtype], name)
+	}
+	return DNSSECSecure, types, nil
+}
+
+// closestEncloser finds the closest existing ancestor of name proven by the
+// NSEC3 records, which must also cover the next name below it. It returns the
+// encloser, and whether the covering record has the opt-out flag set.
+func closestEncloser(name string, nsec3s []*dns.NSEC3) (string, bool, bool) {
+	labels := dns.Split(name)
+	for i := 1; i < len(labels); i++ {
+		encloser := name[labels[i]:]
+		var matched bool
+		for _, nsec3 := range nsec3s {
+			if nsec3.Match(encloser) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		nextCloser := name[labels[i-1]:]
+		for _, nsec3 := range nsec3s {
+			if nsec3.Cover(nextCloser) {
+				return encloser, nsec3.Flags&1 == 1, true
+			}
+		}
+		return "", false, false
+	}
+	return "", false, false
+}
+
+// nsecEncloser returns the closest encloser of a name covered by an NSEC
+// record: the longest ancestor it shares with either end of the record.
+func nsecEncloser(nsec *dns.NSEC, name string) string {
+	encloser := commonAncestor(name, canonicalName(nsec.Hdr.Name))
+	if n := commonAncestor(name, canonicalName(nsec.NextDomain)); len(n) > len(encloser) {
+		encloser = n
+	}
+	return encloser
+}
+
+// commonAncestor returns the longest name which both a and b are at or
+// beneath.
+func commonAncestor(a, b string) string {
+	n := dns.CompareDomainName(a, b)
+	if n == 0 {
+		return "."
+	}
+	labels := dns.Split(a)
+	return a[labels[len(labels)-n]:]
+}
+
+// nsecCovers returns true if name falls strictly between the owner and next
+// names of the NSEC record, in canonical order.
+func nsecCovers(nsec *dns.NSEC, name string) bool {
+	owner, next := nsec.Hdr.Name, nsec.NextDomain
+	if canonicalCompare(owner, next) < 0 {
+		return canonicalCompare(owner, name) < 0 && canonicalCompare(name, next) < 0
+	}
+	// The last NSEC record in a zone wraps around to the apex.
+	return canonicalCompare(owner, name) < 0 || canonicalCompare(name, next) < 0
+}
+
+// canonicalCompare compares two names in DNSSEC canonical order (RFC 4034
+// Section 6.1).
+func canonicalCompare(a, b string) int {
+	al := dns.SplitDomainName(strings.ToLower(a))
+	bl := dns.SplitDomainName(strings.ToLower(b))
+	for i := 1; i <= len(al) && i <= len(bl); i++ {
+		if c := strings.Compare(al[len(al)-i], bl[len(bl)-i]); c != 0 {
+			return c
+		}
+	}
+	return len(al) - len(bl)
+}
@@ -0,0 +1,415 @@
+package bdns
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// testSigner signs RRsets for a zone with a freshly generated key.
+type testSigner struct {
+	zone string
+	key  *dns.DNSKEY
+	priv crypto.Signer
+	clk  clock.Clock
+}
+
+func newTestSigner(t *testing.T, zone string, clk clock.Clock) *testSigner {
+	t.Helper()
+	key := &dns.DNSKEY{
+		Hdr:       dns.RR_Header{Name: zone, Rrtype: dns.TypeDNSKEY, Class: dns.ClassINET, Ttl: 300},
+		Flags:     dns.ZONE | dns.SEP,
+		Protocol:  3,
+		Algorithm: dns.ECDSAP256SHA256,
+	}
+	priv, err := key.Generate(256)
+	test.AssertNotError(t, err, "generating zone key")
+	return &testSigner{zone: zone, key: key, priv: priv.(crypto.Signer), clk: clk}
+}
+
+// sign returns the RRset followed by a signature over it which is valid from
+// an hour ago until an hour from now.
+func (s *testSigner) sign(t *testing.T, rrset ...dns.RR) []dns.RR {
+	t.Helper()
+	return s.signFor(t, s.clk.Now().Add(-time.Hour), s.clk.Now().Add(time.Hour), rrset...)
+}
+
+func (s *testSigner) signFor(t *testing.T, inception, expiration time.Time, rrset ...dns.RR) []dns.RR {
+	t.Helper()
+	sig := &dns.RRSIG{
+		Hdr:        dns.RR_Header{Name: rrset[0].Header().Name, Rrtype: dns.TypeRRSIG, Class: dns.ClassINET, Ttl: 300},
+		KeyTag:     s.key.KeyTag(),
+		SignerName: s.zone,
+		Algorithm:  s.key.Algorithm,
+		Inception:  uint32(inception.Unix()),
+		Expiration: uint32(expiration.Unix()),
+	}
+	test.AssertNotError(t, sig.Sign(s.priv, rrset), "signing RRset")
+	return append(rrset, sig)
+}
+
+// nsec returns a signed NSEC record for name, showing that it has the given
+// types and nothing else.
+func (s *testSigner) nsec(t *testing.T, name, next string, types ...uint16) []dns.RR {
+	t.Helper()
+	types = append(types, dns.TypeRRSIG, dns.TypeNSEC)
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return s.sign(t, &dns.NSEC{
+		Hdr:        dns.RR_Header{Name: name, Rrtype: dns.TypeNSEC, Class: dns.ClassINET, Ttl: 300},
+		NextDomain: next,
+		TypeBitMap: types,
+	})
+}
+
+// expand returns a signed RRset for a wildcard, renamed to name as though the
+// wildcard had been expanded for it.
+func (s *testSigner) expand(t *testing.T, name string, rr dns.RR) []dns.RR {
+	t.Helper()
+	rrs := s.sign(t, rr)
+	for _, rr := range rrs {
+		rr.Header().Name = name
+	}
+	return rrs
+}
+
+func mustRR(t *testing.T, s string) dns.RR {
+	t.Helper()
+	rr, err := dns.NewRR(s)
+	test.AssertNotError(t, err, "parsing RR")
+	return rr
+}
+
+// zoneExchanger answers queries from a fixed set of responses, and NXDOMAIN
+// with no proof for anything else.
+type zoneExchanger struct {
+	t *testing.T
+	sync.Mutex
+	responses map[rrsetKey]*dns.Msg
+}
+
+func (ze *zoneExchanger) add(name string, qtype uint16, rcode int, answer []dns.RR, ns []dns.RR) {
+	ze.Lock()
+	defer ze.Unlock()
+	ze.responses[rrsetKey{name, qtype}] = &dns.Msg{
+		MsgHdr: dns.MsgHdr{Response: true, Rcode: rcode},
+		Answer: answer,
+		Ns:     ns,
+	}
+}
+
+func (ze *zoneExchanger) Exchange(m *dns.Msg, _ string) (*dns.Msg, time.Duration, error) {
+	ze.Lock()
+	defer ze.Unlock()
+	opt := m.IsEdns0()
+	if opt == nil || !opt.Do() || !m.CheckingDisabled {
+		ze.t.Errorf("query for %s was sent without the DO and CD bits", m.Question[0].Name)
+	}
+	q := m.Question[0]
+	resp, ok := ze.responses[rrsetKey{canonicalName(q.Name), q.Qtype}]
+	if !ok {
+		resp = &dns.Msg{MsgHdr: dns.MsgHdr{Response: true, Rcode: dns.RcodeNameError}}
+	}
+	resp = resp.Copy()
+	rcode := resp.Rcode
+	resp.SetReply(m)
+	resp.Rcode = rcode
+	return resp, time.Millisecond, nil
+}
+
+// newValidatingTestClient returns a Client which validates answers from a test
+// hierarchy beneath the trust anchor test., which contains the signed zone
+// secure.test. and the unsigned zone unsigned.test.
+func newValidatingTestClient(t *testing.T) (Client, *zoneExchanger, *testSigner) {
+	t.Helper()
+	clk := clock.NewFake()
+	clk.Set(time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC))
+	root := newTestSigner(t, "test.", clk)
+	child := newTestSigner(t, "secure.test.", clk)
+
+	ze := &zoneExchanger{t: t, responses: make(map[rrsetKey]*dns.Msg)}
+	ze.add("test.", dns.TypeDNSKEY, dns.RcodeSuccess, root.sign(t, root.key), nil)
+	ze.add("secure.test.", dns.TypeDS, dns.RcodeSuccess, root.sign(t, child.key.ToDS(dns.SHA256)), nil)
+	ze.add("secure.test.", dns.TypeDNSKEY, dns.RcodeSuccess, child.sign(t, child.key), nil)
+	ze.add("unsigned.test.", dns.TypeDS, dns.RcodeSuccess, nil,
+		root.nsec(t, "unsigned.test.", "zzz.test.", dns.TypeNS))
+
+	client := NewTest(time.Second, NewStaticProvider([]string{"127.0.0.1:53"}), metrics.NoopRegisterer, clk, 1, blog.UseMock())
+	client.(*impl).dnsClient = ze
+	return WithDNSSEC(client, []*dns.DS{root.key.ToDS(dns.SHA256)}), ze, child
+}
+
+func TestDNSSECLookupTXT(t *testing.T) {
+	client, ze, child := newValidatingTestClient(t)
+	clk := child.clk
+
+	ze.add("secure.test.", dns.TypeTXT, dns.RcodeSuccess,
+		child.sign(t, mustRR(t, `secure.test. 300 IN TXT "signed"`)), nil)
+
+	forged := child.sign(t, mustRR(t, `forged.secure.test. 300 IN TXT "signed"`))
+	forged[0].(*dns.TXT).Txt = []string{"forged"}
+	ze.add("forged.secure.test.", dns.TypeTXT, dns.RcodeSuccess, forged, nil)
+
+	ze.add("stripped.secure.test.", dns.TypeTXT, dns.RcodeSuccess,
+		[]dns.RR{mustRR(t, `stripped.secure.test. 300 IN TXT "unsigned"`)}, nil)
+	ze.add("stripped.secure.test.", dns.TypeDS, dns.RcodeSuccess, nil,
+		child.nsec(t, "stripped.secure.test.", "\\000.stripped.secure.test.", dns.TypeTXT))
+
+	ze.add("expired.secure.test.", dns.TypeTXT, dns.RcodeSuccess,
+		child.signFor(t, clk.Now().Add(-2*time.Hour), clk.Now().Add(-time.Hour),
+			mustRR(t, `expired.secure.test. 300 IN TXT "expired"`)), nil)
+
+	ze.add("unsigned.test.", dns.TypeTXT, dns.RcodeSuccess,
+		[]dns.RR{mustRR(t, `unsigned.test. 300 IN TXT "unsigned"`)}, nil)
+
+	ze.add("outside.example.com.", dns.TypeTXT, dns.RcodeSuccess,
+		[]dns.RR{mustRR(t, `outside.example.com. 300 IN TXT "unsigned"`)}, nil)
+
+	ze.add("alias.secure.test.", dns.TypeTXT, dns.RcodeSuccess, append(
+		child.sign(t, mustRR(t, `alias.secure.test. 300 IN CNAME secure.test.`)),
+		child.sign(t, mustRR(t, `secure.test. 300 IN TXT "signed"`))...), nil)
+
+	ze.add("empty.secure.test.", dns.TypeTXT, dns.RcodeSuccess, nil,
+		child.nsec(t, "empty.secure.test.", "forged.secure.test.", dns.TypeA))
+	ze.add("lying.secure.test.", dns.TypeTXT, dns.RcodeSuccess, nil,
+		child.nsec(t, "lying.secure.test.", "secure.test.", dns.TypeA, dns.TypeTXT))
+
+	ze.add("missing.secure.test.", dns.TypeTXT, dns.RcodeNameError, nil,
+		child.nsec(t, "secure.test.", "www.secure.test.", dns.TypeTXT, dns.TypeDNSKEY))
+
+	// A single NSEC3 record for the apex whose next hash is its own covers
+	// every other name in the zone.
+	apexHash := dns.HashName("secure.test.", dns.SHA1, 0, "")
+	ze.add("hashed.secure.test.", dns.TypeTXT, dns.RcodeNameError, nil,
+		child.sign(t, &dns.NSEC3{
+			Hdr:        dns.RR_Header{Name: apexHash + ".secure.test.", Rrtype: dns.TypeNSEC3, Class: dns.ClassINET, Ttl: 300},
+			Hash:       dns.SHA1,
+			HashLength: 20,
+			NextDomain: apexHash,
+			TypeBitMap: []uint16{dns.TypeTXT, dns.TypeRRSIG, dns.TypeDNSKEY},
+		}))
+
+	// An expansion of *.secure.test. must come with proof that the name it was
+	// expanded for doesn't exist, so it can't be replayed over one that does.
+	wildcardNSEC := child.nsec(t, "whatever.secure.test.", "www.secure.test.", dns.TypeTXT)
+	ze.add("wild.secure.test.", dns.TypeTXT, dns.RcodeSuccess,
+		child.expand(t, "wild.secure.test.", mustRR(t, `*.secure.test. 300 IN TXT "wildcard"`)), wildcardNSEC)
+	ze.add("replayed.secure.test.", dns.TypeTXT, dns.RcodeSuccess,
+		child.expand(t, "replayed.secure.test.", mustRR(t, `*.secure.test. 300 IN TXT "wildcard"`)), wildcardNSEC)
+	ze.add("hashwild.secure.test.", dns.TypeTXT, dns.RcodeSuccess,
+		child.expand(t, "hashwild.secure.test.", mustRR(t, `*.secure.test. 300 IN TXT "wildcard"`)),
+		child.sign(t, &dns.NSEC3{
+			Hdr:        dns.RR_Header{Name: apexHash + ".secure.test.", Rrtype: dns.TypeNSEC3, Class: dns.ClassINET, Ttl: 300},
+			Hash:       dns.SHA1,
+			HashLength: 20,
+			NextDomain: apexHash,
+			TypeBitMap: []uint16{dns.TypeTXT, dns.TypeRRSIG, dns.TypeDNSKEY},
+		}))
+
+	testCases := []struct {
+		name   string
+		txts   []string
+		status DNSSECStatus
+		err    string
+	}{
+		{name: "secure.test", txts: []string{"signed"}, status: DNSSECSecure},
+		{name: "wild.secure.test", txts: []string{"wildcard"}, status: DNSSECSecure},
+		{name: "hashwild.secure.test", txts: []string{"wildcard"}, status: DNSSECSecure},
+		{name: "alias.secure.test", txts: []string{"signed"}, status: DNSSECSecure},
+		{name: "empty.secure.test", status: DNSSECSecure},
+		{name: "unsigned.test", txts: []string{"unsigned"}, status: DNSSECInsecure},
+		{name: "outside.example.com", txts: []string{"unsigned"}, status: DNSSECInsecure},
+		{
+			name:   "missing.secure.test",
+			status: DNSSECSecure,
+			err:    "DNS problem: NXDOMAIN looking up TXT for missing.secure.test - check that a DNS record exists for this domain",
+		},
+		{
+			name:   "hashed.secure.test",
+			status: DNSSECSecure,
+			err:    "DNS problem: NXDOMAIN looking up TXT for hashed.secure.test - check that a DNS record exists for this domain",
+		},
+		{
+			name:   "forged.secure.test",
+			status: DNSSECBogus,
+			err:    "DNS problem: DNSSEC validation failed: invalid signature for TXT forged.secure.test. by secure.test. looking up TXT for forged.secure.test",
+		},
+		{
+			name:   "stripped.secure.test",
+			status: DNSSECBogus,
+			err:    "DNS problem: DNSSEC validation failed: no signature for TXT stripped.secure.test. looking up TXT for stripped.secure.test",
+		},
+		{
+			name:   "expired.secure.test",
+			status: DNSSECBogus,
+			err:    "DNS problem: DNSSEC validation failed: invalid signature for TXT expired.secure.test. by secure.test. looking up TXT for expired.secure.test",
+		},
+		{
+			name:   "lying.secure.test",
+			status: DNSSECBogus,
+			err:    "DNS problem: DNSSEC validation failed: denial of existence for TXT lying.secure.test. shows that it exists looking up TXT for lying.secure.test",
+		},
+		{
+			name:   "replayed.secure.test",
+			status: DNSSECBogus,
+			err:    "DNS problem: DNSSEC validation failed: no proof that replayed.secure.test. does not exist for wildcard expansion TXT replayed.secure.test. looking up TXT for replayed.secure.test",
+		},
+		{
+			name:   "nope.secure.test",
+			status: DNSSECBogus,
+			err:    "DNS problem: DNSSEC validation failed: no proof that DS nope.secure.test. does not exist looking up TXT for nope.secure.test",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			txts, status, err := client.LookupTXT(context.Background(), tc.name)
+			test.AssertEquals(t, status, tc.status)
+			if tc.err != "" {
+				test.AssertError(t, err, "lookup should have failed")
+				test.AssertEquals(t, err.Error(), tc.err)
+				test.AssertEquals(t, errors.Is(err, ErrDNSSECBogus), tc.status == DNSSECBogus)
+				return
+			}
+			test.AssertNotError(t, err, "lookup failed")
+			test.AssertDeepEquals(t, txts, tc.txts)
+		})
+	}
+}
+
+func TestDNSSECLookupHostAndCAA(t *testing.T) {
+	client, ze, child := newValidatingTestClient(t)
+
+	ze.add("host.secure.test.", dns.TypeA, dns.RcodeSuccess,
+		child.sign(t, mustRR(t, `host.secure.test. 300 IN A 192.0.2.1`)), nil)
+	ze.add("host.secure.test.", dns.TypeAAAA, dns.RcodeSuccess, nil,
+		child.nsec(t, "host.secure.test.", "lying.secure.test.", dns.TypeA))
+	ze.add("host.secure.test.", dns.TypeCAA, dns.RcodeSuccess,
+		child.sign(t, mustRR(t, `host.secure.test. 300 IN CAA 0 issue "letsencrypt.org"`)), nil)
+
+	ze.add("half.secure.test.", dns.TypeA, dns.RcodeSuccess,
+		child.sign(t, mustRR(t, `half.secure.test. 300 IN A 192.0.2.1`)), nil)
+	ze.add("half.secure.test.", dns.TypeAAAA, dns.RcodeSuccess,
+		[]dns.RR{mustRR(t, `half.secure.test. 300 IN AAAA 2001:db8::1`)}, nil)
+	ze.add("half.secure.test.", dns.TypeDS, dns.RcodeSuccess, nil,
+		child.nsec(t, "half.secure.test.", "host.secure.test.", dns.TypeA, dns.TypeAAAA))
+
+	addrs, status, err := client.LookupHost(context.Background(), "host.secure.test")
+	test.AssertNotError(t, err, "LookupHost failed")
+	test.AssertEquals(t, status, DNSSECSecure)
+	test.AssertEquals(t, len(addrs), 1)
+
+	// A bogus AAAA answer fails the lookup even though the A answer is fine.
+	_, status, err = client.LookupHost(context.Background(), "half.secure.test")
+	test.AssertEquals(t, status, DNSSECBogus)
+	test.Assert(t, errors.Is(err, ErrDNSSECBogus), "bogus AAAA answer wasn't reported")
+
+	caas, _, status, err := client.LookupCAA(context.Background(), "host.secure.test")
+	test.AssertNotError(t, err, "LookupCAA failed")
+	test.AssertEquals(t, status, DNSSECSecure)
+	test.AssertEquals(t, len(caas), 1)
+
+	// A CAA NXDOMAIN must be proven too, since it permits issuance.
+	_, _, status, err = client.LookupCAA(context.Background(), "nope.secure.test")
+	test.AssertEquals(t, status, DNSSECBogus)
+	test.Assert(t, errors.Is(err, ErrDNSSECBogus), "unproven NXDOMAIN wasn't reported")
+}
+
+func TestDNSSECStatusFromADBit(t *testing.T) {
+	client := NewTest(time.Second, NewStaticProvider([]string{"127.0.0.1:53"}), metrics.NoopRegisterer, clock.NewFake(), 1, blog.UseMock())
+	client.(*impl).dnsClient = &adExchanger{}
+
+	_, status, err := client.LookupTXT(context.Background(), "authenticated.com")
+	test.AssertNotError(t, err, "LookupTXT failed")
+	test.AssertEquals(t, status, DNSSECSecure)
+
+	_, status, err = client.LookupTXT(context.Background(), "unauthenticated.com")
+	test.AssertNotError(t, err, "LookupTXT failed")
+	test.AssertEquals(t, status, DNSSECInsecure)
+}
+
+// adExchanger sets the AD bit on responses for names beginning with
+// "authenticated".
+type adExchanger struct{}
+
+func (adExchanger) Exchange(m *dns.Msg, _ string) (*dns.Msg, time.Duration, error) {
+	resp := new(dns.Msg)
+	resp.SetReply(m)
+	resp.AuthenticatedData = strings.HasPrefix(m.Question[0].Name, "authenticated")
+	return resp, time.Millisecond, nil
+}
+
+func TestLoadTrustAnchors(t *testing.T) {
+	clk := clock.NewFake()
+	signer := newTestSigner(t, "test.", clk)
+	ds := signer.key.ToDS(dns.SHA256)
+
+	f, err := ioutil.TempFile("", "anchors")
+	test.AssertNotError(t, err, "creating temp file")
+	defer os.Remove(f.Name())
+	_, err = f.WriteString(ds.String() + "\n" + signer.key.String() + "\n")
+	test.AssertNotError(t, err, "writing temp file")
+	f.Close()
+
+	anchors, err := LoadTrustAnchors(f.Name())
+	test.AssertNotError(t, err, "loading trust anchors")
+	test.AssertEquals(t, len(anchors), 2)
+	for _, anchor := range anchors {
+		test.AssertEquals(t, anchor.String(), ds.String())
+	}
+
+	empty, err := ioutil.TempFile("", "anchors")
+	test.AssertNotError(t, err, "creating temp file")
+	defer os.Remove(empty.Name())
+	empty.Close()
+	_, err = LoadTrustAnchors(empty.Name())
+	test.AssertError(t, err, "loaded trust anchors from an empty file")
+}
+
+func TestValidatorCacheBounded(t *testing.T) {
+	clk := clock.NewFake()
+	v := newValidator(nil, clk, nil)
+	zone := func(i int) string { return fmt.Sprintf("zone%d.test.", i) }
+
+	// Once the cache is full, expired entries are swept out.
+	for i := 0; i < maxValidatorCacheEntries; i++ {
+		v.cacheKeys(zone(i), cachedKeys{status: DNSSECInsecure, expires: clk.Now().Add(time.Minute)})
+		v.cacheDelegation(zone(i), cachedDelegation{expires: clk.Now().Add(time.Minute)})
+	}
+	clk.Add(2 * time.Minute)
+	v.cacheKeys("fresh.test.", cachedKeys{status: DNSSECInsecure, expires: clk.Now().Add(time.Minute)})
+	v.cacheDelegation("fresh.test.", cachedDelegation{expires: clk.Now().Add(time.Minute)})
+	test.AssertEquals(t, len(v.keys), 1)
+	test.AssertEquals(t, len(v.delegations), 1)
+
+	// And if none have expired, others are evicted to stay within the cap.
+	for i := 0; i < maxValidatorCacheEntries+10; i++ {
+		v.cacheKeys(zone(i), cachedKeys{status: DNSSECInsecure, expires: clk.Now().Add(time.Minute)})
+		v.cacheDelegation(zone(i), cachedDelegation{expires: clk.Now().Add(time.Minute)})
+	}
+	test.AssertEquals(t, len(v.keys), maxValidatorCacheEntries)
+	test.AssertEquals(t, len(v.delegations), maxValidatorCacheEntries)
+	_, ok := v.keys[zone(maxValidatorCacheEntries+9)]
+	test.Assert(t, ok, "most recently cached keys were evicted")
+}
+
+func TestCanonicalCompare(t *testing.T) {
+	// The example from RFC 4034 Section 6.1, less the names with escapes.
+	ordered := []string{"example.", "a.example.", "yljkjljk.a.example.", "Z.a.example.", "zABC.a.EXAMPLE.", "z.example.", "*.z.example."}
+	for i := 0; i < len(ordered)-1; i++ {
+		test.Assert(t, canonicalCompare(ordered[i], ordered[i+1]) < 0, ordered[i]+" should sort before "+ordered[i+1])
+	}
+}
@@ -18,36 +18,44 @@ type MockClient struct {
 }
 
 // LookupTXT is a mock
-func (mock *MockClient) LookupTXT(_ context.Context, hostname string) ([]string, error) {
+func (mock *MockClient) LookupTXT(_ context.Context, hostname string) ([]string, DNSSECStatus, error) {
 	if hostname == "_acme-challenge.servfail.com" {
-		return nil, fmt.Errorf("SERVFAIL")
+		return nil, "", fmt.Errorf("SERVFAIL")
 	}
 	if hostname == "_acme-challenge.good-dns01.com" {
 		// base64(sha256("LoqXcYV8q5ONbJQxbmR7SCTNo3tiAXDfowyjxAjEuX0"
 		//               + "." + "9jg46WB3rR_AHD-EBXdN7cBkH1WOu0tA3M9fm21mqTI"))
 		// expected token + test account jwk thumbprint
-		return []string{"LPsIwTo7o8BoG0-vjCyGQGBWSVIPxI-i_X336eUOQZo"}, nil
+		return []string{"LPsIwTo7o8BoG0-vjCyGQGBWSVIPxI-i_X336eUOQZo"}, DNSSECSecure, nil
+	}
+	if hostname == "_acme-challenge.dnssec-bogus.com" {
+		return nil, DNSSECBogus, &Error{dns.TypeTXT, hostname, bogus("no signature for TXT %s.", hostname), -1}
 	}
 	if hostname == "_acme-challenge.wrong-dns01.com" {
-		return []string{"a"}, nil
+		return []string{"a"}, DNSSECInsecure, nil
 	}
 	if hostname == "_acme-challenge.wrong-many-dns01.com" {
-		return []string{"a", "b", "c", "d", "e"}, nil
+		return []string{"a", "b", "c", "d", "e"}, DNSSECInsecure, nil
 	}
 	if hostname == "_acme-challenge.long-dns01.com" {
-		return []string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}, nil
+		return []string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"}, DNSSECInsecure, nil
 	}
 	if hostname == "_acme-challenge.no-authority-dns01.com" {
 		// base64(sha256("LoqXcYV8q5ONbJQxbmR7SCTNo3tiAXDfowyjxAjEuX0"
 		//               + "." + "9jg46WB3rR_AHD-EBXdN7cBkH1WOu0tA3M9fm21mqTI"))
 		// expected token + test account jwk thumbprint
-		return []string{"LPsIwTo7o8BoG0-vjCyGQGBWSVIPxI-i_X336eUOQZo"}, nil
+		return []string{"LPsIwTo7o8BoG0-vjCyGQGBWSVIPxI-i_X336eUOQZo"}, DNSSECInsecure, nil
+	}
+	if hostname == "_o7v76rusep3qjnvt._acme-challenge.good-dns-account01.com" {
+		// The same digest as good-dns01.com, beneath the DNS-ACCOUNT-01 label
+		// for the account URL "http://boulder:4000/acme/reg/1"
+		return []string{"LPsIwTo7o8BoG0-vjCyGQGBWSVIPxI-i_X336eUOQZo"}, DNSSECInsecure, nil
 	}
 	// empty-txts.com always returns zero TXT records
 	if hostname == "_acme-challenge.empty-txts.com" {
-		return []string{}, nil
+		return []string{}, DNSSECInsecure, nil
 	}
-	return []string{"hostname"}, nil
+	return []string{"hostname"}, DNSSECInsecure, nil
 }
 
 // makeTimeoutError returns a a net.OpError for which Timeout() returns true.
@@ -67,13 +75,16 @@ func (t timeoutError) Timeout() bool {
 }
 
 // LookupHost is a mock
-func (mock *MockClient) LookupHost(_ context.Context, hostname string) ([]net.IP, error) {
+func (mock *MockClient) LookupHost(_ context.Context, hostname string) ([]net.IP, DNSSECStatus, error) {
 	if hostname == "always.invalid" ||
 		hostname == "invalid.invalid" {
-		return []net.IP{}, nil
+		return []net.IP{}, DNSSECInsecure, nil
+	}
+	if hostname == "dnssec-bogus.com" {
+		return nil, DNSSECBogus, &Error{dns.TypeA, hostname, bogus("no signature for A %s.", hostname), -1}
 	}
 	if hostname == "always.timeout" {
-		return []net.IP{}, &Error{dns.TypeA, "always.timeout", makeTimeoutError(), -1}
+		return []net.IP{}, "", &Error{dns.TypeA, "always.timeout", makeTimeoutError(), -1}
 	}
 	if hostname == "always.error" {
 		err := &net.OpError{
@@ -86,7 +97,7 @@ func (mock *MockClient) LookupHost(_ context.Context, hostname string) ([]net.IP
 		m.AuthenticatedData = true
 		m.SetEdns0(4096, false)
 		logDNSError(mock.Log, "mock.server", hostname, m, nil, err)
-		return []net.IP{}, &Error{dns.TypeA, hostname, err, -1}
+		return []net.IP{}, "", &Error{dns.TypeA, hostname, err, -1}
 	}
 	if hostname == "id.mismatch" {
 		err := dns.ErrId
@@ -100,25 +111,25 @@ func (mock *MockClient) LookupHost(_ context.Context, hostname string) ([]net.IP
 		record.A = net.ParseIP("127.0.0.1")
 		r.Answer = append(r.Answer, record)
 		logDNSError(mock.Log, "mock.server", hostname, m, r, err)
-		return []net.IP{}, &Error{dns.TypeA, hostname, err, -1}
+		return []net.IP{}, "", &Error{dns.TypeA, hostname, err, -1}
 	}
 	// dual-homed host with an IPv6 and an IPv4 address
 	if hostname == "ipv4.and.ipv6.localhost" {
 		return []net.IP{
 			net.ParseIP("::1"),
 			net.ParseIP("127.0.0.1"),
-		}, nil
+		}, DNSSECInsecure, nil
 	}
 	if hostname == "ipv6.localhost" {
 		return []net.IP{
 			net.ParseIP("::1"),
-		}, nil
+		}, DNSSECInsecure, nil
 	}
 	ip := net.ParseIP("127.0.0.1")
-	return []net.IP{ip}, nil
+	return []net.IP{ip}, DNSSECInsecure, nil
 }
 
 // LookupCAA returns mock records for use in tests.
-func (mock *MockClient) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, string, error) {
-	return nil, "", nil
+func (mock *MockClient) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, string, DNSSECStatus, error) {
+	return nil, "", DNSSECInsecure, nil
 }
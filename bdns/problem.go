@@ -2,6 +2,7 @@ package bdns
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 
@@ -20,7 +21,9 @@ type Error struct {
 func (d Error) Error() string {
 	var detail, additional string
 	if d.underlying != nil {
-		if netErr, ok := d.underlying.(*net.OpError); ok {
+		if errors.Is(d.underlying, ErrDNSSECBogus) {
+			detail = d.underlying.Error()
+		} else if netErr, ok := d.underlying.(*net.OpError); ok {
 			if netErr.Timeout() {
 				detail = detailDNSTimeout
 			} else {
@@ -47,6 +50,11 @@ func (d Error) Error() string {
 		dns.TypeToString[d.recordType], d.hostname, additional)
 }
 
+// Unwrap returns the underlying error, if any.
+func (d Error) Unwrap() error {
+	return d.underlying
+}
+
 const detailDNSTimeout = "query timed out"
 const detailCanceled = "query timed out (and was canceled)"
 const detailDNSNetFailure = "networking error"
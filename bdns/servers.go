@@ -133,23 +133,9 @@ func (dp *dynamicProvider) update() error {
 	ctx, cancel := context.WithTimeout(context.Background(), dp.refresh/2)
 	defer cancel()
 
-	_, srvs, err := net.DefaultResolver.LookupSRV(ctx, "dns", "udp", dp.name)
+	addrPorts, err := LookupSRVAddrs(ctx, net.DefaultResolver, "dns", "udp", dp.name)
 	if err != nil {
-		return fmt.Errorf("failed to lookup SRV records for %q: %w", dp.name, err)
-	}
-	if srvs == nil || len(srvs) == 0 {
-		return fmt.Errorf("no SRV records found for %q", dp.name)
-	}
-
-	addrPorts := make(map[string][]uint16)
-	for _, srv := range srvs {
-		addrs, err := net.DefaultResolver.LookupHost(ctx, srv.Target)
-		if err != nil {
-			return fmt.Errorf("failed to resolve SRV Target %q: %w", srv.Target, err)
-		}
-		for _, addr := range addrs {
-			addrPorts[addr] = append(addrPorts[addr], srv.Port)
-		}
+		return err
 	}
 
 	dp.mu.Lock()
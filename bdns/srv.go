@@ -0,0 +1,107 @@
+package bdns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/miekg/dns"
+)
+
+// NewResolver returns a *net.Resolver which sends all of its queries to the DNS
+// server at authority, a host:port, or which uses the system's configured
+// resolvers if authority is empty.
+func NewResolver(authority string) *net.Resolver {
+	if authority == "" {
+		return net.DefaultResolver
+	}
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, authority)
+		},
+	}
+}
+
+// LookupSRVAddrs looks up the SRV records for the given service, protocol and
+// domain name, in the same way as net.LookupSRV, and then the IP addresses of
+// each record's Target. It returns a map of each IP address to the ports
+// associated with it. The Priority and Weight of the records are ignored.
+func LookupSRVAddrs(ctx context.Context, resolver *net.Resolver, service, proto, name string) (map[string][]uint16, error) {
+	_, srvs, err := resolver.LookupSRV(ctx, service, proto, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup SRV records for %q: %w", name, err)
+	}
+	if len(srvs) == 0 {
+		return nil, fmt.Errorf("no SRV records found for %q", name)
+	}
+
+	addrPorts := make(map[string][]uint16)
+	for _, srv := range srvs {
+		addrs, err := resolver.LookupHost(ctx, srv.Target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve SRV Target %q: %w", srv.Target, err)
+		}
+		for _, addr := range addrs {
+			addrPorts[addr] = append(addrPorts[addr], srv.Port)
+		}
+	}
+	return addrPorts, nil
+}
+
+// BrowseDNSSD finds the instances of a service in a domain by DNS-Based
+// Service Discovery (RFC 6763): the PTR records for _service._proto.domain
+// name the instances, whose SRV records are looked up with LookupSRVAddrs. It
+// returns the addresses and ports of every instance. The PTR query is sent to
+// authority, a host:port, or to the first nameserver in /etc/resolv.conf if
+// authority is empty.
+func BrowseDNSSD(ctx context.Context, authority string, service, proto, domain string) (map[string][]uint16, error) {
+	if authority == "" {
+		config, err := dns.ClientConfigFromFile("/etc/resolv.conf")
+		if err != nil {
+			return nil, err
+		}
+		if len(config.Servers) == 0 {
+			return nil, fmt.Errorf("no nameservers in /etc/resolv.conf")
+		}
+		authority = net.JoinHostPort(config.Servers[0], config.Port)
+	}
+
+	name := dns.Fqdn(fmt.Sprintf("_%s._%s.%s", service, proto, domain))
+	m := new(dns.Msg)
+	m.SetQuestion(name, dns.TypePTR)
+	client := &dns.Client{Net: "udp"}
+	r, _, err := client.ExchangeContext(ctx, m, authority)
+	if err == nil && r.Truncated {
+		client.Net = "tcp"
+		r, _, err = client.ExchangeContext(ctx, m, authority)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup PTR records for %q: %w", name, err)
+	}
+	if r.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("failed to lookup PTR records for %q: %s", name, dns.RcodeToString[r.Rcode])
+	}
+
+	resolver := NewResolver(authority)
+	addrPorts := make(map[string][]uint16)
+	for _, rr := range r.Answer {
+		ptr, ok := rr.(*dns.PTR)
+		if !ok || !strings.EqualFold(ptr.Hdr.Name, name) {
+			continue
+		}
+		instance, err := LookupSRVAddrs(ctx, resolver, "", "", ptr.Ptr)
+		if err != nil {
+			return nil, err
+		}
+		for addr, ports := range instance {
+			addrPorts[addr] = append(addrPorts[addr], ports...)
+		}
+	}
+	if len(addrPorts) == 0 {
+		return nil, fmt.Errorf("no service instances found for %q", name)
+	}
+	return addrPorts, nil
+}
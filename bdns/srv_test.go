@@ -0,0 +1,77 @@
+package bdns
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/miekg/dns"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+// serveDiscovery answers queries for the "sa" service in the "test" domain,
+// which has two backends, both by SRV and by DNS-SD.
+func serveDiscovery(w dns.ResponseWriter, r *dns.Msg) {
+	m := new(dns.Msg)
+	m.SetReply(r)
+	q := r.Question[0]
+	hdr := dns.RR_Header{Name: q.Name, Rrtype: q.Qtype, Class: dns.ClassINET, Ttl: 0}
+	switch {
+	case q.Qtype == dns.TypeSRV && q.Name == "_sa._tcp.test.":
+		m.Answer = append(m.Answer,
+			&dns.SRV{Hdr: hdr, Target: "sa1.test.", Port: 9095},
+			&dns.SRV{Hdr: hdr, Target: "sa2.test.", Port: 9096})
+	case q.Qtype == dns.TypeSRV && strings.HasSuffix(q.Name, "._sa._tcp.test."):
+		m.Answer = append(m.Answer,
+			&dns.SRV{Hdr: hdr, Target: strings.TrimSuffix(q.Name, "._sa._tcp.test.") + ".test.", Port: 9095})
+	case q.Qtype == dns.TypePTR && q.Name == "_sa._tcp.test.":
+		m.Answer = append(m.Answer,
+			&dns.PTR{Hdr: hdr, Ptr: "sa1._sa._tcp.test."},
+			&dns.PTR{Hdr: hdr, Ptr: "sa2._sa._tcp.test."})
+	case q.Qtype == dns.TypeA && q.Name == "sa1.test.":
+		m.Answer = append(m.Answer, &dns.A{Hdr: hdr, A: net.ParseIP("10.0.0.1")})
+	case q.Qtype == dns.TypeA && q.Name == "sa2.test.":
+		m.Answer = append(m.Answer, &dns.A{Hdr: hdr, A: net.ParseIP("10.0.0.2")})
+	case q.Qtype == dns.TypeAAAA && (q.Name == "sa1.test." || q.Name == "sa2.test."):
+	default:
+		m.Rcode = dns.RcodeNameError
+	}
+	_ = w.WriteMsg(m)
+}
+
+func startDiscoveryServer(t *testing.T) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	test.AssertNotError(t, err, "listening")
+	server := &dns.Server{PacketConn: conn, Handler: dns.HandlerFunc(serveDiscovery)}
+	go func() { _ = server.ActivateAndServe() }()
+	t.Cleanup(func() { _ = server.Shutdown() })
+	return conn.LocalAddr().String()
+}
+
+func TestLookupSRVAddrs(t *testing.T) {
+	authority := startDiscoveryServer(t)
+	resolver := NewResolver(authority)
+
+	addrs, err := LookupSRVAddrs(context.Background(), resolver, "sa", "tcp", "test")
+	test.AssertNotError(t, err, "LookupSRVAddrs failed")
+	test.AssertDeepEquals(t, addrs, map[string][]uint16{"10.0.0.1": {9095}, "10.0.0.2": {9096}})
+
+	_, err = LookupSRVAddrs(context.Background(), resolver, "ra", "tcp", "test")
+	test.AssertError(t, err, "LookupSRVAddrs succeeded for a missing service")
+	test.AssertContains(t, err.Error(), `failed to lookup SRV records for "test"`)
+}
+
+func TestBrowseDNSSD(t *testing.T) {
+	authority := startDiscoveryServer(t)
+
+	addrs, err := BrowseDNSSD(context.Background(), authority, "sa", "tcp", "test")
+	test.AssertNotError(t, err, "BrowseDNSSD failed")
+	test.AssertDeepEquals(t, addrs, map[string][]uint16{"10.0.0.1": {9095}, "10.0.0.2": {9095}})
+
+	_, err = BrowseDNSSD(context.Background(), authority, "ra", "tcp", "test")
+	test.AssertError(t, err, "BrowseDNSSD succeeded for a missing service")
+	test.AssertEquals(t, err.Error(), `failed to lookup PTR records for "_ra._tcp.test.": NXDOMAIN`)
+}
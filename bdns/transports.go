@@ -0,0 +1,306 @@
+package bdns
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Transports by which an upstream resolver can be queried.
+const (
+	// TransportUDP is plain DNS over UDP. It is the default.
+	TransportUDP = "udp"
+	// TransportTLS is DNS over TLS (RFC 7858).
+	TransportTLS = "tls"
+	// TransportHTTPS is DNS over HTTPS (RFC 8484).
+	TransportHTTPS = "https"
+)
+
+// defaultMaxIdleConns is the number of idle connections kept open to each
+// DNS over TLS or DNS over HTTPS resolver if not configured.
+const defaultMaxIdleConns = 4
+
+// dohContentType is the media type of DNS over HTTPS requests and responses.
+const dohContentType = "application/dns-message"
+
+// ServerConfig configures a single upstream resolver.
+type ServerConfig struct {
+	// Address is the host:port of a UDP or DNS over TLS resolver, or the
+	// https URL of a DNS over HTTPS resolver's query endpoint. It is also used
+	// as the "resolver" label of metrics.
+	Address string
+	// Transport is one of TransportUDP, TransportTLS or TransportHTTPS. It
+	// defaults to TransportUDP.
+	Transport string
+	// ServerName is the name the resolver's certificate is verified against.
+	// It defaults to the host in Address.
+	ServerName string
+	// CACertFile is a PEM file of the roots to verify the resolver's
+	// certificate with. The system roots are used if it isn't set.
+	CACertFile string
+	// SPKIPins pins the resolver's certificate: if any are given, the
+	// base64 encoded SHA-256 hash of the certificate's SubjectPublicKeyInfo
+	// must be one of them, as well as the certificate being valid.
+	SPKIPins []string
+	// MaxIdleConns is the most connections which are kept open to the
+	// resolver between queries. It defaults to 4.
+	MaxIdleConns int
+}
+
+// WithServers configures the transport used for each of the given servers by
+// a Client returned from New or NewTest, whose ServerProvider should provide
+// their addresses. Servers which aren't configured are queried over UDP.
+func WithServers(client Client, configs []ServerConfig) (Client, error) {
+	c := client.(*impl)
+	transports := make(map[string]exchanger)
+	for _, sc := range configs {
+		if _, present := transports[sc.Address]; present {
+			return nil, fmt.Errorf("server %q configured more than once", sc.Address)
+		}
+		ex, err := sc.exchanger(c.readTimeout, c.connections)
+		if err != nil {
+			return nil, fmt.Errorf("server %q: %w", sc.Address, err)
+		}
+		transports[sc.Address] = ex
+	}
+	c.transports = transports
+	return c, nil
+}
+
+// exchanger returns an exchanger for the server's transport.
+func (sc ServerConfig) exchanger(readTimeout time.Duration, connections *prometheus.CounterVec) (exchanger, error) {
+	maxIdle := sc.MaxIdleConns
+	if maxIdle <= 0 {
+		maxIdle = defaultMaxIdleConns
+	}
+	switch sc.Transport {
+	case "", TransportUDP:
+		if len(sc.SPKIPins) != 0 || sc.CACertFile != "" || sc.ServerName != "" {
+			return nil, errors.New("TLS settings given for a UDP server")
+		}
+		return &dns.Client{Net: "udp", ReadTimeout: readTimeout}, nil
+	case TransportTLS:
+		host, _, err := net.SplitHostPort(sc.Address)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig, err := sc.tlsConfig(host)
+		if err != nil {
+			return nil, err
+		}
+		return &tlsExchanger{
+			client:      &dns.Client{Net: "tcp-tls", TLSConfig: tlsConfig, Timeout: readTimeout},
+			idle:        make(chan *dns.Conn, maxIdle),
+			connections: connections,
+		}, nil
+	case TransportHTTPS:
+		u, err := url.Parse(sc.Address)
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme != "https" || u.Host == "" {
+			return nil, errors.New("DNS over HTTPS address must be an https URL")
+		}
+		tlsConfig, err := sc.tlsConfig(u.Hostname())
+		if err != nil {
+			return nil, err
+		}
+		return &httpsExchanger{
+			url: sc.Address,
+			client: &http.Client{
+				Timeout: readTimeout,
+				Transport: &http.Transport{
+					DialContext:         (&net.Dialer{Timeout: readTimeout}).DialContext,
+					TLSClientConfig:     tlsConfig,
+					TLSHandshakeTimeout: readTimeout,
+					ForceAttemptHTTP2:   true,
+					MaxIdleConnsPerHost: maxIdle,
+					IdleConnTimeout:     90 * time.Second,
+				},
+			},
+			connections: connections,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown transport %q", sc.Transport)
+	}
+}
+
+// tlsConfig returns the TLS configuration for connecting to the server, whose
+// certificate is verified against host unless ServerName is set.
+func (sc ServerConfig) tlsConfig(host string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: host,
+		MinVersion: tls.VersionTLS12,
+	}
+	if sc.ServerName != "" {
+		tlsConfig.ServerName = sc.ServerName
+	}
+	if sc.CACertFile != "" {
+		pemBytes, err := ioutil.ReadFile(sc.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pemBytes) {
+			return nil, fmt.Errorf("no certificates found in %s", sc.CACertFile)
+		}
+		tlsConfig.RootCAs = roots
+	}
+	if len(sc.SPKIPins) != 0 {
+		var pins [][]byte
+		for _, pin := range sc.SPKIPins {
+			decoded, err := base64.StdEncoding.DecodeString(pin)
+			if err != nil || len(decoded) != sha256.Size {
+				return nil, fmt.Errorf("SPKI pin %q is not a base64 encoded SHA-256 hash", pin)
+			}
+			pins = append(pins, decoded)
+		}
+		tlsConfig.VerifyPeerCertificate = verifySPKIPins(pins)
+	}
+	return tlsConfig, nil
+}
+
+// verifySPKIPins returns a function for tls.Config.VerifyPeerCertificate
+// which requires the hash of the leaf certificate's SubjectPublicKeyInfo to be
+// one of the pins. It is only called after the usual verification succeeds.
+func verifySPKIPins(pins [][]byte) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("resolver presented no certificate")
+		}
+		cert, err := x509.ParseCertificate(rawCerts[0])
+		if err != nil {
+			return err
+		}
+		hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+		for _, pin := range pins {
+			if bytes.Equal(hash[:], pin) {
+				return nil
+			}
+		}
+		return fmt.Errorf("resolver certificate public key %s doesn't match any pin",
+			base64.StdEncoding.EncodeToString(hash[:]))
+	}
+}
+
+// tlsExchanger queries a DNS over TLS resolver, reusing connections between
+// queries. Each connection is used for one query at a time.
+type tlsExchanger struct {
+	client      *dns.Client
+	idle        chan *dns.Conn
+	connections *prometheus.CounterVec
+}
+
+func (te *tlsExchanger) Exchange(m *dns.Msg, a string) (*dns.Msg, time.Duration, error) {
+	select {
+	case conn := <-te.idle:
+		resp, rtt, err := te.client.ExchangeWithConn(m, conn)
+		if err == nil {
+			te.connections.With(prometheus.Labels{"resolver": a, "transport": TransportTLS, "type": "reused"}).Inc()
+			te.release(conn)
+			return resp, rtt, nil
+		}
+		_ = conn.Close()
+		// The resolver may have closed the idle connection, so try again
+		// with a new one, unless it has simply stopped answering.
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return resp, rtt, err
+		}
+	default:
+	}
+
+	conn, err := te.client.Dial(a)
+	if err != nil {
+		return nil, 0, err
+	}
+	te.connections.With(prometheus.Labels{"resolver": a, "transport": TransportTLS, "type": "new"}).Inc()
+	resp, rtt, err := te.client.ExchangeWithConn(m, conn)
+	if err != nil {
+		_ = conn.Close()
+		return resp, rtt, err
+	}
+	te.release(conn)
+	return resp, rtt, nil
+}
+
+// release returns a connection to the idle pool, or closes it if the pool is
+// full.
+func (te *tlsExchanger) release(conn *dns.Conn) {
+	select {
+	case te.idle <- conn:
+	default:
+		_ = conn.Close()
+	}
+}
+
+// httpsExchanger queries a DNS over HTTPS resolver by POSTing queries to its
+// URL. Connections are pooled by the underlying http.Transport.
+type httpsExchanger struct {
+	url         string
+	client      *http.Client
+	connections *prometheus.CounterVec
+}
+
+func (he *httpsExchanger) Exchange(m *dns.Msg, a string) (*dns.Msg, time.Duration, error) {
+	packed, err := m.Pack()
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, he.url, bytes.NewReader(packed))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", dohContentType)
+	req.Header.Set("Accept", dohContentType)
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			connType := "new"
+			if info.Reused {
+				connType = "reused"
+			}
+			he.connections.With(prometheus.Labels{"resolver": a, "transport": TransportHTTPS, "type": connType}).Inc()
+		},
+	}))
+
+	start := time.Now()
+	resp, err := he.client.Do(req)
+	if err != nil {
+		return nil, time.Since(start), err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, dns.MaxMsgSize))
+	rtt := time.Since(start)
+	if err != nil {
+		return nil, rtt, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, rtt, fmt.Errorf("DNS over HTTPS resolver returned HTTP status %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != dohContentType {
+		return nil, rtt, fmt.Errorf("DNS over HTTPS resolver returned Content-Type %q", contentType)
+	}
+	r := new(dns.Msg)
+	err = r.Unpack(body)
+	if err != nil {
+		return nil, rtt, err
+	}
+	if r.Id != m.Id {
+		return r, rtt, dns.ErrId
+	}
+	return r, rtt, nil
+}
@@ -0,0 +1,241 @@
+package bdns
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
+
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// resolverCert returns a self-signed certificate for 127.0.0.1, the path of a
+// PEM file containing it, and its SPKI pin.
+func resolverCert(t *testing.T) (tls.Certificate, string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "generating key")
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	test.AssertNotError(t, err, "creating certificate")
+	cert, err := x509.ParseCertificate(der)
+	test.AssertNotError(t, err, "parsing certificate")
+
+	f, err := ioutil.TempFile("", "resolver-ca")
+	test.AssertNotError(t, err, "creating temp file")
+	t.Cleanup(func() { os.Remove(f.Name()) })
+	err = pem.Encode(f, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	test.AssertNotError(t, err, "writing temp file")
+	f.Close()
+
+	pin := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, f.Name(), base64.StdEncoding.EncodeToString(pin[:])
+}
+
+// answerTXT answers every query with a single TXT record.
+func answerTXT(q *dns.Msg) *dns.Msg {
+	m := new(dns.Msg)
+	m.SetReply(q)
+	m.Answer = append(m.Answer, &dns.TXT{
+		Hdr: dns.RR_Header{Name: q.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 0},
+		Txt: []string{"over an encrypted transport"},
+	})
+	return m
+}
+
+// serveDoH answers DNS over HTTPS queries POSTed to /dns-query with
+// answerTXT.
+func serveDoH(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/dns-query" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != dohContentType {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	q := new(dns.Msg)
+	if err != nil || q.Unpack(body) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	packed, _ := answerTXT(q).Pack()
+	w.Header().Set("Content-Type", dohContentType)
+	_, _ = w.Write(packed)
+}
+
+func newTransportTestClient(t *testing.T, sc ServerConfig) Client {
+	t.Helper()
+	client := NewTest(time.Second, NewStaticProvider([]string{sc.Address}), metrics.NoopRegisterer, clock.New(), 1, blog.UseMock())
+	client, err := WithServers(client, []ServerConfig{sc})
+	test.AssertNotError(t, err, "configuring servers")
+	return client
+}
+
+func TestDNSOverTLS(t *testing.T) {
+	cert, caFile, pin := resolverCert(t)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	test.AssertNotError(t, err, "listening")
+	server := &dns.Server{
+		Listener: listener,
+		Net:      "tcp-tls",
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, q *dns.Msg) {
+			_ = w.WriteMsg(answerTXT(q))
+		}),
+	}
+	go func() { _ = server.ActivateAndServe() }()
+	defer func() { _ = server.Shutdown() }()
+	addr := listener.Addr().String()
+
+	client := newTransportTestClient(t, ServerConfig{Address: addr, Transport: TransportTLS, CACertFile: caFile, SPKIPins: []string{pin}})
+	for i := 0; i < 3; i++ {
+		txts, _, err := client.LookupTXT(context.Background(), "letsencrypt.org")
+		test.AssertNotError(t, err, "LookupTXT over TLS failed")
+		test.AssertDeepEquals(t, txts, []string{"over an encrypted transport"})
+	}
+	// The connection was reused for the later queries.
+	connections := client.(*impl).connections
+	test.AssertMetricWithLabelsEquals(t, connections, prometheus.Labels{"resolver": addr, "transport": TransportTLS, "type": "new"}, 1)
+	test.AssertMetricWithLabelsEquals(t, connections, prometheus.Labels{"resolver": addr, "transport": TransportTLS, "type": "reused"}, 2)
+
+	// A resolver whose certificate doesn't match the pin is refused.
+	wrongPin := base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+	client = newTransportTestClient(t, ServerConfig{Address: addr, Transport: TransportTLS, CACertFile: caFile, SPKIPins: []string{wrongPin}})
+	_, _, err = client.LookupTXT(context.Background(), "letsencrypt.org")
+	test.AssertError(t, err, "LookupTXT succeeded with the wrong pin")
+
+	// As is one whose certificate isn't trusted.
+	client = newTransportTestClient(t, ServerConfig{Address: addr, Transport: TransportTLS, SPKIPins: []string{pin}})
+	_, _, err = client.LookupTXT(context.Background(), "letsencrypt.org")
+	test.AssertError(t, err, "LookupTXT succeeded with an untrusted certificate")
+}
+
+func TestDNSOverHTTPS(t *testing.T) {
+	cert, caFile, pin := resolverCert(t)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	test.AssertNotError(t, err, "listening")
+	server := &http.Server{Handler: http.HandlerFunc(serveDoH)}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+	addr := "https://" + listener.Addr().String() + "/dns-query"
+
+	client := newTransportTestClient(t, ServerConfig{Address: addr, Transport: TransportHTTPS, CACertFile: caFile, SPKIPins: []string{pin}})
+	for i := 0; i < 3; i++ {
+		txts, _, err := client.LookupTXT(context.Background(), "letsencrypt.org")
+		test.AssertNotError(t, err, "LookupTXT over HTTPS failed")
+		test.AssertDeepEquals(t, txts, []string{"over an encrypted transport"})
+	}
+	connections := client.(*impl).connections
+	test.AssertMetricWithLabelsEquals(t, connections, prometheus.Labels{"resolver": addr, "transport": TransportHTTPS, "type": "new"}, 1)
+	test.AssertMetricWithLabelsEquals(t, connections, prometheus.Labels{"resolver": addr, "transport": TransportHTTPS, "type": "reused"}, 2)
+
+	wrongPin := base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+	client = newTransportTestClient(t, ServerConfig{Address: addr, Transport: TransportHTTPS, CACertFile: caFile, SPKIPins: []string{wrongPin}})
+	_, _, err = client.LookupTXT(context.Background(), "letsencrypt.org")
+	test.AssertError(t, err, "LookupTXT succeeded with the wrong pin")
+
+	client = newTransportTestClient(t, ServerConfig{Address: addr + "-missing", Transport: TransportHTTPS, CACertFile: caFile})
+	_, _, err = client.LookupTXT(context.Background(), "letsencrypt.org")
+	test.AssertError(t, err, "LookupTXT succeeded despite an HTTP error")
+}
+
+func TestRetryWithOtherTransport(t *testing.T) {
+	cert, caFile, _ := resolverCert(t)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{cert}})
+	test.AssertNotError(t, err, "listening")
+	server := &http.Server{Handler: http.HandlerFunc(serveDoH)}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+	dohAddr := "https://" + listener.Addr().String() + "/dns-query"
+	udpAddr := "127.0.0.1:53"
+
+	// The first server is queried over UDP and fails with a temporary error,
+	// so the query is retried on the second, over its own transport.
+	client := NewTest(time.Second, NewStaticProvider([]string{udpAddr, dohAddr}), metrics.NoopRegisterer, clock.New(), 2, blog.UseMock())
+	client, err = WithServers(client, []ServerConfig{{Address: dohAddr, Transport: TransportHTTPS, CACertFile: caFile}})
+	test.AssertNotError(t, err, "configuring servers")
+	udp := &testExchanger{errs: []error{&net.OpError{Op: "read", Err: tempError(true)}}}
+	client.(*impl).dnsClient = udp
+
+	txts, _, err := client.LookupTXT(context.Background(), "letsencrypt.org")
+	test.AssertNotError(t, err, "LookupTXT failed after retrying")
+	test.AssertDeepEquals(t, txts, []string{"over an encrypted transport"})
+	test.AssertEquals(t, udp.count, 1)
+	connections := client.(*impl).connections
+	test.AssertMetricWithLabelsEquals(t, connections, prometheus.Labels{"resolver": dohAddr, "transport": TransportHTTPS, "type": "new"}, 1)
+}
+
+func TestWithServersErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		configs []ServerConfig
+		err     string
+	}{
+		{
+			name:    "unknown transport",
+			configs: []ServerConfig{{Address: "127.0.0.1:53", Transport: "quic"}},
+			err:     `server "127.0.0.1:53": unknown transport "quic"`,
+		},
+		{
+			name:    "duplicate server",
+			configs: []ServerConfig{{Address: "127.0.0.1:53"}, {Address: "127.0.0.1:53"}},
+			err:     `server "127.0.0.1:53" configured more than once`,
+		},
+		{
+			name:    "UDP with pins",
+			configs: []ServerConfig{{Address: "127.0.0.1:53", SPKIPins: []string{"pin"}}},
+			err:     `server "127.0.0.1:53": TLS settings given for a UDP server`,
+		},
+		{
+			name:    "TLS without port",
+			configs: []ServerConfig{{Address: "127.0.0.1", Transport: TransportTLS}},
+			err:     `server "127.0.0.1": address 127.0.0.1: missing port in address`,
+		},
+		{
+			name:    "HTTPS with http URL",
+			configs: []ServerConfig{{Address: "http://127.0.0.1/dns-query", Transport: TransportHTTPS}},
+			err:     `server "http://127.0.0.1/dns-query": DNS over HTTPS address must be an https URL`,
+		},
+		{
+			name:    "bad pin",
+			configs: []ServerConfig{{Address: "127.0.0.1:853", Transport: TransportTLS, SPKIPins: []string{"bm90IGEgaGFzaA=="}}},
+			err:     `server "127.0.0.1:853": SPKI pin "bm90IGEgaGFzaA==" is not a base64 encoded SHA-256 hash`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := NewTest(time.Second, NewStaticProvider(nil), metrics.NoopRegisterer, clock.New(), 1, blog.UseMock())
+			_, err := WithServers(client, tc.configs)
+			test.AssertError(t, err, "invalid configuration accepted")
+			test.AssertEquals(t, err.Error(), tc.err)
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
 	"time"
 
@@ -45,6 +46,7 @@ type certificateStorage interface {
 	AddCertificate(context.Context, []byte, int64, []byte, *time.Time) (string, error)
 	GetCertificate(context.Context, string) (core.Certificate, error)
 	AddPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error)
+	AddCertificateWithoutPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error)
 	AddSerial(ctx context.Context, req *sapb.AddSerialRequest) (*corepb.Empty, error)
 }
 
@@ -55,18 +57,25 @@ const (
 	certType    = certificateType("certificate")
 )
 
-// Four maps of keys to internalIssuers. Lookup by PublicKeyAlgorithm is
-// useful for determining which issuer to use to sign a given (pre)cert, based
-// on its PublicKeyAlgorithm. Lookup by ID and by NameID is useful for looking
-// up the appropriate issuer to use for OCSP, where byID uses the value currently
-// stored in the certStatus database table, and byNameID is an easier-to-compute
-// replacement.
+// Maps of keys to internalIssuers. Lookup by PublicKeyAlgorithm is useful for
+// determining which issuer to use to sign a given (pre)cert, based on its
+// PublicKeyAlgorithm, and gives a pool of issuers to choose from. Lookup by ID
+// and by NameID is useful for looking up the appropriate issuer to use for
+// OCSP, where byID uses the value currently stored in the certStatus database
+// table, and byNameID is an easier-to-compute replacement.
 type issuerMaps struct {
-	byAlg    map[x509.PublicKeyAlgorithm]*internalIssuer
+	byAlg    map[x509.PublicKeyAlgorithm]*issuerPool
 	byID     map[issuance.IssuerID]*internalIssuer
 	byNameID map[issuance.IssuerNameID]*internalIssuer
 }
 
+// certProfile is the set of issuers which issue certificates under a single
+// certificate profile, along with how long those certificates are valid for.
+type certProfile struct {
+	issuers        issuerMaps
+	validityPeriod time.Duration
+}
+
 // CertificateAuthorityImpl represents a CA that signs certificates, CRLs, and
 // OCSP responses.
 type CertificateAuthorityImpl struct {
@@ -75,6 +84,7 @@ type CertificateAuthorityImpl struct {
 	sa                 certificateStorage
 	pa                 core.PolicyAuthority
 	issuers            issuerMaps
+	certProfiles       map[string]*certProfile
 	ecdsaAllowedRegIDs map[int64]bool
 	prefix             int // Prepended to the serial number
 	validityPeriod     time.Duration
@@ -91,6 +101,7 @@ type CertificateAuthorityImpl struct {
 	orphanCount        *prometheus.CounterVec
 	adoptedOrphanCount *prometheus.CounterVec
 	signErrorCounter   *prometheus.CounterVec
+	issuerSelections   *prometheus.CounterVec
 }
 
 // Issuer represents a single issuer certificate, along with its key.
@@ -108,8 +119,17 @@ type internalIssuer struct {
 	boulderIssuer *issuance.Issuer
 }
 
-func makeInternalIssuers(issuers []*issuance.Issuer, lifespanOCSP time.Duration) (issuerMaps, error) {
-	issuersByAlg := make(map[x509.PublicKeyAlgorithm]*internalIssuer, 2)
+// makeInternalIssuers builds the issuerMaps for the given issuers, choosing
+// between the issuers for each public key algorithm with the named selection
+// policy. Only active issuers are chosen between, but every issuer can be
+// looked up by ID and NameID, so that those which are no longer active can
+// still sign OCSP or give clear errors.
+func makeInternalIssuers(issuers []*issuance.Issuer, selection string, lifespanOCSP time.Duration) (issuerMaps, error) {
+	err := validSelection(selection)
+	if err != nil {
+		return issuerMaps{}, err
+	}
+	algIssuers := make(map[x509.PublicKeyAlgorithm][]*internalIssuer, 2)
 	issuersByID := make(map[issuance.IssuerID]*internalIssuer, len(issuers))
 	issuersByNameID := make(map[issuance.IssuerNameID]*internalIssuer, len(issuers))
 	for _, issuer := range issuers {
@@ -118,27 +138,56 @@ func makeInternalIssuers(issuers []*issuance.Issuer, lifespanOCSP time.Duration)
 			ocspSigner:    issuer.Signer,
 			boulderIssuer: issuer,
 		}
-		for _, alg := range issuer.Algs() {
-			// TODO(#5259): Enforce that there is only one issuer for each algorithm,
-			// instead of taking the first issuer for each algorithm type.
-			if issuersByAlg[alg] == nil {
-				issuersByAlg[alg] = ii
+		if issuer.Profile.State().CanIssue() {
+			for _, alg := range issuer.Algs() {
+				algIssuers[alg] = append(algIssuers[alg], ii)
 			}
 		}
 		issuersByID[issuer.ID()] = ii
 		issuersByNameID[issuer.Cert.NameID()] = ii
 	}
+	issuersByAlg := make(map[x509.PublicKeyAlgorithm]*issuerPool, len(algIssuers))
+	for alg, iis := range algIssuers {
+		issuersByAlg[alg] = newIssuerPool(selection, iis)
+	}
 	return issuerMaps{issuersByAlg, issuersByID, issuersByNameID}, nil
 }
 
+// checkCTAgreement returns an error if the issuers which may sign under a
+// profile disagree about whether CT is disabled. The RA decides whether to
+// request a precertificate before the CA picks an issuer, so a profile must
+// issue the same way whichever issuer is chosen.
+func checkCTAgreement(profileName string, issuers []*issuance.Issuer) error {
+	var first *issuance.Issuer
+	for _, issuer := range issuers {
+		if !issuer.Profile.State().CanIssue() {
+			continue
+		}
+		if first == nil {
+			first = issuer
+			continue
+		}
+		if issuer.Profile.DisablesCT() != first.Profile.DisablesCT() {
+			return fmt.Errorf("certificate profile %q: issuers %s and %s disagree about whether CT is disabled",
+				profileName, first.Name(), issuer.Name())
+		}
+	}
+	return nil
+}
+
 // NewCertificateAuthorityImpl creates a CA instance that can sign certificates
-// from a single issuer (the first first in the issuers slice), and can sign OCSP
-// for any of the issuer certificates provided.
+// from any of the issuers, choosing between those with the same public key
+// algorithm according to issuerSelection, and can sign OCSP for any of the
+// issuer certificates provided. Each entry in profileIssuers is
+// a named certificate profile which may be requested instead of the default
+// one, made up of issuers for the same certificates as boulderIssuers.
 func NewCertificateAuthorityImpl(
 	sa certificateStorage,
 	pa core.PolicyAuthority,
 	boulderIssuers []*issuance.Issuer,
+	profileIssuers map[string][]*issuance.Issuer,
 	ecdsaAllowedRegIDs []int64,
+	issuerSelection string,
 	certExpiry time.Duration,
 	certBackdate time.Duration,
 	serialPrefix int,
@@ -166,11 +215,43 @@ func NewCertificateAuthorityImpl(
 		err = errors.New("Must have a positive non-zero serial prefix less than 256 for CA.")
 		return nil, err
 	}
-	issuers, err := makeInternalIssuers(boulderIssuers, ocspLifetime)
+	err = checkCTAgreement("", boulderIssuers)
+	if err != nil {
+		return nil, err
+	}
+	issuers, err := makeInternalIssuers(boulderIssuers, issuerSelection, ocspLifetime)
 	if err != nil {
 		return nil, err
 	}
 
+	// The default profile is selected by the empty name.
+	certProfiles := map[string]*certProfile{
+		"": {issuers: issuers, validityPeriod: certExpiry},
+	}
+	for name, profIssuers := range profileIssuers {
+		if name == "" {
+			return nil, errors.New("certificate profile names must not be empty")
+		}
+		if len(profIssuers) == 0 {
+			return nil, fmt.Errorf("certificate profile %q has no issuers", name)
+		}
+		err = checkCTAgreement(name, profIssuers)
+		if err != nil {
+			return nil, err
+		}
+		profMaps, err := makeInternalIssuers(profIssuers, issuerSelection, ocspLifetime)
+		if err != nil {
+			return nil, err
+		}
+		// Every issuer in a profile was built from the same profile config, so
+		// any of them can tell us the validity period.
+		validityPeriod := profIssuers[0].Profile.ValidityPeriod()
+		if validityPeriod == 0 {
+			validityPeriod = certExpiry
+		}
+		certProfiles[name] = &certProfile{issuers: profMaps, validityPeriod: validityPeriod}
+	}
+
 	ecdsaAllowedRegIDsMap := make(map[int64]bool, len(ecdsaAllowedRegIDs))
 	for _, regID := range ecdsaAllowedRegIDs {
 		ecdsaAllowedRegIDsMap[regID] = true
@@ -208,6 +289,14 @@ func NewCertificateAuthorityImpl(
 		[]string{"type"})
 	stats.MustRegister(adoptedOrphanCount)
 
+	issuerSelections := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "issuer_selections",
+			Help: "Number of times each issuer was chosen to issue a certificate, labelled by issuer and certificate profile",
+		},
+		[]string{"issuer", "profile"})
+	stats.MustRegister(issuerSelections)
+
 	signErrorCounter := prometheus.NewCounterVec(prometheus.CounterOpts{
 		Name: "signature_errors",
 		Help: "A counter of signature errors labelled by error type",
@@ -223,6 +312,7 @@ func NewCertificateAuthorityImpl(
 		sa:                 sa,
 		pa:                 pa,
 		issuers:            issuers,
+		certProfiles:       certProfiles,
 		ecdsaAllowedRegIDs: ecdsaAllowedRegIDsMap,
 		validityPeriod:     certExpiry,
 		backdate:           certBackdate,
@@ -238,6 +328,7 @@ func NewCertificateAuthorityImpl(
 		orphanCount:        orphanCount,
 		adoptedOrphanCount: adoptedOrphanCount,
 		signErrorCounter:   signErrorCounter,
+		issuerSelections:   issuerSelections,
 		clk:                clk,
 	}
 
@@ -275,6 +366,10 @@ func (ca *CertificateAuthorityImpl) GenerateOCSP(ctx context.Context, req *capb.
 	if !ok {
 		return nil, fmt.Errorf("This CA doesn't have an issuer cert with ID %d", req.IssuerID)
 	}
+	state := issuer.boulderIssuer.Profile.State()
+	if !state.CanSignRevocation() {
+		return nil, berrors.InternalServerError("issuer %s is %s and no longer signs OCSP responses", issuer.boulderIssuer.Name(), state)
+	}
 
 	now := ca.clk.Now().Truncate(time.Hour)
 	tbsResponse := ocsp.Response{
@@ -300,54 +395,52 @@ func (ca *CertificateAuthorityImpl) GenerateOCSP(ctx context.Context, req *capb.
 	return &capb.OCSPResponse{Response: ocspResponse}, err
 }
 
-func (ca *CertificateAuthorityImpl) IssuePrecertificate(ctx context.Context, issueReq *capb.IssueCertificateRequest) (*capb.IssuePrecertificateResponse, error) {
-	// issueReq.orderID may be zero, for ACMEv1 requests.
-	if core.IsAnyNilOrZero(issueReq, issueReq.Csr, issueReq.RegistrationID) {
-		return nil, berrors.InternalServerError("Incomplete issue certificate request")
-	}
-
-	serialBigInt, validity, err := ca.generateSerialNumberAndValidity()
-	if err != nil {
-		return nil, err
+// GetIssuers lists every issuer this CA is configured with, sorted by name,
+// along with its lifecycle state.
+func (ca *CertificateAuthorityImpl) GetIssuers(ctx context.Context, _ *corepb.Empty) (*capb.Issuers, error) {
+	resp := &capb.Issuers{}
+	for _, issuer := range ca.issuers.byNameID {
+		resp.Issuers = append(resp.Issuers, &capb.Issuer{
+			Id:       int64(issuer.cert.ID()),
+			NameID:   int64(issuer.cert.NameID()),
+			Name:     issuer.boulderIssuer.Name(),
+			State:    string(issuer.boulderIssuer.Profile.State()),
+			NotAfter: issuer.cert.NotAfter.UnixNano(),
+		})
 	}
-
-	serialHex := core.SerialToString(serialBigInt)
-	regID := issueReq.RegistrationID
-	nowNanos := ca.clk.Now().UnixNano()
-	expiresNanos := validity.NotAfter.UnixNano()
-	_, err = ca.sa.AddSerial(ctx, &sapb.AddSerialRequest{
-		Serial:  serialHex,
-		RegID:   regID,
-		Created: nowNanos,
-		Expires: expiresNanos,
+	sort.Slice(resp.Issuers, func(i, j int) bool {
+		return resp.Issuers[i].Name < resp.Issuers[j].Name
 	})
+	return resp, nil
+}
+
+func (ca *CertificateAuthorityImpl) IssuePrecertificate(ctx context.Context, issueReq *capb.IssueCertificateRequest) (*capb.IssuePrecertificateResponse, error) {
+	profile, serialBigInt, validity, err := ca.beginIssuance(ctx, issueReq)
 	if err != nil {
 		return nil, err
 	}
 
-	precertDER, issuer, err := ca.issuePrecertificateInner(ctx, issueReq, serialBigInt, validity)
+	precertDER, issuer, err := ca.issueInner(ctx, issueReq, profile, serialBigInt, validity, precertType)
 	if err != nil {
 		return nil, err
 	}
+	serialHex := core.SerialToString(serialBigInt)
+	regID := issueReq.RegistrationID
+	nowNanos := ca.clk.Now().UnixNano()
 	issuerID := issuer.cert.ID()
 
-	ocspResp, err := ca.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
-		Serial:   serialHex,
-		IssuerID: int64(issuerID),
-		Status:   string(core.OCSPStatusGood),
-	})
+	ocspResp, omitOCSP, err := ca.initialOCSP(ctx, serialHex, issuer, validity)
 	if err != nil {
-		err = berrors.InternalServerError(err.Error())
-		ca.log.AuditInfof("OCSP Signing failure: serial=[%s] err=[%s]", serialHex, err)
 		return nil, err
 	}
 
 	req := &sapb.AddCertificateRequest{
 		Der:      precertDER,
 		RegID:    regID,
-		Ocsp:     ocspResp.Response,
+		Ocsp:     ocspResp,
 		Issued:   nowNanos,
 		IssuerID: int64(issuerID),
+		OmitOCSP: omitOCSP,
 	}
 
 	_, err = ca.sa.AddPrecertificate(ctx, req)
@@ -362,9 +455,10 @@ func (ca *CertificateAuthorityImpl) IssuePrecertificate(ctx context.Context, iss
 			ca.queueOrphan(&orphanedCert{
 				DER:      precertDER,
 				RegID:    regID,
-				OCSPResp: ocspResp.Response,
+				OCSPResp: ocspResp,
 				Precert:  true,
 				IssuerID: int64(issuerID),
+				OmitOCSP: omitOCSP,
 			})
 		}
 		return nil, err
@@ -375,6 +469,131 @@ func (ca *CertificateAuthorityImpl) IssuePrecertificate(ctx context.Context, iss
 	}, nil
 }
 
+// IssueCertificate issues a final certificate directly, without first issuing
+// a precertificate and collecting SCTs for it. It may only be used with
+// issuers whose profile has CT disabled, and stores the certificate without
+// any record of a precertificate.
+func (ca *CertificateAuthorityImpl) IssueCertificate(ctx context.Context, issueReq *capb.IssueCertificateRequest) (*corepb.Certificate, error) {
+	profile, serialBigInt, validity, err := ca.beginIssuance(ctx, issueReq)
+	if err != nil {
+		return nil, err
+	}
+
+	certDER, issuer, err := ca.issueInner(ctx, issueReq, profile, serialBigInt, validity, certType)
+	if err != nil {
+		return nil, err
+	}
+	serialHex := core.SerialToString(serialBigInt)
+	regID := issueReq.RegistrationID
+	now := ca.clk.Now()
+	issuerID := issuer.cert.ID()
+
+	ocspResp, omitOCSP, err := ca.initialOCSP(ctx, serialHex, issuer, validity)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = ca.sa.AddCertificateWithoutPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:      certDER,
+		RegID:    regID,
+		Ocsp:     ocspResp,
+		Issued:   now.UnixNano(),
+		IssuerID: int64(issuerID),
+		OmitOCSP: omitOCSP,
+	})
+	if err != nil {
+		ca.orphanCount.With(prometheus.Labels{"type": "cert"}).Inc()
+		err = berrors.InternalServerError(err.Error())
+		// Note: This log line is parsed by cmd/orphan-finder. If you make any
+		// changes here, you should make sure they are reflected in orphan-finder.
+		// noPrecertificate marks certificates which orphan-finder must store
+		// along with the records normally written for their precertificate.
+		ca.log.AuditErrf("Failed RPC to store at SA, orphaning certificate: serial=[%s], cert=[%s], issuerID=[%d], regID=[%d], orderID=[%d], noPrecertificate=[true], err=[%v]",
+			serialHex, hex.EncodeToString(certDER), issuerID, regID, issueReq.OrderID, err)
+		if ca.orphanQueue != nil {
+			ca.queueOrphan(&orphanedCert{
+				DER:              certDER,
+				RegID:            regID,
+				OCSPResp:         ocspResp,
+				IssuerID:         int64(issuerID),
+				OmitOCSP:         omitOCSP,
+				NoPrecertificate: true,
+			})
+		}
+		return nil, err
+	}
+
+	return &corepb.Certificate{
+		RegistrationID: regID,
+		Serial:         serialHex,
+		Der:            certDER,
+		Digest:         core.Fingerprint256(certDER),
+		Issued:         validity.NotBefore.UnixNano(),
+		Expires:        validity.NotAfter.UnixNano(),
+	}, nil
+}
+
+// beginIssuance checks an issuance request, and generates and records the
+// serial number and validity window for the certificate or precertificate it
+// asks for.
+func (ca *CertificateAuthorityImpl) beginIssuance(ctx context.Context, issueReq *capb.IssueCertificateRequest) (*certProfile, *big.Int, validity, error) {
+	// issueReq.orderID may be zero, for ACMEv1 requests.
+	if core.IsAnyNilOrZero(issueReq, issueReq.Csr, issueReq.RegistrationID) {
+		return nil, nil, validity{}, berrors.InternalServerError("Incomplete issue certificate request")
+	}
+
+	profile, ok := ca.certProfiles[issueReq.CertificateProfileName]
+	if !ok {
+		return nil, nil, validity{}, berrors.InternalServerError("unknown certificate profile %q", issueReq.CertificateProfileName)
+	}
+
+	var requested validity
+	if issueReq.NotBefore != 0 {
+		requested.NotBefore = time.Unix(0, issueReq.NotBefore)
+	}
+	if issueReq.NotAfter != 0 {
+		requested.NotAfter = time.Unix(0, issueReq.NotAfter)
+	}
+
+	serialBigInt, validity, err := ca.generateSerialNumberAndValidity(profile.validityPeriod, requested)
+	if err != nil {
+		return nil, nil, validity, err
+	}
+
+	_, err = ca.sa.AddSerial(ctx, &sapb.AddSerialRequest{
+		Serial:  core.SerialToString(serialBigInt),
+		RegID:   issueReq.RegistrationID,
+		Created: ca.clk.Now().UnixNano(),
+		Expires: validity.NotAfter.UnixNano(),
+	})
+	if err != nil {
+		return nil, nil, validity, err
+	}
+	return profile, serialBigInt, validity, nil
+}
+
+// initialOCSP returns the OCSP response to store along with a newly issued
+// certificate or precertificate, and whether the certificate was issued
+// without an OCSP URL.
+func (ca *CertificateAuthorityImpl) initialOCSP(ctx context.Context, serialHex string, issuer *internalIssuer, validity validity) ([]byte, bool, error) {
+	// Certificates issued without an OCSP URL never have OCSP responses
+	// generated for them, so there is nothing to sign or store here.
+	if issuer.boulderIssuer.Profile.OmitsOCSP(validity.NotAfter.Sub(validity.NotBefore)) {
+		return nil, true, nil
+	}
+	resp, err := ca.GenerateOCSP(ctx, &capb.GenerateOCSPRequest{
+		Serial:   serialHex,
+		IssuerID: int64(issuer.cert.ID()),
+		Status:   string(core.OCSPStatusGood),
+	})
+	if err != nil {
+		err = berrors.InternalServerError(err.Error())
+		ca.log.AuditInfof("OCSP Signing failure: serial=[%s] err=[%s]", serialHex, err)
+		return nil, false, err
+	}
+	return resp.Response, false, nil
+}
+
 // IssueCertificateForPrecertificate takes a precertificate and a set
 // of SCTs for that precertificate and uses the signer to create and
 // sign a certificate from them. The poison extension is removed and a
@@ -426,10 +645,20 @@ func (ca *CertificateAuthorityImpl) IssueCertificateForPrecertificate(ctx contex
 		scts = append(scts, sct)
 	}
 
-	issuer, ok := ca.issuers.byNameID[issuance.GetIssuerNameID(precert)]
+	// The final certificate must be issued under the same profile as the
+	// precertificate, so that the two match.
+	profile, ok := ca.certProfiles[req.CertificateProfileName]
+	if !ok {
+		return nil, berrors.InternalServerError("unknown certificate profile %q", req.CertificateProfileName)
+	}
+	issuer, ok := profile.issuers.byNameID[issuance.GetIssuerNameID(precert)]
 	if !ok {
 		return nil, berrors.InternalServerError("no issuer found for Issuer Name %s", precert.Issuer)
 	}
+	state := issuer.boulderIssuer.Profile.State()
+	if !state.CanIssue() {
+		return nil, berrors.InternalServerError("issuer %s is %s and no longer issues certificates", issuer.boulderIssuer.Name(), state)
+	}
 
 	issuanceReq, err := issuance.RequestFromPrecert(precert, scts)
 	if err != nil {
@@ -441,7 +670,7 @@ func (ca *CertificateAuthorityImpl) IssueCertificateForPrecertificate(ctx contex
 	}
 	ca.signatureCount.With(prometheus.Labels{"purpose": string(certType), "issuer": issuer.boulderIssuer.Name()}).Inc()
 	ca.log.AuditInfof("Signing success: serial=[%s] names=[%s] csr=[%s] certificate=[%s]",
-		serialHex, strings.Join(precert.DNSNames, ", "), hex.EncodeToString(req.DER),
+		serialHex, strings.Join(core.UniqueLowerNamesAndIPs(precert.DNSNames, precert.IPAddresses), ", "), hex.EncodeToString(req.DER),
 		hex.EncodeToString(certDER))
 	err = ca.storeCertificate(ctx, req.RegistrationID, req.OrderID, precert.SerialNumber, certDER, int64(issuer.cert.ID()))
 	if err != nil {
@@ -462,7 +691,13 @@ type validity struct {
 	NotAfter  time.Time
 }
 
-func (ca *CertificateAuthorityImpl) generateSerialNumberAndValidity() (*big.Int, validity, error) {
+// generateSerialNumberAndValidity returns a new random serial, and the validity
+// window for a certificate valid for validityPeriod. Any non-zero bound in
+// requested is used in place of the default. A requested window is measured
+// from the requested NotBefore, or from now if there is none, just as the RA
+// measures it when the order is created, and may be no longer than
+// validityPeriod.
+func (ca *CertificateAuthorityImpl) generateSerialNumberAndValidity(validityPeriod time.Duration, requested validity) (*big.Int, validity, error) {
 	// We want 136 bits of random number, plus an 8-bit instance id prefix.
 	const randBits = 136
 	serialBytes := make([]byte, randBits/8+1)
@@ -476,16 +711,48 @@ func (ca *CertificateAuthorityImpl) generateSerialNumberAndValidity() (*big.Int,
 	serialBigInt := big.NewInt(0)
 	serialBigInt = serialBigInt.SetBytes(serialBytes)
 
-	notBefore := ca.clk.Now().Add(-1 * ca.backdate)
+	now := ca.clk.Now()
+	notBefore := now.Add(-1 * ca.backdate)
+	// A requested NotBefore which has already passed, for instance because the
+	// order was finalized after the start of the window it asked for, is
+	// replaced by the usual backdated one rather than backdating further.
+	if requested.NotBefore.After(notBefore) {
+		notBefore = requested.NotBefore
+	}
+	notAfter := notBefore.Add(validityPeriod)
+	if !requested.NotAfter.IsZero() {
+		if !requested.NotAfter.After(notBefore) {
+			return nil, validity{}, berrors.MalformedError(
+				"requested notAfter %s is not after the certificate's notBefore %s",
+				requested.NotAfter.Format(time.RFC3339), notBefore.Format(time.RFC3339))
+		}
+		start := now
+		if !requested.NotBefore.IsZero() {
+			start = requested.NotBefore
+		}
+		if requested.NotAfter.Sub(start) > validityPeriod {
+			return nil, validity{}, berrors.MalformedError(
+				"requested validity period %s is longer than the maximum of %s",
+				requested.NotAfter.Sub(start), validityPeriod)
+		}
+		// Backdating, or a requested NotBefore which has since passed, can
+		// move notBefore earlier than the start of the requested window, so
+		// the certificate ends early rather than outliving validityPeriod.
+		if requested.NotAfter.Before(notAfter) {
+			notAfter = requested.NotAfter
+		}
+	}
 	validity := validity{
 		NotBefore: notBefore,
-		NotAfter:  notBefore.Add(ca.validityPeriod),
+		NotAfter:  notAfter,
 	}
 
 	return serialBigInt, validity, nil
 }
 
-func (ca *CertificateAuthorityImpl) issuePrecertificateInner(ctx context.Context, issueReq *capb.IssueCertificateRequest, serialBigInt *big.Int, validity validity) ([]byte, *internalIssuer, error) {
+// issueInner selects an issuer for the request and signs either a
+// precertificate or, for issuers with CT disabled, a final certificate for it.
+func (ca *CertificateAuthorityImpl) issueInner(ctx context.Context, issueReq *capb.IssueCertificateRequest, profile *certProfile, serialBigInt *big.Int, validity validity, kind certificateType) ([]byte, *internalIssuer, error) {
 	csr, err := x509.ParseCertificateRequest(issueReq.Csr)
 	if err != nil {
 		return nil, nil, err
@@ -515,33 +782,52 @@ func (ca *CertificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 		if alg == x509.ECDSA && !features.Enabled(features.ECDSAForAll) && !ca.ecdsaAllowedRegIDs[issueReq.RegistrationID] {
 			alg = x509.RSA
 		}
-		issuer, ok = ca.issuers.byAlg[alg]
+		pool, ok := profile.issuers.byAlg[alg]
 		if !ok {
-			return nil, nil, berrors.InternalServerError("no issuer found for public key algorithm %s", csr.PublicKeyAlgorithm)
+			return nil, nil, berrors.InternalServerError("no active issuer found for public key algorithm %s", csr.PublicKeyAlgorithm)
 		}
+		issuer = pool.choose(issueReq.RegistrationID)
 	} else {
-		issuer, ok = ca.issuers.byNameID[issuance.IssuerNameID(issueReq.IssuerNameID)]
+		issuer, ok = profile.issuers.byNameID[issuance.IssuerNameID(issueReq.IssuerNameID)]
 		if !ok {
 			return nil, nil, berrors.InternalServerError("no issuer found for IssuerNameID %d", issueReq.IssuerNameID)
 		}
+		state := issuer.boulderIssuer.Profile.State()
+		if !state.CanIssue() {
+			return nil, nil, berrors.InternalServerError("issuer %s is %s and no longer issues certificates", issuer.boulderIssuer.Name(), state)
+		}
 	}
 
+	ca.issuerSelections.With(prometheus.Labels{"issuer": issuer.boulderIssuer.Name(), "profile": issueReq.CertificateProfileName}).Inc()
+
 	if issuer.cert.NotAfter.Before(validity.NotAfter) {
 		err = berrors.InternalServerError("cannot issue a certificate that expires after the issuer certificate")
 		ca.log.AuditErr(err.Error())
 		return nil, nil, err
 	}
 
+	// Issuers with CT disabled only ever issue final certificates directly,
+	// and all others only ever issue them for a precertificate.
+	disableCT := issuer.boulderIssuer.Profile.DisablesCT()
+	if kind == precertType && disableCT {
+		return nil, nil, berrors.InternalServerError("issuer %s has CT disabled and does not issue precertificates", issuer.boulderIssuer.Name())
+	}
+	if kind == certType && !disableCT {
+		return nil, nil, berrors.InternalServerError("issuer %s requires a precertificate before issuing a certificate", issuer.boulderIssuer.Name())
+	}
+
 	serialHex := core.SerialToString(serialBigInt)
 
+	names := strings.Join(core.UniqueLowerNamesAndIPs(csr.DNSNames, csr.IPAddresses), ", ")
 	ca.log.AuditInfof("Signing: serial=[%s] names=[%s] csr=[%s]",
-		serialHex, strings.Join(csr.DNSNames, ", "), hex.EncodeToString(csr.Raw))
+		serialHex, names, hex.EncodeToString(csr.Raw))
 	certDER, err := issuer.boulderIssuer.Issue(&issuance.IssuanceRequest{
 		PublicKey:         csr.PublicKey,
 		Serial:            serialBigInt.Bytes(),
 		CommonName:        csr.Subject.CommonName,
 		DNSNames:          csr.DNSNames,
-		IncludeCTPoison:   true,
+		IPAddresses:       csr.IPAddresses,
+		IncludeCTPoison:   kind == precertType,
 		IncludeMustStaple: issuance.ContainsMustStaple(csr.Extensions),
 		NotBefore:         validity.NotBefore,
 		NotAfter:          validity.NotAfter,
@@ -552,10 +838,10 @@ func (ca *CertificateAuthorityImpl) issuePrecertificateInner(ctx context.Context
 		ca.log.AuditErrf("Signing failed: serial=[%s] err=[%v]", serialHex, err)
 		return nil, nil, err
 	}
-	ca.signatureCount.With(prometheus.Labels{"purpose": string(precertType), "issuer": issuer.boulderIssuer.Name()}).Inc()
+	ca.signatureCount.With(prometheus.Labels{"purpose": string(kind), "issuer": issuer.boulderIssuer.Name()}).Inc()
 
-	ca.log.AuditInfof("Signing success: serial=[%s] names=[%s] csr=[%s] precertificate=[%s]",
-		serialHex, strings.Join(csr.DNSNames, ", "), hex.EncodeToString(csr.Raw),
+	ca.log.AuditInfof("Signing success: serial=[%s] names=[%s] csr=[%s] %s=[%s]",
+		serialHex, names, hex.EncodeToString(csr.Raw), kind,
 		hex.EncodeToString(certDER))
 
 	return certDER, issuer, nil
@@ -596,6 +882,10 @@ type orphanedCert struct {
 	RegID    int64
 	Precert  bool
 	IssuerID int64
+	OmitOCSP bool
+	// NoPrecertificate is true for a final certificate issued directly by
+	// IssueCertificate, which must be stored along with its status.
+	NoPrecertificate bool
 }
 
 func (ca *CertificateAuthorityImpl) queueOrphan(o *orphanedCert) {
@@ -671,10 +961,23 @@ func (ca *CertificateAuthorityImpl) integrateOrphan() error {
 			Ocsp:     orphan.OCSPResp,
 			Issued:   issuedNanos,
 			IssuerID: orphan.IssuerID,
+			OmitOCSP: orphan.OmitOCSP,
 		})
 		if err != nil && !errors.Is(err, berrors.Duplicate) {
 			return fmt.Errorf("failed to store orphaned precertificate: %s", err)
 		}
+	} else if orphan.NoPrecertificate {
+		_, err = ca.sa.AddCertificateWithoutPrecertificate(context.Background(), &sapb.AddCertificateRequest{
+			Der:      orphan.DER,
+			RegID:    orphan.RegID,
+			Ocsp:     orphan.OCSPResp,
+			Issued:   issued.UnixNano(),
+			IssuerID: orphan.IssuerID,
+			OmitOCSP: orphan.OmitOCSP,
+		})
+		if err != nil && !errors.Is(err, berrors.Duplicate) {
+			return fmt.Errorf("failed to store orphaned certificate: %s", err)
+		}
 	} else {
 		_, err = ca.sa.AddCertificate(context.Background(), orphan.DER, orphan.RegID, nil, &issued)
 		if err != nil && !errors.Is(err, berrors.Duplicate) {
@@ -145,6 +145,8 @@ type testCtx struct {
 
 type mockSA struct {
 	certificate core.Certificate
+	precertReq  *sapb.AddCertificateRequest
+	certReq     *sapb.AddCertificateRequest
 }
 
 func (m *mockSA) AddCertificate(ctx context.Context, der []byte, _ int64, _ []byte, _ *time.Time) (string, error) {
@@ -153,6 +155,12 @@ func (m *mockSA) AddCertificate(ctx context.Context, der []byte, _ int64, _ []by
 }
 
 func (m *mockSA) AddPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error) {
+	m.precertReq = req
+	return &corepb.Empty{}, nil
+}
+
+func (m *mockSA) AddCertificateWithoutPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error) {
+	m.certReq = req
 	return &corepb.Empty{}, nil
 }
 
@@ -265,6 +273,8 @@ func TestFailNoSerialPrefix(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		nil,
+		"",
 		testCtx.certExpiry,
 		testCtx.certBackdate,
 		0,
@@ -364,6 +374,8 @@ func issueCertificateSubTestSetup(t *testing.T) (*CertificateAuthorityImpl, *moc
 		testCtx.pa,
 		testCtx.boulderIssuers,
 		nil,
+		nil,
+		"",
 		testCtx.certExpiry,
 		testCtx.certBackdate,
 		testCtx.serialPrefix,
@@ -403,6 +415,357 @@ func issueCertificateSubTestValidityUsesCAClock(t *testing.T, i *TestCertificate
 	test.AssertEquals(t, i.cert.NotAfter, i.cert.NotBefore.Add(i.ca.validityPeriod))
 }
 
+// shortLivedProfileIssuers returns issuers for the same certificates as the
+// test context's default issuers, but issuing under a 7-day profile.
+func shortLivedProfileIssuers(t *testing.T, testCtx *testCtx) []*issuance.Issuer {
+	// Certificates issued under this profile have no OCSP URL, so the lint
+	// requiring one must be skipped.
+	return profileIssuers(t, testCtx, issuance.ProfileConfig{
+		AllowCTPoison:       true,
+		AllowSCTList:        true,
+		AllowCommonName:     true,
+		MaxValidityPeriod:   cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+		MaxValidityBackdate: cmd.ConfigDuration{Duration: time.Hour},
+		ValidityPeriod:      cmd.ConfigDuration{Duration: 7 * 24 * time.Hour},
+		OmitOCSPThreshold:   cmd.ConfigDuration{Duration: 8 * 24 * time.Hour},
+	}, "e_sub_cert_aia_does_not_contain_ocsp_url")
+}
+
+// noCTProfileIssuers returns issuers for the same certificates as the test
+// context's default issuers, but issuing directly under a profile with CT
+// disabled.
+func noCTProfileIssuers(t *testing.T, testCtx *testCtx) []*issuance.Issuer {
+	// Certificates issued under this profile have no SCTs, so the lint
+	// requiring them must be skipped.
+	return profileIssuers(t, testCtx, issuance.ProfileConfig{
+		AllowCommonName:     true,
+		MaxValidityPeriod:   cmd.ConfigDuration{Duration: 90 * 24 * time.Hour},
+		MaxValidityBackdate: cmd.ConfigDuration{Duration: time.Hour},
+		ValidityPeriod:      cmd.ConfigDuration{Duration: 90 * 24 * time.Hour},
+		DisableCT:           true,
+	}, "w_ct_sct_policy_count_unsatisfied")
+}
+
+// profileIssuers returns issuers for the same certificates as the test
+// context's default issuers, but issuing under the given profile and skipping
+// the given lints.
+func profileIssuers(t *testing.T, testCtx *testCtx, profileConfig issuance.ProfileConfig, skippedLints ...string) []*issuance.Issuer {
+	linter, err := lint.NewLinter(caKey, append([]string{"n_subject_common_name_included"}, skippedLints...))
+	test.AssertNotError(t, err, "Failed to create linter")
+	var issuers []*issuance.Issuer
+	for _, defaultIssuer := range testCtx.boulderIssuers {
+		var rsa, ecdsa bool
+		for _, alg := range defaultIssuer.Algs() {
+			rsa = rsa || alg == x509.RSA
+			ecdsa = ecdsa || alg == x509.ECDSA
+		}
+		profile, err := issuance.NewProfile(
+			profileConfig,
+			issuance.IssuerConfig{
+				UseForECDSALeaves: ecdsa,
+				UseForRSALeaves:   rsa,
+				IssuerURL:         "http://not-example.com/issuer-url",
+				OCSPURL:           "http://not-example.com/ocsp",
+			},
+		)
+		test.AssertNotError(t, err, "Failed to create profile")
+		issuers = append(issuers, &issuance.Issuer{
+			Cert:    defaultIssuer.Cert,
+			Signer:  defaultIssuer.Signer,
+			Profile: profile,
+			Linter:  linter,
+			Clk:     defaultIssuer.Clk,
+		})
+	}
+	return issuers
+}
+
+func TestGenerateSerialNumberAndValidity(t *testing.T) {
+	testCtx := setup(t)
+	ca, err := NewCertificateAuthorityImpl(
+		&mockSA{},
+		testCtx.pa,
+		testCtx.boulderIssuers,
+		nil,
+		nil,
+		"",
+		testCtx.certExpiry,
+		testCtx.certBackdate,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.ocspLifetime,
+		testCtx.keyPolicy,
+		nil,
+		0,
+		time.Second,
+		testCtx.logger,
+		testCtx.stats,
+		testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	now := testCtx.fc.Now()
+	defaultNotBefore := now.Add(-testCtx.certBackdate)
+	period := 7 * 24 * time.Hour
+
+	testCases := []struct {
+		name              string
+		requested         validity
+		expectedNotBefore time.Time
+		expectedNotAfter  time.Time
+		expectedErr       string
+	}{
+		{
+			name:              "default",
+			expectedNotBefore: defaultNotBefore,
+			expectedNotAfter:  defaultNotBefore.Add(period),
+		},
+		{
+			name:              "future notBefore",
+			requested:         validity{NotBefore: now.Add(24 * time.Hour)},
+			expectedNotBefore: now.Add(24 * time.Hour),
+			expectedNotAfter:  now.Add(24 * time.Hour).Add(period),
+		},
+		{
+			name:              "past notBefore",
+			requested:         validity{NotBefore: now.Add(-24 * time.Hour)},
+			expectedNotBefore: defaultNotBefore,
+			expectedNotAfter:  defaultNotBefore.Add(period),
+		},
+		{
+			name:              "notAfter",
+			requested:         validity{NotAfter: now.Add(48 * time.Hour)},
+			expectedNotBefore: defaultNotBefore,
+			expectedNotAfter:  now.Add(48 * time.Hour),
+		},
+		{
+			name: "both",
+			requested: validity{
+				NotBefore: now.Add(24 * time.Hour),
+				NotAfter:  now.Add(48 * time.Hour),
+			},
+			expectedNotBefore: now.Add(24 * time.Hour),
+			expectedNotAfter:  now.Add(48 * time.Hour),
+		},
+		{
+			name:              "notAfter at the maximum",
+			requested:         validity{NotAfter: now.Add(period)},
+			expectedNotBefore: defaultNotBefore,
+			expectedNotAfter:  defaultNotBefore.Add(period),
+		},
+		{
+			name: "past notBefore with notAfter at the maximum",
+			requested: validity{
+				NotBefore: now.Add(-24 * time.Hour),
+				NotAfter:  now.Add(-24 * time.Hour).Add(period),
+			},
+			expectedNotBefore: defaultNotBefore,
+			expectedNotAfter:  now.Add(-24 * time.Hour).Add(period),
+		},
+		{
+			name:        "notAfter too late",
+			requested:   validity{NotAfter: now.Add(period + time.Hour)},
+			expectedErr: "requested validity period 169h0m0s is longer than the maximum of 168h0m0s",
+		},
+		{
+			name: "past notBefore with notAfter too late",
+			requested: validity{
+				NotBefore: now.Add(-24 * time.Hour),
+				NotAfter:  now.Add(-23 * time.Hour).Add(period),
+			},
+			expectedErr: "requested validity period 169h0m0s is longer than the maximum of 168h0m0s",
+		},
+		{
+			name: "notAfter before notBefore",
+			requested: validity{
+				NotBefore: now.Add(48 * time.Hour),
+				NotAfter:  now.Add(24 * time.Hour),
+			},
+			expectedErr: "is not after the certificate's notBefore",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			serial, v, err := ca.generateSerialNumberAndValidity(period, tc.requested)
+			if tc.expectedErr != "" {
+				test.AssertError(t, err, "expected an error")
+				test.AssertErrorIs(t, err, berrors.Malformed)
+				test.AssertContains(t, err.Error(), tc.expectedErr)
+				return
+			}
+			test.AssertNotError(t, err, "generating serial and validity")
+			test.Assert(t, serial != nil, "serial should not be nil")
+			test.AssertEquals(t, v.NotBefore, tc.expectedNotBefore)
+			test.AssertEquals(t, v.NotAfter, tc.expectedNotAfter)
+		})
+	}
+}
+
+func TestCertificateProfileConfig(t *testing.T) {
+	testCtx := setup(t)
+	newCA := func(profileIssuers map[string][]*issuance.Issuer) (*CertificateAuthorityImpl, error) {
+		return NewCertificateAuthorityImpl(
+			&mockSA{},
+			testCtx.pa,
+			testCtx.boulderIssuers,
+			profileIssuers,
+			nil,
+			"",
+			testCtx.certExpiry,
+			testCtx.certBackdate,
+			testCtx.serialPrefix,
+			testCtx.maxNames,
+			testCtx.ocspLifetime,
+			testCtx.keyPolicy,
+			nil,
+			0,
+			time.Second,
+			testCtx.logger,
+			testCtx.stats,
+			testCtx.fc)
+	}
+
+	_, err := newCA(map[string][]*issuance.Issuer{"": shortLivedProfileIssuers(t, testCtx)})
+	test.AssertError(t, err, "CA should have failed with an empty profile name")
+	_, err = newCA(map[string][]*issuance.Issuer{"shortlived": nil})
+	test.AssertError(t, err, "CA should have failed with a profile with no issuers")
+	mixedIssuers := append(noCTProfileIssuers(t, testCtx)[:1], shortLivedProfileIssuers(t, testCtx)[1:]...)
+	_, err = newCA(map[string][]*issuance.Issuer{"mixed": mixedIssuers})
+	test.AssertError(t, err, "CA should have failed with a profile whose issuers disagree about CT")
+
+	testCtx.stats = prometheus.NewRegistry()
+	ca, err := newCA(map[string][]*issuance.Issuer{"shortlived": shortLivedProfileIssuers(t, testCtx)})
+	test.AssertNotError(t, err, "Failed to create CA")
+	test.AssertEquals(t, ca.certProfiles[""].validityPeriod, testCtx.certExpiry)
+	test.AssertEquals(t, ca.certProfiles["shortlived"].validityPeriod, 7*24*time.Hour)
+
+	_, err = ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:                    CNandSANCSR,
+		RegistrationID:         arbitraryRegID,
+		CertificateProfileName: "unknown",
+	})
+	test.AssertError(t, err, "Issuing under an unknown profile should fail")
+	test.AssertErrorIs(t, err, berrors.InternalServer)
+}
+
+func TestIssuePrecertificateWithProfile(t *testing.T) {
+	testCtx := setup(t)
+	sa := &mockSA{}
+	ca, err := NewCertificateAuthorityImpl(
+		sa,
+		testCtx.pa,
+		testCtx.boulderIssuers,
+		map[string][]*issuance.Issuer{"shortlived": shortLivedProfileIssuers(t, testCtx)},
+		nil,
+		"",
+		testCtx.certExpiry,
+		testCtx.certBackdate,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.ocspLifetime,
+		testCtx.keyPolicy,
+		nil,
+		0,
+		time.Second,
+		testCtx.logger,
+		testCtx.stats,
+		testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	res, err := ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:                    CNandSANCSR,
+		RegistrationID:         arbitraryRegID,
+		CertificateProfileName: "shortlived",
+	})
+	test.AssertNotError(t, err, "Failed to issue precertificate under profile")
+	precert, err := x509.ParseCertificate(res.DER)
+	test.AssertNotError(t, err, "Failed to parse precertificate")
+	test.AssertEquals(t, precert.NotAfter.Sub(precert.NotBefore), 7*24*time.Hour)
+	// The short-lived profile doesn't include any certificate policies.
+	test.AssertEquals(t, len(precert.PolicyIdentifiers), 0)
+	// Nor, since its certificates are shorter than its OCSP omission threshold,
+	// an OCSP URL or response.
+	test.AssertEquals(t, len(precert.OCSPServer), 0)
+	test.Assert(t, sa.precertReq.OmitOCSP, "precertificate wasn't stored with OmitOCSP")
+	test.AssertEquals(t, len(sa.precertReq.Ocsp), 0)
+
+	sctBytes, err := makeSCTs()
+	test.AssertNotError(t, err, "Failed to make SCTs")
+	cert, err := ca.IssueCertificateForPrecertificate(ctx, &capb.IssueCertificateForPrecertificateRequest{
+		DER:                    res.DER,
+		SCTs:                   sctBytes,
+		RegistrationID:         arbitraryRegID,
+		CertificateProfileName: "shortlived",
+	})
+	test.AssertNotError(t, err, "Failed to issue certificate for precertificate under profile")
+	final, err := x509.ParseCertificate(cert.Der)
+	test.AssertNotError(t, err, "Failed to parse certificate")
+	test.AssertEquals(t, final.NotAfter, precert.NotAfter)
+	test.AssertEquals(t, len(final.PolicyIdentifiers), 0)
+	test.AssertEquals(t, len(final.OCSPServer), 0)
+}
+
+func TestIssueCertificateCTDisabled(t *testing.T) {
+	testCtx := setup(t)
+	sa := &mockSA{}
+	ca, err := NewCertificateAuthorityImpl(
+		sa,
+		testCtx.pa,
+		testCtx.boulderIssuers,
+		map[string][]*issuance.Issuer{"private": noCTProfileIssuers(t, testCtx)},
+		nil,
+		"",
+		testCtx.certExpiry,
+		testCtx.certBackdate,
+		testCtx.serialPrefix,
+		testCtx.maxNames,
+		testCtx.ocspLifetime,
+		testCtx.keyPolicy,
+		nil,
+		0,
+		time.Second,
+		testCtx.logger,
+		testCtx.stats,
+		testCtx.fc)
+	test.AssertNotError(t, err, "Failed to create CA")
+
+	// Issuers with CT disabled don't issue precertificates, and the others
+	// don't issue certificates without one.
+	_, err = ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:                    CNandSANCSR,
+		RegistrationID:         arbitraryRegID,
+		CertificateProfileName: "private",
+	})
+	test.AssertErrorIs(t, err, berrors.InternalServer)
+	_, err = ca.IssueCertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            CNandSANCSR,
+		RegistrationID: arbitraryRegID,
+	})
+	test.AssertErrorIs(t, err, berrors.InternalServer)
+	test.Assert(t, sa.precertReq == nil, "no precertificate should have been stored")
+	test.Assert(t, sa.certReq == nil, "no certificate should have been stored")
+
+	res, err := ca.IssueCertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:                    CNandSANCSR,
+		RegistrationID:         arbitraryRegID,
+		CertificateProfileName: "private",
+	})
+	test.AssertNotError(t, err, "Failed to issue certificate with CT disabled")
+	cert, err := x509.ParseCertificate(res.Der)
+	test.AssertNotError(t, err, "Failed to parse certificate")
+	test.AssertEquals(t, res.Serial, core.SerialToString(cert.SerialNumber))
+	test.AssertEquals(t, cert.NotAfter.Sub(cert.NotBefore), 90*24*time.Hour)
+	test.Assert(t, findExtension(cert.Extensions, OIDExtensionCTPoison) == nil, "certificate has a CT poison extension")
+	test.Assert(t, findExtension(cert.Extensions, OIDExtensionSCTList) == nil, "certificate has an SCT list extension")
+
+	// The certificate is stored directly, along with its first OCSP response.
+	test.Assert(t, sa.precertReq == nil, "no precertificate should have been stored")
+	test.AssertByteEquals(t, sa.certReq.Der, res.Der)
+	test.Assert(t, len(sa.certReq.Ocsp) > 0, "certificate wasn't stored with an OCSP response")
+	test.Assert(t, !sa.certReq.OmitOCSP, "certificate was stored with OmitOCSP")
+	test.AssertMetricWithLabelsEquals(t, ca.signatureCount, prometheus.Labels{"purpose": "certificate"}, 1)
+	test.AssertMetricWithLabelsEquals(t, ca.signatureCount, prometheus.Labels{"purpose": "precertificate"}, 0)
+}
+
 // Test issuing when multiple issuers are present.
 func TestMultipleIssuers(t *testing.T) {
 	testCtx := setup(t)
@@ -412,6 +775,8 @@ func TestMultipleIssuers(t *testing.T) {
 		testCtx.pa,
 		testCtx.boulderIssuers,
 		nil,
+		nil,
+		"",
 		testCtx.certExpiry,
 		testCtx.certBackdate,
 		testCtx.serialPrefix,
@@ -483,6 +848,8 @@ func TestOCSP(t *testing.T) {
 		testCtx.pa,
 		testCtx.boulderIssuers,
 		nil,
+		nil,
+		"",
 		testCtx.certExpiry,
 		testCtx.certBackdate,
 		testCtx.serialPrefix,
@@ -498,7 +865,7 @@ func TestOCSP(t *testing.T) {
 	test.AssertNotError(t, err, "Failed to create CA")
 
 	// Issue a certificate from the RSA issuer caCert, then check OCSP comes from the same issuer.
-	rsaIssuerID := ca.issuers.byAlg[x509.RSA].cert.ID()
+	rsaIssuerID := ca.issuers.byAlg[x509.RSA].issuers[0].cert.ID()
 	rsaCertPB, err := ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{Csr: CNandSANCSR, RegistrationID: arbitraryRegID})
 	test.AssertNotError(t, err, "Failed to issue certificate")
 	rsaCert, err := x509.ParseCertificate(rsaCertPB.DER)
@@ -516,7 +883,7 @@ func TestOCSP(t *testing.T) {
 	test.AssertEquals(t, rsaOCSP.SerialNumber.Cmp(rsaCert.SerialNumber), 0)
 
 	// Issue a certificate from the ECDSA issuer caCert2, then check OCSP comes from the same issuer.
-	ecdsaIssuerID := ca.issuers.byAlg[x509.ECDSA].cert.ID()
+	ecdsaIssuerID := ca.issuers.byAlg[x509.ECDSA].issuers[0].cert.ID()
 	ecdsaCertPB, err := ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{Csr: ECDSACSR, RegistrationID: arbitraryRegID})
 	test.AssertNotError(t, err, "Failed to issue certificate")
 	ecdsaCert, err := x509.ParseCertificate(ecdsaCertPB.DER)
@@ -558,6 +925,103 @@ func TestOCSP(t *testing.T) {
 	test.AssertNotError(t, err, "GenerateOCSP failed with fake-but-valid Serial")
 }
 
+// issuerWithState returns a copy of the given issuer, with the same profile
+// as the test context's default issuers but in the given lifecycle state.
+func issuerWithState(t *testing.T, issuer *issuance.Issuer, state issuance.IssuerState) *issuance.Issuer {
+	var rsa, ecdsa bool
+	for _, alg := range issuer.Algs() {
+		rsa = rsa || alg == x509.RSA
+		ecdsa = ecdsa || alg == x509.ECDSA
+	}
+	profile, err := issuance.NewProfile(
+		issuance.ProfileConfig{
+			AllowCTPoison:       true,
+			AllowCommonName:     true,
+			MaxValidityPeriod:   cmd.ConfigDuration{Duration: time.Hour * 8760},
+			MaxValidityBackdate: cmd.ConfigDuration{Duration: time.Hour},
+		},
+		issuance.IssuerConfig{
+			UseForECDSALeaves: ecdsa,
+			UseForRSALeaves:   rsa,
+			IssuerURL:         "http://not-example.com/issuer-url",
+			OCSPURL:           "http://not-example.com/ocsp",
+			State:             state,
+		},
+	)
+	test.AssertNotError(t, err, "Failed to create profile")
+	copied := *issuer
+	copied.Profile = profile
+	return &copied
+}
+
+func TestIssuerStates(t *testing.T) {
+	testCtx := setup(t)
+	newCA := func(ecdsaIssuerState issuance.IssuerState) *CertificateAuthorityImpl {
+		ca, err := NewCertificateAuthorityImpl(
+			&mockSA{},
+			testCtx.pa,
+			[]*issuance.Issuer{
+				issuerWithState(t, testCtx.boulderIssuers[0], ecdsaIssuerState),
+				testCtx.boulderIssuers[1],
+			},
+			nil,
+			nil,
+			"",
+			testCtx.certExpiry,
+			testCtx.certBackdate,
+			testCtx.serialPrefix,
+			testCtx.maxNames,
+			testCtx.ocspLifetime,
+			testCtx.keyPolicy,
+			nil,
+			0,
+			time.Second,
+			testCtx.logger,
+			testCtx.stats,
+			testCtx.fc)
+		test.AssertNotError(t, err, "Failed to create CA")
+		return ca
+	}
+	ocspReq := &capb.GenerateOCSPRequest{
+		Serial:   "03DEADBEEFBADDECAFFADEFACECAFE30",
+		IssuerID: int64(caCert2.ID()),
+		Status:   string(core.OCSPStatusGood),
+	}
+
+	// An OCSP-only issuer is never chosen for issuance, and can't be asked
+	// for by name, but still signs OCSP.
+	ca := newCA(issuance.StateOCSPOnly)
+	pool := ca.issuers.byAlg[x509.ECDSA]
+	test.AssertEquals(t, len(pool.issuers), 1)
+	test.AssertEquals(t, pool.issuers[0].cert.ID(), caCert.ID())
+	_, err := ca.IssuePrecertificate(ctx, &capb.IssueCertificateRequest{
+		Csr:            ECDSACSR,
+		RegistrationID: arbitraryRegID,
+		IssuerNameID:   int64(caCert2.NameID()),
+	})
+	test.AssertError(t, err, "IssuePrecertificate succeeded from an OCSP-only issuer")
+	test.AssertContains(t, err.Error(), "is ocsp-only and no longer issues certificates")
+	_, err = ca.GenerateOCSP(ctx, ocspReq)
+	test.AssertNotError(t, err, "GenerateOCSP failed for an OCSP-only issuer")
+
+	// A retired issuer doesn't sign OCSP either.
+	ca = newCA(issuance.StateRetired)
+	_, err = ca.GenerateOCSP(ctx, ocspReq)
+	test.AssertError(t, err, "GenerateOCSP succeeded for a retired issuer")
+	test.AssertContains(t, err.Error(), "is retired and no longer signs OCSP responses")
+
+	// Every issuer is listed along with its state.
+	resp, err := ca.GetIssuers(ctx, &corepb.Empty{})
+	test.AssertNotError(t, err, "GetIssuers failed")
+	test.AssertEquals(t, len(resp.Issuers), 2)
+	states := make(map[int64]string)
+	for _, issuer := range resp.Issuers {
+		states[issuer.Id] = issuer.State
+	}
+	test.AssertEquals(t, states[int64(caCert2.ID())], string(issuance.StateRetired))
+	test.AssertEquals(t, states[int64(caCert.ID())], string(issuance.StateActive))
+}
+
 func TestInvalidCSRs(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -609,6 +1073,8 @@ func TestInvalidCSRs(t *testing.T) {
 			testCtx.pa,
 			testCtx.boulderIssuers,
 			nil,
+			nil,
+			"",
 			testCtx.certExpiry,
 			testCtx.certBackdate,
 			testCtx.serialPrefix,
@@ -647,6 +1113,8 @@ func TestRejectValidityTooLong(t *testing.T) {
 		testCtx.pa,
 		testCtx.boulderIssuers,
 		nil,
+		nil,
+		"",
 		testCtx.certExpiry,
 		testCtx.certBackdate,
 		testCtx.serialPrefix,
@@ -677,6 +1145,11 @@ func issueCertificateSubTestProfileSelectionRSA(t *testing.T, i *TestCertificate
 	expectedKeyUsage := x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
 	t.Logf("expected key usage %v, got %v", expectedKeyUsage, i.cert.KeyUsage)
 	test.AssertEquals(t, i.cert.KeyUsage, expectedKeyUsage)
+
+	// The issuer which was chosen should be counted.
+	issuer := i.ca.issuers.byNameID[issuance.GetIssuerNameID(i.cert)]
+	test.AssertNotNil(t, issuer, "certificate wasn't issued by a known issuer")
+	test.AssertMetricWithLabelsEquals(t, i.ca.issuerSelections, prometheus.Labels{"issuer": issuer.boulderIssuer.Name(), "profile": ""}, 1)
 }
 
 func issueCertificateSubTestProfileSelectionECDSA(t *testing.T, i *TestCertificateIssuance) {
@@ -749,6 +1222,8 @@ func TestIssueCertificateForPrecertificate(t *testing.T) {
 		testCtx.pa,
 		testCtx.boulderIssuers,
 		nil,
+		nil,
+		"",
 		testCtx.certExpiry,
 		testCtx.certBackdate,
 		testCtx.serialPrefix,
@@ -856,6 +1331,8 @@ func TestIssueCertificateForPrecertificateDuplicateSerial(t *testing.T) {
 		testCtx.pa,
 		testCtx.boulderIssuers,
 		nil,
+		nil,
+		"",
 		testCtx.certExpiry,
 		testCtx.certBackdate,
 		testCtx.serialPrefix,
@@ -899,6 +1376,8 @@ func TestIssueCertificateForPrecertificateDuplicateSerial(t *testing.T) {
 		testCtx.pa,
 		testCtx.boulderIssuers,
 		nil,
+		nil,
+		"",
 		testCtx.certExpiry,
 		testCtx.certBackdate,
 		testCtx.serialPrefix,
@@ -979,6 +1458,8 @@ func TestPrecertOrphanQueue(t *testing.T) {
 		testCtx.pa,
 		testCtx.boulderIssuers,
 		nil,
+		nil,
+		"",
 		testCtx.certExpiry,
 		testCtx.certBackdate,
 		testCtx.serialPrefix,
@@ -1048,6 +1529,8 @@ func TestOrphanQueue(t *testing.T) {
 		testCtx.pa,
 		testCtx.boulderIssuers,
 		nil,
+		nil,
+		"",
 		testCtx.certExpiry,
 		testCtx.certBackdate,
 		testCtx.serialPrefix,
@@ -0,0 +1,200 @@
+package ca
+
+import (
+	"context"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/crl"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/issuance"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/x509crl"
+)
+
+// crlImpl signs CRLs on behalf of the crl-updater. It is kept separate from
+// the CertificateAuthorityImpl so that it can be served as its own gRPC
+// service, in the same way as the OCSPGenerator.
+type crlImpl struct {
+	capb.UnimplementedCRLGeneratorServer
+	issuers        map[issuance.IssuerNameID]*issuance.Issuer
+	lifetime       time.Duration
+	idpBase        string
+	log            blog.Logger
+	signatureCount *prometheus.CounterVec
+}
+
+// NewCRLImpl returns a new CRLGenerator server which can sign CRLs for any
+// of the given issuers. Each CRL's nextUpdate is set to its thisUpdate plus
+// lifetime. If idpBase is non-empty, each CRL will carry an Issuing
+// Distribution Point extension whose URI is derived from idpBase, the
+// issuer's NameID, and the shard index.
+func NewCRLImpl(
+	issuers []*issuance.Issuer,
+	lifetime time.Duration,
+	idpBase string,
+	logger blog.Logger,
+	stats prometheus.Registerer,
+) (*crlImpl, error) {
+	if lifetime <= 0 {
+		return nil, errors.New("CRL lifetime must be positive")
+	}
+	// CABF BRs Section 4.9.7 limit CRLs for subscriber certificates to a
+	// validity period of at most ten days.
+	if lifetime > 10*24*time.Hour {
+		return nil, errors.New("CRL lifetime must be no more than 10 days")
+	}
+
+	issuersByNameID := make(map[issuance.IssuerNameID]*issuance.Issuer, len(issuers))
+	for _, issuer := range issuers {
+		issuersByNameID[issuer.Cert.NameID()] = issuer
+	}
+
+	signatureCount := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "crl_signatures",
+			Help: "Number of CRL signatures labelled by issuer",
+		},
+		[]string{"issuer"})
+	stats.MustRegister(signatureCount)
+
+	return &crlImpl{
+		issuers:        issuersByNameID,
+		lifetime:       lifetime,
+		idpBase:        idpBase,
+		log:            logger,
+		signatureCount: signatureCount,
+	}, nil
+}
+
+// GenerateCRL signs a CRL containing the given entries for a single shard of
+// the given issuer.
+func (ci *crlImpl) GenerateCRL(ctx context.Context, req *capb.GenerateCRLRequest) (*capb.GenerateCRLResponse, error) {
+	// req.ShardIdx is zero for the first shard, and req.Entries may be empty.
+	if core.IsAnyNilOrZero(req, req.IssuerNameID, req.ThisUpdate) {
+		return nil, berrors.InternalServerError("Incomplete generate CRL request")
+	}
+
+	issuer, ok := ci.issuers[issuance.IssuerNameID(req.IssuerNameID)]
+	if !ok {
+		return nil, fmt.Errorf("This CA doesn't have an issuer cert with NameID %d", req.IssuerNameID)
+	}
+	state := issuer.Profile.State()
+	if !state.CanSignRevocation() {
+		return nil, berrors.InternalServerError("issuer %s is %s and no longer signs CRLs", issuer.Name(), state)
+	}
+
+	revokedCerts := make([]pkix.RevokedCertificate, 0, len(req.Entries))
+	for _, entry := range req.Entries {
+		rc, err := entryToRevokedCertificate(entry)
+		if err != nil {
+			return nil, err
+		}
+		revokedCerts = append(revokedCerts, rc)
+	}
+
+	thisUpdate := time.Unix(0, req.ThisUpdate)
+	template := &x509crl.RevocationList{
+		RevokedCertificates: revokedCerts,
+		Number:              crl.Number(thisUpdate),
+		ThisUpdate:          thisUpdate,
+		NextUpdate:          thisUpdate.Add(ci.lifetime),
+	}
+
+	if ci.idpBase != "" {
+		idp, err := makeIDPExtension(fmt.Sprintf("%s/%d/%d.crl", ci.idpBase, req.IssuerNameID, req.ShardIdx))
+		if err != nil {
+			return nil, err
+		}
+		template.ExtraExtensions = append(template.ExtraExtensions, idp)
+	}
+
+	crlBytes, err := x509crl.CreateRevocationList(&failReader{}, template, issuer.Cert.Certificate, issuer.Signer)
+	if err != nil {
+		return nil, err
+	}
+	ci.signatureCount.With(prometheus.Labels{"issuer": issuer.Name()}).Inc()
+
+	ci.log.AuditInfof(
+		"Signed CRL: issuer=[%s] number=[%s] shard=[%d] thisUpdate=[%s] entries=[%d]",
+		issuer.Name(), template.Number, req.ShardIdx, thisUpdate.Format(time.RFC3339), len(revokedCerts))
+
+	return &capb.GenerateCRLResponse{Crl: crlBytes}, nil
+}
+
+// entryToRevokedCertificate converts a CRL entry received over gRPC into the
+// form expected by the x509crl package. Per RFC 5280 Section 5.3.1, the
+// reasonCode extension is omitted when the reason is unspecified.
+func entryToRevokedCertificate(entry *corepb.CRLEntry) (pkix.RevokedCertificate, error) {
+	if core.IsAnyNilOrZero(entry, entry.Serial, entry.RevokedAt) {
+		return pkix.RevokedCertificate{}, berrors.InternalServerError("Incomplete CRL entry")
+	}
+	serial, err := core.StringToSerial(entry.Serial)
+	if err != nil {
+		return pkix.RevokedCertificate{}, err
+	}
+	rc := pkix.RevokedCertificate{
+		SerialNumber:   serial,
+		RevocationTime: time.Unix(0, entry.RevokedAt),
+	}
+	if entry.Reason != 0 {
+		reasonBytes, err := asn1.Marshal(asn1.Enumerated(entry.Reason))
+		if err != nil {
+			return pkix.RevokedCertificate{}, err
+		}
+		rc.Extensions = []pkix.Extension{{Id: crl.OIDReasonCode, Value: reasonBytes}}
+	}
+	return rc, nil
+}
+
+// distributionPointName and issuingDistributionPoint mirror the ASN.1
+// structures of the same names in RFC 5280 Section 5.2.5, limited to the
+// fields we populate.
+type distributionPointName struct {
+	FullName []asn1.RawValue `asn1:"optional,tag:0"`
+}
+
+type issuingDistributionPoint struct {
+	DistributionPoint     distributionPointName `asn1:"optional,tag:0"`
+	OnlyContainsUserCerts bool                  `asn1:"optional,tag:1"`
+}
+
+// makeIDPExtension returns a critical Issuing Distribution Point extension
+// which names the given URI and asserts that the CRL only contains
+// end-entity certificates.
+func makeIDPExtension(uri string) (pkix.Extension, error) {
+	val, err := asn1.Marshal(issuingDistributionPoint{
+		DistributionPoint: distributionPointName{
+			FullName: []asn1.RawValue{
+				// GeneralName uniformResourceIdentifier [6] IA5String
+				{Class: asn1.ClassContextSpecific, Tag: 6, Bytes: []byte(uri)},
+			},
+		},
+		OnlyContainsUserCerts: true,
+	})
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{
+		Id:       crl.OIDIssuingDistributionPoint,
+		Critical: true,
+		Value:    val,
+	}, nil
+}
+
+// failReader is passed to x509crl.CreateRevocationList in place of a source
+// of randomness. Our signers don't need one, so we'd rather fail loudly if
+// that ever changes.
+type failReader struct{}
+
+func (fr *failReader) Read([]byte) (int, error) {
+	return 0, errors.New("empty reader used by x509crl.CreateRevocationList")
+}
@@ -0,0 +1,124 @@
+package ca
+
+import (
+	"crypto/x509"
+	"encoding/asn1"
+	"fmt"
+	"testing"
+	"time"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/crl"
+	"github.com/letsencrypt/boulder/issuance"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestNewCRLImplLifetime(t *testing.T) {
+	testCtx := setup(t)
+
+	_, err := NewCRLImpl(testCtx.boulderIssuers, 0, "", testCtx.logger, testCtx.stats)
+	test.AssertError(t, err, "NewCRLImpl should have failed with zero lifetime")
+
+	_, err = NewCRLImpl(testCtx.boulderIssuers, 11*24*time.Hour, "", testCtx.logger, testCtx.stats)
+	test.AssertError(t, err, "NewCRLImpl should have failed with too-long lifetime")
+}
+
+func TestGenerateCRL(t *testing.T) {
+	testCtx := setup(t)
+	ci, err := NewCRLImpl(testCtx.boulderIssuers, 7*24*time.Hour, "http://c.boulder.test", testCtx.logger, testCtx.stats)
+	test.AssertNotError(t, err, "Failed to create CRL impl")
+
+	thisUpdate := testCtx.fc.Now()
+	revokedAt := thisUpdate.Add(-time.Minute)
+	resp, err := ci.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+		IssuerNameID: int64(caCert.NameID()),
+		ThisUpdate:   thisUpdate.UnixNano(),
+		ShardIdx:     3,
+		Entries: []*corepb.CRLEntry{
+			{Serial: "000000000000000000000000000000000001", Reason: 0, RevokedAt: revokedAt.UnixNano()},
+			{Serial: "000000000000000000000000000000000002", Reason: 1, RevokedAt: revokedAt.UnixNano()},
+		},
+	})
+	test.AssertNotError(t, err, "Failed to generate CRL")
+
+	parsed, err := x509.ParseDERCRL(resp.Crl)
+	test.AssertNotError(t, err, "Failed to parse CRL")
+	err = caCert.CheckCRLSignature(parsed)
+	test.AssertNotError(t, err, "CRL signature didn't verify")
+	test.AssertEquals(t, parsed.TBSCertList.ThisUpdate.Equal(thisUpdate.Truncate(time.Second)), true)
+	test.AssertEquals(t, parsed.TBSCertList.NextUpdate.Equal(thisUpdate.Add(7*24*time.Hour).Truncate(time.Second)), true)
+
+	number, err := crl.GetNumber(parsed)
+	test.AssertNotError(t, err, "Failed to get CRL number")
+	test.AssertEquals(t, number.Cmp(crl.Number(thisUpdate)), 0)
+
+	var foundIDP bool
+	for _, ext := range parsed.TBSCertList.Extensions {
+		if ext.Id.Equal(crl.OIDIssuingDistributionPoint) {
+			foundIDP = true
+			test.Assert(t, ext.Critical, "IDP extension should be critical")
+			var idp issuingDistributionPoint
+			_, err = asn1.Unmarshal(ext.Value, &idp)
+			test.AssertNotError(t, err, "Failed to unmarshal IDP extension")
+			test.AssertEquals(t, len(idp.DistributionPoint.FullName), 1)
+			test.AssertEquals(t, string(idp.DistributionPoint.FullName[0].Bytes), fmt.Sprintf("http://c.boulder.test/%d/3.crl", caCert.NameID()))
+			test.Assert(t, idp.OnlyContainsUserCerts, "IDP should assert onlyContainsUserCerts")
+		}
+	}
+	test.Assert(t, foundIDP, "CRL should contain an IDP extension")
+
+	revoked := parsed.TBSCertList.RevokedCertificates
+	test.AssertEquals(t, len(revoked), 2)
+	test.AssertEquals(t, revoked[0].SerialNumber.Int64(), int64(1))
+	test.AssertEquals(t, len(revoked[0].Extensions), 0)
+	test.AssertEquals(t, revoked[1].SerialNumber.Int64(), int64(2))
+	test.AssertEquals(t, len(revoked[1].Extensions), 1)
+	test.AssertDeepEquals(t, revoked[1].Extensions[0].Id, crl.OIDReasonCode)
+	var reason asn1.Enumerated
+	_, err = asn1.Unmarshal(revoked[1].Extensions[0].Value, &reason)
+	test.AssertNotError(t, err, "Failed to unmarshal reason code")
+	test.AssertEquals(t, reason, asn1.Enumerated(1))
+}
+
+func TestGenerateCRLErrors(t *testing.T) {
+	testCtx := setup(t)
+	ci, err := NewCRLImpl(testCtx.boulderIssuers, 7*24*time.Hour, "", testCtx.logger, testCtx.stats)
+	test.AssertNotError(t, err, "Failed to create CRL impl")
+
+	_, err = ci.GenerateCRL(ctx, &capb.GenerateCRLRequest{ThisUpdate: testCtx.fc.Now().UnixNano()})
+	test.AssertError(t, err, "GenerateCRL should have failed without an issuer")
+
+	_, err = ci.GenerateCRL(ctx, &capb.GenerateCRLRequest{IssuerNameID: 1, ThisUpdate: testCtx.fc.Now().UnixNano()})
+	test.AssertError(t, err, "GenerateCRL should have failed with an unknown issuer")
+
+	_, err = ci.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+		IssuerNameID: int64(caCert.NameID()),
+		ThisUpdate:   testCtx.fc.Now().UnixNano(),
+		Entries:      []*corepb.CRLEntry{{Serial: "not hex", RevokedAt: 1}},
+	})
+	test.AssertError(t, err, "GenerateCRL should have failed with a malformed serial")
+}
+
+func TestGenerateCRLRetiredIssuer(t *testing.T) {
+	testCtx := setup(t)
+	issuers := []*issuance.Issuer{
+		issuerWithState(t, testCtx.boulderIssuers[0], issuance.StateRetired),
+		issuerWithState(t, testCtx.boulderIssuers[1], issuance.StateOCSPOnly),
+	}
+	ci, err := NewCRLImpl(issuers, 7*24*time.Hour, "", testCtx.logger, testCtx.stats)
+	test.AssertNotError(t, err, "Failed to create CRL impl")
+
+	_, err = ci.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+		IssuerNameID: int64(caCert2.NameID()),
+		ThisUpdate:   testCtx.fc.Now().UnixNano(),
+	})
+	test.AssertError(t, err, "GenerateCRL should have failed for a retired issuer")
+	test.AssertContains(t, err.Error(), "is retired and no longer signs CRLs")
+
+	_, err = ci.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+		IssuerNameID: int64(caCert.NameID()),
+		ThisUpdate:   testCtx.fc.Now().UnixNano(),
+	})
+	test.AssertNotError(t, err, "GenerateCRL failed for an OCSP-only issuer")
+}
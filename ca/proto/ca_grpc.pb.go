@@ -21,7 +21,12 @@ const _ = grpc.SupportPackageIsVersion7
 type CertificateAuthorityClient interface {
 	IssuePrecertificate(ctx context.Context, in *IssueCertificateRequest, opts ...grpc.CallOption) (*IssuePrecertificateResponse, error)
 	IssueCertificateForPrecertificate(ctx context.Context, in *IssueCertificateForPrecertificateRequest, opts ...grpc.CallOption) (*proto.Certificate, error)
+	// IssueCertificate issues a final certificate directly, without a
+	// precertificate, from an issuer or profile which has CT disabled.
+	IssueCertificate(ctx context.Context, in *IssueCertificateRequest, opts ...grpc.CallOption) (*proto.Certificate, error)
 	GenerateOCSP(ctx context.Context, in *GenerateOCSPRequest, opts ...grpc.CallOption) (*OCSPResponse, error)
+	// GetIssuers lists every configured issuer along with its lifecycle state.
+	GetIssuers(ctx context.Context, in *proto.Empty, opts ...grpc.CallOption) (*Issuers, error)
 }
 
 type certificateAuthorityClient struct {
@@ -50,6 +55,15 @@ func (c *certificateAuthorityClient) IssueCertificateForPrecertificate(ctx conte
 	return out, nil
 }
 
+func (c *certificateAuthorityClient) IssueCertificate(ctx context.Context, in *IssueCertificateRequest, opts ...grpc.CallOption) (*proto.Certificate, error) {
+	out := new(proto.Certificate)
+	err := c.cc.Invoke(ctx, "/ca.CertificateAuthority/IssueCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *certificateAuthorityClient) GenerateOCSP(ctx context.Context, in *GenerateOCSPRequest, opts ...grpc.CallOption) (*OCSPResponse, error) {
 	out := new(OCSPResponse)
 	err := c.cc.Invoke(ctx, "/ca.CertificateAuthority/GenerateOCSP", in, out, opts...)
@@ -59,13 +73,27 @@ func (c *certificateAuthorityClient) GenerateOCSP(ctx context.Context, in *Gener
 	return out, nil
 }
 
+func (c *certificateAuthorityClient) GetIssuers(ctx context.Context, in *proto.Empty, opts ...grpc.CallOption) (*Issuers, error) {
+	out := new(Issuers)
+	err := c.cc.Invoke(ctx, "/ca.CertificateAuthority/GetIssuers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CertificateAuthorityServer is the server API for CertificateAuthority service.
 // All implementations must embed UnimplementedCertificateAuthorityServer
 // for forward compatibility
 type CertificateAuthorityServer interface {
 	IssuePrecertificate(context.Context, *IssueCertificateRequest) (*IssuePrecertificateResponse, error)
 	IssueCertificateForPrecertificate(context.Context, *IssueCertificateForPrecertificateRequest) (*proto.Certificate, error)
+	// IssueCertificate issues a final certificate directly, without a
+	// precertificate, from an issuer or profile which has CT disabled.
+	IssueCertificate(context.Context, *IssueCertificateRequest) (*proto.Certificate, error)
 	GenerateOCSP(context.Context, *GenerateOCSPRequest) (*OCSPResponse, error)
+	// GetIssuers lists every configured issuer along with its lifecycle state.
+	GetIssuers(context.Context, *proto.Empty) (*Issuers, error)
 	mustEmbedUnimplementedCertificateAuthorityServer()
 }
 
@@ -79,9 +107,15 @@ func (UnimplementedCertificateAuthorityServer) IssuePrecertificate(context.Conte
 func (UnimplementedCertificateAuthorityServer) IssueCertificateForPrecertificate(context.Context, *IssueCertificateForPrecertificateRequest) (*proto.Certificate, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method IssueCertificateForPrecertificate not implemented")
 }
+func (UnimplementedCertificateAuthorityServer) IssueCertificate(context.Context, *IssueCertificateRequest) (*proto.Certificate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueCertificate not implemented")
+}
 func (UnimplementedCertificateAuthorityServer) GenerateOCSP(context.Context, *GenerateOCSPRequest) (*OCSPResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GenerateOCSP not implemented")
 }
+func (UnimplementedCertificateAuthorityServer) GetIssuers(context.Context, *proto.Empty) (*Issuers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIssuers not implemented")
+}
 func (UnimplementedCertificateAuthorityServer) mustEmbedUnimplementedCertificateAuthorityServer() {}
 
 // UnsafeCertificateAuthorityServer may be embedded to opt out of forward compatibility for this service.
@@ -131,6 +165,24 @@ func _CertificateAuthority_IssueCertificateForPrecertificate_Handler(srv interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CertificateAuthority_IssueCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertificateAuthorityServer).IssueCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ca.CertificateAuthority/IssueCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertificateAuthorityServer).IssueCertificate(ctx, req.(*IssueCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CertificateAuthority_GenerateOCSP_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GenerateOCSPRequest)
 	if err := dec(in); err != nil {
@@ -149,6 +201,24 @@ func _CertificateAuthority_GenerateOCSP_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CertificateAuthority_GetIssuers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(proto.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CertificateAuthorityServer).GetIssuers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ca.CertificateAuthority/GetIssuers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CertificateAuthorityServer).GetIssuers(ctx, req.(*proto.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CertificateAuthority_ServiceDesc is the grpc.ServiceDesc for CertificateAuthority service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -164,10 +234,18 @@ var CertificateAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IssueCertificateForPrecertificate",
 			Handler:    _CertificateAuthority_IssueCertificateForPrecertificate_Handler,
 		},
+		{
+			MethodName: "IssueCertificate",
+			Handler:    _CertificateAuthority_IssueCertificate_Handler,
+		},
 		{
 			MethodName: "GenerateOCSP",
 			Handler:    _CertificateAuthority_GenerateOCSP_Handler,
 		},
+		{
+			MethodName: "GetIssuers",
+			Handler:    _CertificateAuthority_GetIssuers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ca.proto",
@@ -258,3 +336,89 @@ var OCSPGenerator_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ca.proto",
 }
+
+// CRLGeneratorClient is the client API for CRLGenerator service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CRLGeneratorClient interface {
+	GenerateCRL(ctx context.Context, in *GenerateCRLRequest, opts ...grpc.CallOption) (*GenerateCRLResponse, error)
+}
+
+type cRLGeneratorClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCRLGeneratorClient(cc grpc.ClientConnInterface) CRLGeneratorClient {
+	return &cRLGeneratorClient{cc}
+}
+
+func (c *cRLGeneratorClient) GenerateCRL(ctx context.Context, in *GenerateCRLRequest, opts ...grpc.CallOption) (*GenerateCRLResponse, error) {
+	out := new(GenerateCRLResponse)
+	err := c.cc.Invoke(ctx, "/ca.CRLGenerator/GenerateCRL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CRLGeneratorServer is the server API for CRLGenerator service.
+// All implementations must embed UnimplementedCRLGeneratorServer
+// for forward compatibility
+type CRLGeneratorServer interface {
+	GenerateCRL(context.Context, *GenerateCRLRequest) (*GenerateCRLResponse, error)
+	mustEmbedUnimplementedCRLGeneratorServer()
+}
+
+// UnimplementedCRLGeneratorServer must be embedded to have forward compatible implementations.
+type UnimplementedCRLGeneratorServer struct {
+}
+
+func (UnimplementedCRLGeneratorServer) GenerateCRL(context.Context, *GenerateCRLRequest) (*GenerateCRLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateCRL not implemented")
+}
+func (UnimplementedCRLGeneratorServer) mustEmbedUnimplementedCRLGeneratorServer() {}
+
+// UnsafeCRLGeneratorServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CRLGeneratorServer will
+// result in compilation errors.
+type UnsafeCRLGeneratorServer interface {
+	mustEmbedUnimplementedCRLGeneratorServer()
+}
+
+func RegisterCRLGeneratorServer(s grpc.ServiceRegistrar, srv CRLGeneratorServer) {
+	s.RegisterService(&CRLGenerator_ServiceDesc, srv)
+}
+
+func _CRLGenerator_GenerateCRL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateCRLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CRLGeneratorServer).GenerateCRL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ca.CRLGenerator/GenerateCRL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CRLGeneratorServer).GenerateCRL(ctx, req.(*GenerateCRLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CRLGenerator_ServiceDesc is the grpc.ServiceDesc for CRLGenerator service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CRLGenerator_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ca.CRLGenerator",
+	HandlerType: (*CRLGeneratorServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GenerateCRL",
+			Handler:    _CRLGenerator_GenerateCRL_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ca.proto",
+}
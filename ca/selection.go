@@ -0,0 +1,104 @@
+package ca
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"sync/atomic"
+)
+
+// Issuer selection policies choose between the issuers for the same public key
+// algorithm, for requests which don't ask for a particular issuer.
+const (
+	// SelectFirst always chooses the first configured issuer. It is the
+	// default.
+	SelectFirst = "first"
+	// SelectWeighted chooses an issuer at random, in proportion to the
+	// issuers' weights.
+	SelectWeighted = "weighted"
+	// SelectRoundRobin chooses each issuer in turn, for as many consecutive
+	// requests as its weight.
+	SelectRoundRobin = "round-robin"
+	// SelectByAccount chooses an issuer in proportion to the issuers' weights,
+	// but always chooses the same issuer for the same account for as long as
+	// the weights are unchanged.
+	SelectByAccount = "account"
+)
+
+// issuerPool is the set of issuers for a single public key algorithm, along
+// with the policy used to choose between them.
+type issuerPool struct {
+	// count is the number of selections made so far, for SelectRoundRobin. It
+	// is first so that it is 64-bit aligned for atomic access.
+	count uint64
+
+	selection string
+	issuers   []*internalIssuer
+	// weights holds the weight of each of the issuers, and total their sum.
+	// Issuers with a zero weight are only ever chosen by SelectFirst.
+	weights []int
+	total   int
+}
+
+// validSelection returns an error if selection isn't the name of an issuer
+// selection policy. The empty name selects SelectFirst.
+func validSelection(selection string) error {
+	switch selection {
+	case "", SelectFirst, SelectWeighted, SelectRoundRobin, SelectByAccount:
+		return nil
+	}
+	return fmt.Errorf("unknown issuer selection policy %q", selection)
+}
+
+// newIssuerPool returns a pool of the given issuers, which must not be empty,
+// using the named selection policy.
+func newIssuerPool(selection string, issuers []*internalIssuer) *issuerPool {
+	p := &issuerPool{
+		selection: selection,
+		issuers:   issuers,
+		weights:   make([]int, len(issuers)),
+	}
+	for i, issuer := range issuers {
+		p.weights[i] = issuer.boulderIssuer.Profile.Weight()
+		p.total += p.weights[i]
+	}
+	if p.total == 0 {
+		// No issuer has a weight, so they are all weighted equally.
+		for i := range p.weights {
+			p.weights[i] = 1
+		}
+		p.total = len(issuers)
+	}
+	return p
+}
+
+// choose returns the issuer which the pool's policy selects for a request
+// from the given account.
+func (p *issuerPool) choose(regID int64) *internalIssuer {
+	switch p.selection {
+	case SelectWeighted:
+		return p.at(rand.Intn(p.total))
+	case SelectRoundRobin:
+		count := atomic.AddUint64(&p.count, 1) - 1
+		return p.at(int(count % uint64(p.total)))
+	case SelectByAccount:
+		h := fnv.New32a()
+		_ = binary.Write(h, binary.BigEndian, regID)
+		return p.at(int(h.Sum32() % uint32(p.total)))
+	default:
+		return p.issuers[0]
+	}
+}
+
+// at returns the issuer whose share of the total weight contains the given
+// point, which must be less than the total.
+func (p *issuerPool) at(point int) *internalIssuer {
+	for i, weight := range p.weights {
+		if point < weight {
+			return p.issuers[i]
+		}
+		point -= weight
+	}
+	return p.issuers[len(p.issuers)-1]
+}
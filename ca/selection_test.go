@@ -0,0 +1,85 @@
+package ca
+
+import (
+	"testing"
+
+	"github.com/letsencrypt/boulder/issuance"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// weightedIssuers returns an internalIssuer for each of the given weights.
+func weightedIssuers(t *testing.T, weights ...int) []*internalIssuer {
+	var iis []*internalIssuer
+	for _, weight := range weights {
+		profile, err := issuance.NewProfile(
+			issuance.ProfileConfig{},
+			issuance.IssuerConfig{
+				UseForRSALeaves: true,
+				IssuerURL:       "http://not-example.com/issuer-url",
+				OCSPURL:         "http://not-example.com/ocsp",
+				Weight:          weight,
+			},
+		)
+		test.AssertNotError(t, err, "Failed to create profile")
+		iis = append(iis, &internalIssuer{boulderIssuer: &issuance.Issuer{Profile: profile}})
+	}
+	return iis
+}
+
+func TestValidSelection(t *testing.T) {
+	for _, selection := range []string{"", SelectFirst, SelectWeighted, SelectRoundRobin, SelectByAccount} {
+		test.AssertNotError(t, validSelection(selection), "valid selection policy rejected")
+	}
+	test.AssertError(t, validSelection("random"), "unknown selection policy accepted")
+}
+
+func TestSelectFirst(t *testing.T) {
+	iis := weightedIssuers(t, 0, 5)
+	pool := newIssuerPool("", iis)
+	for i := int64(0); i < 10; i++ {
+		test.Assert(t, pool.choose(i) == iis[0], "first issuer wasn't chosen")
+	}
+}
+
+func TestSelectRoundRobin(t *testing.T) {
+	iis := weightedIssuers(t, 2, 1)
+	pool := newIssuerPool(SelectRoundRobin, iis)
+	expected := []*internalIssuer{iis[0], iis[0], iis[1], iis[0], iis[0], iis[1]}
+	for _, ii := range expected {
+		test.Assert(t, pool.choose(1) == ii, "wrong issuer chosen")
+	}
+}
+
+func TestSelectWeighted(t *testing.T) {
+	iis := weightedIssuers(t, 1, 0, 3)
+	pool := newIssuerPool(SelectWeighted, iis)
+	counts := make(map[*internalIssuer]int)
+	for i := 0; i < 4000; i++ {
+		counts[pool.choose(1)]++
+	}
+	test.AssertEquals(t, counts[iis[1]], 0)
+	test.Assert(t, counts[iis[0]] > 0, "issuer with weight 1 was never chosen")
+	test.Assert(t, counts[iis[2]] > 2*counts[iis[0]], "issuer with weight 3 wasn't chosen most often")
+}
+
+func TestSelectByAccount(t *testing.T) {
+	iis := weightedIssuers(t, 1, 1)
+	pool := newIssuerPool(SelectByAccount, iis)
+	counts := make(map[*internalIssuer]int)
+	for regID := int64(1); regID <= 100; regID++ {
+		chosen := pool.choose(regID)
+		for i := 0; i < 5; i++ {
+			test.Assert(t, pool.choose(regID) == chosen, "account was given a different issuer")
+		}
+		counts[chosen]++
+	}
+	test.Assert(t, counts[iis[0]] > 0 && counts[iis[1]] > 0, "accounts weren't spread between issuers")
+}
+
+func TestSelectAllZeroWeights(t *testing.T) {
+	iis := weightedIssuers(t, 0, 0)
+	pool := newIssuerPool(SelectRoundRobin, iis)
+	test.AssertEquals(t, pool.total, 2)
+	test.Assert(t, pool.choose(1) == iis[0], "wrong issuer chosen")
+	test.Assert(t, pool.choose(1) == iis[1], "wrong issuer chosen")
+}
@@ -76,9 +76,11 @@ func TestRevokeBatch(t *testing.T) {
 		nil,
 		nil,
 		0,
+		time.Hour,
 		nil,
 		&mockPurger{},
 		[]*issuance.Certificate{{Certificate: issuer}},
+		nil,
 	)
 	ra.SA = ssa
 	ra.CA = &mockCA{}
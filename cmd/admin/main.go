@@ -0,0 +1,325 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/grpc"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/cmd"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/features"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/ratelimit"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+const usageString = `
+usage:
+admin add-eab-key --config <path> <key-id>
+admin add-rate-limit-override --config <path> <limit-name> <key> <threshold> <expires-in> <comment>
+admin list-rate-limit-overrides --config <path> [--all]
+admin expire-rate-limit-override --config <path> <override-id>
+admin list-issuers --config <path>
+
+command descriptions:
+  add-eab-key                 Generate a MAC key for a new external account
+                              and store it, so that ACME accounts can be bound
+                              to the external account identified by key-id
+  add-rate-limit-override     Override the threshold of the named rate limit
+                              for a single key (e.g. a registered domain or
+                              IP address), or for a single account if key is
+                              of the form reg:<registration-id>. The override
+                              expires after expires-in (e.g. 720h)
+  list-rate-limit-overrides   List unexpired rate limit overrides, or all of
+                              them with --all
+  expire-rate-limit-override  Expire the rate limit override with the given ID
+                              immediately
+  list-issuers                List the CA's issuers and the lifecycle state
+                              (active, ocsp-only, or retired) of each
+
+args:
+  config    File path to the configuration file for this service
+`
+
+// eabKeyLength is the length in bytes of generated external account MAC keys,
+// which are used with HMAC-SHA256 and so should be at least 256 bits long.
+const eabKeyLength = 32
+
+type config struct {
+	Admin struct {
+		// The admin tool needs a TLSConfig to set up its gRPC client certs, but
+		// doesn't get the TLS field from ServiceConfig, so declares its own.
+		TLS cmd.TLSConfig
+
+		SAService *cmd.GRPCClientConfig
+		// CAService is only needed by list-issuers.
+		CAService *cmd.GRPCClientConfig
+
+		Features map[string]bool
+	}
+
+	Syslog cmd.SyslogConfig
+}
+
+// externalAccountKeyAdder is the subset of the SA's gRPC client used by this
+// tool, to simplify testing.
+type externalAccountKeyAdder interface {
+	AddExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKey, opts ...grpc.CallOption) (*corepb.Empty, error)
+}
+
+// rateLimitOverrideStorer is the subset of the SA's gRPC client used to
+// manage rate limit overrides, to simplify testing.
+type rateLimitOverrideStorer interface {
+	AddRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverride, opts ...grpc.CallOption) (*sapb.RateLimitOverrideID, error)
+	GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest, opts ...grpc.CallOption) (*sapb.RateLimitOverrides, error)
+	ExpireRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverrideID, opts ...grpc.CallOption) (*corepb.Empty, error)
+}
+
+// issuerLister is the subset of the CA's gRPC client used by this tool, to
+// simplify testing.
+type issuerLister interface {
+	GetIssuers(ctx context.Context, req *corepb.Empty, opts ...grpc.CallOption) (*capb.Issuers, error)
+}
+
+func setupContext(c config) (sapb.StorageAuthorityClient, blog.Logger, clock.Clock) {
+	logger := cmd.NewLogger(c.Syslog)
+
+	tlsConfig, err := c.Admin.TLS.Load()
+	cmd.FailOnError(err, "TLS config")
+
+	clk := cmd.Clock()
+
+	clientMetrics := bgrpc.NewClientMetrics(metrics.NoopRegisterer)
+	saConn, err := bgrpc.ClientSetup(c.Admin.SAService, tlsConfig, clientMetrics, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to SA")
+	return sapb.NewStorageAuthorityClient(saConn), logger, clk
+}
+
+func setupCAContext(c config) (capb.CertificateAuthorityClient, blog.Logger) {
+	logger := cmd.NewLogger(c.Syslog)
+
+	tlsConfig, err := c.Admin.TLS.Load()
+	cmd.FailOnError(err, "TLS config")
+
+	clientMetrics := bgrpc.NewClientMetrics(metrics.NoopRegisterer)
+	caConn, err := bgrpc.ClientSetup(c.Admin.CAService, tlsConfig, clientMetrics, cmd.Clock())
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to CA")
+	return capb.NewCertificateAuthorityClient(caConn), logger
+}
+
+// addEABKey generates a new random MAC key for the external account with the
+// given key ID, stores it via the SA, and returns it.
+func addEABKey(ctx context.Context, sac externalAccountKeyAdder, clk clock.Clock, keyID string) ([]byte, error) {
+	if keyID == "" {
+		return nil, errors.New("key ID must not be empty")
+	}
+	macKey := make([]byte, eabKeyLength)
+	_, err := rand.Read(macKey)
+	if err != nil {
+		return nil, err
+	}
+	_, err = sac.AddExternalAccountKey(ctx, &sapb.ExternalAccountKey{
+		KeyID:     keyID,
+		MacKey:    macKey,
+		CreatedAt: clk.Now().UnixNano(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return macKey, nil
+}
+
+// regKeyPrefix marks a rate limit override key as a registration ID rather
+// than a key such as a domain name or IP address.
+const regKeyPrefix = "reg:"
+
+// addOverride validates and stores a rate limit override, returning its ID.
+func addOverride(
+	ctx context.Context,
+	sac rateLimitOverrideStorer,
+	clk clock.Clock,
+	limitName string,
+	key string,
+	threshold int64,
+	expiresIn time.Duration,
+	comment string,
+) (int64, error) {
+	if !ratelimit.ValidLimitName(limitName) {
+		return 0, fmt.Errorf("unknown rate limit %q", limitName)
+	}
+	if key == "" {
+		return 0, errors.New("key must not be empty")
+	}
+	if threshold < 0 {
+		return 0, fmt.Errorf("threshold must not be negative, got %d", threshold)
+	}
+	if expiresIn <= 0 {
+		return 0, fmt.Errorf("expires-in must be positive, got %s", expiresIn)
+	}
+	if comment == "" {
+		return 0, errors.New("comment must not be empty")
+	}
+
+	req := &sapb.RateLimitOverride{
+		LimitName: limitName,
+		Threshold: threshold,
+		Comment:   comment,
+		Expires:   clk.Now().Add(expiresIn).UnixNano(),
+	}
+	if strings.HasPrefix(key, regKeyPrefix) {
+		regID, err := strconv.ParseInt(strings.TrimPrefix(key, regKeyPrefix), 10, 64)
+		if err != nil || regID <= 0 {
+			return 0, fmt.Errorf("malformed registration ID in key %q", key)
+		}
+		req.RegistrationID = regID
+	} else {
+		req.Key = key
+	}
+
+	resp, err := sac.AddRateLimitOverride(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Id, nil
+}
+
+// listOverrides writes a table of the stored rate limit overrides to w.
+func listOverrides(ctx context.Context, sac rateLimitOverrideStorer, w io.Writer, includeExpired bool) error {
+	resp, err := sac.GetRateLimitOverrides(ctx, &sapb.GetRateLimitOverridesRequest{IncludeExpired: includeExpired})
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tLIMIT\tKEY\tTHRESHOLD\tEXPIRES\tCOMMENT")
+	for _, o := range resp.Overrides {
+		key := o.Key
+		if o.RegistrationID != 0 {
+			key = fmt.Sprintf("%s%d", regKeyPrefix, o.RegistrationID)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%d\t%s\t%s\n",
+			o.Id, o.LimitName, key, o.Threshold,
+			time.Unix(0, o.Expires).UTC().Format(time.RFC3339), o.Comment)
+	}
+	return tw.Flush()
+}
+
+// listIssuers writes a table of the CA's issuers and their states to w.
+func listIssuers(ctx context.Context, cac issuerLister, w io.Writer) error {
+	resp, err := cac.GetIssuers(ctx, &corepb.Empty{})
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tID\tNAMEID\tSTATE\tNOTAFTER")
+	for _, issuer := range resp.Issuers {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\n",
+			issuer.Name, issuer.Id, issuer.NameID, issuer.State,
+			time.Unix(0, issuer.NotAfter).UTC().Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
+
+func main() {
+	usage := func() {
+		fmt.Fprint(os.Stderr, usageString)
+		os.Exit(1)
+	}
+	if len(os.Args) <= 2 {
+		usage()
+	}
+
+	command := os.Args[1]
+	flagSet := flag.NewFlagSet(command, flag.ContinueOnError)
+	configFile := flagSet.String("config", "", "File path to the configuration file for this service")
+	all := flagSet.Bool("all", false, "For list-rate-limit-overrides, include expired overrides")
+	err := flagSet.Parse(os.Args[2:])
+	cmd.FailOnError(err, "Error parsing flagset")
+
+	if *configFile == "" {
+		usage()
+	}
+
+	var c config
+	err = cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, "Reading JSON config file into config structure")
+	err = features.Set(c.Admin.Features)
+	cmd.FailOnError(err, "Failed to set feature flags")
+
+	ctx := context.Background()
+	args := flagSet.Args()
+	switch {
+	case command == "add-eab-key" && len(args) == 1:
+		// 1: key ID
+		keyID := args[0]
+
+		sac, logger, clk := setupContext(c)
+		defer logger.AuditPanic()
+
+		macKey, err := addEABKey(ctx, sac, clk, keyID)
+		cmd.FailOnError(err, "Couldn't add external account key")
+		logger.AuditInfof("Added external account key: keyID=[%s]", keyID)
+		fmt.Printf("keyID: %s\nhmacKey: %s\n", keyID, base64.RawURLEncoding.EncodeToString(macKey))
+
+	case command == "add-rate-limit-override" && len(args) == 5:
+		// 1: limit name,  2: key,  3: threshold,  4: expires in,  5: comment
+		limitName, key, comment := args[0], args[1], args[4]
+		threshold, err := strconv.ParseInt(args[2], 10, 64)
+		cmd.FailOnError(err, "Threshold argument must be an integer")
+		expiresIn, err := time.ParseDuration(args[3])
+		cmd.FailOnError(err, "Expires-in argument must be a duration")
+
+		sac, logger, clk := setupContext(c)
+		defer logger.AuditPanic()
+
+		id, err := addOverride(ctx, sac, clk, limitName, key, threshold, expiresIn, comment)
+		cmd.FailOnError(err, "Couldn't add rate limit override")
+		logger.AuditInfof(
+			"Added rate limit override: id=[%d] limit=[%s] key=[%s] threshold=[%d] expiresIn=[%s] comment=[%s]",
+			id, limitName, key, threshold, expiresIn, comment)
+		fmt.Printf("id: %d\n", id)
+
+	case command == "list-rate-limit-overrides" && len(args) == 0:
+		sac, logger, _ := setupContext(c)
+		defer logger.AuditPanic()
+
+		err := listOverrides(ctx, sac, os.Stdout, *all)
+		cmd.FailOnError(err, "Couldn't list rate limit overrides")
+
+	case command == "expire-rate-limit-override" && len(args) == 1:
+		// 1: override ID
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		cmd.FailOnError(err, "Override ID argument must be an integer")
+
+		sac, logger, _ := setupContext(c)
+		defer logger.AuditPanic()
+
+		_, err = sac.ExpireRateLimitOverride(ctx, &sapb.RateLimitOverrideID{Id: id})
+		cmd.FailOnError(err, "Couldn't expire rate limit override")
+		logger.AuditInfof("Expired rate limit override: id=[%d]", id)
+
+	case command == "list-issuers" && len(args) == 0:
+		cac, logger := setupCAContext(c)
+		defer logger.AuditPanic()
+
+		err := listIssuers(ctx, cac, os.Stdout)
+		cmd.FailOnError(err, "Couldn't list issuers")
+
+	default:
+		usage()
+	}
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/grpc"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+type mockSA struct {
+	added     []*sapb.ExternalAccountKey
+	overrides []*sapb.RateLimitOverride
+	err       error
+}
+
+func (msa *mockSA) AddExternalAccountKey(_ context.Context, req *sapb.ExternalAccountKey, _ ...grpc.CallOption) (*corepb.Empty, error) {
+	if msa.err != nil {
+		return nil, msa.err
+	}
+	msa.added = append(msa.added, req)
+	return &corepb.Empty{}, nil
+}
+
+func TestAddEABKey(t *testing.T) {
+	msa := &mockSA{}
+	fc := clock.NewFake()
+
+	_, err := addEABKey(context.Background(), msa, fc, "")
+	test.AssertError(t, err, "adding a key with an empty key ID should fail")
+	test.AssertEquals(t, len(msa.added), 0)
+
+	macKey, err := addEABKey(context.Background(), msa, fc, "tenant-1")
+	test.AssertNotError(t, err, "addEABKey failed")
+	test.AssertEquals(t, len(macKey), eabKeyLength)
+	test.AssertEquals(t, len(msa.added), 1)
+	test.AssertEquals(t, msa.added[0].KeyID, "tenant-1")
+	test.AssertByteEquals(t, msa.added[0].MacKey, macKey)
+	test.AssertEquals(t, msa.added[0].CreatedAt, fc.Now().UnixNano())
+
+	otherKey, err := addEABKey(context.Background(), msa, fc, "tenant-2")
+	test.AssertNotError(t, err, "addEABKey failed")
+	test.Assert(t, string(otherKey) != string(macKey), "generated MAC keys should differ")
+
+	msa.err = errors.New("duplicate key ID")
+	_, err = addEABKey(context.Background(), msa, fc, "tenant-1")
+	test.AssertError(t, err, "addEABKey should fail when the SA does")
+}
+
+func (msa *mockSA) AddRateLimitOverride(_ context.Context, req *sapb.RateLimitOverride, _ ...grpc.CallOption) (*sapb.RateLimitOverrideID, error) {
+	if msa.err != nil {
+		return nil, msa.err
+	}
+	req.Id = int64(len(msa.overrides) + 1)
+	msa.overrides = append(msa.overrides, req)
+	return &sapb.RateLimitOverrideID{Id: req.Id}, nil
+}
+
+func (msa *mockSA) GetRateLimitOverrides(_ context.Context, _ *sapb.GetRateLimitOverridesRequest, _ ...grpc.CallOption) (*sapb.RateLimitOverrides, error) {
+	if msa.err != nil {
+		return nil, msa.err
+	}
+	return &sapb.RateLimitOverrides{Overrides: msa.overrides}, nil
+}
+
+func (msa *mockSA) ExpireRateLimitOverride(_ context.Context, _ *sapb.RateLimitOverrideID, _ ...grpc.CallOption) (*corepb.Empty, error) {
+	return &corepb.Empty{}, msa.err
+}
+
+func TestAddOverride(t *testing.T) {
+	msa := &mockSA{}
+	fc := clock.NewFake()
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name      string
+		limitName string
+		key       string
+		threshold int64
+		expiresIn time.Duration
+		comment   string
+	}{
+		{"unknown limit", "certificatesPerDomain", "example.com", 10, time.Hour, "comment"},
+		{"empty key", "certificatesPerName", "", 10, time.Hour, "comment"},
+		{"negative threshold", "certificatesPerName", "example.com", -1, time.Hour, "comment"},
+		{"no expiry", "certificatesPerName", "example.com", 10, 0, "comment"},
+		{"no comment", "certificatesPerName", "example.com", 10, time.Hour, ""},
+		{"malformed registration", "newOrdersPerAccount", "reg:abc", 10, time.Hour, "comment"},
+		{"zero registration", "newOrdersPerAccount", "reg:0", 10, time.Hour, "comment"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := addOverride(ctx, msa, fc, tc.limitName, tc.key, tc.threshold, tc.expiresIn, tc.comment)
+			test.AssertError(t, err, "addOverride should have failed")
+		})
+	}
+	test.AssertEquals(t, len(msa.overrides), 0)
+
+	id, err := addOverride(ctx, msa, fc, "certificatesPerName", "example.com", 100, 720*time.Hour, "hosting provider")
+	test.AssertNotError(t, err, "addOverride failed")
+	test.AssertEquals(t, id, int64(1))
+	test.AssertEquals(t, msa.overrides[0].LimitName, "certificatesPerName")
+	test.AssertEquals(t, msa.overrides[0].Key, "example.com")
+	test.AssertEquals(t, msa.overrides[0].RegistrationID, int64(0))
+	test.AssertEquals(t, msa.overrides[0].Threshold, int64(100))
+	test.AssertEquals(t, msa.overrides[0].Expires, fc.Now().Add(720*time.Hour).UnixNano())
+	test.AssertEquals(t, msa.overrides[0].Comment, "hosting provider")
+
+	id, err = addOverride(ctx, msa, fc, "newOrdersPerAccount", "reg:1234", 0, time.Hour, "abusive account")
+	test.AssertNotError(t, err, "addOverride failed")
+	test.AssertEquals(t, id, int64(2))
+	test.AssertEquals(t, msa.overrides[1].Key, "")
+	test.AssertEquals(t, msa.overrides[1].RegistrationID, int64(1234))
+	test.AssertEquals(t, msa.overrides[1].Threshold, int64(0))
+
+	msa.err = errors.New("db down")
+	_, err = addOverride(ctx, msa, fc, "certificatesPerName", "example.com", 100, time.Hour, "comment")
+	test.AssertError(t, err, "addOverride should fail when the SA does")
+}
+
+func TestListOverrides(t *testing.T) {
+	expires := time.Date(2021, 8, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	msa := &mockSA{
+		overrides: []*sapb.RateLimitOverride{
+			{Id: 1, LimitName: "certificatesPerName", Key: "example.com", Threshold: 100, Expires: expires, Comment: "hosting provider"},
+			{Id: 2, LimitName: "newOrdersPerAccount", RegistrationID: 1234, Threshold: 0, Expires: expires, Comment: "abusive account"},
+		},
+	}
+	var buf bytes.Buffer
+	err := listOverrides(context.Background(), msa, &buf, false)
+	test.AssertNotError(t, err, "listOverrides failed")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	test.AssertEquals(t, len(lines), 3)
+	test.AssertContains(t, lines[0], "LIMIT")
+	test.AssertContains(t, lines[1], "example.com")
+	test.AssertContains(t, lines[1], "2021-08-01T00:00:00Z")
+	test.AssertContains(t, lines[1], "hosting provider")
+	test.AssertContains(t, lines[2], "reg:1234")
+
+	msa.err = errors.New("db down")
+	err = listOverrides(context.Background(), msa, &buf, false)
+	test.AssertError(t, err, "listOverrides should fail when the SA does")
+}
+
+type mockCA struct {
+	issuers []*capb.Issuer
+}
+
+func (mca *mockCA) GetIssuers(_ context.Context, _ *corepb.Empty, _ ...grpc.CallOption) (*capb.Issuers, error) {
+	return &capb.Issuers{Issuers: mca.issuers}, nil
+}
+
+func TestListIssuers(t *testing.T) {
+	notAfter := time.Date(2025, 9, 15, 0, 0, 0, 0, time.UTC).UnixNano()
+	mca := &mockCA{
+		issuers: []*capb.Issuer{
+			{Id: 1, NameID: 11, Name: "int-r3", State: "ocsp-only", NotAfter: notAfter},
+			{Id: 2, NameID: 22, Name: "int-r10", State: "active", NotAfter: notAfter},
+		},
+	}
+	var buf bytes.Buffer
+	err := listIssuers(context.Background(), mca, &buf)
+	test.AssertNotError(t, err, "listIssuers failed")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	test.AssertEquals(t, len(lines), 3)
+	test.AssertContains(t, lines[0], "STATE")
+	test.AssertContains(t, lines[1], "int-r3")
+	test.AssertContains(t, lines[1], "ocsp-only")
+	test.AssertContains(t, lines[1], "2025-09-15T00:00:00Z")
+	test.AssertContains(t, lines[2], "active")
+}
@@ -1,12 +1,16 @@
 package main
 
 import (
+	"crypto"
 	"flag"
 	"fmt"
+	"net"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/beeker1121/goque"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
@@ -31,14 +35,24 @@ type config struct {
 
 		GRPCCA            *cmd.GRPCServerConfig
 		GRPCOCSPGenerator *cmd.GRPCServerConfig
+		GRPCCRLGenerator  *cmd.GRPCServerConfig
 
 		SAService *cmd.GRPCClientConfig
 
 		// Issuance contains all information necessary to load and initialize issuers.
 		Issuance struct {
-			Profile      issuance.ProfileConfig
+			Profile issuance.ProfileConfig
+			// Profiles are named certificate profiles which may be requested in
+			// place of the default Profile. Each of them is used with every one
+			// of the Issuers.
+			Profiles     map[string]issuance.ProfileConfig
 			Issuers      []issuance.IssuerConfig
 			IgnoredLints []string
+			// IssuerSelection names the policy used to choose between Issuers
+			// with the same public key algorithm: "first" (the default),
+			// "weighted", "round-robin" or "account". All but "first" take
+			// each issuer's weight into account.
+			IssuerSelection string
 		}
 
 		// How long issued certificates are valid for.
@@ -57,6 +71,15 @@ type config struct {
 		// than the minTimeToExpiry field for the OCSP Updater.
 		LifespanOCSP cmd.ConfigDuration
 
+		// LifespanCRL is how long CRLs are valid for. It should be longer than the
+		// updatePeriod of the crl-updater. Only used if GRPCCRLGenerator is set.
+		LifespanCRL cmd.ConfigDuration
+
+		// CRLDPBase is the base URL used to build the Issuing Distribution Point
+		// extension of each CRL shard, as <base>/<issuerNameID>/<shardIdx>.crl.
+		// If empty, CRLs are produced without that extension.
+		CRLDPBase string
+
 		// WeakKeyFile is the path to a JSON file containing truncated RSA modulus
 		// hashes of known easily enumerable keys.
 		WeakKeyFile string
@@ -101,37 +124,73 @@ type config struct {
 	Syslog cmd.SyslogConfig
 }
 
-func loadBoulderIssuers(profileConfig issuance.ProfileConfig, issuerConfigs []issuance.IssuerConfig, ignoredLints []string) ([]*issuance.Issuer, error) {
+// loadBoulderIssuers loads each of the configured issuers once, and returns
+// issuers for all of them under the default profile, along with issuers for
+// all of them under each of the named profiles.
+func loadBoulderIssuers(profileConfig issuance.ProfileConfig, namedProfileConfigs map[string]issuance.ProfileConfig, issuerConfigs []issuance.IssuerConfig, ignoredLints []string) ([]*issuance.Issuer, map[string][]*issuance.Issuer, error) {
 	issuers := make([]*issuance.Issuer, 0, len(issuerConfigs))
+	profileIssuers := make(map[string][]*issuance.Issuer, len(namedProfileConfigs))
 	for _, issuerConfig := range issuerConfigs {
-		profile, err := issuance.NewProfile(profileConfig, issuerConfig)
+		cert, signer, err := issuance.LoadIssuer(issuerConfig.Location)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
-		cert, signer, err := issuance.LoadIssuer(issuerConfig.Location)
-		if err != nil {
-			return nil, err
+		// Profiles which may omit the OCSP URL or SCTs get their own linters,
+		// so that the lints requiring them are skipped only for those profiles.
+		linters := make(map[string]*lint.Linter)
+		linterFor := func(profileConfig issuance.ProfileConfig) (*lint.Linter, error) {
+			var skipped []string
+			if profileConfig.OmitOCSPThreshold.Duration != 0 {
+				skipped = append(skipped, "e_sub_cert_aia_does_not_contain_ocsp_url")
+			}
+			if profileConfig.DisableCT || issuerConfig.DisableCT {
+				skipped = append(skipped, "w_ct_sct_policy_count_unsatisfied")
+			}
+			key := strings.Join(skipped, ",")
+			if linters[key] == nil {
+				l, err := lint.NewLinter(signer, append(skipped, ignoredLints...))
+				if err != nil {
+					return nil, err
+				}
+				linters[key] = l
+			}
+			return linters[key], nil
 		}
 
-		linter, err := lint.NewLinter(signer, ignoredLints)
+		issuer, err := newBoulderIssuer(profileConfig, issuerConfig, cert, signer, linterFor)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		issuers = append(issuers, issuer)
 
-		issuer, err := issuance.NewIssuer(cert, signer, profile, linter, cmd.Clock())
-		if err != nil {
-			return nil, err
+		for name, namedProfileConfig := range namedProfileConfigs {
+			issuer, err := newBoulderIssuer(namedProfileConfig, issuerConfig, cert, signer, linterFor)
+			if err != nil {
+				return nil, nil, fmt.Errorf("certificate profile %q: %w", name, err)
+			}
+			profileIssuers[name] = append(profileIssuers[name], issuer)
 		}
+	}
+	return issuers, profileIssuers, nil
+}
 
-		issuers = append(issuers, issuer)
+func newBoulderIssuer(profileConfig issuance.ProfileConfig, issuerConfig issuance.IssuerConfig, cert *issuance.Certificate, signer crypto.Signer, linterFor func(issuance.ProfileConfig) (*lint.Linter, error)) (*issuance.Issuer, error) {
+	profile, err := issuance.NewProfile(profileConfig, issuerConfig)
+	if err != nil {
+		return nil, err
 	}
-	return issuers, nil
+	linter, err := linterFor(profileConfig)
+	if err != nil {
+		return nil, err
+	}
+	return issuance.NewIssuer(cert, signer, profile, linter, cmd.Clock())
 }
 
 func main() {
 	caAddr := flag.String("ca-addr", "", "CA gRPC listen address override")
 	ocspAddr := flag.String("ocsp-addr", "", "OCSP gRPC listen address override")
+	crlAddr := flag.String("crl-addr", "", "CRL gRPC listen address override")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
 	configFile := flag.String("config", "", "File path to the configuration file for this service")
 	flag.Parse()
@@ -153,6 +212,9 @@ func main() {
 	if *ocspAddr != "" {
 		c.CA.GRPCOCSPGenerator.Address = *ocspAddr
 	}
+	if *crlAddr != "" && c.CA.GRPCCRLGenerator != nil {
+		c.CA.GRPCCRLGenerator.Address = *crlAddr
+	}
 	if *debugAddr != "" {
 		c.CA.DebugAddr = *debugAddr
 	}
@@ -176,8 +238,8 @@ func main() {
 	err = pa.SetHostnamePolicyFile(c.CA.HostnamePolicyFile)
 	cmd.FailOnError(err, "Couldn't load hostname policy file")
 
-	var boulderIssuers []*issuance.Issuer
-	boulderIssuers, err = loadBoulderIssuers(c.CA.Issuance.Profile, c.CA.Issuance.Issuers, c.CA.Issuance.IgnoredLints)
+	boulderIssuers, profileIssuers, err := loadBoulderIssuers(
+		c.CA.Issuance.Profile, c.CA.Issuance.Profiles, c.CA.Issuance.Issuers, c.CA.Issuance.IgnoredLints)
 	cmd.FailOnError(err, "Couldn't load issuers")
 
 	tlsConfig, err := c.CA.TLS.Load()
@@ -204,7 +266,9 @@ func main() {
 		sa,
 		pa,
 		boulderIssuers,
+		profileIssuers,
 		c.CA.ECDSAAllowedAccounts,
+		c.CA.Issuance.IssuerSelection,
 		c.CA.Expiry.Duration,
 		c.CA.Backdate.Duration,
 		c.CA.SerialPrefix,
@@ -250,11 +314,42 @@ func main() {
 		wg.Done()
 	}()
 
+	var crlSrv *grpc.Server
+	var crlHealth *health.Server
+	if c.CA.GRPCCRLGenerator != nil {
+		crli, err := ca.NewCRLImpl(
+			boulderIssuers,
+			c.CA.LifespanCRL.Duration,
+			c.CA.CRLDPBase,
+			logger,
+			scope)
+		cmd.FailOnError(err, "Failed to create CRL impl")
+
+		var crlListener net.Listener
+		crlSrv, crlListener, err = bgrpc.NewServer(c.CA.GRPCCRLGenerator, tlsConfig, serverMetrics, clk)
+		cmd.FailOnError(err, "Unable to setup CA gRPC server")
+		capb.RegisterCRLGeneratorServer(crlSrv, crli)
+		crlHealth = health.NewServer()
+		healthpb.RegisterHealthServer(crlSrv, crlHealth)
+		wg.Add(1)
+		go func() {
+			cmd.FailOnError(cmd.FilterShutdownErrors(crlSrv.Serve(crlListener)),
+				"CRLGenerator gRPC service failed")
+			wg.Done()
+		}()
+	}
+
 	go cmd.CatchSignals(logger, func() {
 		caHealth.Shutdown()
 		ocspHealth.Shutdown()
+		if crlHealth != nil {
+			crlHealth.Shutdown()
+		}
 		caSrv.GracefulStop()
 		ocspSrv.GracefulStop()
+		if crlSrv != nil {
+			crlSrv.GracefulStop()
+		}
 		wg.Wait()
 		cai.Stop()
 	})
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -22,6 +23,7 @@ import (
 	pubpb "github.com/letsencrypt/boulder/publisher/proto"
 	"github.com/letsencrypt/boulder/ra"
 	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/ratelimit"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
 	vapb "github.com/letsencrypt/boulder/va/proto"
 )
@@ -32,6 +34,13 @@ type config struct {
 		cmd.HostnamePolicyConfig
 
 		RateLimitPoliciesFilename string
+		// Limiter, if present, enforces the rate limit policies using token
+		// buckets instead of counting past events in the SA's database.
+		Limiter *ratelimit.LimiterConfig
+		// RateLimitOverridesRefreshInterval is how often to reload the rate
+		// limit overrides stored in the database. If it is zero, only the
+		// overrides in the policy file apply.
+		RateLimitOverridesRefreshInterval cmd.ConfigDuration
 
 		MaxContactsPerRegistration int
 
@@ -72,6 +81,11 @@ type config struct {
 
 		OrderLifetime cmd.ConfigDuration
 
+		// FinalizeTimeout is how long issuance for an order may take once the
+		// finalize request has returned, when the AsyncFinalize feature is
+		// enabled. Defaults to 5 minutes.
+		FinalizeTimeout cmd.ConfigDuration
+
 		// CTLogGroups contains groupings of CT logs which we want SCTs from.
 		// When we retrieve SCTs we will submit the certificate to each log
 		// in a group and the first SCT returned will be used. This allows
@@ -93,6 +107,28 @@ type config struct {
 		// generate OCSP URLs to purge during revocation.
 		IssuerCerts []string
 
+		// CertificateProfileNames are the names of the certificate profiles,
+		// other than the default, which subscribers may request when creating
+		// an order. Each must match a profile configured on the CA.
+		CertificateProfileNames []string
+
+		// OrderValidityBounds limit the notBefore and notAfter which new orders
+		// may request, keyed by certificate profile name with the default
+		// profile under the empty name. Orders for a profile without bounds
+		// can't request a validity window. The bounds should match the
+		// profile's configuration on the CA.
+		OrderValidityBounds map[string]struct {
+			MaxValidity       cmd.ConfigDuration
+			MaxNotBeforeDelay cmd.ConfigDuration
+		}
+
+		// CTDisabledProfiles are the names of the certificate profiles, with
+		// the default profile named by the empty string, whose certificates
+		// are issued directly without a precertificate or SCTs. Each must
+		// match a profile, or a profile whose issuers all have CT disabled,
+		// on the CA.
+		CTDisabledProfiles []string
+
 		Features map[string]bool
 	}
 
@@ -214,6 +250,11 @@ func main() {
 		pendingAuthorizationLifetime = time.Duration(c.RA.PendingAuthorizationLifetimeDays) * 24 * time.Hour
 	}
 
+	finalizeTimeout := 5 * time.Minute
+	if c.RA.FinalizeTimeout.Duration != 0 {
+		finalizeTimeout = c.RA.FinalizeTimeout.Duration
+	}
+
 	kp, err := goodkey.NewKeyPolicy(c.RA.WeakKeyFile, c.RA.BlockedKeyFile, sac.KeyBlocked)
 	cmd.FailOnError(err, "Unable to create key policy")
 
@@ -234,19 +275,41 @@ func main() {
 		pubc,
 		caaClient,
 		c.RA.OrderLifetime.Duration,
+		finalizeTimeout,
 		ctp,
 		apc,
 		issuerCerts,
+		c.RA.CertificateProfileNames,
 	)
 
 	policyErr := rai.SetRateLimitPoliciesFile(c.RA.RateLimitPoliciesFilename)
 	cmd.FailOnError(policyErr, "Couldn't load rate limit policies file")
 	rai.PA = pa
+	rai.ValidityBounds = make(map[string]ra.ValidityBounds, len(c.RA.OrderValidityBounds))
+	for name, bounds := range c.RA.OrderValidityBounds {
+		rai.ValidityBounds[name] = ra.ValidityBounds{
+			MaxValidity:       bounds.MaxValidity.Duration,
+			MaxNotBeforeDelay: bounds.MaxNotBeforeDelay.Duration,
+		}
+	}
+	rai.CTDisabledProfiles = make(map[string]bool, len(c.RA.CTDisabledProfiles))
+	for _, name := range c.RA.CTDisabledProfiles {
+		rai.CTDisabledProfiles[name] = true
+	}
+	if c.RA.Limiter != nil {
+		source, err := c.RA.Limiter.NewSource()
+		cmd.FailOnError(err, "Couldn't create rate limit source")
+		rai.Limiter = ratelimit.NewLimiter(clk, source, scope)
+	}
 
 	rai.VA = vac
 	rai.CA = cac
 	rai.SA = sac
 
+	if c.RA.RateLimitOverridesRefreshInterval.Duration > 0 {
+		go rai.RefreshRateLimitOverridesEvery(context.Background(), c.RA.RateLimitOverridesRefreshInterval.Duration)
+	}
+
 	serverMetrics := bgrpc.NewServerMetrics(scope)
 	grpcSrv, listener, err := bgrpc.NewServer(c.RA.GRPC, tlsConfig, serverMetrics, clk)
 	cmd.FailOnError(err, "Unable to setup RA gRPC server")
@@ -258,6 +321,7 @@ func main() {
 	go cmd.CatchSignals(logger, func() {
 		hs.Shutdown()
 		grpcSrv.GracefulStop()
+		rai.DrainFinalize()
 	})
 
 	err = cmd.FilterShutdownErrors(grpcSrv.Serve(listener))
@@ -43,9 +43,24 @@ type config struct {
 		DNSResolvers              []string
 		DNSTimeout                string
 		DNSAllowLoopbackAddresses bool
-
-		RemoteVAs                   []cmd.GRPCClientConfig
+		// DNSSECTrustAnchorFile, if set, enables in-process DNSSEC validation
+		// of all DNS answers. It names a zone file of DS or DNSKEY records
+		// for the trust anchors to validate from, usually the root zone's.
+		// Otherwise DNSSEC validation is left to the resolvers, and the
+		// status of each answer is taken from its AD bit.
+		DNSSECTrustAnchorFile string
+		// DNSUpstreams, if set, replaces DNSResolver and DNSResolvers with
+		// a static list of resolvers, each of which may be queried over UDP,
+		// DNS over TLS or DNS over HTTPS.
+		DNSUpstreams []bdns.ServerConfig
+
+		RemoteVAs                   []RemoteVAGRPCClientConfig
 		MaxRemoteValidationFailures int
+		// RemotePerspectiveQuorum, if non-zero, replaces
+		// MaxRemoteValidationFailures: validation then requires remote VAs
+		// in at least this many distinct perspectives to succeed, and every
+		// remote VA must have a Perspective.
+		RemotePerspectiveQuorum int
 
 		Features map[string]bool
 
@@ -60,6 +75,13 @@ type config struct {
 	}
 }
 
+// RemoteVAGRPCClientConfig configures a remote VA, which validates from the
+// region or RIR given by its Perspective.
+type RemoteVAGRPCClientConfig struct {
+	cmd.GRPCClientConfig
+	Perspective string
+}
+
 func main() {
 	grpcAddr := flag.String("addr", "", "gRPC listen address override")
 	debugAddr := flag.String("debug-addr", "", "Debug server address override")
@@ -117,7 +139,16 @@ func main() {
 	clk := cmd.Clock()
 
 	var servers bdns.ServerProvider
-	if c.VA.DNSResolver != "" {
+	if len(c.VA.DNSUpstreams) != 0 {
+		if c.VA.DNSResolver != "" || len(c.VA.DNSResolvers) != 0 {
+			cmd.Fail("Only one of DNSUpstreams, DNSResolver and DNSResolvers may be set")
+		}
+		var addrs []string
+		for _, upstream := range c.VA.DNSUpstreams {
+			addrs = append(addrs, upstream.Address)
+		}
+		servers = bdns.NewStaticProvider(addrs)
+	} else if c.VA.DNSResolver != "" {
 		servers, err = bdns.StartDynamicProvider(c.VA.DNSResolver, 60*time.Second)
 		cmd.FailOnError(err, "Couldn't start dynamic DNS server resolver")
 	} else {
@@ -142,6 +173,15 @@ func main() {
 			dnsTries,
 			logger)
 	}
+	if len(c.VA.DNSUpstreams) != 0 {
+		resolver, err = bdns.WithServers(resolver, c.VA.DNSUpstreams)
+		cmd.FailOnError(err, "Couldn't configure DNS upstreams")
+	}
+	if c.VA.DNSSECTrustAnchorFile != "" {
+		trustAnchors, err := bdns.LoadTrustAnchors(c.VA.DNSSECTrustAnchorFile)
+		cmd.FailOnError(err, "Couldn't load DNSSEC trust anchors")
+		resolver = bdns.WithDNSSEC(resolver, trustAnchors)
+	}
 
 	tlsConfig, err := c.VA.TLS.Load()
 	cmd.FailOnError(err, "tlsConfig config")
@@ -151,13 +191,19 @@ func main() {
 	if len(c.VA.RemoteVAs) > 0 {
 		for _, rva := range c.VA.RemoteVAs {
 			rva := rva
-			vaConn, err := bgrpc.ClientSetup(&rva, tlsConfig, clientMetrics, clk)
+			vaConn, err := bgrpc.ClientSetup(&rva.GRPCClientConfig, tlsConfig, clientMetrics, clk)
 			cmd.FailOnError(err, "Unable to create remote VA client")
+			address := rva.ServerAddress
+			if rva.SRVLookup != nil {
+				address = rva.SRVLookup.Name()
+			}
 			remotes = append(
 				remotes,
 				va.RemoteVA{
-					VAClient: vapb.NewVAClient(vaConn),
-					Address:  rva.ServerAddress,
+					VAClient:    vapb.NewVAClient(vaConn),
+					CAAClient:   vapb.NewCAAClient(vaConn),
+					Address:     address,
+					Perspective: rva.Perspective,
 				},
 			)
 		}
@@ -168,6 +214,7 @@ func main() {
 		resolver,
 		remotes,
 		c.VA.MaxRemoteValidationFailures,
+		c.VA.RemotePerspectiveQuorum,
 		c.VA.UserAgent,
 		c.VA.IssuerDomain,
 		scope,
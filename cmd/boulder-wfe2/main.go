@@ -23,9 +23,11 @@ import (
 	blog "github.com/letsencrypt/boulder/log"
 	noncepb "github.com/letsencrypt/boulder/nonce/proto"
 	rapb "github.com/letsencrypt/boulder/ra/proto"
+	"github.com/letsencrypt/boulder/reloader"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
 	"github.com/letsencrypt/boulder/wfe2"
 	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/yaml.v2"
 )
 
 type config struct {
@@ -83,6 +85,15 @@ type config struct {
 		// ignored. They will be removed in a future release.
 		Chains [][]string
 
+		// ChainsFile is the path to a YAML file containing a list of chains in
+		// the same form as Chains. The file is watched and reloaded whenever it
+		// changes, so that chains can be added, removed, or reordered without a
+		// restart. The issuers are fixed by the chains loaded at startup, and a
+		// reloaded file which drops every chain for one of them, or adds a chain
+		// for a new one, is rejected. If it is present, Chains,
+		// CertificateChains, and AlternateCertificateChains are ignored.
+		ChainsFile string
+
 		Features map[string]bool
 
 		// DirectoryCAAIdentity is used for the /directory response's "meta"
@@ -93,6 +104,12 @@ type config struct {
 		// "website" field.
 		DirectoryWebsite string
 
+		// ExternalAccountRequired causes newAccount requests without a valid
+		// externalAccountBinding to be rejected, and is advertised in the
+		// /directory response's "meta" element. It requires the
+		// ExternalAccountBinding feature to be enabled.
+		ExternalAccountRequired bool
+
 		// ACMEv2 requests (outside some registration/revocation messages) use a JWS with
 		// a KeyID header containing the full account URL. For new accounts this
 		// will be a KeyID based on the HTTP request's Host header and the ACMEv2
@@ -264,6 +281,41 @@ func loadChain(certFiles []string) (*issuance.Certificate, []byte, error) {
 	return certs[0], buf.Bytes(), nil
 }
 
+// loadChains loads each of the given lists of filenames with loadChain, and
+// returns the resulting chains along with their issuer certificates, both
+// keyed by the issuer's IssuerNameID.
+func loadChains(chainFiles [][]string) (map[issuance.IssuerNameID][][]byte, map[issuance.IssuerNameID]*issuance.Certificate, error) {
+	allCertChains := map[issuance.IssuerNameID][][]byte{}
+	issuerCerts := map[issuance.IssuerNameID]*issuance.Certificate{}
+	for _, files := range chainFiles {
+		issuer, chain, err := loadChain(files)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		id := issuer.NameID()
+		allCertChains[id] = append(allCertChains[id], chain)
+		// This may overwrite a previously-set issuerCert (e.g. if there are two
+		// chains for the same issuer, but with different versions of the same
+		// same intermediate issued by different roots). This is okay, as the
+		// only truly important content here is the public key to verify other
+		// certs.
+		issuerCerts[id] = issuer
+	}
+	return allCertChains, issuerCerts, nil
+}
+
+// loadChainsFile parses the contents of a ChainsFile and loads the chains it
+// lists with loadChains.
+func loadChainsFile(contents []byte) (map[issuance.IssuerNameID][][]byte, map[issuance.IssuerNameID]*issuance.Certificate, error) {
+	var chainFiles [][]string
+	err := yaml.UnmarshalStrict(contents, &chainFiles)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing chains file: %w", err)
+	}
+	return loadChains(chainFiles)
+}
+
 func setupWFE(c config, logger blog.Logger, stats prometheus.Registerer, clk clock.Clock) (core.RegistrationAuthority, core.StorageAuthority, noncepb.NonceServiceClient, map[string]noncepb.NonceServiceClient) {
 	tlsConfig, err := c.WFE.TLS.Load()
 	cmd.FailOnError(err, "TLS config")
@@ -326,20 +378,14 @@ func main() {
 
 	allCertChains := map[issuance.IssuerNameID][][]byte{}
 	issuerCerts := map[issuance.IssuerNameID]*issuance.Certificate{}
-	if c.WFE.Chains != nil {
-		for _, files := range c.WFE.Chains {
-			issuer, chain, err := loadChain(files)
-			cmd.FailOnError(err, "Failed to load chain")
-
-			id := issuer.NameID()
-			allCertChains[id] = append(allCertChains[id], chain)
-			// This may overwrite a previously-set issuerCert (e.g. if there are two
-			// chains for the same issuer, but with different versions of the same
-			// same intermediate issued by different roots). This is okay, as the
-			// only truly important content here is the public key to verify other
-			// certs.
-			issuerCerts[id] = issuer
-		}
+	if c.WFE.ChainsFile != "" {
+		contents, err := ioutil.ReadFile(c.WFE.ChainsFile)
+		cmd.FailOnError(err, "Failed to read chains file")
+		allCertChains, issuerCerts, err = loadChainsFile(contents)
+		cmd.FailOnError(err, "Failed to load chains file")
+	} else if c.WFE.Chains != nil {
+		allCertChains, issuerCerts, err = loadChains(c.WFE.Chains)
+		cmd.FailOnError(err, "Failed to load chain")
 	} else {
 		// TODO(5164): Remove this after all configs have migrated to `Chains`.
 		var certChains map[issuance.IssuerNameID][]byte
@@ -394,10 +440,32 @@ func main() {
 	wfe.RA = rac
 	wfe.SA = sac
 
+	if c.WFE.ChainsFile != "" {
+		_, err = reloader.New(c.WFE.ChainsFile, func(contents []byte) error {
+			chains, _, err := loadChainsFile(contents)
+			if err != nil {
+				return err
+			}
+			err = wfe.SetCertificateChains(chains)
+			if err != nil {
+				return err
+			}
+			logger.Infof("Loaded certificate chains from %s", c.WFE.ChainsFile)
+			return nil
+		}, func(err error) {
+			logger.AuditErrf("Failed to reload certificate chains from %s: %s", c.WFE.ChainsFile, err)
+		})
+		cmd.FailOnError(err, "Failed to watch chains file")
+	}
+
 	wfe.SubscriberAgreementURL = c.WFE.SubscriberAgreementURL
 	wfe.AllowOrigins = c.WFE.AllowOrigins
 	wfe.DirectoryCAAIdentity = c.WFE.DirectoryCAAIdentity
 	wfe.DirectoryWebsite = c.WFE.DirectoryWebsite
+	if c.WFE.ExternalAccountRequired && !features.Enabled(features.ExternalAccountBinding) {
+		cmd.Fail("externalAccountRequired requires the ExternalAccountBinding feature")
+	}
+	wfe.ExternalAccountRequired = c.WFE.ExternalAccountRequired
 	wfe.LegacyKeyIDPrefix = c.WFE.LegacyKeyIDPrefix
 
 	logger.Infof("WFE using key policy: %#v", kp)
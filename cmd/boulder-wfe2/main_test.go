@@ -261,3 +261,29 @@ func TestLoadCertificateChains(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadChainsFile(t *testing.T) {
+	chains, issuerCerts, err := loadChainsFile([]byte(`
+- - ../../test/hierarchy/int-r3.cert.pem
+  - ../../test/hierarchy/root-x1.cert.pem
+- - ../../test/hierarchy/int-r3-cross.cert.pem
+  - ../../test/hierarchy/root-dst.cert.pem
+- - ../../test/hierarchy/int-e1.cert.pem
+  - ../../test/hierarchy/root-x2.cert.pem
+`))
+	test.AssertNotError(t, err, "Failed to load chains file")
+	test.AssertEquals(t, len(chains), 2)
+	test.AssertEquals(t, len(issuerCerts), 2)
+
+	r3, err := issuance.LoadCertificate("../../test/hierarchy/int-r3.cert.pem")
+	test.AssertNotError(t, err, "Failed to load test issuer")
+	test.AssertEquals(t, len(chains[r3.NameID()]), 2)
+
+	_, _, err = loadChainsFile([]byte("chains: not a list"))
+	test.AssertError(t, err, "Loaded a malformed chains file")
+
+	_, _, err = loadChainsFile([]byte(`
+- - ../../test/hierarchy/int-r3.cert.pem
+`))
+	test.AssertError(t, err, "Loaded a chains file with a chain that's too short")
+}
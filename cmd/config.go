@@ -233,8 +233,35 @@ func (d *ConfigDuration) UnmarshalYAML(unmarshal func(interface{}) error) error
 
 // GRPCClientConfig contains the information needed to talk to the gRPC service
 type GRPCClientConfig struct {
+	// ServerAddress is the host:port of the service. The host is resolved to
+	// the addresses of its backends. Exactly one of ServerAddress and
+	// SRVLookup must be set.
 	ServerAddress string
-	Timeout       ConfigDuration
+	// SRVLookup, if set, discovers the service's backends from DNS, so that
+	// backends can be added or removed without changing the client's config.
+	SRVLookup *ServiceDomain
+	// DNSAuthority is the host:port of the DNS server which SRVLookup queries
+	// are sent to. If it is empty the system's resolvers are used.
+	DNSAuthority string
+	Timeout      ConfigDuration
+}
+
+// ServiceDomain names a gRPC service whose backends are discovered from DNS.
+type ServiceDomain struct {
+	// Service and Domain give the SRV records to look up, at
+	// _Service._tcp.Domain. The backends' certificates are verified against
+	// the name Service.Domain.
+	Service string
+	Domain  string
+	// DNSSD, if true, finds the backends by DNS-Based Service Discovery
+	// (RFC 6763) instead: _Service._tcp.Domain has a PTR record for each
+	// backend, naming the SRV records for that backend.
+	DNSSD bool
+}
+
+// Name returns the name which backends of the service are identified by.
+func (sd ServiceDomain) Name() string {
+	return sd.Service + "." + sd.Domain
 }
 
 // GRPCServerConfig contains the information needed to run a gRPC service
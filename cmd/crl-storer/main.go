@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/crl/storer"
+	cspb "github.com/letsencrypt/boulder/crl/storer/proto"
+	"github.com/letsencrypt/boulder/features"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/issuance"
+)
+
+type config struct {
+	CRLStorer struct {
+		cmd.ServiceConfig
+
+		GRPC *cmd.GRPCServerConfig
+
+		// IssuerCerts is a list of paths to issuer certificates on disk. The
+		// crl-storer will only accept CRLs signed by one of these issuers.
+		IssuerCerts []string
+
+		// LocalDirectory is the directory beneath which CRLs are written. It is
+		// created if it does not already exist.
+		LocalDirectory string
+
+		Features map[string]bool
+	}
+
+	Syslog cmd.SyslogConfig
+}
+
+func main() {
+	grpcAddr := flag.String("addr", "", "gRPC listen address override")
+	debugAddr := flag.String("debug-addr", "", "Debug server address override")
+	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	flag.Parse()
+	if *configFile == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var c config
+	err := cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, "Reading JSON config file into config structure")
+
+	err = features.Set(c.CRLStorer.Features)
+	cmd.FailOnError(err, "Failed to set feature flags")
+
+	if *grpcAddr != "" {
+		c.CRLStorer.GRPC.Address = *grpcAddr
+	}
+	if *debugAddr != "" {
+		c.CRLStorer.DebugAddr = *debugAddr
+	}
+
+	stats, logger := cmd.StatsAndLogging(c.Syslog, c.CRLStorer.DebugAddr)
+	defer logger.AuditPanic()
+	logger.Info(cmd.VersionString())
+	clk := cmd.Clock()
+
+	tlsConfig, err := c.CRLStorer.TLS.Load()
+	cmd.FailOnError(err, "TLS config")
+
+	issuers := make([]*issuance.Certificate, 0, len(c.CRLStorer.IssuerCerts))
+	for _, filepath := range c.CRLStorer.IssuerCerts {
+		cert, err := issuance.LoadCertificate(filepath)
+		cmd.FailOnError(err, "Failed to load issuer cert")
+		issuers = append(issuers, cert)
+	}
+
+	backend, err := storer.NewLocalBackend(c.CRLStorer.LocalDirectory)
+	cmd.FailOnError(err, "Failed to set up local CRL storage")
+
+	csi, err := storer.New(issuers, backend, stats, logger, clk)
+	cmd.FailOnError(err, "Failed to create CRLStorer impl")
+
+	serverMetrics := bgrpc.NewServerMetrics(stats)
+	grpcSrv, listener, err := bgrpc.NewServer(c.CRLStorer.GRPC, tlsConfig, serverMetrics, clk)
+	cmd.FailOnError(err, "Unable to setup CRLStorer gRPC server")
+	cspb.RegisterCRLStorerServer(grpcSrv, csi)
+	hs := health.NewServer()
+	healthpb.RegisterHealthServer(grpcSrv, hs)
+
+	go cmd.CatchSignals(logger, func() {
+		hs.Shutdown()
+		grpcSrv.GracefulStop()
+	})
+
+	err = cmd.FilterShutdownErrors(grpcSrv.Serve(listener))
+	cmd.FailOnError(err, "CRLStorer gRPC service failed")
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/cmd"
+	cspb "github.com/letsencrypt/boulder/crl/storer/proto"
+	"github.com/letsencrypt/boulder/crl/updater"
+	"github.com/letsencrypt/boulder/features"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/issuance"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+type config struct {
+	CRLUpdater struct {
+		cmd.ServiceConfig
+
+		CRLGeneratorService *cmd.GRPCClientConfig
+		SAService           *cmd.GRPCClientConfig
+		CRLStorerService    *cmd.GRPCClientConfig
+
+		// IssuerCerts is a list of paths to issuer certificates on disk. This
+		// controls the set of CRLs which will be published by this updater: it
+		// will publish one set of NumShards CRL shards for each issuer in this
+		// list.
+		IssuerCerts []string
+
+		// NumShards is the number of shards into which each issuer's "full and
+		// complete" CRL will be split.
+		// WARNING: When this number is changed, the "JSON Array of CRL URLs" field
+		// in CCADB MUST be updated.
+		NumShards int
+
+		// CertificateLifetime is the validity period (usually expressed in hours,
+		// like "2160h") of the longest-lived currently-unexpired certificate. For
+		// Let's Encrypt, this is usually ninety days. If the validity period of
+		// the issued certificates ever changes upwards, this value must be updated
+		// immediately; if the validity period of the issued certificates ever
+		// changes downwards, the value must not change until after all certificates
+		// with the old validity period have expired.
+		CertificateLifetime cmd.ConfigDuration
+
+		// UpdatePeriod controls how frequently the crl-updater runs and publishes
+		// new versions of every CRL shard. The Baseline Requirements, Section
+		// 4.9.7 state that this MUST NOT be more than 7 days. We believe that
+		// future updates may require that this not be more than 24 hours, and
+		// currently recommend an UpdatePeriod of 6 hours.
+		UpdatePeriod cmd.ConfigDuration
+
+		// LookbackPeriod is how far back the updater should look for revoked
+		// expired certificates. We are required to include every revoked cert in
+		// at least one CRL, even if it is revoked seconds before it expires, so
+		// this must always be greater than the UpdatePeriod, and should be
+		// increased when recovering from an outage to ensure continuity of
+		// coverage.
+		LookbackPeriod cmd.ConfigDuration
+
+		Features map[string]bool
+	}
+
+	Syslog cmd.SyslogConfig
+}
+
+func main() {
+	configFile := flag.String("config", "", "File path to the configuration file for this service")
+	debugAddr := flag.String("debug-addr", "", "Debug server address override")
+	flag.Parse()
+	if *configFile == "" {
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	var c config
+	err := cmd.ReadConfigFile(*configFile, &c)
+	cmd.FailOnError(err, "Reading JSON config file into config structure")
+
+	err = features.Set(c.CRLUpdater.Features)
+	cmd.FailOnError(err, "Failed to set feature flags")
+
+	if *debugAddr != "" {
+		c.CRLUpdater.DebugAddr = *debugAddr
+	}
+
+	stats, logger := cmd.StatsAndLogging(c.Syslog, c.CRLUpdater.DebugAddr)
+	defer logger.AuditPanic()
+	logger.Info(cmd.VersionString())
+	clk := cmd.Clock()
+
+	tlsConfig, err := c.CRLUpdater.TLS.Load()
+	cmd.FailOnError(err, "TLS config")
+
+	issuers := make([]*issuance.Certificate, 0, len(c.CRLUpdater.IssuerCerts))
+	for _, filepath := range c.CRLUpdater.IssuerCerts {
+		cert, err := issuance.LoadCertificate(filepath)
+		cmd.FailOnError(err, "Failed to load issuer cert")
+		issuers = append(issuers, cert)
+	}
+
+	clientMetrics := bgrpc.NewClientMetrics(stats)
+
+	saConn, err := bgrpc.ClientSetup(c.CRLUpdater.SAService, tlsConfig, clientMetrics, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to SA")
+	sac := sapb.NewStorageAuthorityClient(saConn)
+
+	caConn, err := bgrpc.ClientSetup(c.CRLUpdater.CRLGeneratorService, tlsConfig, clientMetrics, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to CRLGenerator")
+	cac := capb.NewCRLGeneratorClient(caConn)
+
+	csConn, err := bgrpc.ClientSetup(c.CRLUpdater.CRLStorerService, tlsConfig, clientMetrics, clk)
+	cmd.FailOnError(err, "Failed to load credentials and create gRPC connection to CRLStorer")
+	csc := cspb.NewCRLStorerClient(csConn)
+
+	u, err := updater.NewUpdater(
+		issuers,
+		c.CRLUpdater.NumShards,
+		c.CRLUpdater.CertificateLifetime.Duration,
+		c.CRLUpdater.UpdatePeriod.Duration,
+		c.CRLUpdater.LookbackPeriod.Duration,
+		sac,
+		cac,
+		csc,
+		stats,
+		logger,
+		clk,
+	)
+	cmd.FailOnError(err, "Failed to create crl-updater")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go cmd.CatchSignals(logger, cancel)
+
+	u.Run(ctx)
+}
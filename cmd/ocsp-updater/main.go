@@ -131,12 +131,16 @@ func (updater *OCSPUpdater) findStaleOCSPResponses(oldestLastUpdatedTime time.Ti
 	statuses, err := sa.SelectCertificateStatuses(
 		updater.dbMap,
 		`WHERE ocspLastUpdated < :lastUpdate
+		 AND ocspLastUpdated > :never
 		 AND NOT isExpired
 		 ORDER BY ocspLastUpdated ASC
 		 LIMIT :limit`,
 		map[string]interface{}{
 			"lastUpdate": oldestLastUpdatedTime,
-			"limit":      batchSize,
+			// Certificates issued without an OCSP URL are stored with a zero
+			// ocspLastUpdated, and must never have responses generated.
+			"never": time.Time{},
+			"limit": batchSize,
 		},
 	)
 	if db.IsNoRows(err) {
@@ -242,6 +242,34 @@ func TestFindStaleOCSPResponses(t *testing.T) {
 	test.AssertEquals(t, len(statuses), 0)
 }
 
+func TestFindStaleOCSPResponsesOmitOCSP(t *testing.T) {
+	updater, sa, _, fc, cleanUp := setup(t)
+	defer cleanUp()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+	parsedCert, err := core.LoadCert("test-cert.pem")
+	test.AssertNotError(t, err, "Couldn't read test certificate")
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:      parsedCert.Raw,
+		RegID:    reg.ID,
+		Ocsp:     nil,
+		Issued:   nowNano(fc),
+		IssuerID: 1,
+		OmitOCSP: true,
+	})
+	test.AssertNotError(t, err, "Couldn't add test-cert.pem")
+
+	// Jump time forward by 2 hours, which would make any normal certificate's
+	// response stale.
+	fc.Set(fc.Now().Add(2 * time.Hour))
+	earliest := fc.Now().Add(-time.Hour)
+
+	// A certificate without OCSP should never need a response.
+	statuses, err := updater.findStaleOCSPResponses(earliest, 10)
+	test.AssertNotError(t, err, "Couldn't find stale responses")
+	test.AssertEquals(t, len(statuses), 0)
+}
+
 func TestFindStaleOCSPResponsesRevokedReason(t *testing.T) {
 	updater, sa, dbMap, fc, cleanUp := setup(t)
 	defer cleanUp()
@@ -62,6 +62,7 @@ type config struct {
 type certificateStorage interface {
 	AddCertificate(context.Context, []byte, int64, []byte, *time.Time) (string, error)
 	AddPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error)
+	AddCertificateWithoutPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error)
 	GetCertificate(ctx context.Context, serial string) (core.Certificate, error)
 	GetPrecertificate(ctx context.Context, reqSerial *sapb.Serial) (*corepb.Certificate, error)
 }
@@ -100,17 +101,23 @@ func (t orphanType) String() string {
 // "orphaning", "(pre)?certificate", "cert=[\w+]", "issuerID=[\d+]", and "regID=[\d]".
 // For example:
 // `[AUDIT] Failed RPC to store at SA, orphaning precertificate: serial=[04asdf1234], cert=[MIIdeafbeef], issuerID=[112358], regID=[1001], orderID=[1002], err=[Timed out]`
-// The orphan-finder does not care about the serial, error, or orderID.
+// The orphan-finder does not care about the serial, error, or orderID. A
+// certificate issued directly, without a precertificate, is marked with
+// "noPrecertificate=[true]".
 type parsedLine struct {
 	certDER  []byte
 	issuerID int64
 	regID    int64
+	// noPrecert is true if the certificate was issued directly, so the
+	// records which are normally written for its precertificate don't exist.
+	noPrecert bool
 }
 
 var (
 	derOrphan        = regexp.MustCompile(`cert=\[([0-9a-f]+)\]`)
 	regOrphan        = regexp.MustCompile(`regID=\[(\d+)\]`)
 	issuerOrphan     = regexp.MustCompile(`issuerID=\[(\d+)\]`)
+	noPrecertOrphan  = regexp.MustCompile(`noPrecertificate=\[true\]`)
 	errAlreadyExists = fmt.Errorf("Certificate already exists in DB")
 )
 
@@ -191,9 +198,10 @@ func parseLogLine(line string, logger blog.Logger) (parsedLine, error) {
 	}
 
 	return parsedLine{
-		certDER:  der,
-		regID:    regID,
-		issuerID: issuerID,
+		certDER:   der,
+		regID:     regID,
+		issuerID:  issuerID,
+		noPrecert: noPrecertOrphan.MatchString(line),
 	}, nil
 }
 
@@ -325,10 +333,18 @@ func (opf *orphanFinder) storeLogLine(ctx context.Context, line string) (found b
 	// orphan issued in the past. Because certificates are backdated we need to
 	// add the backdate duration to find the true issued time.
 	issuedDate := cert.NotBefore.Add(opf.backdate)
-	switch typ {
-	case certOrphan:
+	switch {
+	case typ == certOrphan && parsed.noPrecert:
+		_, err = opf.sa.AddCertificateWithoutPrecertificate(ctx, &sapb.AddCertificateRequest{
+			Der:      parsed.certDER,
+			RegID:    parsed.regID,
+			Ocsp:     response,
+			Issued:   issuedDate.UnixNano(),
+			IssuerID: parsed.issuerID,
+		})
+	case typ == certOrphan:
 		_, err = opf.sa.AddCertificate(ctx, parsed.certDER, parsed.regID, response, &issuedDate)
-	case precertOrphan:
+	case typ == precertOrphan:
 		_, err = opf.sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
 			Der:      parsed.certDER,
 			RegID:    parsed.regID,
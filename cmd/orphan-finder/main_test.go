@@ -31,7 +31,10 @@ import (
 type mockSA struct {
 	certificates    []core.Certificate
 	precertificates []core.Certificate
-	clk             clock.FakeClock
+	// direct holds the serials of certificates added without a
+	// precertificate.
+	direct map[string]bool
+	clk    clock.FakeClock
 }
 
 func (m *mockSA) AddCertificate(ctx context.Context, der []byte, regID int64, _ []byte, issued *time.Time) (string, error) {
@@ -53,6 +56,23 @@ func (m *mockSA) AddCertificate(ctx context.Context, der []byte, regID int64, _
 	return "", nil
 }
 
+func (m *mockSA) AddCertificateWithoutPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error) {
+	if core.IsAnyNilOrZero(req.Der, req.Issued, req.RegID, req.IssuerID) {
+		return nil, berrors.InternalServerError("Incomplete request")
+	}
+	issued := time.Unix(0, req.Issued)
+	_, err := m.AddCertificate(ctx, req.Der, req.RegID, req.Ocsp, &issued)
+	if err != nil {
+		return nil, err
+	}
+	parsed, _ := x509.ParseCertificate(req.Der)
+	if m.direct == nil {
+		m.direct = make(map[string]bool)
+	}
+	m.direct[core.SerialToString(parsed.SerialNumber)] = true
+	return &corepb.Empty{}, nil
+}
+
 func (m *mockSA) GetCertificate(ctx context.Context, s string) (core.Certificate, error) {
 	if len(m.certificates) == 0 {
 		return core.Certificate{}, berrors.NotFoundError("no certs stored")
@@ -125,6 +145,13 @@ func TestParseLine(t *testing.T) {
 	precertDER, err := x509.CreateCertificate(rand.Reader, &precertTmpl, issuer.Certificate, key.Public(), key)
 	test.AssertNotError(t, err, "failed to generate test precert")
 	precertStr := hex.EncodeToString(precertDER)
+	directTmpl := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+	}
+	directDER, err := x509.CreateCertificate(rand.Reader, &directTmpl, issuer.Certificate, key.Public(), key)
+	test.AssertNotError(t, err, "failed to generate test cert")
+	directStr := hex.EncodeToString(directDER)
 
 	opf := &orphanFinder{
 		sa:       &mockSA{},
@@ -150,6 +177,7 @@ func TestParseLine(t *testing.T) {
 		ExpectNoErrors bool
 		ExpectAddedDER string
 		ExpectRegID    int
+		ExpectDirect   bool
 	}{
 		{
 			Name:           "Empty line",
@@ -190,6 +218,16 @@ func TestParseLine(t *testing.T) {
 			ExpectAdded:    false,
 			ExpectNoErrors: true,
 		},
+		{
+			Name:           "Valid directly issued cert in line",
+			LogLine:        logLine(certOrphan, directStr, "1", "1002", "0") + ", noPrecertificate=[true]",
+			ExpectFound:    true,
+			ExpectAdded:    true,
+			ExpectAddedDER: directStr,
+			ExpectRegID:    1002,
+			ExpectDirect:   true,
+			ExpectNoErrors: true,
+		},
 		{
 			Name:           "Empty precert in line",
 			LogLine:        logLine(precertOrphan, "", "1", "1337", "0"),
@@ -280,6 +318,9 @@ func TestParseLine(t *testing.T) {
 				}
 				// The orphan should have been added with the correct registration ID from the log line
 				test.AssertEquals(t, storedCert.RegistrationID, int64(tc.ExpectRegID))
+				// Certificates issued directly need the records which would
+				// otherwise have been written for their precertificate.
+				test.AssertEquals(t, opf.sa.(*mockSA).direct[testCertSerial], tc.ExpectDirect)
 				// The Issued timestamp should be the certificate's NotBefore timestamp offset by the backdate
 				expectedIssued := testCert.NotBefore.Add(opf.backdate)
 				test.Assert(t, storedCert.Issued.Equal(expectedIssued),
@@ -85,6 +85,19 @@ type RegistrationAuthority interface {
 	// [WebFrontEnd]
 	FinalizeOrder(ctx context.Context, req *rapb.FinalizeOrderRequest) (*corepb.Order, error)
 
+	// [WebFrontEnd]
+	RenewalInfo(ctx context.Context, req *rapb.RenewalInfoRequest) (*rapb.RenewalInfo, error)
+	GetRateLimitUsage(ctx context.Context, req *rapb.GetRateLimitUsageRequest) (*rapb.RateLimitUsages, error)
+
+	// [WebFrontEnd]
+	NewPreAuthorization(ctx context.Context, req *rapb.NewPreAuthorizationRequest) (*corepb.Authorization, error)
+
+	// [WebFrontEnd]
+	RevokeCertificatesByName(ctx context.Context, req *rapb.RevokeCertificatesByNameRequest) (*rapb.RevokedSerials, error)
+
+	// [WebFrontEnd]
+	RevokeCertificatesByKey(ctx context.Context, req *rapb.RevokeCertificatesByKeyRequest) (*rapb.RevokedSerials, error)
+
 	// [AdminRevoker]
 	AdministrativelyRevokeCertificate(ctx context.Context, cert x509.Certificate, code revocation.Reason, adminName string) error
 }
@@ -120,8 +133,17 @@ type StorageGetter interface {
 	CountPendingAuthorizations2(ctx context.Context, req *sapb.RegistrationID) (*sapb.Count, error)
 	GetValidOrderAuthorizations2(ctx context.Context, req *sapb.GetValidOrderAuthorizationsRequest) (*sapb.Authorizations, error)
 	CountInvalidAuthorizations2(ctx context.Context, req *sapb.CountInvalidAuthorizationsRequest) (*sapb.Count, error)
+	CountInvalidAuthorizationsByName(ctx context.Context, req *sapb.CountInvalidAuthorizationsByNameRequest) (*sapb.CountByNames, error)
 	GetValidAuthorizations2(ctx context.Context, req *sapb.GetValidAuthorizationsRequest) (*sapb.Authorizations, error)
 	KeyBlocked(ctx context.Context, req *sapb.KeyBlockedRequest) (*sapb.Exists, error)
+	GetRevokedCerts(ctx context.Context, req *sapb.GetRevokedCertsRequest) (*sapb.RevokedCerts, error)
+	GetCertificateStatusByIssuer(ctx context.Context, req *sapb.IssuerSerial) (*corepb.CertificateStatus, error)
+	GetExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKeyID) (*sapb.ExternalAccountKey, error)
+	GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error)
+	GetOrdersForAccount(ctx context.Context, req *sapb.GetByAccountRequest) (*sapb.OrderIDs, error)
+	GetSerialsForAccount(ctx context.Context, req *sapb.GetByAccountRequest) (*sapb.AccountSerials, error)
+	GetUnexpiredSerialsForName(ctx context.Context, req *sapb.GetUnexpiredSerialsForNameRequest) (*sapb.Serials, error)
+	GetUnexpiredSerialsForKey(ctx context.Context, req *sapb.GetUnexpiredSerialsForKeyRequest) (*sapb.Serials, error)
 }
 
 // StorageAdder are the Boulder SA's write/update methods
@@ -130,6 +152,7 @@ type StorageAdder interface {
 	UpdateRegistration(ctx context.Context, reg Registration) error
 	AddCertificate(ctx context.Context, der []byte, regID int64, ocsp []byte, issued *time.Time) (digest string, err error)
 	AddPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error)
+	AddCertificateWithoutPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error)
 	AddSerial(ctx context.Context, req *sapb.AddSerialRequest) (*corepb.Empty, error)
 	DeactivateRegistration(ctx context.Context, id int64) error
 	NewOrder(ctx context.Context, order *corepb.Order) (*corepb.Order, error)
@@ -142,6 +165,9 @@ type StorageAdder interface {
 	FinalizeAuthorization2(ctx context.Context, req *sapb.FinalizeAuthorizationRequest) error
 	DeactivateAuthorization2(ctx context.Context, req *sapb.AuthorizationID2) (*corepb.Empty, error)
 	AddBlockedKey(ctx context.Context, req *sapb.AddBlockedKeyRequest) (*corepb.Empty, error)
+	AddExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKey) (*corepb.Empty, error)
+	AddRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverride) (*sapb.RateLimitOverrideID, error)
+	ExpireRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverrideID) (*corepb.Empty, error)
 }
 
 // StorageAuthority interface represents a simple key/value
@@ -2,7 +2,9 @@ package core
 
 import (
 	"crypto"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base32"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -54,15 +56,16 @@ type AcmeChallenge string
 // These types are the available challenges
 // TODO(#5009): Make this a custom type as well.
 const (
-	ChallengeTypeHTTP01    = AcmeChallenge("http-01")
-	ChallengeTypeDNS01     = AcmeChallenge("dns-01")
-	ChallengeTypeTLSALPN01 = AcmeChallenge("tls-alpn-01")
+	ChallengeTypeHTTP01       = AcmeChallenge("http-01")
+	ChallengeTypeDNS01        = AcmeChallenge("dns-01")
+	ChallengeTypeTLSALPN01    = AcmeChallenge("tls-alpn-01")
+	ChallengeTypeDNSAccount01 = AcmeChallenge("dns-account-01")
 )
 
 // IsValid tests whether the challenge is a known challenge
 func (c AcmeChallenge) IsValid() bool {
 	switch c {
-	case ChallengeTypeHTTP01, ChallengeTypeDNS01, ChallengeTypeTLSALPN01:
+	case ChallengeTypeHTTP01, ChallengeTypeDNS01, ChallengeTypeTLSALPN01, ChallengeTypeDNSAccount01:
 		return true
 	default:
 		return false
@@ -81,6 +84,16 @@ const (
 // DNSPrefix is attached to DNS names in DNS challenges
 const DNSPrefix = "_acme-challenge"
 
+// DNSAccountLabel returns the account-scoped label which, together with
+// DNSPrefix, is attached to DNS names in DNS-ACCOUNT-01 challenges. It is an
+// underscore followed by the lowercase, unpadded base32 encoding of the first
+// 10 bytes of the SHA-256 hash of the account URL, so that several accounts
+// can validate the same name at once without clobbering each other's records.
+func DNSAccountLabel(accountURL string) string {
+	h := sha256.Sum256([]byte(accountURL))
+	return "_" + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(h[:10]))
+}
+
 // CertificateRequest is just a CSR
 //
 // This data is unmarshalled from JSON by way of RawCertificateRequest, which
@@ -140,6 +153,17 @@ type Registration struct {
 	CreatedAt time.Time `json:"createdAt"`
 
 	Status AcmeStatus `json:"status"`
+
+	// ExternalAccountID is the key ID of the external account this
+	// registration was bound to when it was created, if any. It is not shown
+	// to clients.
+	ExternalAccountID string `json:"-"`
+
+	// Orders and Certificates are the URLs of the lists of the account's
+	// orders and certificates. They are not stored, and are only filled in by
+	// the WFE when displaying the account.
+	Orders       string `json:"orders,omitempty"`
+	Certificates string `json:"certificates,omitempty"`
 }
 
 // ValidationRecord represents a validation attempt against a specific URL/hostname
@@ -168,6 +192,19 @@ type ValidationRecord struct {
 	//   ...
 	// }
 	AddressesTried []net.IP `json:"addressesTried,omitempty"`
+	// DNSSEC is the DNSSEC status (secure, insecure or bogus) of the DNS
+	// answers the validation relied on, if any.
+	DNSSEC string `json:"dnssec,omitempty"`
+
+	// RemoteVA is the address of the remote VA which made this record while
+	// corroborating the primary VA's validation. It is empty for records made
+	// by the primary VA, which come before any records from remote VAs.
+	RemoteVA string `json:"remoteVA,omitempty"`
+	// Perspective is the region or RIR which the remote VA validates from.
+	Perspective string `json:"perspective,omitempty"`
+	// RemoteProblem is the detail of the problem which the remote VA's
+	// validation failed with, if it failed.
+	RemoteProblem string `json:"remoteProblem,omitempty"`
 }
 
 func looksLikeKeyAuthorization(str string) error {
@@ -225,7 +262,8 @@ type Challenge struct {
 }
 
 // ExpectedKeyAuthorization computes the expected KeyAuthorization value for
-// the challenge.
+// the challenge. The value is the same for every challenge type; DNS-01 and
+// DNS-ACCOUNT-01 differ only in the name at which its digest is provisioned.
 func (ch Challenge) ExpectedKeyAuthorization(key *jose.JSONWebKey) (string, error) {
 	if key == nil {
 		return "", fmt.Errorf("Cannot authorize a nil key")
@@ -239,37 +277,51 @@ func (ch Challenge) ExpectedKeyAuthorization(key *jose.JSONWebKey) (string, erro
 	return ch.Token + "." + base64.RawURLEncoding.EncodeToString(thumbprint), nil
 }
 
+// PrimaryValidationRecords returns the challenge's ValidationRecords which
+// were made by the primary VA, leaving out those made by remote VAs.
+func (ch Challenge) PrimaryValidationRecords() []ValidationRecord {
+	var records []ValidationRecord
+	for _, rec := range ch.ValidationRecord {
+		if rec.RemoteVA == "" {
+			records = append(records, rec)
+		}
+	}
+	return records
+}
+
 // RecordsSane checks the sanity of a ValidationRecord object before sending it
-// back to the RA to be stored.
+// back to the RA to be stored. Only the records made by the primary VA are
+// checked.
 func (ch Challenge) RecordsSane() bool {
-	if ch.ValidationRecord == nil || len(ch.ValidationRecord) == 0 {
+	records := ch.PrimaryValidationRecords()
+	if len(records) == 0 {
 		return false
 	}
 
 	switch ch.Type {
 	case ChallengeTypeHTTP01:
-		for _, rec := range ch.ValidationRecord {
+		for _, rec := range records {
 			if rec.URL == "" || rec.Hostname == "" || rec.Port == "" || rec.AddressUsed == nil ||
 				len(rec.AddressesResolved) == 0 {
 				return false
 			}
 		}
 	case ChallengeTypeTLSALPN01:
-		if len(ch.ValidationRecord) > 1 {
+		if len(records) > 1 {
 			return false
 		}
-		if ch.ValidationRecord[0].URL != "" {
+		if records[0].URL != "" {
 			return false
 		}
-		if ch.ValidationRecord[0].Hostname == "" || ch.ValidationRecord[0].Port == "" ||
-			ch.ValidationRecord[0].AddressUsed == nil || len(ch.ValidationRecord[0].AddressesResolved) == 0 {
+		if records[0].Hostname == "" || records[0].Port == "" ||
+			records[0].AddressUsed == nil || len(records[0].AddressesResolved) == 0 {
 			return false
 		}
-	case ChallengeTypeDNS01:
-		if len(ch.ValidationRecord) > 1 {
+	case ChallengeTypeDNS01, ChallengeTypeDNSAccount01:
+		if len(records) > 1 {
 			return false
 		}
-		if ch.ValidationRecord[0].Hostname == "" {
+		if records[0].Hostname == "" {
 			return false
 		}
 		return true
@@ -509,3 +561,36 @@ type SCTDERs [][]byte
 // CertDER is a convenience type that helps differentiate what the
 // underlying byte slice contains
 type CertDER []byte
+
+// SuggestedWindow is a type exposed inside the RenewalInfo resource.
+type SuggestedWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// RenewalInfo is a type which is exposed to clients which query the renewalInfo
+// endpoint specified in draft-aaron-ari.
+type RenewalInfo struct {
+	SuggestedWindow SuggestedWindow `json:"suggestedWindow"`
+}
+
+// RateLimitUsage is a type exposed inside the RateLimitUsages resource. It
+// describes how much of a single rate limit an account has used. Durations are
+// given in whole seconds, rounded up.
+type RateLimitUsage struct {
+	Name string `json:"name"`
+	// Key is the name, set of names, or hostname the limit is counted against,
+	// or empty if it is counted against the account as a whole.
+	Key        string `json:"key,omitempty"`
+	Limit      int64  `json:"limit"`
+	Remaining  int64  `json:"remaining"`
+	Window     int64  `json:"window"`
+	RetryAfter int64  `json:"retryAfter,omitempty"`
+	Reset      int64  `json:"reset"`
+}
+
+// RateLimitUsages is a type which is exposed to clients which query the
+// rateLimits endpoint.
+type RateLimitUsages struct {
+	Limits []RateLimitUsage `json:"limits"`
+}
@@ -47,6 +47,25 @@ func TestRecordSanityCheckOnUnsupportChallengeType(t *testing.T) {
 	test.Assert(t, !chall.RecordsSane(), "Record with unsupported challenge type should not be sane")
 }
 
+func TestRecordSanityCheckIgnoresRemoteRecords(t *testing.T) {
+	primary := ValidationRecord{Hostname: "localhost"}
+	remote := ValidationRecord{Hostname: "localhost", RemoteVA: "va1.boulder:9097", Perspective: "RIPE"}
+
+	chall := Challenge{Type: ChallengeTypeDNS01, ValidationRecord: []ValidationRecord{primary, remote, remote}}
+	test.Assert(t, chall.RecordsSane(), "Remote VA records should not be sanity checked")
+	test.AssertDeepEquals(t, chall.PrimaryValidationRecords(), []ValidationRecord{primary})
+
+	chall.ValidationRecord = []ValidationRecord{remote}
+	test.Assert(t, !chall.RecordsSane(), "Records without a primary VA record should not be sane")
+}
+
+func TestDNSAccountLabel(t *testing.T) {
+	test.AssertEquals(t, DNSAccountLabel("https://example.com/acme/acct/ExampleAccount"), "_ujmmovf2vn55tgye")
+	test.AssertNotEquals(t,
+		DNSAccountLabel("https://example.com/acme/acct/1"),
+		DNSAccountLabel("https://example.com/acme/acct/2"))
+}
+
 func TestChallengeSanityCheck(t *testing.T) {
 	// Make a temporary account key
 	var accountKey *jose.JSONWebKey
@@ -57,7 +76,7 @@ func TestChallengeSanityCheck(t *testing.T) {
   }`), &accountKey)
 	test.AssertNotError(t, err, "Error unmarshaling JWK")
 
-	types := []AcmeChallenge{ChallengeTypeHTTP01, ChallengeTypeDNS01, ChallengeTypeTLSALPN01}
+	types := []AcmeChallenge{ChallengeTypeHTTP01, ChallengeTypeDNS01, ChallengeTypeTLSALPN01, ChallengeTypeDNSAccount01}
 	for _, challengeType := range types {
 		chall := Challenge{
 			Type:   challengeType,
@@ -16,6 +16,7 @@ import (
 	"io/ioutil"
 	"math/big"
 	mrand "math/rand"
+	"net"
 	"reflect"
 	"regexp"
 	"sort"
@@ -247,6 +248,19 @@ func UniqueLowerNames(names []string) (unique []string) {
 	return
 }
 
+// UniqueLowerNamesAndIPs returns the set of all unique names in the input
+// DNS names and IP addresses, as UniqueLowerNames does. IP addresses are
+// included in their canonical textual form, which is also how they appear as
+// the names in orders and authorizations.
+func UniqueLowerNamesAndIPs(dnsNames []string, ips []net.IP) []string {
+	names := make([]string, 0, len(dnsNames)+len(ips))
+	names = append(names, dnsNames...)
+	for _, ip := range ips {
+		names = append(names, ip.String())
+	}
+	return UniqueLowerNames(names)
+}
+
 // LoadCert loads a PEM certificate specified by filename or returns an error
 func LoadCert(filename string) (*x509.Certificate, error) {
 	certPEM, err := ioutil.ReadFile(filename)
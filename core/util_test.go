@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"net"
 	"os"
 	"sort"
 	"strings"
@@ -137,6 +138,13 @@ func TestIsAnyNilOrZero(t *testing.T) {
 	test.Assert(t, IsAnyNilOrZero("", 1), "Mixed values seen as non-zero")
 }
 
+func TestUniqueLowerNamesAndIPs(t *testing.T) {
+	u := UniqueLowerNamesAndIPs(
+		[]string{"foobar.com", "fooBAR.com", "a.com"},
+		[]net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.1"), net.ParseIP("2001:DB8::1")})
+	test.AssertDeepEquals(t, u, []string{"10.0.0.1", "2001:db8::1", "a.com", "foobar.com"})
+}
+
 func TestUniqueLowerNames(t *testing.T) {
 	u := UniqueLowerNames([]string{"foobar.com", "fooBAR.com", "baz.com", "foobar.com", "bar.com", "bar.com", "a.com"})
 	sort.Strings(u)
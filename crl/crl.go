@@ -0,0 +1,109 @@
+// Package crl contains helpers shared by the components which generate, sign,
+// and store sharded Certificate Revocation Lists.
+package crl
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+var (
+	// oidCRLNumber is the id-ce-cRLNumber extension defined in RFC 5280,
+	// Section 5.2.3.
+	oidCRLNumber = asn1.ObjectIdentifier{2, 5, 29, 20}
+
+	// OIDReasonCode is the id-ce-cRLReasons CRL entry extension defined in RFC
+	// 5280, Section 5.3.1.
+	OIDReasonCode = asn1.ObjectIdentifier{2, 5, 29, 21}
+
+	// OIDIssuingDistributionPoint is the id-ce-issuingDistributionPoint CRL
+	// extension defined in RFC 5280, Section 5.2.5.
+	OIDIssuingDistributionPoint = asn1.ObjectIdentifier{2, 5, 29, 28}
+)
+
+// Number derives the CRL number for a CRL from its thisUpdate time. Because
+// CRLs for a given shard are never produced more than once per nanosecond,
+// this yields the monotonically increasing sequence required by RFC 5280
+// without any coordination between crl-updater instances.
+func Number(thisUpdate time.Time) *big.Int {
+	return big.NewInt(thisUpdate.UnixNano())
+}
+
+// GetNumber returns the value of the cRLNumber extension of the given CRL.
+func GetNumber(crl *pkix.CertificateList) (*big.Int, error) {
+	for _, ext := range crl.TBSCertList.Extensions {
+		if !ext.Id.Equal(oidCRLNumber) {
+			continue
+		}
+		number := new(big.Int)
+		rest, err := asn1.Unmarshal(ext.Value, &number)
+		if err != nil {
+			return nil, fmt.Errorf("parsing CRL number: %w", err)
+		}
+		if len(rest) != 0 {
+			return nil, errors.New("trailing data after CRL number")
+		}
+		return number, nil
+	}
+	return nil, errors.New("CRL has no CRL number extension")
+}
+
+// ShardRange is a half-open range of certificate expiration times, [Start,
+// End), whose revoked certificates belong on a single CRL shard.
+type ShardRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// ShardRanges computes which portions of the window [start, end) belong to
+// the shard with the given index. Time is divided into chunks of width
+// numShards-th of the window, anchored at the Unix epoch, and chunks are
+// assigned to shards round-robin. Anchoring the chunks (rather than the
+// window) means that a certificate stays on the same shard from the time it
+// is revoked until it falls off the end of the window.
+func ShardRanges(start, end time.Time, numShards int, shardIdx int) ([]ShardRange, error) {
+	if numShards <= 0 {
+		return nil, errors.New("number of shards must be positive")
+	}
+	if shardIdx < 0 || shardIdx >= numShards {
+		return nil, fmt.Errorf("shard index %d out of range [0, %d)", shardIdx, numShards)
+	}
+	if !end.After(start) {
+		return nil, errors.New("window end must be after window start")
+	}
+	width := end.Sub(start) / time.Duration(numShards)
+	if width <= 0 {
+		return nil, errors.New("window is too small for the number of shards")
+	}
+
+	var ranges []ShardRange
+	first := start.UnixNano() / int64(width)
+	if start.UnixNano()%int64(width) < 0 {
+		first--
+	}
+	for chunk := first; chunk*int64(width) < end.UnixNano(); chunk++ {
+		idx := chunk % int64(numShards)
+		if idx < 0 {
+			idx += int64(numShards)
+		}
+		if idx != int64(shardIdx) {
+			continue
+		}
+		r := ShardRange{
+			Start: time.Unix(0, chunk*int64(width)),
+			End:   time.Unix(0, (chunk+1)*int64(width)),
+		}
+		if r.Start.Before(start) {
+			r.Start = start
+		}
+		if r.End.After(end) {
+			r.End = end
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
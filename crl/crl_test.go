@@ -0,0 +1,73 @@
+package crl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestShardRanges(t *testing.T) {
+	start := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(100 * time.Hour)
+	numShards := 4
+
+	_, err := ShardRanges(start, end, 0, 0)
+	test.AssertError(t, err, "should fail with zero shards")
+	_, err = ShardRanges(start, end, numShards, numShards)
+	test.AssertError(t, err, "should fail with out-of-range shard index")
+	_, err = ShardRanges(end, start, numShards, 0)
+	test.AssertError(t, err, "should fail with inverted window")
+
+	// Every instant in the window must be covered by exactly one shard.
+	var total time.Duration
+	covered := func(instant time.Time) int {
+		count := 0
+		for i := 0; i < numShards; i++ {
+			ranges, err := ShardRanges(start, end, numShards, i)
+			test.AssertNotError(t, err, "ShardRanges failed")
+			for _, r := range ranges {
+				if !instant.Before(r.Start) && instant.Before(r.End) {
+					count++
+				}
+			}
+		}
+		return count
+	}
+	for instant := start; instant.Before(end); instant = instant.Add(17 * time.Minute) {
+		test.AssertEquals(t, covered(instant), 1)
+	}
+	for i := 0; i < numShards; i++ {
+		ranges, err := ShardRanges(start, end, numShards, i)
+		test.AssertNotError(t, err, "ShardRanges failed")
+		for _, r := range ranges {
+			test.Assert(t, !r.Start.Before(start), "range starts before window")
+			test.Assert(t, !r.End.After(end), "range ends after window")
+			total += r.End.Sub(r.Start)
+		}
+	}
+	test.AssertEquals(t, total, end.Sub(start))
+
+	// Sliding the window forward must not move a given expiry to a different
+	// shard.
+	shardOf := func(start time.Time, instant time.Time) int {
+		for i := 0; i < numShards; i++ {
+			ranges, err := ShardRanges(start, start.Add(100*time.Hour), numShards, i)
+			test.AssertNotError(t, err, "ShardRanges failed")
+			for _, r := range ranges {
+				if !instant.Before(r.Start) && instant.Before(r.End) {
+					return i
+				}
+			}
+		}
+		return -1
+	}
+	instant := start.Add(60 * time.Hour)
+	test.AssertEquals(t, shardOf(start, instant), shardOf(start.Add(13*time.Hour), instant))
+}
+
+func TestNumber(t *testing.T) {
+	earlier := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Nanosecond)
+	test.Assert(t, Number(earlier).Cmp(Number(later)) < 0, "CRL numbers should increase with thisUpdate")
+}
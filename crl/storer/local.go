@@ -0,0 +1,110 @@
+package storer
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/letsencrypt/boulder/crl"
+	"github.com/letsencrypt/boulder/issuance"
+)
+
+// localBackend stores CRLs on the local filesystem. Every CRL is kept at
+// <dir>/<issuerNameID>/<shardIdx>/<number>.crl, and the most recent CRL for
+// each shard is also available at <dir>/<issuerNameID>/<shardIdx>.crl, which
+// is replaced atomically so that it can be served directly.
+type localBackend struct {
+	dir string
+	// mu serializes Puts so that the check for a newer current CRL and the
+	// replacement of it can't interleave.
+	mu sync.Mutex
+}
+
+// NewLocalBackend returns a Backend which writes CRLs beneath the given
+// directory, creating it if necessary.
+func NewLocalBackend(dir string) (*localBackend, error) {
+	if dir == "" {
+		return nil, errors.New("CRL storage directory must not be empty")
+	}
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &localBackend{dir: dir}, nil
+}
+
+// Put implements Backend.
+func (lb *localBackend) Put(ctx context.Context, issuerNameID issuance.IssuerNameID, shardIdx int64, number *big.Int, der []byte) error {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+
+	issuerDir := filepath.Join(lb.dir, fmt.Sprintf("%d", issuerNameID))
+	shardDir := filepath.Join(issuerDir, fmt.Sprintf("%d", shardIdx))
+	err := os.MkdirAll(shardDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	currentPath := filepath.Join(issuerDir, fmt.Sprintf("%d.crl", shardIdx))
+	current, err := currentNumber(currentPath)
+	if err != nil {
+		return err
+	}
+	if current != nil && number.Cmp(current) <= 0 {
+		return fmt.Errorf("CRL number %s is not greater than current CRL number %s", number, current)
+	}
+
+	err = writeFileAtomic(filepath.Join(shardDir, fmt.Sprintf("%s.crl", number)), der)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(currentPath, der)
+}
+
+// currentNumber returns the CRL number of the CRL at the given path, or nil if
+// there is no file there.
+func currentNumber(path string) (*big.Int, error) {
+	der, err := ioutil.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	parsed, err := x509.ParseDERCRL(der)
+	if err != nil {
+		return nil, fmt.Errorf("parsing current CRL %q: %w", path, err)
+	}
+	return crl.GetNumber(parsed)
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory as
+// path and then renames it into place, so that readers never observe a
+// partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(tmp.Name(), 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
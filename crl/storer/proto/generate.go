@@ -0,0 +1,3 @@
+package proto
+
+//go:generate sh -c "cd ../../.. && protoc -I crl/storer/proto/ -I . --go_out=crl/storer/proto --go-grpc_out=crl/storer/proto --go_opt=paths=source_relative --go-grpc_opt=paths=source_relative crl/storer/proto/storer.proto"
@@ -0,0 +1,124 @@
+package storer
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"math/big"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/crl"
+	cspb "github.com/letsencrypt/boulder/crl/storer/proto"
+	berrors "github.com/letsencrypt/boulder/errors"
+	"github.com/letsencrypt/boulder/issuance"
+	blog "github.com/letsencrypt/boulder/log"
+)
+
+// Backend is the interface implemented by the places the crl-storer can put
+// CRLs. Implementations must refuse to replace a shard's current CRL with one
+// whose number is not strictly greater.
+type Backend interface {
+	Put(ctx context.Context, issuerNameID issuance.IssuerNameID, shardIdx int64, number *big.Int, der []byte) error
+}
+
+type crlStorer struct {
+	cspb.UnimplementedCRLStorerServer
+	issuers     map[issuance.IssuerNameID]*issuance.Certificate
+	backend     Backend
+	log         blog.Logger
+	clk         clock.Clock
+	uploadCount *prometheus.CounterVec
+	sizeHist    prometheus.Histogram
+}
+
+// New returns a CRLStorer server which accepts CRLs signed by any of the
+// given issuers and writes them to the given backend.
+func New(
+	issuers []*issuance.Certificate,
+	backend Backend,
+	stats prometheus.Registerer,
+	log blog.Logger,
+	clk clock.Clock,
+) (*crlStorer, error) {
+	issuersByNameID := make(map[issuance.IssuerNameID]*issuance.Certificate, len(issuers))
+	for _, issuer := range issuers {
+		issuersByNameID[issuer.NameID()] = issuer
+	}
+
+	uploadCount := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "crl_storer_uploads",
+		Help: "A counter of the number of CRLs uploaded by crl-storer, labelled by issuer and result",
+	}, []string{"issuer", "result"})
+	stats.MustRegister(uploadCount)
+
+	sizeHist := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "crl_storer_sizes",
+		Help:    "A histogram of the sizes (in bytes) of CRLs uploaded by crl-storer",
+		Buckets: []float64{0, 256, 1024, 4096, 16384, 65536, 262144, 1048576},
+	})
+	stats.MustRegister(sizeHist)
+
+	return &crlStorer{
+		issuers:     issuersByNameID,
+		backend:     backend,
+		log:         log,
+		clk:         clk,
+		uploadCount: uploadCount,
+		sizeHist:    sizeHist,
+	}, nil
+}
+
+// UploadCRL checks that the given CRL was correctly signed by the issuer it
+// claims to be from, and then hands it to the backend for storage.
+func (cs *crlStorer) UploadCRL(ctx context.Context, req *cspb.UploadCRLRequest) (*corepb.Empty, error) {
+	// req.ShardIdx is zero for the first shard.
+	if core.IsAnyNilOrZero(req, req.IssuerNameID, req.Crl) {
+		return nil, berrors.InternalServerError("Incomplete upload CRL request")
+	}
+
+	issuer, ok := cs.issuers[issuance.IssuerNameID(req.IssuerNameID)]
+	if !ok {
+		return nil, fmt.Errorf("No issuer with NameID %d", req.IssuerNameID)
+	}
+
+	err := cs.upload(ctx, issuer, req.ShardIdx, req.Crl)
+	if err != nil {
+		cs.uploadCount.With(prometheus.Labels{"issuer": issuer.Subject.CommonName, "result": "failed"}).Inc()
+		return nil, err
+	}
+	cs.uploadCount.With(prometheus.Labels{"issuer": issuer.Subject.CommonName, "result": "success"}).Inc()
+	cs.sizeHist.Observe(float64(len(req.Crl)))
+	return &corepb.Empty{}, nil
+}
+
+func (cs *crlStorer) upload(ctx context.Context, issuer *issuance.Certificate, shardIdx int64, der []byte) error {
+	parsed, err := x509.ParseDERCRL(der)
+	if err != nil {
+		return fmt.Errorf("parsing CRL: %w", err)
+	}
+	err = issuer.CheckCRLSignature(parsed)
+	if err != nil {
+		return fmt.Errorf("validating CRL signature: %w", err)
+	}
+	if parsed.HasExpired(cs.clk.Now()) {
+		return fmt.Errorf("CRL is already expired (nextUpdate %s)", parsed.TBSCertList.NextUpdate)
+	}
+	number, err := crl.GetNumber(parsed)
+	if err != nil {
+		return err
+	}
+
+	err = cs.backend.Put(ctx, issuer.NameID(), shardIdx, number, der)
+	if err != nil {
+		return err
+	}
+
+	cs.log.Infof(
+		"Stored CRL: issuer=[%s] number=[%s] shard=[%d] entries=[%d]",
+		issuer.Subject.CommonName, number, shardIdx, len(parsed.TBSCertList.RevokedCertificates))
+	return nil
+}
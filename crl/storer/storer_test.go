@@ -0,0 +1,135 @@
+package storer
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/crl"
+	cspb "github.com/letsencrypt/boulder/crl/storer/proto"
+	"github.com/letsencrypt/boulder/issuance"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+	"github.com/letsencrypt/boulder/x509crl"
+)
+
+type failReader struct{}
+
+func (fr *failReader) Read([]byte) (int, error) {
+	return 0, errors.New("empty reader")
+}
+
+func setup(t *testing.T) (*crlStorer, *issuance.Certificate, crypto.Signer, string, clock.FakeClock) {
+	t.Helper()
+	issuer, signer, err := issuance.LoadIssuer(issuance.IssuerLoc{
+		File:     "../../test/test-ca.key",
+		CertFile: "../../test/test-ca.pem",
+	})
+	test.AssertNotError(t, err, "loading test issuer")
+
+	dir, err := ioutil.TempDir("", "crl-storer-test")
+	test.AssertNotError(t, err, "creating temp dir")
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	backend, err := NewLocalBackend(dir)
+	test.AssertNotError(t, err, "creating local backend")
+
+	fc := clock.NewFake()
+	fc.Set(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	cs, err := New([]*issuance.Certificate{issuer}, backend, metrics.NoopRegisterer, blog.NewMock(), fc)
+	test.AssertNotError(t, err, "creating crl-storer")
+	return cs, issuer, signer, dir, fc
+}
+
+func makeCRL(t *testing.T, issuer *issuance.Certificate, signer crypto.Signer, thisUpdate time.Time) []byte {
+	t.Helper()
+	der, err := x509crl.CreateRevocationList(&failReader{}, &x509crl.RevocationList{
+		Number:     crl.Number(thisUpdate),
+		ThisUpdate: thisUpdate,
+		NextUpdate: thisUpdate.Add(24 * time.Hour),
+	}, issuer.Certificate, signer)
+	test.AssertNotError(t, err, "creating CRL")
+	return der
+}
+
+func TestUploadCRL(t *testing.T) {
+	cs, issuer, signer, dir, fc := setup(t)
+	ctx := context.Background()
+
+	first := makeCRL(t, issuer, signer, fc.Now())
+	_, err := cs.UploadCRL(ctx, &cspb.UploadCRLRequest{
+		IssuerNameID: int64(issuer.NameID()),
+		ShardIdx:     2,
+		Crl:          first,
+	})
+	test.AssertNotError(t, err, "uploading CRL")
+
+	issuerDir := filepath.Join(dir, fmt.Sprintf("%d", issuer.NameID()))
+	current, err := ioutil.ReadFile(filepath.Join(issuerDir, "2.crl"))
+	test.AssertNotError(t, err, "reading current CRL")
+	test.AssertDeepEquals(t, current, first)
+	archived, err := ioutil.ReadFile(filepath.Join(issuerDir, "2", crl.Number(fc.Now()).String()+".crl"))
+	test.AssertNotError(t, err, "reading archived CRL")
+	test.AssertDeepEquals(t, archived, first)
+
+	// Re-uploading the same CRL, or an older one, must not replace the
+	// current CRL.
+	_, err = cs.UploadCRL(ctx, &cspb.UploadCRLRequest{
+		IssuerNameID: int64(issuer.NameID()),
+		ShardIdx:     2,
+		Crl:          first,
+	})
+	test.AssertError(t, err, "re-uploading the same CRL should fail")
+	older := makeCRL(t, issuer, signer, fc.Now().Add(-time.Minute))
+	_, err = cs.UploadCRL(ctx, &cspb.UploadCRLRequest{
+		IssuerNameID: int64(issuer.NameID()),
+		ShardIdx:     2,
+		Crl:          older,
+	})
+	test.AssertError(t, err, "uploading an older CRL should fail")
+
+	fc.Add(time.Hour)
+	second := makeCRL(t, issuer, signer, fc.Now())
+	_, err = cs.UploadCRL(ctx, &cspb.UploadCRLRequest{
+		IssuerNameID: int64(issuer.NameID()),
+		ShardIdx:     2,
+		Crl:          second,
+	})
+	test.AssertNotError(t, err, "uploading newer CRL")
+	current, err = ioutil.ReadFile(filepath.Join(issuerDir, "2.crl"))
+	test.AssertNotError(t, err, "reading current CRL")
+	test.AssertDeepEquals(t, current, second)
+}
+
+func TestUploadCRLErrors(t *testing.T) {
+	cs, issuer, signer, _, fc := setup(t)
+	ctx := context.Background()
+
+	_, err := cs.UploadCRL(ctx, &cspb.UploadCRLRequest{IssuerNameID: int64(issuer.NameID())})
+	test.AssertError(t, err, "uploading an empty CRL should fail")
+
+	good := makeCRL(t, issuer, signer, fc.Now())
+	_, err = cs.UploadCRL(ctx, &cspb.UploadCRLRequest{IssuerNameID: 1, Crl: good})
+	test.AssertError(t, err, "uploading for an unknown issuer should fail")
+
+	_, err = cs.UploadCRL(ctx, &cspb.UploadCRLRequest{IssuerNameID: int64(issuer.NameID()), Crl: []byte{1, 2, 3}})
+	test.AssertError(t, err, "uploading a malformed CRL should fail")
+
+	badSig := append([]byte{}, good...)
+	badSig[len(badSig)-1] ^= 0xff
+	_, err = cs.UploadCRL(ctx, &cspb.UploadCRLRequest{IssuerNameID: int64(issuer.NameID()), Crl: badSig})
+	test.AssertError(t, err, "uploading a CRL with a bad signature should fail")
+
+	expired := makeCRL(t, issuer, signer, fc.Now().Add(-48*time.Hour))
+	_, err = cs.UploadCRL(ctx, &cspb.UploadCRLRequest{IssuerNameID: int64(issuer.NameID()), Crl: expired})
+	test.AssertError(t, err, "uploading an expired CRL should fail")
+}
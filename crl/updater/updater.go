@@ -0,0 +1,213 @@
+package updater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	"github.com/letsencrypt/boulder/crl"
+	cspb "github.com/letsencrypt/boulder/crl/storer/proto"
+	"github.com/letsencrypt/boulder/issuance"
+	blog "github.com/letsencrypt/boulder/log"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+)
+
+// revokedCertsGetter, crlGenerator, and crlUploader are interfaces used to
+// reduce the scope of the SA, CA, and crl-storer gRPC clients to only the
+// methods we need, which makes testing significantly simpler.
+type revokedCertsGetter interface {
+	GetRevokedCerts(ctx context.Context, in *sapb.GetRevokedCertsRequest, opts ...grpc.CallOption) (*sapb.RevokedCerts, error)
+}
+
+type crlGenerator interface {
+	GenerateCRL(ctx context.Context, in *capb.GenerateCRLRequest, opts ...grpc.CallOption) (*capb.GenerateCRLResponse, error)
+}
+
+type crlUploader interface {
+	UploadCRL(ctx context.Context, in *cspb.UploadCRLRequest, opts ...grpc.CallOption) (*corepb.Empty, error)
+}
+
+type crlUpdater struct {
+	issuers        []*issuance.Certificate
+	numShards      int
+	lookbackPeriod time.Duration
+	certLifetime   time.Duration
+	updatePeriod   time.Duration
+
+	sa revokedCertsGetter
+	ca crlGenerator
+	cs crlUploader
+
+	tickHistogram  *prometheus.HistogramVec
+	generatedCount *prometheus.CounterVec
+
+	log blog.Logger
+	clk clock.Clock
+
+	tickFailures int
+}
+
+// NewUpdater returns a crl-updater which, every updatePeriod, produces
+// numShards CRLs for each of the given issuers. Together the shards cover
+// every certificate which expired no more than lookbackPeriod ago or which
+// will expire in the next certLifetime.
+func NewUpdater(
+	issuers []*issuance.Certificate,
+	numShards int,
+	certLifetime time.Duration,
+	updatePeriod time.Duration,
+	lookbackPeriod time.Duration,
+	sa revokedCertsGetter,
+	ca crlGenerator,
+	cs crlUploader,
+	stats prometheus.Registerer,
+	log blog.Logger,
+	clk clock.Clock,
+) (*crlUpdater, error) {
+	if len(issuers) == 0 {
+		return nil, errors.New("must have at least one issuer")
+	}
+	if numShards < 1 {
+		return nil, fmt.Errorf("must have positive number of shards, got: %d", numShards)
+	}
+	if updatePeriod <= 0 {
+		return nil, fmt.Errorf("must have positive update period, got: %s", updatePeriod)
+	}
+	// Every CRL must be regenerated before the previous one could have fallen
+	// out of the lookback window, or a revoked certificate which expired
+	// between updates could silently disappear from every CRL.
+	if lookbackPeriod < 2*updatePeriod {
+		return nil, fmt.Errorf("lookbackPeriod (%s) must be at least twice updatePeriod (%s)", lookbackPeriod, updatePeriod)
+	}
+	if certLifetime <= 0 {
+		return nil, fmt.Errorf("must have positive certificate lifetime, got: %s", certLifetime)
+	}
+
+	tickHistogram := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "crl_updater_ticks",
+		Help:    "A histogram of crl-updater tick latencies labelled by issuer and result",
+		Buckets: []float64{0.01, 0.2, 0.5, 1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000},
+	}, []string{"issuer", "result"})
+	stats.MustRegister(tickHistogram)
+
+	generatedCount := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "crl_updater_generated",
+		Help: "A counter of CRL generation calls labelled by result",
+	}, []string{"result"})
+	stats.MustRegister(generatedCount)
+
+	return &crlUpdater{
+		issuers:        issuers,
+		numShards:      numShards,
+		lookbackPeriod: lookbackPeriod,
+		certLifetime:   certLifetime,
+		updatePeriod:   updatePeriod,
+		sa:             sa,
+		ca:             ca,
+		cs:             cs,
+		tickHistogram:  tickHistogram,
+		generatedCount: generatedCount,
+		log:            log,
+		clk:            clk,
+	}, nil
+}
+
+// Run calls Tick every updatePeriod until the given context is cancelled. If a
+// tick fails, the next one is attempted sooner, with exponential backoff.
+func (cu *crlUpdater) Run(ctx context.Context) {
+	for {
+		start := cu.clk.Now()
+		err := cu.Tick(ctx, start)
+		sleepDur := start.Add(cu.updatePeriod).Sub(cu.clk.Now())
+		if err != nil {
+			cu.log.AuditErrf("Generating CRLs failed: %s", err)
+			cu.tickFailures++
+			sleepDur = core.RetryBackoff(cu.tickFailures, time.Second, cu.updatePeriod, 2)
+		} else if cu.tickFailures > 0 {
+			cu.tickFailures = 0
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-cu.clk.After(sleepDur):
+		}
+	}
+}
+
+// Tick generates and uploads every shard of the CRL for every issuer, as of
+// the given time. It attempts every shard even if some fail, and returns an
+// error if any did.
+func (cu *crlUpdater) Tick(ctx context.Context, atTime time.Time) error {
+	var failed int
+	for _, issuer := range cu.issuers {
+		start := cu.clk.Now()
+		result := "success"
+		for shardIdx := 0; shardIdx < cu.numShards; shardIdx++ {
+			err := cu.tickShard(ctx, atTime, issuer, shardIdx)
+			if err != nil {
+				cu.log.AuditErrf(
+					"Generating CRL failed: issuer=[%s] shard=[%d] err=[%s]",
+					issuer.Subject.CommonName, shardIdx, err)
+				result = "failed"
+				failed++
+			}
+		}
+		cu.tickHistogram.WithLabelValues(issuer.Subject.CommonName, result).Observe(cu.clk.Since(start).Seconds())
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d CRL shards failed", failed, cu.numShards*len(cu.issuers))
+	}
+	return nil
+}
+
+// tickShard gathers the entries for a single shard of a single issuer's CRL,
+// has the CA sign them, and hands the result to the crl-storer.
+func (cu *crlUpdater) tickShard(ctx context.Context, atTime time.Time, issuer *issuance.Certificate, shardIdx int) error {
+	ranges, err := crl.ShardRanges(atTime.Add(-cu.lookbackPeriod), atTime.Add(cu.certLifetime), cu.numShards, shardIdx)
+	if err != nil {
+		return err
+	}
+
+	var entries []*corepb.CRLEntry
+	for _, r := range ranges {
+		resp, err := cu.sa.GetRevokedCerts(ctx, &sapb.GetRevokedCertsRequest{
+			IssuerID:      int64(issuer.ID()),
+			ExpiresAfter:  r.Start.UnixNano(),
+			ExpiresBefore: r.End.UnixNano(),
+		})
+		if err != nil {
+			return fmt.Errorf("getting revoked certs: %w", err)
+		}
+		entries = append(entries, resp.Entries...)
+	}
+
+	crlResp, err := cu.ca.GenerateCRL(ctx, &capb.GenerateCRLRequest{
+		IssuerNameID: int64(issuer.NameID()),
+		ThisUpdate:   atTime.UnixNano(),
+		ShardIdx:     int64(shardIdx),
+		Entries:      entries,
+	})
+	if err != nil {
+		cu.generatedCount.WithLabelValues("failed").Inc()
+		return fmt.Errorf("generating CRL: %w", err)
+	}
+	cu.generatedCount.WithLabelValues("success").Inc()
+
+	_, err = cu.cs.UploadCRL(ctx, &cspb.UploadCRLRequest{
+		IssuerNameID: int64(issuer.NameID()),
+		ShardIdx:     int64(shardIdx),
+		Crl:          crlResp.Crl,
+	})
+	if err != nil {
+		return fmt.Errorf("uploading CRL: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package updater
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"google.golang.org/grpc"
+
+	capb "github.com/letsencrypt/boulder/ca/proto"
+	corepb "github.com/letsencrypt/boulder/core/proto"
+	cspb "github.com/letsencrypt/boulder/crl/storer/proto"
+	"github.com/letsencrypt/boulder/issuance"
+	blog "github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/metrics"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// fakeSA returns a single revoked cert for every query, and records the
+// ranges it was asked about.
+type fakeSA struct {
+	reqs []*sapb.GetRevokedCertsRequest
+	err  error
+}
+
+func (f *fakeSA) GetRevokedCerts(_ context.Context, req *sapb.GetRevokedCertsRequest, _ ...grpc.CallOption) (*sapb.RevokedCerts, error) {
+	f.reqs = append(f.reqs, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &sapb.RevokedCerts{Entries: []*corepb.CRLEntry{{Serial: "0311b5d430823cfa25b0fc85d14c54ee35", RevokedAt: 1}}}, nil
+}
+
+type fakeCA struct {
+	reqs []*capb.GenerateCRLRequest
+	err  error
+}
+
+func (f *fakeCA) GenerateCRL(_ context.Context, req *capb.GenerateCRLRequest, _ ...grpc.CallOption) (*capb.GenerateCRLResponse, error) {
+	f.reqs = append(f.reqs, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &capb.GenerateCRLResponse{Crl: []byte{1, 2, 3}}, nil
+}
+
+type fakeStorer struct {
+	reqs []*cspb.UploadCRLRequest
+	err  error
+}
+
+func (f *fakeStorer) UploadCRL(_ context.Context, req *cspb.UploadCRLRequest, _ ...grpc.CallOption) (*corepb.Empty, error) {
+	f.reqs = append(f.reqs, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &corepb.Empty{}, nil
+}
+
+func setup(t *testing.T) (*crlUpdater, *issuance.Certificate, *fakeSA, *fakeCA, *fakeStorer, clock.FakeClock) {
+	t.Helper()
+	issuer, err := issuance.LoadCertificate("../../test/test-ca.pem")
+	test.AssertNotError(t, err, "loading test issuer")
+
+	sa, ca, cs := &fakeSA{}, &fakeCA{}, &fakeStorer{}
+	fc := clock.NewFake()
+	fc.Set(time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC))
+	cu, err := NewUpdater(
+		[]*issuance.Certificate{issuer},
+		4,
+		90*24*time.Hour,
+		6*time.Hour,
+		24*time.Hour,
+		sa, ca, cs,
+		metrics.NoopRegisterer, blog.NewMock(), fc,
+	)
+	test.AssertNotError(t, err, "creating crl-updater")
+	return cu, issuer, sa, ca, cs, fc
+}
+
+func TestNewUpdaterValidation(t *testing.T) {
+	issuer, err := issuance.LoadCertificate("../../test/test-ca.pem")
+	test.AssertNotError(t, err, "loading test issuer")
+	issuers := []*issuance.Certificate{issuer}
+	log := blog.NewMock()
+	fc := clock.NewFake()
+
+	_, err = NewUpdater(nil, 4, time.Hour, time.Hour, 2*time.Hour, nil, nil, nil, metrics.NoopRegisterer, log, fc)
+	test.AssertError(t, err, "should fail with no issuers")
+	_, err = NewUpdater(issuers, 0, time.Hour, time.Hour, 2*time.Hour, nil, nil, nil, metrics.NoopRegisterer, log, fc)
+	test.AssertError(t, err, "should fail with no shards")
+	_, err = NewUpdater(issuers, 4, time.Hour, time.Hour, time.Hour, nil, nil, nil, metrics.NoopRegisterer, log, fc)
+	test.AssertError(t, err, "should fail with too-short lookback period")
+	_, err = NewUpdater(issuers, 4, 0, time.Hour, 2*time.Hour, nil, nil, nil, metrics.NoopRegisterer, log, fc)
+	test.AssertError(t, err, "should fail with zero cert lifetime")
+}
+
+func TestTick(t *testing.T) {
+	cu, issuer, sa, ca, cs, fc := setup(t)
+
+	err := cu.Tick(context.Background(), fc.Now())
+	test.AssertNotError(t, err, "tick failed")
+
+	// Every query should be for this issuer, and together they should cover
+	// exactly the lookback window plus the certificate lifetime.
+	var covered time.Duration
+	for _, req := range sa.reqs {
+		test.AssertEquals(t, req.IssuerID, int64(issuer.ID()))
+		covered += time.Duration(req.ExpiresBefore - req.ExpiresAfter)
+	}
+	test.AssertEquals(t, covered, 24*time.Hour+90*24*time.Hour)
+
+	test.AssertEquals(t, len(ca.reqs), 4)
+	test.AssertEquals(t, len(cs.reqs), 4)
+	for i, req := range ca.reqs {
+		test.AssertEquals(t, req.IssuerNameID, int64(issuer.NameID()))
+		test.AssertEquals(t, req.ShardIdx, int64(i))
+		test.AssertEquals(t, req.ThisUpdate, fc.Now().UnixNano())
+		test.Assert(t, len(req.Entries) > 0, "CRL request should contain entries from the SA")
+		test.AssertEquals(t, cs.reqs[i].ShardIdx, int64(i))
+		test.AssertDeepEquals(t, cs.reqs[i].Crl, []byte{1, 2, 3})
+	}
+}
+
+func TestTickErrors(t *testing.T) {
+	cu, _, sa, ca, cs, fc := setup(t)
+
+	sa.err = errors.New("db down")
+	err := cu.Tick(context.Background(), fc.Now())
+	test.AssertError(t, err, "tick should fail when the SA does")
+	test.AssertEquals(t, len(ca.reqs), 0)
+
+	sa.err = nil
+	ca.err = errors.New("hsm down")
+	err = cu.Tick(context.Background(), fc.Now())
+	test.AssertError(t, err, "tick should fail when the CA does")
+	test.AssertEquals(t, len(cs.reqs), 0)
+
+	ca.err = nil
+	cs.err = errors.New("disk full")
+	err = cu.Tick(context.Background(), fc.Now())
+	test.AssertError(t, err, "tick should fail when the crl-storer does")
+	// All shards should still have been attempted.
+	test.AssertEquals(t, len(cs.reqs), 4)
+}
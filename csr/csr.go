@@ -5,6 +5,7 @@ import (
 	"crypto"
 	"crypto/x509"
 	"errors"
+	"net"
 	"strings"
 
 	"github.com/letsencrypt/boulder/core"
@@ -38,14 +39,13 @@ var (
 	unsupportedSigAlg    = berrors.BadCSRError("signature algorithm not supported")
 	invalidSig           = berrors.BadCSRError("invalid signature on CSR")
 	invalidEmailPresent  = berrors.BadCSRError("CSR contains one or more email address fields")
-	invalidIPPresent     = berrors.BadCSRError("CSR contains one or more IP address fields")
-	invalidNoDNS         = berrors.BadCSRError("at least one DNS name is required")
+	invalidNoDNS         = berrors.BadCSRError("at least one DNS name or IP address is required")
 	invalidAllSANTooLong = berrors.BadCSRError("CSR doesn't contain a SAN short enough to fit in CN")
 )
 
 // VerifyCSR checks the validity of a x509.CertificateRequest. Before doing checks it normalizes
 // the CSR which lowers the case of DNS names and subject CN, and hoist a DNS name into the CN
-// if it is empty.
+// if it is empty. A CSR containing only IP addresses may have an empty CN.
 func VerifyCSR(ctx context.Context, csr *x509.CertificateRequest, maxNames int, keyPolicy *goodkey.KeyPolicy, pa core.PolicyAuthority, regID int64) error {
 	normalizeCSR(csr)
 	key, ok := csr.PublicKey.(crypto.PublicKey)
@@ -67,24 +67,24 @@ func VerifyCSR(ctx context.Context, csr *x509.CertificateRequest, maxNames int,
 	if len(csr.EmailAddresses) > 0 {
 		return invalidEmailPresent
 	}
-	if len(csr.IPAddresses) > 0 {
-		return invalidIPPresent
-	}
-	if len(csr.DNSNames) == 0 && csr.Subject.CommonName == "" {
+	if len(csr.DNSNames) == 0 && len(csr.IPAddresses) == 0 && csr.Subject.CommonName == "" {
 		return invalidNoDNS
 	}
-	if csr.Subject.CommonName == "" {
+	if csr.Subject.CommonName == "" && len(csr.DNSNames) > 0 {
 		return invalidAllSANTooLong
 	}
 	if len(csr.Subject.CommonName) > maxCNLength {
 		return berrors.BadCSRError("CN was longer than %d bytes", maxCNLength)
 	}
-	if len(csr.DNSNames) > maxNames {
-		return berrors.BadCSRError("CSR contains more than %d DNS names", maxNames)
+	if len(csr.DNSNames)+len(csr.IPAddresses) > maxNames {
+		return berrors.BadCSRError("CSR contains more than %d DNS names and IP addresses", maxNames)
 	}
-	idents := make([]identifier.ACMEIdentifier, len(csr.DNSNames))
-	for i, dnsName := range csr.DNSNames {
-		idents[i] = identifier.DNSIdentifier(dnsName)
+	idents := make([]identifier.ACMEIdentifier, 0, len(csr.DNSNames)+len(csr.IPAddresses))
+	for _, dnsName := range csr.DNSNames {
+		idents = append(idents, identifier.DNSIdentifier(dnsName))
+	}
+	for _, ip := range csr.IPAddresses {
+		idents = append(idents, identifier.IPIdentifier(ip))
 	}
 	if err := pa.WillingToIssueWildcards(idents); err != nil {
 		return err
@@ -92,8 +92,10 @@ func VerifyCSR(ctx context.Context, csr *x509.CertificateRequest, maxNames int,
 	return nil
 }
 
-// normalizeCSR deduplicates and lowers the case of dNSNames and the subject CN.
-// It will also hoist a dNSName into the CN if it is empty.
+// normalizeCSR deduplicates and lowers the case of dNSNames and the subject CN,
+// and deduplicates iPAddresses. It will also hoist a dNSName into the CN if it
+// is empty. A CN which is an IP address is treated as an iPAddress rather than
+// a dNSName.
 func normalizeCSR(csr *x509.CertificateRequest) {
 	if csr.Subject.CommonName == "" {
 		var forcedCN string
@@ -105,9 +107,30 @@ func normalizeCSR(csr *x509.CertificateRequest) {
 			}
 		}
 		csr.Subject.CommonName = forcedCN
+	} else if ip := net.ParseIP(csr.Subject.CommonName); ip != nil {
+		csr.IPAddresses = append(csr.IPAddresses, ip)
 	} else if csr.Subject.CommonName != "" {
 		csr.DNSNames = append(csr.DNSNames, csr.Subject.CommonName)
 	}
 	csr.Subject.CommonName = strings.ToLower(csr.Subject.CommonName)
 	csr.DNSNames = core.UniqueLowerNames(csr.DNSNames)
+	csr.IPAddresses = uniqueIPs(csr.IPAddresses)
+}
+
+// uniqueIPs returns the set of all unique IP addresses in the input, sorted by
+// their textual form. IPv4 addresses are returned in their 4-byte form, which
+// is how they are parsed from CSRs and certificates.
+func uniqueIPs(ips []net.IP) []net.IP {
+	if len(ips) == 0 {
+		return ips
+	}
+	var unique []net.IP
+	for _, name := range core.UniqueLowerNamesAndIPs(nil, ips) {
+		ip := net.ParseIP(name)
+		if ip4 := ip.To4(); ip4 != nil {
+			ip = ip4
+		}
+		unique = append(unique, ip)
+	}
+	return unique
 }
@@ -36,7 +36,7 @@ func (pa *mockPA) WillingToIssue(id identifier.ACMEIdentifier) error {
 
 func (pa *mockPA) WillingToIssueWildcards(idents []identifier.ACMEIdentifier) error {
 	for _, ident := range idents {
-		if ident.Value == "bad-name.com" || ident.Value == "other-bad-name.com" {
+		if ident.Value == "bad-name.com" || ident.Value == "other-bad-name.com" || ident.Value == "6.6.6.6" {
 			return errors.New("policy forbids issuing for identifier")
 		}
 	}
@@ -72,6 +72,12 @@ func TestVerifyCSR(t *testing.T) {
 	signedReqWithIPAddress := new(x509.CertificateRequest)
 	*signedReqWithIPAddress = *signedReq
 	signedReqWithIPAddress.IPAddresses = []net.IP{net.IPv4(1, 2, 3, 4)}
+	signedReqWithHostsAndIPAddress := new(x509.CertificateRequest)
+	*signedReqWithHostsAndIPAddress = *signedReqWithHosts
+	signedReqWithHostsAndIPAddress.IPAddresses = []net.IP{net.IPv4(1, 2, 3, 4)}
+	signedReqWithBadIPAddress := new(x509.CertificateRequest)
+	*signedReqWithBadIPAddress = *signedReq
+	signedReqWithBadIPAddress.IPAddresses = []net.IP{net.IPv4(6, 6, 6, 6)}
 	signedReqWithAllLongSANs := new(x509.CertificateRequest)
 	*signedReqWithAllLongSANs = *signedReq
 	signedReqWithAllLongSANs.DNSNames = []string{"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa.com"}
@@ -130,7 +136,7 @@ func TestVerifyCSR(t *testing.T) {
 			testingPolicy,
 			&mockPA{},
 			0,
-			berrors.BadCSRError("CSR contains more than 1 DNS names"),
+			berrors.BadCSRError("CSR contains more than 1 DNS names and IP addresses"),
 		},
 		{
 			signedReqWithBadNames,
@@ -154,7 +160,23 @@ func TestVerifyCSR(t *testing.T) {
 			testingPolicy,
 			&mockPA{},
 			0,
-			invalidIPPresent,
+			nil,
+		},
+		{
+			signedReqWithHostsAndIPAddress,
+			2,
+			testingPolicy,
+			&mockPA{},
+			0,
+			berrors.BadCSRError("CSR contains more than 2 DNS names and IP addresses"),
+		},
+		{
+			signedReqWithBadIPAddress,
+			100,
+			testingPolicy,
+			&mockPA{},
+			0,
+			errors.New("policy forbids issuing for identifier"),
 		},
 		{
 			signedReqWithAllLongSANs,
@@ -226,3 +248,18 @@ func TestNormalizeCSR(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizeCSRIPAddresses(t *testing.T) {
+	csr := &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: "10.0.0.2"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1"), net.IPv4(10, 0, 0, 1).To4(), net.ParseIP("2001:db8::1")},
+	}
+	normalizeCSR(csr)
+	test.AssertEquals(t, csr.Subject.CommonName, "10.0.0.2")
+	test.AssertEquals(t, len(csr.DNSNames), 0)
+	test.AssertDeepEquals(t, csr.IPAddresses, []net.IP{
+		net.IPv4(10, 0, 0, 1).To4(),
+		net.IPv4(10, 0, 0, 2).To4(),
+		net.ParseIP("2001:db8::1"),
+	})
+}
@@ -2,6 +2,7 @@ package errors
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/letsencrypt/boulder/identifier"
 )
@@ -41,6 +42,23 @@ type BoulderError struct {
 	Type      ErrorType
 	Detail    string
 	SubErrors []SubBoulderError
+	// RateLimitStatus is optionally set on RateLimit errors to describe the
+	// state of the limit which was exceeded.
+	RateLimitStatus *RateLimitStatus `json:",omitempty"`
+}
+
+// RateLimitStatus describes how much of a rate limit has been used, so that
+// clients which exceed it can be told when to try again.
+type RateLimitStatus struct {
+	// Limit is the number of requests allowed within the limit's window.
+	Limit int64
+	// Remaining is the number of requests which can currently be made.
+	Remaining int64
+	// RetryAfter is how long the client must wait before another request can
+	// succeed. It is zero if Remaining is positive.
+	RetryAfter time.Duration
+	// ResetIn is how long it will be until the whole limit is available again.
+	ResetIn time.Duration
 }
 
 // SubBoulderError represents sub-errors specific to an identifier that are
@@ -62,9 +80,10 @@ func (be *BoulderError) Unwrap() error {
 // provided subErrs to the existing BoulderError.
 func (be *BoulderError) WithSubErrors(subErrs []SubBoulderError) *BoulderError {
 	return &BoulderError{
-		Type:      be.Type,
-		Detail:    be.Detail,
-		SubErrors: append(be.SubErrors, subErrs...),
+		Type:            be.Type,
+		Detail:          be.Detail,
+		SubErrors:       append(be.SubErrors, subErrs...),
+		RateLimitStatus: be.RateLimitStatus,
 	}
 }
 
@@ -99,6 +118,14 @@ func RateLimitError(msg string, args ...interface{}) error {
 	}
 }
 
+// RateLimitErrorWithStatus is like RateLimitError, but additionally records
+// the status of the limit which was exceeded.
+func RateLimitErrorWithStatus(status *RateLimitStatus, msg string, args ...interface{}) error {
+	err := RateLimitError(msg, args...).(*BoulderError)
+	err.RateLimitStatus = status
+	return err
+}
+
 func RejectedIdentifierError(msg string, args ...interface{}) error {
 	return New(RejectedIdentifier, msg, args...)
 }
@@ -2,6 +2,7 @@ package errors
 
 import (
 	"testing"
+	"time"
 
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/test"
@@ -48,3 +49,24 @@ func TestWithSubErrors(t *testing.T) {
 	outResult = outResult.WithSubErrors([]SubBoulderError{anotherSubErr})
 	test.AssertDeepEquals(t, outResult.SubErrors, append(subErrs, anotherSubErr))
 }
+
+func TestRateLimitErrorWithStatus(t *testing.T) {
+	status := &RateLimitStatus{
+		Limit:      50,
+		Remaining:  0,
+		RetryAfter: time.Hour,
+		ResetIn:    24 * time.Hour,
+	}
+	err := RateLimitErrorWithStatus(status, "too many %s", "things")
+	test.AssertErrorIs(t, err, RateLimit)
+	berr := err.(*BoulderError)
+	test.AssertEquals(t, berr.Detail, "too many things: see https://letsencrypt.org/docs/rate-limits/")
+	test.AssertDeepEquals(t, berr.RateLimitStatus, status)
+
+	// The status survives the addition of suberrors.
+	withSubErrs := berr.WithSubErrors([]SubBoulderError{{
+		Identifier:   identifier.DNSIdentifier("example.com"),
+		BoulderError: &BoulderError{Type: RateLimit, Detail: "too many"},
+	}})
+	test.AssertDeepEquals(t, withSubErrs.RateLimitStatus, status)
+}
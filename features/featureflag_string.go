@@ -24,11 +24,24 @@ func _() {
 	_ = x[RestrictRSAKeySizes-13]
 	_ = x[FasterNewOrdersRateLimit-14]
 	_ = x[ECDSAForAll-15]
+	_ = x[ServeRenewalInfo-16]
+	_ = x[ExternalAccountBinding-17]
+	_ = x[MultipleCertificateProfiles-18]
+	_ = x[ServeRateLimitUsage-19]
+	_ = x[ServeAccountOrders-20]
+	_ = x[ServeNewAuthz-21]
+	_ = x[AsyncFinalize-22]
+	_ = x[ServeRevokeByName-23]
+	_ = x[ServeRevokeByKey-24]
+	_ = x[EnforceMultiCAA-25]
+	_ = x[MultiCAAFullResults-26]
+	_ = x[RequestedOrderValidity-27]
+	_ = x[RecordRemoteValidations-28]
 }
 
-const _FeatureFlag_name = "unusedPrecertificateRevocationStripDefaultSchemePortNonCFSSLSignerStoreIssuerInfoCAAValidationMethodsCAAAccountURIEnforceMultiVAMultiVAFullResultsMandatoryPOSTAsGETAllowV1RegistrationV1DisableNewValidationsStoreRevokerInfoRestrictRSAKeySizesFasterNewOrdersRateLimitECDSAForAll"
+const _FeatureFlag_name = "unusedPrecertificateRevocationStripDefaultSchemePortNonCFSSLSignerStoreIssuerInfoCAAValidationMethodsCAAAccountURIEnforceMultiVAMultiVAFullResultsMandatoryPOSTAsGETAllowV1RegistrationV1DisableNewValidationsStoreRevokerInfoRestrictRSAKeySizesFasterNewOrdersRateLimitECDSAForAllServeRenewalInfoExternalAccountBindingMultipleCertificateProfilesServeRateLimitUsageServeAccountOrdersServeNewAuthzAsyncFinalizeServeRevokeByNameServeRevokeByKeyEnforceMultiCAAMultiCAAFullResultsRequestedOrderValidityRecordRemoteValidations"
 
-var _FeatureFlag_index = [...]uint16{0, 6, 30, 52, 66, 81, 101, 114, 128, 146, 164, 183, 206, 222, 241, 265, 276}
+var _FeatureFlag_index = [...]uint16{0, 6, 30, 52, 66, 81, 101, 114, 128, 146, 164, 183, 206, 222, 241, 265, 276, 292, 314, 341, 360, 378, 391, 404, 421, 437, 452, 471, 493, 516}
 
 func (i FeatureFlag) String() string {
 	if i < 0 || i >= FeatureFlag(len(_FeatureFlag_index)-1) {
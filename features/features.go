@@ -48,26 +48,92 @@ const (
 	// ECDSAForAll enables all accounts, regardless of their presence in the CA's
 	// ecdsaAllowedAccounts config value, to get issuance from ECDSA issuers.
 	ECDSAForAll
+	// ServeRenewalInfo exposes the renewalInfo endpoint in the directory and for
+	// GET requests. WARNING: This feature is a draft and highly unstable.
+	ServeRenewalInfo
+	// ExternalAccountBinding enables verification of the externalAccountBinding
+	// field of newAccount requests, and storage of the resulting binding in the
+	// externalAccountBindings table.
+	ExternalAccountBinding
+	// MultipleCertificateProfiles enables storage of the certificate profile
+	// requested in newOrder in the certificateProfileName column of the orders
+	// table. The RA rejects orders which request a profile unless it is
+	// enabled.
+	MultipleCertificateProfiles
+	// ServeRateLimitUsage exposes the rateLimits endpoint, which reports an
+	// account's usage of each rate limit, in the directory and for POST
+	// requests.
+	ServeRateLimitUsage
+	// ServeAccountOrders adds orders and certificates URLs to account objects,
+	// and serves the paginated lists of the account's orders and certificates
+	// found at them.
+	ServeAccountOrders
+	// ServeNewAuthz exposes the newAuthz endpoint in the directory, allowing
+	// accounts to create authorizations ahead of placing an order.
+	ServeNewAuthz
+	// AsyncFinalize makes the RA return from FinalizeOrder as soon as the
+	// order is set to processing, completing issuance in the background while
+	// the client polls the order.
+	AsyncFinalize
+	// ServeRevokeByName exposes the revokeName endpoint in the directory,
+	// allowing an account which holds a valid authorization for a domain to
+	// revoke every unexpired certificate naming it.
+	ServeRevokeByName
+	// ServeRevokeByKey exposes the revokeKey endpoint in the directory,
+	// allowing anyone holding a compromised key to block it and revoke every
+	// certificate which uses it, by submitting a CSR signed with it.
+	ServeRevokeByKey
+	// EnforceMultiCAA causes the VA to block on remote VA IsCAAValid requests
+	// when checking CAA outside of a validation, as the RA does when rechecking
+	// CAA, and to make a valid/invalid decision with the results.
+	EnforceMultiCAA
+	// MultiCAAFullResults will cause the VA to wait for all of the remote VA
+	// IsCAAValid results, not just the threshold required to make a decision,
+	// and to log the differential between them and the primary VA's result.
+	MultiCAAFullResults
+	// RequestedOrderValidity allows newOrder requests to specify notBefore and
+	// notAfter, and enables their storage in the notBefore and notAfter
+	// columns of the orders table, which exist only in the db-next schema. The
+	// RA rejects orders which request either unless it is enabled.
+	RequestedOrderValidity
+	// RecordRemoteValidations causes the VA, when enforcing multi VA, to add
+	// the validation record of every remote VA, marked with its perspective, to
+	// the challenge alongside its own. It must only be enabled once the RA
+	// accepts such records and the WFE knows how to present them.
+	RecordRemoteValidations
 )
 
 // List of features and their default value, protected by fMu
 var features = map[FeatureFlag]bool{
-	unused:                   false,
-	CAAValidationMethods:     false,
-	CAAAccountURI:            false,
-	EnforceMultiVA:           false,
-	MultiVAFullResults:       false,
-	MandatoryPOSTAsGET:       false,
-	AllowV1Registration:      true,
-	V1DisableNewValidations:  false,
-	PrecertificateRevocation: false,
-	StripDefaultSchemePort:   false,
-	StoreIssuerInfo:          false,
-	StoreRevokerInfo:         false,
-	RestrictRSAKeySizes:      false,
-	FasterNewOrdersRateLimit: false,
-	NonCFSSLSigner:           false,
-	ECDSAForAll:              false,
+	unused:                      false,
+	CAAValidationMethods:        false,
+	CAAAccountURI:               false,
+	EnforceMultiVA:              false,
+	MultiVAFullResults:          false,
+	MandatoryPOSTAsGET:          false,
+	AllowV1Registration:         true,
+	V1DisableNewValidations:     false,
+	PrecertificateRevocation:    false,
+	StripDefaultSchemePort:      false,
+	StoreIssuerInfo:             false,
+	StoreRevokerInfo:            false,
+	RestrictRSAKeySizes:         false,
+	FasterNewOrdersRateLimit:    false,
+	NonCFSSLSigner:              false,
+	ECDSAForAll:                 false,
+	ServeRenewalInfo:            false,
+	ExternalAccountBinding:      false,
+	MultipleCertificateProfiles: false,
+	ServeRateLimitUsage:         false,
+	ServeAccountOrders:          false,
+	ServeNewAuthz:               false,
+	AsyncFinalize:               false,
+	ServeRevokeByName:           false,
+	ServeRevokeByKey:            false,
+	EnforceMultiCAA:             false,
+	MultiCAAFullResults:         false,
+	RequestedOrderValidity:      false,
+	RecordRemoteValidations:     false,
 }
 
 var fMu = new(sync.RWMutex)
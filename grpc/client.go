@@ -3,6 +3,7 @@ package grpc
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"net"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
@@ -25,27 +26,53 @@ func ClientSetup(c *cmd.GRPCClientConfig, tlsConfig *tls.Config, metrics clientM
 	if c == nil {
 		return nil, errors.New("nil gRPC client config provided. JSON config is probably missing a fooService section.")
 	}
-	if c.ServerAddress == "" {
-		return nil, errors.New("ServerAddress must not be empty")
-	}
 	if tlsConfig == nil {
 		return nil, errNilTLS
 	}
-
-	ci := clientInterceptor{c.Timeout.Duration, metrics, clk}
-	host, _, err := net.SplitHostPort(c.ServerAddress)
+	target, host, err := clientTarget(c)
 	if err != nil {
 		return nil, err
 	}
+
+	ci := clientInterceptor{c.Timeout.Duration, metrics, clk}
 	creds := bcreds.NewClientCredentials(tlsConfig.RootCAs, tlsConfig.Certificates, host)
 	return grpc.Dial(
-		"dns:///"+c.ServerAddress,
+		target,
 		grpc.WithBalancerName("round_robin"),
 		grpc.WithTransportCredentials(creds),
 		grpc.WithUnaryInterceptor(ci.intercept),
 	)
 }
 
+// clientTarget returns the gRPC target to dial for the service, and the
+// hostname which its backends' certificates are verified against.
+func clientTarget(c *cmd.GRPCClientConfig) (string, string, error) {
+	if c.SRVLookup != nil {
+		if c.ServerAddress != "" {
+			return "", "", errors.New("only one of ServerAddress and SRVLookup may be set")
+		}
+		if c.SRVLookup.Service == "" || c.SRVLookup.Domain == "" {
+			return "", "", errors.New("SRVLookup must have a Service and a Domain")
+		}
+		scheme := srvScheme
+		if c.SRVLookup.DNSSD {
+			scheme = dnssdScheme
+		}
+		return fmt.Sprintf("%s://%s/_%s._tcp.%s", scheme, c.DNSAuthority, c.SRVLookup.Service, c.SRVLookup.Domain), c.SRVLookup.Name(), nil
+	}
+	if c.ServerAddress == "" {
+		return "", "", errors.New("ServerAddress must not be empty")
+	}
+	if c.DNSAuthority != "" {
+		return "", "", errors.New("DNSAuthority may only be set with SRVLookup")
+	}
+	host, _, err := net.SplitHostPort(c.ServerAddress)
+	if err != nil {
+		return "", "", err
+	}
+	return "dns:///" + c.ServerAddress, host, nil
+}
+
 type registry interface {
 	MustRegister(...prometheus.Collector)
 }
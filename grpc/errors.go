@@ -49,6 +49,19 @@ func wrapError(ctx context.Context, err error) error {
 			pairs = append(pairs, string(jsonSubErrs))
 		}
 
+		// Likewise, if there is a rate limit status then include its JSON
+		// marshaling.
+		if berr.RateLimitStatus != nil {
+			jsonStatus, err := json.Marshal(berr.RateLimitStatus)
+			if err != nil {
+				return berrors.InternalServerError(
+					"error marshaling json RateLimitStatus, orig error %q",
+					err)
+			}
+			pairs = append(pairs, "ratelimitstatus")
+			pairs = append(pairs, string(jsonStatus))
+		}
+
 		// Ignoring the error return here is safe because if setting the metadata
 		// fails, we'll still return an error, but it will be interpreted on the
 		// other side as an InternalServerError instead of a more specific one.
@@ -109,6 +122,26 @@ func unwrapError(err error, md metadata.MD) error {
 				)
 			}
 		}
+		if statusJSON, ok := md["ratelimitstatus"]; ok {
+			if len(statusJSON) != 1 {
+				return berrors.InternalServerError(
+					"multiple ratelimitstatus metadata, wrapped error %q",
+					unwrappedErr,
+				)
+			}
+			var status berrors.RateLimitStatus
+			if err := json.Unmarshal([]byte(statusJSON[0]), &status); err != nil {
+				return berrors.InternalServerError(
+					"error unmarshaling ratelimitstatus JSON %q, wrapped error %q",
+					statusJSON[0],
+					unwrappedErr,
+				)
+			}
+			var berr *berrors.BoulderError
+			if errors.As(outErr, &berr) {
+				berr.RateLimitStatus = &status
+			}
+		}
 		return outErr
 	}
 	return err
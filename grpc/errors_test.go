@@ -95,3 +95,37 @@ func TestSubErrorWrapping(t *testing.T) {
 	test.Assert(t, err != nil, fmt.Sprintf("nil error returned, expected: %s", err))
 	test.AssertDeepEquals(t, err, es.err)
 }
+
+// TestRateLimitStatusWrapping tests that a rate limit error with a status can
+// be correctly wrapped and unwrapped across the RPC layer.
+func TestRateLimitStatusWrapping(t *testing.T) {
+	serverMetrics := NewServerMetrics(metrics.NoopRegisterer)
+	si := newServerInterceptor(serverMetrics, clock.NewFake())
+	ci := clientInterceptor{time.Second, NewClientMetrics(metrics.NoopRegisterer), clock.NewFake()}
+	srv := grpc.NewServer(grpc.UnaryInterceptor(si.intercept))
+	es := &errorServer{}
+	testproto.RegisterChillerServer(srv, es)
+	lis, err := net.Listen("tcp", "127.0.0.1:")
+	test.AssertNotError(t, err, "Failed to create listener")
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	conn, err := grpc.Dial(
+		lis.Addr().String(),
+		grpc.WithInsecure(),
+		grpc.WithUnaryInterceptor(ci.intercept),
+	)
+	test.AssertNotError(t, err, "Failed to dial grpc test server")
+	client := testproto.NewChillerClient(conn)
+
+	es.err = berrors.RateLimitErrorWithStatus(&berrors.RateLimitStatus{
+		Limit:      300,
+		Remaining:  0,
+		RetryAfter: 36 * time.Second,
+		ResetIn:    3 * time.Hour,
+	}, "too much chilling")
+
+	_, err = client.Chill(context.Background(), &testproto.Time{})
+	test.Assert(t, err != nil, fmt.Sprintf("nil error returned, expected: %s", err))
+	test.AssertDeepEquals(t, err, es.err)
+}
@@ -106,6 +106,10 @@ func TestValidationRecord(t *testing.T) {
 		AddressUsed:       ip,
 		URL:               "url",
 		AddressesTried:    []net.IP{ip},
+		DNSSEC:            "secure",
+		RemoteVA:          "va1.boulder:9097",
+		Perspective:       "ARIN",
+		RemoteProblem:     "Connection refused",
 	}
 
 	pb, err := ValidationRecordToPB(vr)
@@ -184,6 +184,66 @@ func (ras *RegistrationAuthorityClientWrapper) FinalizeOrder(ctx context.Context
 	return resp, nil
 }
 
+func (ras *RegistrationAuthorityClientWrapper) RenewalInfo(ctx context.Context, request *rapb.RenewalInfoRequest) (*rapb.RenewalInfo, error) {
+	resp, err := ras.inner.RenewalInfo(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.WindowStart == 0 || resp.WindowEnd == 0 {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (ras *RegistrationAuthorityClientWrapper) GetRateLimitUsage(ctx context.Context, request *rapb.GetRateLimitUsageRequest) (*rapb.RateLimitUsages, error) {
+	resp, err := ras.inner.GetRateLimitUsage(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	for _, usage := range resp.Usages {
+		if usage == nil || usage.LimitName == "" {
+			return nil, errIncompleteResponse
+		}
+	}
+	return resp, nil
+}
+
+func (ras *RegistrationAuthorityClientWrapper) NewPreAuthorization(ctx context.Context, request *rapb.NewPreAuthorizationRequest) (*corepb.Authorization, error) {
+	resp, err := ras.inner.NewPreAuthorization(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || !authorizationValid(resp) {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (ras *RegistrationAuthorityClientWrapper) RevokeCertificatesByName(ctx context.Context, request *rapb.RevokeCertificatesByNameRequest) (*rapb.RevokedSerials, error) {
+	resp, err := ras.inner.RevokeCertificatesByName(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (ras *RegistrationAuthorityClientWrapper) RevokeCertificatesByKey(ctx context.Context, request *rapb.RevokeCertificatesByKeyRequest) (*rapb.RevokedSerials, error) {
+	resp, err := ras.inner.RevokeCertificatesByKey(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
 // RegistrationAuthorityServerWrapper is the gRPC version of a core.RegistrationAuthority server
 type RegistrationAuthorityServerWrapper struct {
 	rapb.UnimplementedRegistrationAuthorityServer
@@ -344,3 +404,43 @@ func (ras *RegistrationAuthorityServerWrapper) FinalizeOrder(ctx context.Context
 
 	return ras.inner.FinalizeOrder(ctx, request)
 }
+
+func (ras *RegistrationAuthorityServerWrapper) RenewalInfo(ctx context.Context, request *rapb.RenewalInfoRequest) (*rapb.RenewalInfo, error) {
+	if request == nil || request.IssuerID == 0 || request.Serial == "" {
+		return nil, errIncompleteRequest
+	}
+
+	return ras.inner.RenewalInfo(ctx, request)
+}
+
+func (ras *RegistrationAuthorityServerWrapper) GetRateLimitUsage(ctx context.Context, request *rapb.GetRateLimitUsageRequest) (*rapb.RateLimitUsages, error) {
+	if request == nil || request.RegistrationID == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	return ras.inner.GetRateLimitUsage(ctx, request)
+}
+
+func (ras *RegistrationAuthorityServerWrapper) NewPreAuthorization(ctx context.Context, request *rapb.NewPreAuthorizationRequest) (*corepb.Authorization, error) {
+	if request == nil || request.RegistrationID == 0 || request.IdentifierType == "" || request.IdentifierValue == "" {
+		return nil, errIncompleteRequest
+	}
+
+	return ras.inner.NewPreAuthorization(ctx, request)
+}
+
+func (ras *RegistrationAuthorityServerWrapper) RevokeCertificatesByName(ctx context.Context, request *rapb.RevokeCertificatesByNameRequest) (*rapb.RevokedSerials, error) {
+	if request == nil || request.RegistrationID == 0 || request.Domain == "" {
+		return nil, errIncompleteRequest
+	}
+
+	return ras.inner.RevokeCertificatesByName(ctx, request)
+}
+
+func (ras *RegistrationAuthorityServerWrapper) RevokeCertificatesByKey(ctx context.Context, request *rapb.RevokeCertificatesByKeyRequest) (*rapb.RevokedSerials, error) {
+	if request == nil || len(request.Csr) == 0 {
+		return nil, errIncompleteRequest
+	}
+
+	return ras.inner.RevokeCertificatesByKey(ctx, request)
+}
@@ -0,0 +1,165 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+
+	"github.com/letsencrypt/boulder/bdns"
+)
+
+const (
+	// srvScheme is the gRPC target scheme for backends discovered from SRV
+	// records, as in "srv://[authority]/_service._tcp.domain".
+	srvScheme = "srv"
+	// dnssdScheme is the gRPC target scheme for backends discovered by
+	// DNS-SD, as in "dnssd://[authority]/_service._tcp.domain".
+	dnssdScheme = "dnssd"
+)
+
+var (
+	// refreshInterval is how often backends are looked up again, to pick up
+	// backends which have been added or removed.
+	refreshInterval = 30 * time.Second
+	// minResolveInterval limits how often backends are looked up when gRPC
+	// asks for them after connections fail.
+	minResolveInterval = 5 * time.Second
+	// resolveTimeout limits each lookup of the backends.
+	resolveTimeout = 10 * time.Second
+)
+
+func init() {
+	resolver.Register(&srvBuilder{scheme: srvScheme})
+	resolver.Register(&srvBuilder{scheme: dnssdScheme})
+}
+
+// srvBuilder builds resolvers for the srv and dnssd target schemes.
+type srvBuilder struct {
+	scheme string
+}
+
+func (b *srvBuilder) Scheme() string {
+	return b.scheme
+}
+
+// Build parses the target, whose endpoint must be an SRV or DNS-SD service
+// name of the form _service._proto.domain, and starts a resolver for it. The
+// target's authority, if present, is the DNS server to query.
+func (b *srvBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	labels := strings.SplitN(target.Endpoint, ".", 3)
+	if len(labels) != 3 || !strings.HasPrefix(labels[0], "_") || !strings.HasPrefix(labels[1], "_") || labels[2] == "" {
+		return nil, fmt.Errorf("%s target %q must be of the form _service._proto.domain", b.scheme, target.Endpoint)
+	}
+	service, proto, domain := labels[0][1:], labels[1][1:], labels[2]
+
+	var lookup func(context.Context) (map[string][]uint16, error)
+	if b.scheme == dnssdScheme {
+		lookup = func(ctx context.Context) (map[string][]uint16, error) {
+			return bdns.BrowseDNSSD(ctx, target.Authority, service, proto, domain)
+		}
+	} else {
+		res := bdns.NewResolver(target.Authority)
+		lookup = func(ctx context.Context) (map[string][]uint16, error) {
+			return bdns.LookupSRVAddrs(ctx, res, service, proto, domain)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &srvResolver{
+		lookup: lookup,
+		cc:     cc,
+		ctx:    ctx,
+		cancel: cancel,
+		rn:     make(chan struct{}, 1),
+	}
+	r.wg.Add(1)
+	go r.watch()
+	return r, nil
+}
+
+// srvResolver looks up the backends of a service when it starts, regularly
+// thereafter, and whenever gRPC asks it to, and passes them to gRPC.
+type srvResolver struct {
+	lookup func(context.Context) (map[string][]uint16, error)
+	cc     resolver.ClientConn
+	ctx    context.Context
+	cancel context.CancelFunc
+	// rn is signalled by ResolveNow.
+	rn chan struct{}
+	wg sync.WaitGroup
+}
+
+// ResolveNow asks the resolver to look up the backends again, no sooner than
+// minResolveInterval after the last lookup.
+func (r *srvResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.rn <- struct{}{}:
+	default:
+	}
+}
+
+// Close stops the resolver and waits for it to finish.
+func (r *srvResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *srvResolver) watch() {
+	defer r.wg.Done()
+	for {
+		r.resolve()
+
+		limit := time.NewTimer(minResolveInterval)
+		select {
+		case <-r.ctx.Done():
+			limit.Stop()
+			return
+		case <-limit.C:
+		}
+
+		refresh := time.NewTimer(refreshInterval - minResolveInterval)
+		select {
+		case <-r.ctx.Done():
+			refresh.Stop()
+			return
+		case <-r.rn:
+			refresh.Stop()
+		case <-refresh.C:
+		}
+	}
+}
+
+// resolve looks up the backends and updates gRPC with them, or reports the
+// error, in which case gRPC keeps using the backends it already has and
+// calls ResolveNow again later.
+func (r *srvResolver) resolve() {
+	ctx, cancel := context.WithTimeout(r.ctx, resolveTimeout)
+	defer cancel()
+	addrPorts, err := r.lookup(ctx)
+	if err != nil {
+		if r.ctx.Err() == nil {
+			r.cc.ReportError(err)
+		}
+		return
+	}
+	var addrs []resolver.Address
+	for ip, ports := range addrPorts {
+		seen := make(map[uint16]bool)
+		for _, port := range ports {
+			if seen[port] {
+				continue
+			}
+			seen[port] = true
+			addrs = append(addrs, resolver.Address{Addr: net.JoinHostPort(ip, strconv.Itoa(int(port)))})
+		}
+	}
+	sort.Slice(addrs, func(i, j int) bool { return addrs[i].Addr < addrs[j].Addr })
+	r.cc.UpdateState(resolver.State{Addresses: addrs})
+}
@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/serviceconfig"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/test"
+)
+
+// fakeClientConn records the state and errors passed to it by a resolver.
+type fakeClientConn struct {
+	states chan resolver.State
+	errs   chan error
+}
+
+func newFakeClientConn() *fakeClientConn {
+	return &fakeClientConn{
+		states: make(chan resolver.State, 10),
+		errs:   make(chan error, 10),
+	}
+}
+
+func (f *fakeClientConn) UpdateState(s resolver.State)                         { f.states <- s }
+func (f *fakeClientConn) ReportError(err error)                                { f.errs <- err }
+func (f *fakeClientConn) NewAddress([]resolver.Address)                        {}
+func (f *fakeClientConn) NewServiceConfig(string)                              {}
+func (f *fakeClientConn) ParseServiceConfig(string) *serviceconfig.ParseResult { return nil }
+
+func startResolver(lookup func(context.Context) (map[string][]uint16, error), cc resolver.ClientConn) *srvResolver {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &srvResolver{lookup: lookup, cc: cc, ctx: ctx, cancel: cancel, rn: make(chan struct{}, 1)}
+	r.wg.Add(1)
+	go r.watch()
+	return r
+}
+
+func TestSRVResolver(t *testing.T) {
+	defer func(old time.Duration) { minResolveInterval = old }(minResolveInterval)
+	minResolveInterval = time.Millisecond
+
+	lookups := make(chan map[string][]uint16, 1)
+	lookups <- map[string][]uint16{
+		"10.77.77.77": {9095, 9095},
+		"10.88.88.88": {9095, 9096},
+	}
+	lookup := func(ctx context.Context) (map[string][]uint16, error) {
+		select {
+		case addrs := <-lookups:
+			return addrs, nil
+		default:
+			return nil, errors.New("SERVFAIL")
+		}
+	}
+	cc := newFakeClientConn()
+	r := startResolver(lookup, cc)
+	defer r.Close()
+
+	state := <-cc.states
+	test.AssertDeepEquals(t, state.Addresses, []resolver.Address{
+		{Addr: "10.77.77.77:9095"},
+		{Addr: "10.88.88.88:9095"},
+		{Addr: "10.88.88.88:9096"},
+	})
+
+	// A failed lookup is reported to gRPC.
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	err := <-cc.errs
+	test.AssertEquals(t, err.Error(), "SERVFAIL")
+
+	// A backend was added.
+	lookups <- map[string][]uint16{
+		"10.77.77.77": {9095},
+		"10.88.88.88": {9095},
+		"10.99.99.99": {9095},
+	}
+	r.ResolveNow(resolver.ResolveNowOptions{})
+	state = <-cc.states
+	test.AssertDeepEquals(t, state.Addresses, []resolver.Address{
+		{Addr: "10.77.77.77:9095"},
+		{Addr: "10.88.88.88:9095"},
+		{Addr: "10.99.99.99:9095"},
+	})
+}
+
+func TestSRVBuilderBadTarget(t *testing.T) {
+	for _, endpoint := range []string{"", "sa.boulder", "_sa.boulder", "sa._tcp.boulder", "_sa._tcp."} {
+		_, err := (&srvBuilder{scheme: srvScheme}).Build(resolver.Target{Scheme: srvScheme, Endpoint: endpoint}, newFakeClientConn(), resolver.BuildOptions{})
+		test.AssertError(t, err, "built a resolver for "+endpoint)
+	}
+}
+
+func TestClientTarget(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config cmd.GRPCClientConfig
+		target string
+		host   string
+		err    string
+	}{
+		{
+			name:   "ServerAddress",
+			config: cmd.GRPCClientConfig{ServerAddress: "sa.boulder:9095"},
+			target: "dns:///sa.boulder:9095",
+			host:   "sa.boulder",
+		},
+		{
+			name:   "SRVLookup",
+			config: cmd.GRPCClientConfig{SRVLookup: &cmd.ServiceDomain{Service: "sa", Domain: "boulder"}},
+			target: "srv:///_sa._tcp.boulder",
+			host:   "sa.boulder",
+		},
+		{
+			name:   "SRVLookup with DNSAuthority",
+			config: cmd.GRPCClientConfig{SRVLookup: &cmd.ServiceDomain{Service: "sa", Domain: "boulder"}, DNSAuthority: "10.77.77.77:53"},
+			target: "srv://10.77.77.77:53/_sa._tcp.boulder",
+			host:   "sa.boulder",
+		},
+		{
+			name:   "DNS-SD",
+			config: cmd.GRPCClientConfig{SRVLookup: &cmd.ServiceDomain{Service: "sa", Domain: "boulder", DNSSD: true}},
+			target: "dnssd:///_sa._tcp.boulder",
+			host:   "sa.boulder",
+		},
+		{
+			name:   "neither",
+			config: cmd.GRPCClientConfig{},
+			err:    "ServerAddress must not be empty",
+		},
+		{
+			name:   "both",
+			config: cmd.GRPCClientConfig{ServerAddress: "sa.boulder:9095", SRVLookup: &cmd.ServiceDomain{Service: "sa", Domain: "boulder"}},
+			err:    "only one of ServerAddress and SRVLookup may be set",
+		},
+		{
+			name:   "SRVLookup without Service",
+			config: cmd.GRPCClientConfig{SRVLookup: &cmd.ServiceDomain{Domain: "boulder"}},
+			err:    "SRVLookup must have a Service and a Domain",
+		},
+		{
+			name:   "DNSAuthority without SRVLookup",
+			config: cmd.GRPCClientConfig{ServerAddress: "sa.boulder:9095", DNSAuthority: "10.77.77.77:53"},
+			err:    "DNSAuthority may only be set with SRVLookup",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			target, host, err := clientTarget(&tc.config)
+			if tc.err != "" {
+				test.AssertError(t, err, "invalid config accepted")
+				test.AssertEquals(t, err.Error(), tc.err)
+				return
+			}
+			test.AssertNotError(t, err, "clientTarget failed")
+			test.AssertEquals(t, target, tc.target)
+			test.AssertEquals(t, host, tc.host)
+		})
+	}
+}
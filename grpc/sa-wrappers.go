@@ -225,6 +225,20 @@ func (sac StorageAuthorityClientWrapper) AddPrecertificate(
 	return empty, nil
 }
 
+func (sac StorageAuthorityClientWrapper) AddCertificateWithoutPrecertificate(
+	ctx context.Context,
+	req *sapb.AddCertificateRequest,
+) (*corepb.Empty, error) {
+	empty, err := sac.inner.AddCertificateWithoutPrecertificate(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if empty == nil {
+		return nil, errIncompleteResponse
+	}
+	return empty, nil
+}
+
 func (sac StorageAuthorityClientWrapper) AddSerial(
 	ctx context.Context,
 	req *sapb.AddSerialRequest,
@@ -462,6 +476,22 @@ func (sas StorageAuthorityClientWrapper) CountInvalidAuthorizations2(ctx context
 	return count, nil
 }
 
+func (sas StorageAuthorityClientWrapper) CountInvalidAuthorizationsByName(ctx context.Context, req *sapb.CountInvalidAuthorizationsByNameRequest) (*sapb.CountByNames, error) {
+	counts, err := sas.inner.CountInvalidAuthorizationsByName(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if counts == nil {
+		return nil, errIncompleteResponse
+	}
+	for _, count := range counts.CountByNames {
+		if count == nil {
+			return nil, errIncompleteResponse
+		}
+	}
+	return counts, nil
+}
+
 func (sas StorageAuthorityClientWrapper) GetValidAuthorizations2(ctx context.Context, req *sapb.GetValidAuthorizationsRequest) (*sapb.Authorizations, error) {
 	authorizations, err := sas.inner.GetValidAuthorizations2(ctx, req)
 	if err != nil {
@@ -488,6 +518,115 @@ func (sac StorageAuthorityClientWrapper) KeyBlocked(ctx context.Context, req *sa
 	return sac.inner.KeyBlocked(ctx, req)
 }
 
+func (sac StorageAuthorityClientWrapper) AddExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKey) (*corepb.Empty, error) {
+	// All return checking is done at the call site
+	return sac.inner.AddExternalAccountKey(ctx, req)
+}
+
+func (sac StorageAuthorityClientWrapper) GetExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKeyID) (*sapb.ExternalAccountKey, error) {
+	resp, err := sac.inner.GetExternalAccountKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.KeyID == "" || len(resp.MacKey) == 0 {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error) {
+	resp, err := sac.inner.GetRateLimitOverrides(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetOrdersForAccount(ctx context.Context, req *sapb.GetByAccountRequest) (*sapb.OrderIDs, error) {
+	resp, err := sac.inner.GetOrdersForAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetSerialsForAccount(ctx context.Context, req *sapb.GetByAccountRequest) (*sapb.AccountSerials, error) {
+	resp, err := sac.inner.GetSerialsForAccount(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || (len(resp.Serials) > 0 && resp.LastID == 0) {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetUnexpiredSerialsForName(ctx context.Context, req *sapb.GetUnexpiredSerialsForNameRequest) (*sapb.Serials, error) {
+	resp, err := sac.inner.GetUnexpiredSerialsForName(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetUnexpiredSerialsForKey(ctx context.Context, req *sapb.GetUnexpiredSerialsForKeyRequest) (*sapb.Serials, error) {
+	resp, err := sac.inner.GetUnexpiredSerialsForKey(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) AddRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverride) (*sapb.RateLimitOverrideID, error) {
+	resp, err := sac.inner.AddRateLimitOverride(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Id == 0 {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) ExpireRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverrideID) (*corepb.Empty, error) {
+	// All return checking is done at the call site
+	return sac.inner.ExpireRateLimitOverride(ctx, req)
+}
+
+func (sac StorageAuthorityClientWrapper) GetCertificateStatusByIssuer(ctx context.Context, req *sapb.IssuerSerial) (*corepb.CertificateStatus, error) {
+	resp, err := sac.inner.GetCertificateStatusByIssuer(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Serial == "" || resp.Status == "" || resp.NotAfter == 0 {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
+func (sac StorageAuthorityClientWrapper) GetRevokedCerts(ctx context.Context, req *sapb.GetRevokedCertsRequest) (*sapb.RevokedCerts, error) {
+	resp, err := sac.inner.GetRevokedCerts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, errIncompleteResponse
+	}
+	return resp, nil
+}
+
 // StorageAuthorityServerWrapper is the gRPC version of a core.ServerAuthority server
 type StorageAuthorityServerWrapper struct {
 	sapb.UnimplementedStorageAuthorityServer
@@ -502,6 +641,10 @@ func (sas *StorageAuthorityServerWrapper) AddPrecertificate(ctx context.Context,
 	return sas.inner.AddPrecertificate(ctx, req)
 }
 
+func (sas *StorageAuthorityServerWrapper) AddCertificateWithoutPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error) {
+	return sas.inner.AddCertificateWithoutPrecertificate(ctx, req)
+}
+
 func (sas *StorageAuthorityServerWrapper) AddSerial(ctx context.Context, req *sapb.AddSerialRequest) (*corepb.Empty, error) {
 	return sas.inner.AddSerial(ctx, req)
 }
@@ -868,6 +1011,14 @@ func (sas StorageAuthorityServerWrapper) CountInvalidAuthorizations2(ctx context
 	return sas.inner.CountInvalidAuthorizations2(ctx, req)
 }
 
+func (sas StorageAuthorityServerWrapper) CountInvalidAuthorizationsByName(ctx context.Context, req *sapb.CountInvalidAuthorizationsByNameRequest) (*sapb.CountByNames, error) {
+	if core.IsAnyNilOrZero(req, req.RegistrationID, req.Range, req.Range.Earliest, req.Range.Latest) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.CountInvalidAuthorizationsByName(ctx, req)
+}
+
 func (sas StorageAuthorityServerWrapper) GetValidAuthorizations2(ctx context.Context, req *sapb.GetValidAuthorizationsRequest) (*sapb.Authorizations, error) {
 	if core.IsAnyNilOrZero(req, req.Domains, req.RegistrationID, req.Now) {
 		return nil, errIncompleteRequest
@@ -893,3 +1044,91 @@ func (sas StorageAuthorityServerWrapper) KeyBlocked(ctx context.Context, req *sa
 	// All request checking is done in the method
 	return sas.inner.KeyBlocked(ctx, req)
 }
+
+func (sas StorageAuthorityServerWrapper) AddExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKey) (*corepb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.KeyID, req.MacKey, req.CreatedAt) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.AddExternalAccountKey(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKeyID) (*sapb.ExternalAccountKey, error) {
+	if core.IsAnyNilOrZero(req, req.KeyID) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.GetExternalAccountKey(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error) {
+	if req == nil {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.GetRateLimitOverrides(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetOrdersForAccount(ctx context.Context, req *sapb.GetByAccountRequest) (*sapb.OrderIDs, error) {
+	if core.IsAnyNilOrZero(req, req.RegistrationID, req.Limit) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.GetOrdersForAccount(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetSerialsForAccount(ctx context.Context, req *sapb.GetByAccountRequest) (*sapb.AccountSerials, error) {
+	if core.IsAnyNilOrZero(req, req.RegistrationID, req.Limit) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.GetSerialsForAccount(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetUnexpiredSerialsForName(ctx context.Context, req *sapb.GetUnexpiredSerialsForNameRequest) (*sapb.Serials, error) {
+	if core.IsAnyNilOrZero(req, req.Domain, req.Now) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.GetUnexpiredSerialsForName(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetUnexpiredSerialsForKey(ctx context.Context, req *sapb.GetUnexpiredSerialsForKeyRequest) (*sapb.Serials, error) {
+	if core.IsAnyNilOrZero(req, req.KeyHash, req.Now) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.GetUnexpiredSerialsForKey(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) AddRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverride) (*sapb.RateLimitOverrideID, error) {
+	if core.IsAnyNilOrZero(req, req.LimitName, req.Comment, req.Expires) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.AddRateLimitOverride(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) ExpireRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverrideID) (*corepb.Empty, error) {
+	if core.IsAnyNilOrZero(req, req.Id) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.ExpireRateLimitOverride(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetCertificateStatusByIssuer(ctx context.Context, req *sapb.IssuerSerial) (*corepb.CertificateStatus, error) {
+	if core.IsAnyNilOrZero(req, req.IssuerID, req.Serial) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.GetCertificateStatusByIssuer(ctx, req)
+}
+
+func (sas StorageAuthorityServerWrapper) GetRevokedCerts(ctx context.Context, req *sapb.GetRevokedCertsRequest) (*sapb.RevokedCerts, error) {
+	if core.IsAnyNilOrZero(req, req.IssuerID, req.ExpiresBefore) {
+		return nil, errIncompleteRequest
+	}
+
+	return sas.inner.GetRevokedCerts(ctx, req)
+}
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"math/big"
+	"net"
 	"strconv"
 	"strings"
 	"time"
@@ -43,6 +44,30 @@ type ProfileConfig struct {
 	Policies            []PolicyInformation
 	MaxValidityPeriod   cmd.ConfigDuration
 	MaxValidityBackdate cmd.ConfigDuration
+
+	// ValidityPeriod is how long certificates issued under this profile are
+	// valid for. If it is zero the CA's configured expiry is used instead. It
+	// must not be greater than MaxValidityPeriod.
+	ValidityPeriod cmd.ConfigDuration
+
+	// OmitOCSPThreshold, if non-zero, causes certificates whose validity period
+	// is less than it to be issued without an OCSP URL in their AIA extension.
+	// No OCSP responses are generated or refreshed for such certificates;
+	// relying parties are expected to rely on their short lifetime and on
+	// CRLs instead.
+	OmitOCSPThreshold cmd.ConfigDuration
+
+	// MaxNotBeforeDelay is how far in the future the NotBefore of a
+	// certificate issued under this profile may be, for orders which request a
+	// validity window starting later. If it is zero NotBefore may not be in the
+	// future.
+	MaxNotBeforeDelay cmd.ConfigDuration
+
+	// DisableCT causes certificates issued under this profile to be issued
+	// directly, without first issuing a precertificate and collecting SCTs
+	// for it. It is meant for private hierarchies whose certificates are
+	// never logged.
+	DisableCT bool
 }
 
 // PolicyInformation describes a policy
@@ -66,9 +91,54 @@ type IssuerConfig struct {
 	OCSPURL   string
 	CRLURL    string
 
+	// DisableCT causes certificates from this issuer to be issued directly
+	// under every profile, as for ProfileConfig.DisableCT. The CA refuses to
+	// start if it is set on only some of the issuers which can issue under a
+	// profile.
+	DisableCT bool
+
+	// Weight is how often this issuer is chosen relative to the others for
+	// the same key algorithm, under the CA's weighted, round-robin, and
+	// per-account issuer selection policies. An issuer with a zero weight is
+	// never chosen by them, unless every issuer for its algorithms has a zero
+	// weight, in which case they are all chosen equally often.
+	Weight int
+
+	// State is the stage of this issuer's lifecycle: "active" (the default),
+	// "ocsp-only", or "retired". See IssuerState.
+	State IssuerState
+
 	Location IssuerLoc
 }
 
+// IssuerState is the stage of an issuer's lifecycle, which determines what it
+// may be used to sign. An issuer is normally retired by first moving it to
+// StateOCSPOnly once a replacement is active, and then to StateRetired once
+// every certificate it issued has expired.
+type IssuerState string
+
+const (
+	// StateActive issuers sign certificates, OCSP responses, and CRLs.
+	StateActive = IssuerState("active")
+	// StateOCSPOnly issuers sign OCSP responses and CRLs for the certificates
+	// they have already issued, but no new certificates.
+	StateOCSPOnly = IssuerState("ocsp-only")
+	// StateRetired issuers sign nothing, but remain configured so that
+	// requests for them fail clearly rather than as unknown issuers.
+	StateRetired = IssuerState("retired")
+)
+
+// CanIssue returns true if an issuer in this state may sign certificates.
+func (s IssuerState) CanIssue() bool {
+	return s == StateActive
+}
+
+// CanSignRevocation returns true if an issuer in this state may sign OCSP
+// responses and CRLs.
+func (s IssuerState) CanSignRevocation() bool {
+	return s == StateActive || s == StateOCSPOnly
+}
+
 // IssuerLoc describes the on-disk location and parameters that an issuer
 // should use to retrieve its certificate and private key.
 // Only one of File, ConfigFile, or PKCS11 should be set.
@@ -202,6 +272,14 @@ type Profile struct {
 
 	maxBackdate time.Duration
 	maxValidity time.Duration
+	validity    time.Duration
+
+	omitOCSPThreshold time.Duration
+	maxNotBeforeDelay time.Duration
+
+	disableCT bool
+	weight    int
+	state     IssuerState
 }
 
 func parseOID(oidStr string) (asn1.ObjectIdentifier, error) {
@@ -244,6 +322,31 @@ func NewProfile(profileConfig ProfileConfig, issuerConfig IssuerConfig) (*Profil
 		ocspURL:           issuerConfig.OCSPURL,
 		maxBackdate:       profileConfig.MaxValidityBackdate.Duration,
 		maxValidity:       profileConfig.MaxValidityPeriod.Duration,
+		validity:          profileConfig.ValidityPeriod.Duration,
+		omitOCSPThreshold: profileConfig.OmitOCSPThreshold.Duration,
+		maxNotBeforeDelay: profileConfig.MaxNotBeforeDelay.Duration,
+		disableCT:         profileConfig.DisableCT || issuerConfig.DisableCT,
+		weight:            issuerConfig.Weight,
+		state:             issuerConfig.State,
+	}
+	if sp.validity < 0 || sp.validity > sp.maxValidity {
+		return nil, fmt.Errorf("validity period %s is not between zero and the maximum validity period %s", sp.validity, sp.maxValidity)
+	}
+	if sp.omitOCSPThreshold < 0 {
+		return nil, fmt.Errorf("OCSP omission threshold %s must not be negative", sp.omitOCSPThreshold)
+	}
+	if sp.maxNotBeforeDelay < 0 {
+		return nil, fmt.Errorf("maximum NotBefore delay %s must not be negative", sp.maxNotBeforeDelay)
+	}
+	if sp.weight < 0 {
+		return nil, fmt.Errorf("issuer weight %d must not be negative", sp.weight)
+	}
+	switch sp.state {
+	case "":
+		sp.state = StateActive
+	case StateActive, StateOCSPOnly, StateRetired:
+	default:
+		return nil, fmt.Errorf("unknown issuer state %q", sp.state)
 	}
 	if len(profileConfig.Policies) > 0 {
 		var policies []policyasn1.PolicyInformation
@@ -298,6 +401,10 @@ func (p *Profile) requestValid(clk clock.Clock, req *IssuanceRequest) error {
 		return errors.New("must-staple extension cannot be included")
 	}
 
+	if req.IncludeMustStaple && p.OmitsOCSP(req.NotAfter.Sub(req.NotBefore)) {
+		return errors.New("must-staple extension cannot be included in certificates without an OCSP URL")
+	}
+
 	if !p.allowCTPoison && req.IncludeCTPoison {
 		return errors.New("ct poison extension cannot be included")
 	}
@@ -310,6 +417,10 @@ func (p *Profile) requestValid(clk clock.Clock, req *IssuanceRequest) error {
 		return errors.New("cannot include both ct poison and sct list extensions")
 	}
 
+	if p.disableCT && (req.IncludeCTPoison || req.SCTList != nil) {
+		return errors.New("ct extensions cannot be included when ct is disabled")
+	}
+
 	if !p.allowCommonName && req.CommonName != "" {
 		return errors.New("common name cannot be included")
 	}
@@ -325,8 +436,11 @@ func (p *Profile) requestValid(clk clock.Clock, req *IssuanceRequest) error {
 	if backdatedBy > p.maxBackdate {
 		return fmt.Errorf("NotBefore is backdated more than the maximum allowed period (%s>%s)", backdatedBy, p.maxBackdate)
 	}
-	if backdatedBy < 0 {
-		return errors.New("NotBefore is in the future")
+	if delayedBy := -backdatedBy; delayedBy > p.maxNotBeforeDelay {
+		if p.maxNotBeforeDelay == 0 {
+			return errors.New("NotBefore is in the future")
+		}
+		return fmt.Errorf("NotBefore is further in the future than the maximum allowed delay (%s>%s)", delayedBy, p.maxNotBeforeDelay)
 	}
 
 	if len(req.Serial) > 20 || len(req.Serial) < 8 {
@@ -336,6 +450,35 @@ func (p *Profile) requestValid(clk clock.Clock, req *IssuanceRequest) error {
 	return nil
 }
 
+// ValidityPeriod returns how long certificates issued under this profile
+// should be valid for, or zero if the profile doesn't specify.
+func (p *Profile) ValidityPeriod() time.Duration {
+	return p.validity
+}
+
+// OmitsOCSP returns true if certificates with the given validity period are
+// issued under this profile without an OCSP URL, in which case no OCSP
+// responses should be produced for them.
+func (p *Profile) OmitsOCSP(validity time.Duration) bool {
+	return validity < p.omitOCSPThreshold
+}
+
+// DisablesCT returns true if certificates issued under this profile are
+// issued directly, rather than by way of a precertificate.
+func (p *Profile) DisablesCT() bool {
+	return p.disableCT
+}
+
+// Weight returns the issuer's weight for the CA's issuer selection policies.
+func (p *Profile) Weight() int {
+	return p.weight
+}
+
+// State returns the issuer's lifecycle state.
+func (p *Profile) State() IssuerState {
+	return p.state
+}
+
 var defaultEKU = []x509.ExtKeyUsage{
 	x509.ExtKeyUsageServerAuth,
 	x509.ExtKeyUsageClientAuth,
@@ -552,8 +695,9 @@ type IssuanceRequest struct {
 	NotBefore time.Time
 	NotAfter  time.Time
 
-	CommonName string
-	DNSNames   []string
+	CommonName  string
+	DNSNames    []string
+	IPAddresses []net.IP
 
 	IncludeMustStaple bool
 	IncludeCTPoison   bool
@@ -576,11 +720,15 @@ func (i *Issuer) Issue(req *IssuanceRequest) ([]byte, error) {
 
 	// populate template from the issuance request
 	template.NotBefore, template.NotAfter = req.NotBefore, req.NotAfter
+	if i.Profile.OmitsOCSP(req.NotAfter.Sub(req.NotBefore)) {
+		template.OCSPServer = nil
+	}
 	template.SerialNumber = big.NewInt(0).SetBytes(req.Serial)
 	if req.CommonName != "" {
 		template.Subject.CommonName = req.CommonName
 	}
 	template.DNSNames = req.DNSNames
+	template.IPAddresses = req.IPAddresses
 	template.AuthorityKeyId = i.Cert.SubjectKeyId
 	skid, err := generateSKID(req.PublicKey)
 	if err != nil {
@@ -650,6 +798,7 @@ func RequestFromPrecert(precert *x509.Certificate, scts []ct.SignedCertificateTi
 		NotAfter:          precert.NotAfter,
 		CommonName:        precert.Subject.CommonName,
 		DNSNames:          precert.DNSNames,
+		IPAddresses:       precert.IPAddresses,
 		IncludeMustStaple: ContainsMustStaple(precert.Extensions),
 		SCTList:           scts,
 	}, nil
@@ -13,6 +13,7 @@ import (
 	"encoding/asn1"
 	"io/ioutil"
 	"math/big"
+	"net"
 	"os"
 	"testing"
 	"time"
@@ -108,6 +109,7 @@ func TestNewProfilePolicies(t *testing.T) {
 		},
 		maxBackdate: time.Hour,
 		maxValidity: time.Hour,
+		state:       StateActive,
 	})
 	var policies []policyasn1.PolicyInformation
 	_, err = asn1.Unmarshal(profile.policies.Value, &policies)
@@ -161,6 +163,89 @@ func TestNewProfileUnknownQualifierType(t *testing.T) {
 	test.AssertEquals(t, err.Error(), "unknown qualifier type: asd")
 }
 
+func TestNewProfileValidityPeriod(t *testing.T) {
+	config := defaultProfileConfig()
+	config.ValidityPeriod = cmd.ConfigDuration{Duration: 30 * time.Minute}
+	profile, err := NewProfile(config, defaultIssuerConfig())
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.AssertEquals(t, profile.ValidityPeriod(), 30*time.Minute)
+
+	config.ValidityPeriod = cmd.ConfigDuration{Duration: config.MaxValidityPeriod.Duration + time.Second}
+	_, err = NewProfile(config, defaultIssuerConfig())
+	test.AssertError(t, err, "NewProfile didn't fail with a validity period longer than the maximum")
+}
+
+func TestNewProfileOmitOCSPThreshold(t *testing.T) {
+	profile, err := NewProfile(defaultProfileConfig(), defaultIssuerConfig())
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.Assert(t, !profile.OmitsOCSP(time.Minute), "profile without a threshold should never omit OCSP")
+
+	config := defaultProfileConfig()
+	config.OmitOCSPThreshold = cmd.ConfigDuration{Duration: 30 * time.Minute}
+	profile, err = NewProfile(config, defaultIssuerConfig())
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.Assert(t, profile.OmitsOCSP(29*time.Minute), "certificates shorter than the threshold should omit OCSP")
+	test.Assert(t, !profile.OmitsOCSP(30*time.Minute), "certificates as long as the threshold should include OCSP")
+
+	config.OmitOCSPThreshold = cmd.ConfigDuration{Duration: -time.Minute}
+	_, err = NewProfile(config, defaultIssuerConfig())
+	test.AssertError(t, err, "NewProfile didn't fail with a negative OCSP omission threshold")
+}
+
+func TestNewProfileDisableCT(t *testing.T) {
+	profile, err := NewProfile(defaultProfileConfig(), defaultIssuerConfig())
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.Assert(t, !profile.DisablesCT(), "profile should use CT by default")
+
+	config := defaultProfileConfig()
+	config.DisableCT = true
+	profile, err = NewProfile(config, defaultIssuerConfig())
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.Assert(t, profile.DisablesCT(), "profile with CT disabled should disable CT")
+
+	issuerConfig := defaultIssuerConfig()
+	issuerConfig.DisableCT = true
+	profile, err = NewProfile(defaultProfileConfig(), issuerConfig)
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.Assert(t, profile.DisablesCT(), "profile for an issuer with CT disabled should disable CT")
+}
+
+func TestNewProfileWeight(t *testing.T) {
+	issuerConfig := defaultIssuerConfig()
+	issuerConfig.Weight = 3
+	profile, err := NewProfile(defaultProfileConfig(), issuerConfig)
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.AssertEquals(t, profile.Weight(), 3)
+
+	issuerConfig.Weight = -1
+	_, err = NewProfile(defaultProfileConfig(), issuerConfig)
+	test.AssertError(t, err, "NewProfile didn't fail with a negative weight")
+}
+
+func TestNewProfileState(t *testing.T) {
+	issuerConfig := defaultIssuerConfig()
+	profile, err := NewProfile(defaultProfileConfig(), issuerConfig)
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.AssertEquals(t, profile.State(), StateActive)
+	test.Assert(t, profile.State().CanIssue(), "active issuer can't issue")
+
+	issuerConfig.State = StateOCSPOnly
+	profile, err = NewProfile(defaultProfileConfig(), issuerConfig)
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.Assert(t, !profile.State().CanIssue(), "OCSP-only issuer can issue")
+	test.Assert(t, profile.State().CanSignRevocation(), "OCSP-only issuer can't sign OCSP")
+
+	issuerConfig.State = StateRetired
+	profile, err = NewProfile(defaultProfileConfig(), issuerConfig)
+	test.AssertNotError(t, err, "NewProfile failed")
+	test.Assert(t, !profile.State().CanIssue(), "retired issuer can issue")
+	test.Assert(t, !profile.State().CanSignRevocation(), "retired issuer can sign OCSP")
+
+	issuerConfig.State = "dormant"
+	_, err = NewProfile(defaultProfileConfig(), issuerConfig)
+	test.AssertError(t, err, "NewProfile didn't fail with an unknown state")
+}
+
 func TestRequestValid(t *testing.T) {
 	fc := clock.NewFake()
 	fc.Add(time.Hour * 24)
@@ -199,6 +284,21 @@ func TestRequestValid(t *testing.T) {
 			},
 			expectedError: "must-staple extension cannot be included",
 		},
+		{
+			name: "must staple without ocsp",
+			profile: &Profile{
+				useForECDSALeaves: true,
+				allowMustStaple:   true,
+				omitOCSPThreshold: time.Hour * 24,
+			},
+			request: &IssuanceRequest{
+				PublicKey:         &ecdsa.PublicKey{},
+				IncludeMustStaple: true,
+				NotBefore:         fc.Now(),
+				NotAfter:          fc.Now().Add(time.Hour),
+			},
+			expectedError: "must-staple extension cannot be included in certificates without an OCSP URL",
+		},
 		{
 			name: "ct poison not allowed",
 			profile: &Profile{
@@ -235,6 +335,19 @@ func TestRequestValid(t *testing.T) {
 			},
 			expectedError: "cannot include both ct poison and sct list extensions",
 		},
+		{
+			name: "ct poison with ct disabled",
+			profile: &Profile{
+				useForECDSALeaves: true,
+				allowCTPoison:     true,
+				disableCT:         true,
+			},
+			request: &IssuanceRequest{
+				PublicKey:       &ecdsa.PublicKey{},
+				IncludeCTPoison: true,
+			},
+			expectedError: "ct extensions cannot be included when ct is disabled",
+		},
 		{
 			name: "common name not allowed",
 			profile: &Profile{
@@ -299,6 +412,21 @@ func TestRequestValid(t *testing.T) {
 			},
 			expectedError: "NotBefore is in the future",
 		},
+		{
+			name: "validity is forward dated more than max",
+			profile: &Profile{
+				useForECDSALeaves: true,
+				maxValidity:       time.Hour * 2,
+				maxBackdate:       time.Hour,
+				maxNotBeforeDelay: time.Hour,
+			},
+			request: &IssuanceRequest{
+				PublicKey: &ecdsa.PublicKey{},
+				NotBefore: fc.Now().Add(time.Hour * 2),
+				NotAfter:  fc.Now().Add(time.Hour * 3),
+			},
+			expectedError: "NotBefore is further in the future than the maximum allowed delay (2h0m0s>1h0m0s)",
+		},
 		{
 			name: "serial too short",
 			profile: &Profile{
@@ -339,6 +467,20 @@ func TestRequestValid(t *testing.T) {
 				Serial:    []byte{1, 2, 3, 4, 5, 6, 7, 8},
 			},
 		},
+		{
+			name: "good, forward dated within max",
+			profile: &Profile{
+				useForECDSALeaves: true,
+				maxValidity:       time.Hour * 2,
+				maxNotBeforeDelay: time.Hour * 24,
+			},
+			request: &IssuanceRequest{
+				PublicKey: &ecdsa.PublicKey{},
+				NotBefore: fc.Now().Add(time.Hour * 12),
+				NotAfter:  fc.Now().Add(time.Hour * 13),
+				Serial:    []byte{1, 2, 3, 4, 5, 6, 7, 8},
+			},
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
@@ -582,6 +724,33 @@ func TestIssueRSA(t *testing.T) {
 	test.AssertEquals(t, cert.KeyUsage, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment)
 }
 
+func TestIssueIPAddresses(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Now())
+	linter, _ := lint.NewLinter(
+		issuerSigner,
+		[]string{"w_ct_sct_policy_count_unsatisfied"},
+	)
+	signer, err := NewIssuer(issuerCert, issuerSigner, defaultProfile(), linter, fc)
+	test.AssertNotError(t, err, "NewIssuer failed")
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+	ips := []net.IP{net.ParseIP("64.112.117.122").To4(), net.ParseIP("2602:80a:6000:abad:cafe::1")}
+	certBytes, err := signer.Issue(&IssuanceRequest{
+		PublicKey:   pk.Public(),
+		Serial:      []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		DNSNames:    []string{"example.com"},
+		IPAddresses: ips,
+		NotBefore:   fc.Now(),
+		NotAfter:    fc.Now().Add(time.Hour),
+	})
+	test.AssertNotError(t, err, "Issue failed")
+	cert, err := x509.ParseCertificate(certBytes)
+	test.AssertNotError(t, err, "failed to parse certificate")
+	test.AssertDeepEquals(t, cert.DNSNames, []string{"example.com"})
+	test.AssertDeepEquals(t, cert.IPAddresses, ips)
+}
+
 func TestIssueCTPoison(t *testing.T) {
 	fc := clock.NewFake()
 	fc.Set(time.Now())
@@ -677,6 +846,48 @@ func TestIssueMustStaple(t *testing.T) {
 	test.AssertDeepEquals(t, cert.Extensions[8], mustStapleExt)
 }
 
+func TestIssueOmitOCSP(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Now())
+	linter, _ := lint.NewLinter(
+		issuerSigner,
+		[]string{"w_ct_sct_policy_count_unsatisfied", "e_sub_cert_aia_does_not_contain_ocsp_url"},
+	)
+	config := defaultProfileConfig()
+	config.OmitOCSPThreshold = cmd.ConfigDuration{Duration: 30 * time.Minute}
+	profile, err := NewProfile(config, defaultIssuerConfig())
+	test.AssertNotError(t, err, "NewProfile failed")
+	signer, err := NewIssuer(issuerCert, issuerSigner, profile, linter, fc)
+	test.AssertNotError(t, err, "NewIssuer failed")
+	pk, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "failed to generate test key")
+
+	certBytes, err := signer.Issue(&IssuanceRequest{
+		PublicKey: pk.Public(),
+		Serial:    []byte{1, 2, 3, 4, 5, 6, 7, 8},
+		DNSNames:  []string{"example.com"},
+		NotBefore: fc.Now(),
+		NotAfter:  fc.Now().Add(10 * time.Minute),
+	})
+	test.AssertNotError(t, err, "Issue failed")
+	cert, err := x509.ParseCertificate(certBytes)
+	test.AssertNotError(t, err, "failed to parse certificate")
+	test.AssertEquals(t, len(cert.OCSPServer), 0)
+	test.AssertDeepEquals(t, cert.IssuingCertificateURL, []string{"http://issuer-url"})
+
+	certBytes, err = signer.Issue(&IssuanceRequest{
+		PublicKey: pk.Public(),
+		Serial:    []byte{1, 2, 3, 4, 5, 6, 7, 9},
+		DNSNames:  []string{"example.com"},
+		NotBefore: fc.Now(),
+		NotAfter:  fc.Now().Add(time.Hour),
+	})
+	test.AssertNotError(t, err, "Issue failed")
+	cert, err = x509.ParseCertificate(certBytes)
+	test.AssertNotError(t, err, "failed to parse certificate")
+	test.AssertDeepEquals(t, cert.OCSPServer, []string{"http://ocsp-url"})
+}
+
 func TestIssueBadLint(t *testing.T) {
 	fc := clock.NewFake()
 	fc.Set(time.Now())
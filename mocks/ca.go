@@ -44,7 +44,32 @@ func (ca *MockCA) IssueCertificateForPrecertificate(ctx context.Context, req *ca
 	}, nil
 }
 
+// IssueCertificate is a mock
+func (ca *MockCA) IssueCertificate(ctx context.Context, _ *capb.IssueCertificateRequest, _ ...grpc.CallOption) (*corepb.Certificate, error) {
+	if ca.PEM == nil {
+		return nil, fmt.Errorf("MockCA's PEM field must be set before calling IssueCertificate")
+	}
+	block, _ := pem.Decode(ca.PEM)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return &corepb.Certificate{
+		Der:            cert.Raw,
+		RegistrationID: 1,
+		Serial:         "mock",
+		Digest:         "mock",
+		Issued:         1,
+		Expires:        1,
+	}, nil
+}
+
 // GenerateOCSP is a mock
 func (ca *MockCA) GenerateOCSP(ctx context.Context, req *capb.GenerateOCSPRequest, _ ...grpc.CallOption) (*capb.OCSPResponse, error) {
 	return nil, nil
 }
+
+// GetIssuers is a mock
+func (ca *MockCA) GetIssuers(ctx context.Context, _ *corepb.Empty, _ ...grpc.CallOption) (*capb.Issuers, error) {
+	return &capb.Issuers{}, nil
+}
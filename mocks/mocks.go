@@ -333,6 +333,11 @@ func (sa *StorageAuthority) AddPrecertificate(ctx context.Context, req *sapb.Add
 	return
 }
 
+// AddCertificateWithoutPrecertificate is a mock
+func (sa *StorageAuthority) AddCertificateWithoutPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (empty *corepb.Empty, err error) {
+	return
+}
+
 // AddSerial is a mock
 func (sa *StorageAuthority) AddSerial(ctx context.Context, req *sapb.AddSerialRequest) (empty *corepb.Empty, err error) {
 	return
@@ -526,6 +531,12 @@ func (sa *StorageAuthority) GetOrder(_ context.Context, req *sapb.OrderRequest)
 		validOrder.Created = sa.clk.Now().Unix()
 	}
 
+	// Order 10 is still being finalized
+	if req.Id == 10 {
+		validOrder.Status = string(core.StatusProcessing)
+		validOrder.CertificateSerial = ""
+	}
+
 	return validOrder, nil
 }
 
@@ -577,6 +588,11 @@ func (sa *StorageAuthority) CountInvalidAuthorizations2(ctx context.Context, req
 	return nil, nil
 }
 
+// CountInvalidAuthorizationsByName is a mock
+func (sa *StorageAuthority) CountInvalidAuthorizationsByName(ctx context.Context, req *sapb.CountInvalidAuthorizationsByNameRequest) (*sapb.CountByNames, error) {
+	return &sapb.CountByNames{}, nil
+}
+
 func (sa *StorageAuthority) GetValidAuthorizations2(ctx context.Context, req *sapb.GetValidAuthorizationsRequest) (*sapb.Authorizations, error) {
 	if req.RegistrationID != 1 && req.RegistrationID != 5 && req.RegistrationID != 4 {
 		return &sapb.Authorizations{}, nil
@@ -690,6 +706,88 @@ func (sa *StorageAuthority) KeyBlocked(ctx context.Context, req *sapb.KeyBlocked
 	return &sapb.Exists{Exists: false}, nil
 }
 
+// AddExternalAccountKey is a mock
+func (sa *StorageAuthority) AddExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKey) (*corepb.Empty, error) {
+	return &corepb.Empty{}, nil
+}
+
+// GetExternalAccountKey is a mock
+func (sa *StorageAuthority) GetExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKeyID) (*sapb.ExternalAccountKey, error) {
+	if req.KeyID == "eab-key-id" {
+		return &sapb.ExternalAccountKey{
+			KeyID:     req.KeyID,
+			MacKey:    []byte("this is a very secret eab key!!!"),
+			CreatedAt: sa.clk.Now().UnixNano(),
+		}, nil
+	}
+	if req.KeyID == "broken-key-id" {
+		return nil, errors.New("external account key lookup is broken")
+	}
+	return nil, berrors.NotFoundError("no external account key with ID %q", req.KeyID)
+}
+
+// GetRateLimitOverrides is a mock
+func (sa *StorageAuthority) GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error) {
+	return &sapb.RateLimitOverrides{}, nil
+}
+
+// GetOrdersForAccount is a mock which returns up to five orders for
+// registration 1, and none for any other registration.
+func (sa *StorageAuthority) GetOrdersForAccount(ctx context.Context, req *sapb.GetByAccountRequest) (*sapb.OrderIDs, error) {
+	resp := &sapb.OrderIDs{}
+	if req.RegistrationID != 1 {
+		return resp, nil
+	}
+	for id := req.AfterID + 1; id <= 5 && int64(len(resp.Ids)) < req.Limit; id++ {
+		resp.Ids = append(resp.Ids, id)
+	}
+	return resp, nil
+}
+
+// GetSerialsForAccount is a mock which returns up to five certificate serials
+// for registration 1, and none for any other registration.
+func (sa *StorageAuthority) GetSerialsForAccount(ctx context.Context, req *sapb.GetByAccountRequest) (*sapb.AccountSerials, error) {
+	resp := &sapb.AccountSerials{}
+	if req.RegistrationID != 1 {
+		return resp, nil
+	}
+	for id := req.AfterID + 1; id <= 5 && int64(len(resp.Serials)) < req.Limit; id++ {
+		resp.Serials = append(resp.Serials, fmt.Sprintf("%036x", id))
+		resp.LastID = id
+	}
+	return resp, nil
+}
+
+// GetUnexpiredSerialsForName is a mock
+func (sa *StorageAuthority) GetUnexpiredSerialsForName(ctx context.Context, req *sapb.GetUnexpiredSerialsForNameRequest) (*sapb.Serials, error) {
+	return &sapb.Serials{}, nil
+}
+
+// GetUnexpiredSerialsForKey is a mock
+func (sa *StorageAuthority) GetUnexpiredSerialsForKey(ctx context.Context, req *sapb.GetUnexpiredSerialsForKeyRequest) (*sapb.Serials, error) {
+	return &sapb.Serials{}, nil
+}
+
+// AddRateLimitOverride is a mock
+func (sa *StorageAuthority) AddRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverride) (*sapb.RateLimitOverrideID, error) {
+	return &sapb.RateLimitOverrideID{Id: 1}, nil
+}
+
+// ExpireRateLimitOverride is a mock
+func (sa *StorageAuthority) ExpireRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverrideID) (*corepb.Empty, error) {
+	return &corepb.Empty{}, nil
+}
+
+// GetCertificateStatusByIssuer is a mock
+func (sa *StorageAuthority) GetCertificateStatusByIssuer(ctx context.Context, req *sapb.IssuerSerial) (*corepb.CertificateStatus, error) {
+	return nil, berrors.NotFoundError("no certificate status for issuer %d and serial %q", req.IssuerID, req.Serial)
+}
+
+// GetRevokedCerts is a mock
+func (sa *StorageAuthority) GetRevokedCerts(ctx context.Context, req *sapb.GetRevokedCertsRequest) (*sapb.RevokedCerts, error) {
+	return &sapb.RevokedCerts{}, nil
+}
+
 // Publisher is a mock
 type PublisherClient struct {
 	// empty
@@ -31,6 +31,7 @@ type AuthorityImpl struct {
 	blocklist              map[string]bool
 	exactBlocklist         map[string]bool
 	wildcardExactBlocklist map[string]bool
+	allowedIPRanges        []*net.IPNet
 	blocklistMu            sync.RWMutex
 
 	enabledChallenges map[core.AcmeChallenge]bool
@@ -71,6 +72,12 @@ type blockedNamesPolicy struct {
 	// time above and beyond the high-risk domains. Managing these entries separately
 	// from HighRiskBlockedNames makes it easier to vet changes accurately.
 	AdminBlockedNames []string `yaml:"AdminBlockedNames"`
+
+	// AllowedIPRanges is a list of IP address ranges in CIDR notation (e.g.
+	// `10.0.0.0/8` or `2001:db8::/32`). Issuance for IP address identifiers is
+	// forbidden unless the address falls within one of these ranges, so an
+	// empty list disables IP address issuance entirely.
+	AllowedIPRanges []string `yaml:"AllowedIPRanges"`
 }
 
 // SetHostnamePolicyFile will load the given policy file, returning error if it
@@ -139,10 +146,19 @@ func (pa *AuthorityImpl) processHostnamePolicy(policy blockedNamesPolicy) error
 		// wildcardNameMap to block issuance for `*.`+parts[1]
 		wildcardNameMap[parts[1]] = true
 	}
+	var ipRanges []*net.IPNet
+	for _, v := range policy.AllowedIPRanges {
+		_, ipNet, err := net.ParseCIDR(v)
+		if err != nil {
+			return fmt.Errorf("Malformed AllowedIPRanges entry %q: %s", v, err)
+		}
+		ipRanges = append(ipRanges, ipNet)
+	}
 	pa.blocklistMu.Lock()
 	pa.blocklist = nameMap
 	pa.exactBlocklist = exactNameMap
 	pa.wildcardExactBlocklist = wildcardNameMap
+	pa.allowedIPRanges = ipRanges
 	pa.blocklistMu.Unlock()
 	return nil
 }
@@ -199,6 +215,9 @@ var (
 	errMalformedWildcard    = berrors.MalformedError("Domain name contains an invalid wildcard. A wildcard is only permitted before the first dot in a domain name")
 	errICANNTLDWildcard     = berrors.MalformedError("Domain name is a wildcard for an ICANN TLD")
 	errWildcardNotSupported = berrors.MalformedError("Wildcard domain names are not supported")
+	errInvalidIP            = berrors.MalformedError("IP address is invalid")
+	errNonCanonicalIP       = berrors.MalformedError("IP address is not in canonical form")
+	errIPForbidden          = berrors.RejectedIdentifierError("The ACME server refuses to issue a certificate for this IP address, because it is forbidden by policy")
 )
 
 // ValidDomain checks that a domain isn't:
@@ -336,9 +355,8 @@ func ValidEmail(address string) error {
 // identifier. It expects domains in id to be lowercase to prevent mismatched
 // cases breaking queries.
 //
-// We place several criteria on identifiers we are willing to issue for:
+// We place several criteria on DNS identifiers we are willing to issue for:
 //
-//  * MUST self-identify as DNS identifiers
 //  * MUST contain only bytes in the DNS hostname character set
 //  * MUST NOT have more than maxLabels labels
 //  * MUST follow the DNS hostname syntax rules in RFC 1035 and RFC 2181
@@ -350,9 +368,19 @@ func ValidEmail(address string) error {
 //  * MUST NOT be a label-wise suffix match for a name on the block list,
 //    where comparison is case-independent (normalized to lower case)
 //
+// And on IP identifiers:
+//
+//  * MUST be an IPv4 or IPv6 address in the canonical textual form
+//  * MUST fall within one of the policy's allowed IP ranges
+//
+// Identifiers of any other type are rejected.
+//
 // If WillingToIssue returns an error, it will be of type MalformedRequestError
 // or RejectedIdentifierError
 func (pa *AuthorityImpl) WillingToIssue(id identifier.ACMEIdentifier) error {
+	if id.Type == identifier.IP {
+		return pa.willingToIssueIP(id.Value)
+	}
 	if id.Type != identifier.DNS {
 		return errInvalidIdentifier
 	}
@@ -370,6 +398,36 @@ func (pa *AuthorityImpl) WillingToIssue(id identifier.ACMEIdentifier) error {
 	return nil
 }
 
+// willingToIssueIP checks that the value of an IP identifier is a canonically
+// formatted IP address within one of the allowed IP ranges.
+func (pa *AuthorityImpl) willingToIssueIP(value string) error {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return errInvalidIP
+	}
+	// RFC 8738 requires the identifier value to be in the textual form of RFC
+	// 1123 or RFC 5952, which is what net.IP's String method produces. Rejecting
+	// anything else means each address has exactly one representation in
+	// orders, authorizations, and rate limits.
+	if ip.String() != value {
+		return errNonCanonicalIP
+	}
+
+	pa.blocklistMu.RLock()
+	defer pa.blocklistMu.RUnlock()
+
+	if pa.blocklist == nil {
+		return fmt.Errorf("Hostname policy not yet loaded.")
+	}
+
+	for _, ipRange := range pa.allowedIPRanges {
+		if ipRange.Contains(ip) {
+			return nil
+		}
+	}
+	return errIPForbidden
+}
+
 // WillingToIssueWildcards is an extension of WillingToIssue that accepts DNS
 // identifiers for well formed wildcard domains in addition to regular
 // identifiers.
@@ -378,7 +436,7 @@ func (pa *AuthorityImpl) WillingToIssue(id identifier.ACMEIdentifier) error {
 // returned. In addition to the regular WillingToIssue checks this function
 // also checks each wildcard identifier to enforce that:
 //
-// * The identifier is a DNS type identifier
+// * The identifier is a DNS or IP type identifier
 // * There is at most one `*` wildcard character
 // * That the wildcard character is the leftmost label
 // * That the wildcard label is not immediately adjacent to a top level ICANN
@@ -436,7 +494,11 @@ func (pa *AuthorityImpl) WillingToIssueWildcards(idents []identifier.ACMEIdentif
 // willingToIssueWildcard vets a single identifier. It is used by
 // the plural WillingToIssueWildcards when evaluating a list of identifiers.
 func (pa *AuthorityImpl) willingToIssueWildcard(ident identifier.ACMEIdentifier) error {
-	// We're only willing to process DNS identifiers
+	// IP identifiers can't be wildcards, so they only need the regular checks.
+	if ident.Type == identifier.IP {
+		return pa.WillingToIssue(ident)
+	}
+	// Otherwise we're only willing to process DNS identifiers
 	if ident.Type != identifier.DNS {
 		return errInvalidIdentifier
 	}
@@ -532,23 +594,46 @@ func (pa *AuthorityImpl) checkHostLists(domain string) error {
 
 // ChallengesFor makes a decision of what challenges are acceptable for
 // the given identifier.
-func (pa *AuthorityImpl) ChallengesFor(identifier identifier.ACMEIdentifier) ([]core.Challenge, error) {
+func (pa *AuthorityImpl) ChallengesFor(ident identifier.ACMEIdentifier) ([]core.Challenge, error) {
 	challenges := []core.Challenge{}
 
 	token := core.NewToken()
 
-	// If the identifier is for a DNS wildcard name we only
-	// provide a DNS-01 challenge as a matter of CA policy.
-	if strings.HasPrefix(identifier.Value, "*.") {
-		// We must have the DNS-01 challenge type enabled to create challenges for
-		// a wildcard identifier per LE policy.
-		if !pa.ChallengeTypeEnabled(core.ChallengeTypeDNS01) {
+	// There is no DNS zone in which to provision a DNS-01 challenge for an IP
+	// address, so per RFC 8738 IP identifiers only get the challenge types which
+	// connect to the address itself.
+	if ident.Type == identifier.IP {
+		if pa.ChallengeTypeEnabled(core.ChallengeTypeHTTP01) {
+			challenges = append(challenges, core.HTTPChallenge01(token))
+		}
+
+		if pa.ChallengeTypeEnabled(core.ChallengeTypeTLSALPN01) {
+			challenges = append(challenges, core.TLSALPNChallenge01(token))
+		}
+
+		if len(challenges) == 0 {
 			return nil, fmt.Errorf(
-				"Challenges requested for wildcard identifier but DNS-01 " +
-					"challenge type is not enabled")
+				"Challenges requested for IP identifier but neither HTTP-01 " +
+					"nor TLS-ALPN-01 challenge type is enabled")
+		}
+	} else if strings.HasPrefix(ident.Value, "*.") {
+		// If the identifier is for a DNS wildcard name we only
+		// provide DNS-based challenges as a matter of CA policy.
+		// We must have the DNS-01 or DNS-ACCOUNT-01 challenge type enabled to
+		// create challenges for a wildcard identifier per LE policy.
+		if pa.ChallengeTypeEnabled(core.ChallengeTypeDNS01) {
+			challenges = append(challenges, core.DNSChallenge01(token))
+		}
+
+		if pa.ChallengeTypeEnabled(core.ChallengeTypeDNSAccount01) {
+			challenges = append(challenges, core.DNSAccountChallenge01(token))
+		}
+
+		if len(challenges) == 0 {
+			return nil, fmt.Errorf(
+				"Challenges requested for wildcard identifier but neither DNS-01 " +
+					"nor DNS-ACCOUNT-01 challenge type is enabled")
 		}
-		// Only provide a DNS-01-Wildcard challenge
-		challenges = []core.Challenge{core.DNSChallenge01(token)}
 	} else {
 		// Otherwise we collect up challenges based on what is enabled.
 		if pa.ChallengeTypeEnabled(core.ChallengeTypeHTTP01) {
@@ -562,6 +647,10 @@ func (pa *AuthorityImpl) ChallengesFor(identifier identifier.ACMEIdentifier) ([]
 		if pa.ChallengeTypeEnabled(core.ChallengeTypeDNS01) {
 			challenges = append(challenges, core.DNSChallenge01(token))
 		}
+
+		if pa.ChallengeTypeEnabled(core.ChallengeTypeDNSAccount01) {
+			challenges = append(challenges, core.DNSAccountChallenge01(token))
+		}
 	}
 
 	// We shuffle the challenges to prevent ACME clients from relying on the
@@ -2,6 +2,7 @@ package policy
 
 import (
 	"io/ioutil"
+	"net"
 	"os"
 	"testing"
 
@@ -154,7 +155,7 @@ func TestWillingToIssue(t *testing.T) {
 	test.AssertNotError(t, err, "Couldn't load rules")
 
 	// Test for invalid identifier type
-	ident := identifier.ACMEIdentifier{Type: "ip", Value: "example.com"}
+	ident := identifier.ACMEIdentifier{Type: "email", Value: "example.com"}
 	err = pa.WillingToIssue(ident)
 	if err != errInvalidIdentifier {
 		t.Error("Identifier was not correctly forbidden: ", ident)
@@ -207,6 +208,56 @@ func TestWillingToIssue(t *testing.T) {
 	}
 }
 
+func TestWillingToIssueIP(t *testing.T) {
+	pa := paImpl(t)
+
+	err := pa.WillingToIssue(identifier.IPIdentifier(net.ParseIP("10.0.0.1")))
+	test.AssertError(t, err, "WillingToIssue succeeded before the policy was loaded")
+
+	err = pa.processHostnamePolicy(blockedNamesPolicy{
+		HighRiskBlockedNames: []string{"zombo.gov.us"},
+		ExactBlockedNames:    []string{"www.zombo.gov.us"},
+		AllowedIPRanges:      []string{"10.0.0.0/8", "2001:db8::/32"},
+	})
+	test.AssertNotError(t, err, "Couldn't load policy")
+
+	testCases := []struct {
+		ip  string
+		err error
+	}{
+		{"10.0.0.1", nil},
+		{"10.255.255.255", nil},
+		{"2001:db8::1", nil},
+		{"11.0.0.1", errIPForbidden},
+		{"192.168.1.1", errIPForbidden},
+		{"2001:db9::1", errIPForbidden},
+		{"::ffff:10.0.0.1", errNonCanonicalIP},
+		{"2001:DB8::1", errNonCanonicalIP},
+		{"2001:db8:0:0:0:0:0:1", errNonCanonicalIP},
+		{"010.0.0.1", errInvalidIP},
+		{"10.0.0.0/8", errInvalidIP},
+		{"zombo.com", errInvalidIP},
+		{"", errInvalidIP},
+	}
+	for _, tc := range testCases {
+		err := pa.WillingToIssue(identifier.ACMEIdentifier{Type: identifier.IP, Value: tc.ip})
+		if err != tc.err {
+			t.Errorf("WillingToIssue(%q) = %v, expected %v", tc.ip, err, tc.err)
+		}
+		err = pa.WillingToIssueWildcards([]identifier.ACMEIdentifier{{Type: identifier.IP, Value: tc.ip}})
+		if (err == nil) != (tc.err == nil) {
+			t.Errorf("WillingToIssueWildcards(%q) = %v, expected error: %t", tc.ip, err, tc.err != nil)
+		}
+	}
+
+	err = pa.processHostnamePolicy(blockedNamesPolicy{
+		HighRiskBlockedNames: []string{"zombo.gov.us"},
+		ExactBlockedNames:    []string{"www.zombo.gov.us"},
+		AllowedIPRanges:      []string{"10.0.0.1"},
+	})
+	test.AssertError(t, err, "Loaded policy with malformed AllowedIPRanges entry")
+}
+
 func TestWillingToIssueWildcard(t *testing.T) {
 	bannedDomains := []string{
 		"zombo.gov.us",
@@ -404,7 +455,7 @@ func TestChallengesForWildcard(t *testing.T) {
 	test.AssertError(t, err, "ChallengesFor did not error for a wildcard ident "+
 		"when DNS-01 was disabled")
 	test.AssertEquals(t, err.Error(), "Challenges requested for wildcard "+
-		"identifier but DNS-01 challenge type is not enabled")
+		"identifier but neither DNS-01 nor DNS-ACCOUNT-01 challenge type is enabled")
 
 	// Try again with DNS-01 enabled. It should not error and
 	// should return only one DNS-01 type challenge
@@ -415,6 +466,52 @@ func TestChallengesForWildcard(t *testing.T) {
 		"unexpectedly")
 	test.AssertEquals(t, len(challenges), 1)
 	test.AssertEquals(t, challenges[0].Type, core.ChallengeTypeDNS01)
+
+	// With DNS-ACCOUNT-01 also enabled it should return both DNS challenge
+	// types, and still no HTTP-01 challenge.
+	enabledChallenges[core.ChallengeTypeDNSAccount01] = true
+	pa = mustConstructPA(t, enabledChallenges)
+	challenges, err = pa.ChallengesFor(wildcardIdent)
+	test.AssertNotError(t, err, "ChallengesFor errored for a wildcard ident "+
+		"unexpectedly")
+	test.AssertEquals(t, len(challenges), 2)
+	for _, chall := range challenges {
+		test.Assert(t, chall.Type == core.ChallengeTypeDNS01 || chall.Type == core.ChallengeTypeDNSAccount01,
+			"Non-DNS challenge returned for wildcard ident")
+	}
+
+	// With only DNS-ACCOUNT-01 enabled it should return only that.
+	enabledChallenges[core.ChallengeTypeDNS01] = false
+	pa = mustConstructPA(t, enabledChallenges)
+	challenges, err = pa.ChallengesFor(wildcardIdent)
+	test.AssertNotError(t, err, "ChallengesFor errored for a wildcard ident "+
+		"unexpectedly")
+	test.AssertEquals(t, len(challenges), 1)
+	test.AssertEquals(t, challenges[0].Type, core.ChallengeTypeDNSAccount01)
+}
+
+func TestChallengesForIP(t *testing.T) {
+	ipIdent := identifier.IPIdentifier(net.ParseIP("10.0.0.1"))
+
+	// Only DNS-01 enabled: there are no challenges suitable for an IP.
+	pa, err := New(map[core.AcmeChallenge]bool{core.ChallengeTypeDNS01: true})
+	test.AssertNotError(t, err, "Couldn't create policy implementation")
+	_, err = pa.ChallengesFor(ipIdent)
+	test.AssertError(t, err, "ChallengesFor did not error for an IP ident "+
+		"when only DNS-01 was enabled")
+
+	pa, err = New(map[core.AcmeChallenge]bool{
+		core.ChallengeTypeHTTP01:    true,
+		core.ChallengeTypeDNS01:     true,
+		core.ChallengeTypeTLSALPN01: true,
+	})
+	test.AssertNotError(t, err, "Couldn't create policy implementation")
+	challenges, err := pa.ChallengesFor(ipIdent)
+	test.AssertNotError(t, err, "ChallengesFor errored for an IP ident")
+	test.AssertEquals(t, len(challenges), 2)
+	for _, chall := range challenges {
+		test.Assert(t, chall.Type != core.ChallengeTypeDNS01, "DNS-01 challenge offered for an IP ident")
+	}
 }
 
 // TestMalformedExactBlocklist tests that loading a YAML policy file with an
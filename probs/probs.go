@@ -9,24 +9,30 @@ import (
 
 // Error types that can be used in ACME payloads
 const (
-	ConnectionProblem            = ProblemType("connection")
-	MalformedProblem             = ProblemType("malformed")
-	ServerInternalProblem        = ProblemType("serverInternal")
-	TLSProblem                   = ProblemType("tls")
-	UnauthorizedProblem          = ProblemType("unauthorized")
-	RateLimitedProblem           = ProblemType("rateLimited")
-	BadNonceProblem              = ProblemType("badNonce")
-	InvalidEmailProblem          = ProblemType("invalidEmail")
-	RejectedIdentifierProblem    = ProblemType("rejectedIdentifier")
-	AccountDoesNotExistProblem   = ProblemType("accountDoesNotExist")
-	CAAProblem                   = ProblemType("caa")
-	DNSProblem                   = ProblemType("dns")
-	AlreadyRevokedProblem        = ProblemType("alreadyRevoked")
-	OrderNotReadyProblem         = ProblemType("orderNotReady")
-	BadSignatureAlgorithmProblem = ProblemType("badSignatureAlgorithm")
-	BadPublicKeyProblem          = ProblemType("badPublicKey")
-	BadRevocationReasonProblem   = ProblemType("badRevocationReason")
-	BadCSRProblem                = ProblemType("badCSR")
+	ConnectionProblem              = ProblemType("connection")
+	MalformedProblem               = ProblemType("malformed")
+	ServerInternalProblem          = ProblemType("serverInternal")
+	TLSProblem                     = ProblemType("tls")
+	UnauthorizedProblem            = ProblemType("unauthorized")
+	RateLimitedProblem             = ProblemType("rateLimited")
+	BadNonceProblem                = ProblemType("badNonce")
+	InvalidEmailProblem            = ProblemType("invalidEmail")
+	RejectedIdentifierProblem      = ProblemType("rejectedIdentifier")
+	AccountDoesNotExistProblem     = ProblemType("accountDoesNotExist")
+	CAAProblem                     = ProblemType("caa")
+	DNSProblem                     = ProblemType("dns")
+	AlreadyRevokedProblem          = ProblemType("alreadyRevoked")
+	OrderNotReadyProblem           = ProblemType("orderNotReady")
+	BadSignatureAlgorithmProblem   = ProblemType("badSignatureAlgorithm")
+	BadPublicKeyProblem            = ProblemType("badPublicKey")
+	BadRevocationReasonProblem     = ProblemType("badRevocationReason")
+	BadCSRProblem                  = ProblemType("badCSR")
+	ExternalAccountRequiredProblem = ProblemType("externalAccountRequired")
+
+	// DNSSECBogusProblem is not an ACME problem type. It lets Boulder's
+	// components tell answers which failed DNSSEC validation apart from other
+	// DNS problems, and is presented to ACME clients as a DNSProblem.
+	DNSSECBogusProblem = ProblemType("dnssecBogus")
 
 	V1ErrorNS = "urn:acme:error:"
 	V2ErrorNS = "urn:ietf:params:acme:error:"
@@ -75,6 +81,16 @@ func (pd *ProblemDetails) WithSubProblems(subProbs []SubProblemDetails) *Problem
 // errors. It's not currently in the net/http library so we add it here.
 const statusTooManyRequests = 429
 
+// ACMEType returns the type which a problem of the given type is presented to
+// ACME clients as. This is the type itself, except for Boulder's internal
+// types, which are presented as the closest ACME type.
+func ACMEType(t ProblemType) ProblemType {
+	if t == DNSSECBogusProblem {
+		return DNSProblem
+	}
+	return t
+}
+
 // ProblemDetailsToStatusCode inspects the given ProblemDetails to figure out
 // what HTTP status code it should represent. It should only be used by the WFE
 // but is included in this package because of its reliance on ProblemTypes.
@@ -93,13 +109,15 @@ func ProblemDetailsToStatusCode(prob *ProblemDetails) int {
 		InvalidEmailProblem,
 		RejectedIdentifierProblem,
 		AccountDoesNotExistProblem,
-		BadRevocationReasonProblem:
+		BadRevocationReasonProblem,
+		DNSSECBogusProblem:
 		return http.StatusBadRequest
 	case ServerInternalProblem:
 		return http.StatusInternalServerError
 	case
 		UnauthorizedProblem,
-		CAAProblem:
+		CAAProblem,
+		ExternalAccountRequiredProblem:
 		return http.StatusForbidden
 	case RateLimitedProblem:
 		return statusTooManyRequests
@@ -307,6 +325,17 @@ func DNS(detail string) *ProblemDetails {
 	}
 }
 
+// DNSSECBogus returns a ProblemDetails representing a DNSSECBogusProblem: a
+// DNS problem caused by answers which failed DNSSEC validation, rather than by
+// a failure to get an answer at all.
+func DNSSECBogus(detail string) *ProblemDetails {
+	return &ProblemDetails{
+		Type:       DNSSECBogusProblem,
+		Detail:     detail,
+		HTTPStatus: http.StatusBadRequest,
+	}
+}
+
 // OrderNotReady returns a ProblemDetails representing a OrderNotReadyProblem
 func OrderNotReady(detail string, a ...interface{}) *ProblemDetails {
 	return &ProblemDetails{
@@ -334,3 +363,13 @@ func BadCSR(detail string, a ...interface{}) *ProblemDetails {
 		HTTPStatus: http.StatusBadRequest,
 	}
 }
+
+// ExternalAccountRequired returns a ProblemDetails representing an
+// ExternalAccountRequiredProblem.
+func ExternalAccountRequired(detail string) *ProblemDetails {
+	return &ProblemDetails{
+		Type:       ExternalAccountRequiredProblem,
+		Detail:     detail,
+		HTTPStatus: http.StatusForbidden,
+	}
+}
@@ -36,6 +36,8 @@ func TestProblemDetailsToStatusCode(t *testing.T) {
 		{&ProblemDetails{Type: ConnectionProblem, HTTPStatus: 200}, 200},
 		{&ProblemDetails{Type: AccountDoesNotExistProblem}, http.StatusBadRequest},
 		{&ProblemDetails{Type: BadRevocationReasonProblem}, http.StatusBadRequest},
+		{&ProblemDetails{Type: ExternalAccountRequiredProblem}, http.StatusForbidden},
+		{&ProblemDetails{Type: DNSSECBogusProblem}, http.StatusBadRequest},
 	}
 
 	for _, c := range testCases {
@@ -46,6 +48,15 @@ func TestProblemDetailsToStatusCode(t *testing.T) {
 	}
 }
 
+func TestACMEType(t *testing.T) {
+	if ACMEType(DNSSECBogusProblem) != DNSProblem {
+		t.Errorf("Expected %s to be presented as %s", DNSSECBogusProblem, DNSProblem)
+	}
+	if ACMEType(CAAProblem) != CAAProblem {
+		t.Errorf("Expected %s to be presented as itself", CAAProblem)
+	}
+}
+
 func TestProblemDetailsConvenience(t *testing.T) {
 	testCases := []struct {
 		pb           *ProblemDetails
@@ -61,9 +72,11 @@ func TestProblemDetailsConvenience(t *testing.T) {
 		{RateLimited("rate limited detail"), RateLimitedProblem, statusTooManyRequests, "rate limited detail"},
 		{BadNonce("bad nonce detail"), BadNonceProblem, http.StatusBadRequest, "bad nonce detail"},
 		{TLSError("TLS error detail"), TLSProblem, http.StatusBadRequest, "TLS error detail"},
+		{DNSSECBogus("DNSSEC validation failed detail"), DNSSECBogusProblem, http.StatusBadRequest, "DNSSEC validation failed detail"},
 		{RejectedIdentifier("rejected identifier detail"), RejectedIdentifierProblem, http.StatusBadRequest, "rejected identifier detail"},
 		{AccountDoesNotExist("no account detail"), AccountDoesNotExistProblem, http.StatusBadRequest, "no account detail"},
 		{BadRevocationReason("only reason xxx is supported"), BadRevocationReasonProblem, http.StatusBadRequest, "only reason xxx is supported"},
+		{ExternalAccountRequired("external account required detail"), ExternalAccountRequiredProblem, http.StatusForbidden, "external account required detail"},
 	}
 
 	for _, c := range testCases {
@@ -30,6 +30,11 @@ type RegistrationAuthorityClient interface {
 	AdministrativelyRevokeCertificate(ctx context.Context, in *AdministrativelyRevokeCertificateRequest, opts ...grpc.CallOption) (*proto.Empty, error)
 	NewOrder(ctx context.Context, in *NewOrderRequest, opts ...grpc.CallOption) (*proto.Order, error)
 	FinalizeOrder(ctx context.Context, in *FinalizeOrderRequest, opts ...grpc.CallOption) (*proto.Order, error)
+	RenewalInfo(ctx context.Context, in *RenewalInfoRequest, opts ...grpc.CallOption) (*RenewalInfo, error)
+	GetRateLimitUsage(ctx context.Context, in *GetRateLimitUsageRequest, opts ...grpc.CallOption) (*RateLimitUsages, error)
+	NewPreAuthorization(ctx context.Context, in *NewPreAuthorizationRequest, opts ...grpc.CallOption) (*proto.Authorization, error)
+	RevokeCertificatesByName(ctx context.Context, in *RevokeCertificatesByNameRequest, opts ...grpc.CallOption) (*RevokedSerials, error)
+	RevokeCertificatesByKey(ctx context.Context, in *RevokeCertificatesByKeyRequest, opts ...grpc.CallOption) (*RevokedSerials, error)
 }
 
 type registrationAuthorityClient struct {
@@ -139,6 +144,51 @@ func (c *registrationAuthorityClient) FinalizeOrder(ctx context.Context, in *Fin
 	return out, nil
 }
 
+func (c *registrationAuthorityClient) RenewalInfo(ctx context.Context, in *RenewalInfoRequest, opts ...grpc.CallOption) (*RenewalInfo, error) {
+	out := new(RenewalInfo)
+	err := c.cc.Invoke(ctx, "/ra.RegistrationAuthority/RenewalInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationAuthorityClient) GetRateLimitUsage(ctx context.Context, in *GetRateLimitUsageRequest, opts ...grpc.CallOption) (*RateLimitUsages, error) {
+	out := new(RateLimitUsages)
+	err := c.cc.Invoke(ctx, "/ra.RegistrationAuthority/GetRateLimitUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationAuthorityClient) NewPreAuthorization(ctx context.Context, in *NewPreAuthorizationRequest, opts ...grpc.CallOption) (*proto.Authorization, error) {
+	out := new(proto.Authorization)
+	err := c.cc.Invoke(ctx, "/ra.RegistrationAuthority/NewPreAuthorization", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationAuthorityClient) RevokeCertificatesByName(ctx context.Context, in *RevokeCertificatesByNameRequest, opts ...grpc.CallOption) (*RevokedSerials, error) {
+	out := new(RevokedSerials)
+	err := c.cc.Invoke(ctx, "/ra.RegistrationAuthority/RevokeCertificatesByName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationAuthorityClient) RevokeCertificatesByKey(ctx context.Context, in *RevokeCertificatesByKeyRequest, opts ...grpc.CallOption) (*RevokedSerials, error) {
+	out := new(RevokedSerials)
+	err := c.cc.Invoke(ctx, "/ra.RegistrationAuthority/RevokeCertificatesByKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RegistrationAuthorityServer is the server API for RegistrationAuthority service.
 // All implementations must embed UnimplementedRegistrationAuthorityServer
 // for forward compatibility
@@ -154,6 +204,11 @@ type RegistrationAuthorityServer interface {
 	AdministrativelyRevokeCertificate(context.Context, *AdministrativelyRevokeCertificateRequest) (*proto.Empty, error)
 	NewOrder(context.Context, *NewOrderRequest) (*proto.Order, error)
 	FinalizeOrder(context.Context, *FinalizeOrderRequest) (*proto.Order, error)
+	RenewalInfo(context.Context, *RenewalInfoRequest) (*RenewalInfo, error)
+	GetRateLimitUsage(context.Context, *GetRateLimitUsageRequest) (*RateLimitUsages, error)
+	NewPreAuthorization(context.Context, *NewPreAuthorizationRequest) (*proto.Authorization, error)
+	RevokeCertificatesByName(context.Context, *RevokeCertificatesByNameRequest) (*RevokedSerials, error)
+	RevokeCertificatesByKey(context.Context, *RevokeCertificatesByKeyRequest) (*RevokedSerials, error)
 	mustEmbedUnimplementedRegistrationAuthorityServer()
 }
 
@@ -194,6 +249,21 @@ func (UnimplementedRegistrationAuthorityServer) NewOrder(context.Context, *NewOr
 func (UnimplementedRegistrationAuthorityServer) FinalizeOrder(context.Context, *FinalizeOrderRequest) (*proto.Order, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method FinalizeOrder not implemented")
 }
+func (UnimplementedRegistrationAuthorityServer) RenewalInfo(context.Context, *RenewalInfoRequest) (*RenewalInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenewalInfo not implemented")
+}
+func (UnimplementedRegistrationAuthorityServer) GetRateLimitUsage(context.Context, *GetRateLimitUsageRequest) (*RateLimitUsages, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateLimitUsage not implemented")
+}
+func (UnimplementedRegistrationAuthorityServer) NewPreAuthorization(context.Context, *NewPreAuthorizationRequest) (*proto.Authorization, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewPreAuthorization not implemented")
+}
+func (UnimplementedRegistrationAuthorityServer) RevokeCertificatesByName(context.Context, *RevokeCertificatesByNameRequest) (*RevokedSerials, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCertificatesByName not implemented")
+}
+func (UnimplementedRegistrationAuthorityServer) RevokeCertificatesByKey(context.Context, *RevokeCertificatesByKeyRequest) (*RevokedSerials, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeCertificatesByKey not implemented")
+}
 func (UnimplementedRegistrationAuthorityServer) mustEmbedUnimplementedRegistrationAuthorityServer() {}
 
 // UnsafeRegistrationAuthorityServer may be embedded to opt out of forward compatibility for this service.
@@ -405,6 +475,96 @@ func _RegistrationAuthority_FinalizeOrder_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RegistrationAuthority_RenewalInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenewalInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationAuthorityServer).RenewalInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ra.RegistrationAuthority/RenewalInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationAuthorityServer).RenewalInfo(ctx, req.(*RenewalInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RegistrationAuthority_GetRateLimitUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRateLimitUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationAuthorityServer).GetRateLimitUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ra.RegistrationAuthority/GetRateLimitUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationAuthorityServer).GetRateLimitUsage(ctx, req.(*GetRateLimitUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RegistrationAuthority_NewPreAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NewPreAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationAuthorityServer).NewPreAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ra.RegistrationAuthority/NewPreAuthorization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationAuthorityServer).NewPreAuthorization(ctx, req.(*NewPreAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RegistrationAuthority_RevokeCertificatesByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCertificatesByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationAuthorityServer).RevokeCertificatesByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ra.RegistrationAuthority/RevokeCertificatesByName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationAuthorityServer).RevokeCertificatesByName(ctx, req.(*RevokeCertificatesByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RegistrationAuthority_RevokeCertificatesByKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCertificatesByKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationAuthorityServer).RevokeCertificatesByKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ra.RegistrationAuthority/RevokeCertificatesByKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationAuthorityServer).RevokeCertificatesByKey(ctx, req.(*RevokeCertificatesByKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RegistrationAuthority_ServiceDesc is the grpc.ServiceDesc for RegistrationAuthority service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -456,6 +616,26 @@ var RegistrationAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "FinalizeOrder",
 			Handler:    _RegistrationAuthority_FinalizeOrder_Handler,
 		},
+		{
+			MethodName: "RenewalInfo",
+			Handler:    _RegistrationAuthority_RenewalInfo_Handler,
+		},
+		{
+			MethodName: "GetRateLimitUsage",
+			Handler:    _RegistrationAuthority_GetRateLimitUsage_Handler,
+		},
+		{
+			MethodName: "NewPreAuthorization",
+			Handler:    _RegistrationAuthority_NewPreAuthorization_Handler,
+		},
+		{
+			MethodName: "RevokeCertificatesByName",
+			Handler:    _RegistrationAuthority_RevokeCertificatesByName_Handler,
+		},
+		{
+			MethodName: "RevokeCertificatesByKey",
+			Handler:    _RegistrationAuthority_RevokeCertificatesByKey_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ra.proto",
@@ -2,7 +2,9 @@ package ra
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -12,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/jmhodges/clock"
@@ -44,6 +47,7 @@ import (
 	"github.com/weppos/publicsuffix-go/publicsuffix"
 	"golang.org/x/crypto/ocsp"
 	grpc "google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 type caaChecker interface {
@@ -65,6 +69,19 @@ type RegistrationAuthorityImpl struct {
 	PA        core.PolicyAuthority
 	publisher pubpb.PublisherClient
 	caa       caaChecker
+	// Limiter, if not nil, is consulted for every rate limit in place of
+	// counting queries against the SA.
+	Limiter *ratelimit.Limiter
+	// ValidityBounds limits the validity window which new orders may request
+	// for each certificate profile, keyed by profile name with the default
+	// profile under the empty name. Orders for a profile without bounds can't
+	// request a validity window.
+	ValidityBounds map[string]ValidityBounds
+	// CTDisabledProfiles is the set of certificate profiles, with the default
+	// profile under the empty name, whose certificates the CA issues directly
+	// without a precertificate or SCTs. It should match the CA's
+	// configuration.
+	CTDisabledProfiles map[string]bool
 
 	clk       clock.Clock
 	log       blog.Logger
@@ -77,9 +94,22 @@ type RegistrationAuthorityImpl struct {
 	maxNames                     int
 	reuseValidAuthz              bool
 	orderLifetime                time.Duration
+	// finalizeTimeout bounds how long issuance for an order may take once
+	// FinalizeOrder has returned, when the AsyncFinalize feature is enabled.
+	finalizeTimeout time.Duration
+	// finalizeWG tracks issuance running in the background, so that it can be
+	// allowed to complete before shutdown.
+	finalizeWG sync.WaitGroup
+	// rlOverrides holds the rate limit overrides loaded from the database by
+	// RefreshRateLimitOverrides, which rlPolicies applies on top of the policy
+	// file.
+	rlOverrides *ratelimit.OverridableLimits
 
 	issuers map[issuance.IssuerNameID]*issuance.Certificate
 	purger  akamaipb.AkamaiPurgerClient
+	// certProfileNames is the set of certificate profile names, beyond the
+	// default, which subscribers may request for an order.
+	certProfileNames map[string]bool
 
 	ctpolicy *ctpolicy.CTPolicy
 
@@ -91,6 +121,7 @@ type RegistrationAuthorityImpl struct {
 	reusedValidAuthzCounter prometheus.Counter
 	recheckCAACounter       prometheus.Counter
 	newCertCounter          prometheus.Counter
+	inflightFinalizes       prometheus.Gauge
 }
 
 // NewRegistrationAuthorityImpl constructs a new RA object.
@@ -107,9 +138,11 @@ func NewRegistrationAuthorityImpl(
 	pubc pubpb.PublisherClient,
 	caaClient caaChecker,
 	orderLifetime time.Duration,
+	finalizeTimeout time.Duration,
 	ctp *ctpolicy.CTPolicy,
 	purger akamaipb.AkamaiPurgerClient,
 	issuers []*issuance.Certificate,
+	certProfileNames []string,
 ) *RegistrationAuthorityImpl {
 	ctpolicyResults := prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -170,17 +203,31 @@ func NewRegistrationAuthorityImpl(
 	}, []string{"reason"})
 	stats.MustRegister(revocationReasonCounter)
 
+	inflightFinalizes := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "inflight_finalizes",
+		Help: "Gauge of the number of orders being finalized in the background",
+	})
+	stats.MustRegister(inflightFinalizes)
+
 	issuersByID := make(map[issuance.IssuerNameID]*issuance.Certificate)
 	for _, issuer := range issuers {
 		issuersByID[issuer.NameID()] = issuer
 	}
 
+	profileNames := make(map[string]bool, len(certProfileNames))
+	for _, name := range certProfileNames {
+		profileNames[name] = true
+	}
+
+	rlOverrides := ratelimit.NewOverridable(ratelimit.New())
+
 	ra := &RegistrationAuthorityImpl{
 		clk:                          clk,
 		log:                          logger,
 		authorizationLifetime:        authorizationLifetime,
 		pendingAuthorizationLifetime: pendingAuthorizationLifetime,
-		rlPolicies:                   ratelimit.New(),
+		rlPolicies:                   rlOverrides,
+		rlOverrides:                  rlOverrides,
 		maxContactsPerReg:            maxContactsPerReg,
 		keyPolicy:                    keyPolicy,
 		maxNames:                     maxNames,
@@ -188,10 +235,12 @@ func NewRegistrationAuthorityImpl(
 		publisher:                    pubc,
 		caa:                          caaClient,
 		orderLifetime:                orderLifetime,
+		finalizeTimeout:              finalizeTimeout,
 		ctpolicy:                     ctp,
 		ctpolicyResults:              ctpolicyResults,
 		purger:                       purger,
 		issuers:                      issuersByID,
+		certProfileNames:             profileNames,
 		namesPerCert:                 namesPerCert,
 		rateLimitCounter:             rateLimitCounter,
 		newRegCounter:                newRegCounter,
@@ -199,6 +248,7 @@ func NewRegistrationAuthorityImpl(
 		recheckCAACounter:            recheckCAACounter,
 		newCertCounter:               newCertCounter,
 		revocationReasonCounter:      revocationReasonCounter,
+		inflightFinalizes:            inflightFinalizes,
 	}
 	return ra
 }
@@ -216,6 +266,46 @@ func (ra *RegistrationAuthorityImpl) rateLimitPoliciesLoadError(err error) {
 	ra.log.Errf("error reloading rate limit policy: %s", err)
 }
 
+// RefreshRateLimitOverrides loads the unexpired rate limit overrides stored by
+// the SA, replacing those loaded previously. They apply on top of the
+// overrides in the policy file until the next refresh.
+func (ra *RegistrationAuthorityImpl) RefreshRateLimitOverrides(ctx context.Context) error {
+	resp, err := ra.SA.GetRateLimitOverrides(ctx, &sapb.GetRateLimitOverridesRequest{})
+	if err != nil {
+		return err
+	}
+	// The SA returns overrides oldest first, so where several apply to the
+	// same key or registration the newest wins.
+	overrides := make([]ratelimit.Override, 0, len(resp.Overrides))
+	for _, o := range resp.Overrides {
+		overrides = append(overrides, ratelimit.Override{
+			LimitName:      o.LimitName,
+			Key:            o.Key,
+			RegistrationID: o.RegistrationID,
+			Threshold:      int(o.Threshold),
+		})
+	}
+	ra.rlOverrides.SetOverrides(overrides)
+	return nil
+}
+
+// RefreshRateLimitOverridesEvery calls RefreshRateLimitOverrides immediately
+// and then once every interval until the context is cancelled. If a refresh
+// fails, the previously loaded overrides remain in effect.
+func (ra *RegistrationAuthorityImpl) RefreshRateLimitOverridesEvery(ctx context.Context, interval time.Duration) {
+	for {
+		err := ra.RefreshRateLimitOverrides(ctx)
+		if err != nil {
+			ra.log.Errf("error refreshing rate limit overrides: %s", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ra.clk.After(interval):
+		}
+	}
+}
+
 // certificateRequestAuthz is a struct for holding information about a valid
 // authz referenced during a certificateRequestEvent. It holds both the
 // authorization ID and the challenge type that made the authorization valid. We
@@ -268,27 +358,42 @@ const noRegistrationID = -1
 type registrationCounter func(context.Context, net.IP, time.Time, time.Time) (int, error)
 
 // checkRegistrationIPLimit checks a specific registraton limit by using the
-// provided registrationCounter function to determine if the limit has been
-// exceeded for a given IP or IP range
+// provided registrationCounter function, or the given Limiter bucket if the RA
+// has a Limiter, to determine if the limit has been exceeded for a given IP or
+// IP range
 func (ra *RegistrationAuthorityImpl) checkRegistrationIPLimit(
 	ctx context.Context,
 	limit ratelimit.RateLimitPolicy,
 	ip net.IP,
-	counter registrationCounter) error {
+	counter registrationCounter,
+	bucket ratelimit.Bucket) error {
 
 	if !limit.Enabled() {
 		return nil
 	}
 
-	now := ra.clk.Now()
-	windowBegin := limit.WindowBegin(now)
-	count, err := counter(ctx, ip, windowBegin, now)
-	if err != nil {
-		return err
+	var exceeded bool
+	var status *berrors.RateLimitStatus
+	if ra.Limiter != nil {
+		var err error
+		exceeded, status, err = ra.bucketExceeded(ctx, bucket)
+		if err != nil {
+			return err
+		}
+	} else {
+		now := ra.clk.Now()
+		windowBegin := limit.WindowBegin(now)
+		count, err := counter(ctx, ip, windowBegin, now)
+		if err != nil {
+			return err
+		}
+		threshold := limit.GetThreshold(ip.String(), noRegistrationID)
+		exceeded = count >= threshold
+		status = countStatus(limit, threshold, count)
 	}
 
-	if count >= limit.GetThreshold(ip.String(), noRegistrationID) {
-		return berrors.RateLimitError("too many registrations for this IP")
+	if exceeded {
+		return berrors.RateLimitErrorWithStatus(status, "too many registrations for this IP")
 	}
 
 	return nil
@@ -300,7 +405,7 @@ func (ra *RegistrationAuthorityImpl) checkRegistrationLimits(ctx context.Context
 	// Check the registrations per IP limit using the CountRegistrationsByIP SA
 	// function that matches IP addresses exactly
 	exactRegLimit := ra.rlPolicies.RegistrationsPerIP()
-	err := ra.checkRegistrationIPLimit(ctx, exactRegLimit, ip, ra.SA.CountRegistrationsByIP)
+	err := ra.checkRegistrationIPLimit(ctx, exactRegLimit, ip, ra.SA.CountRegistrationsByIP, registrationsPerIPBucket(exactRegLimit, ip))
 	if err != nil {
 		ra.rateLimitCounter.WithLabelValues("registrations_by_ip", "exceeded").Inc()
 		ra.log.Infof("Rate limit exceeded, RegistrationsByIP, IP: %s", ip)
@@ -319,19 +424,143 @@ func (ra *RegistrationAuthorityImpl) checkRegistrationLimits(ctx context.Context
 	// CountRegistrationsByIPRange SA function that fuzzy-matches IPv6 addresses
 	// within a larger address range
 	fuzzyRegLimit := ra.rlPolicies.RegistrationsPerIPRange()
-	err = ra.checkRegistrationIPLimit(ctx, fuzzyRegLimit, ip, ra.SA.CountRegistrationsByIPRange)
+	err = ra.checkRegistrationIPLimit(ctx, fuzzyRegLimit, ip, ra.SA.CountRegistrationsByIPRange, registrationsPerIPRangeBucket(fuzzyRegLimit, ip))
 	if err != nil {
 		ra.rateLimitCounter.WithLabelValues("registrations_by_ip_range", "exceeded").Inc()
 		ra.log.Infof("Rate limit exceeded, RegistrationsByIPRange, IP: %s", ip)
 		// For the fuzzyRegLimit we use a new error message that specifically
 		// mentions that the limit being exceeded is applied to a *range* of IPs
-		return berrors.RateLimitError("too many registrations for this IP range")
+		var status *berrors.RateLimitStatus
+		var berr *berrors.BoulderError
+		if errors.As(err, &berr) {
+			status = berr.RateLimitStatus
+		}
+		return berrors.RateLimitErrorWithStatus(status, "too many registrations for this IP range")
 	}
 	ra.rateLimitCounter.WithLabelValues("registrations_by_ip_range", "pass").Inc()
 
 	return nil
 }
 
+// registrationsPerIPBucket returns the Limiter bucket for the
+// RegistrationsPerIP limit for the given IP.
+func registrationsPerIPBucket(limit ratelimit.RateLimitPolicy, ip net.IP) ratelimit.Bucket {
+	return limit.Bucket(ratelimit.RegistrationsPerIPName, ip.String(), ip.String(), noRegistrationID)
+}
+
+// registrationsPerIPRangeBucket returns the Limiter bucket for the
+// RegistrationsPerIPRange limit for the /48 containing the given IP. As with
+// the SA's counting query, overrides are keyed on the exact IP.
+func registrationsPerIPRangeBucket(limit ratelimit.RateLimitPolicy, ip net.IP) ratelimit.Bucket {
+	ipNet := &net.IPNet{IP: ip.Mask(net.CIDRMask(48, 128)), Mask: net.CIDRMask(48, 128)}
+	return limit.Bucket(ratelimit.RegistrationsPerIPRangeName, ipNet.String(), ip.String(), noRegistrationID)
+}
+
+// spendRegistrationLimits records a new registration from the given IP against
+// the registration limits which apply to it.
+func (ra *RegistrationAuthorityImpl) spendRegistrationLimits(ctx context.Context, ip net.IP) {
+	exactRegLimit := ra.rlPolicies.RegistrationsPerIP()
+	if exactRegLimit.Enabled() {
+		ra.spendBuckets(ctx, 1, registrationsPerIPBucket(exactRegLimit, ip))
+	}
+	fuzzyRegLimit := ra.rlPolicies.RegistrationsPerIPRange()
+	if ip.To4() == nil && fuzzyRegLimit.Enabled() {
+		ra.spendBuckets(ctx, 1, registrationsPerIPRangeBucket(fuzzyRegLimit, ip))
+	}
+}
+
+// bucketExceeded asks the RA's Limiter whether the given bucket is out of room
+// for another request. It also returns the bucket's status, for use in a rate
+// limit error.
+func (ra *RegistrationAuthorityImpl) bucketExceeded(ctx context.Context, bucket ratelimit.Bucket) (bool, *berrors.RateLimitStatus, error) {
+	d, err := ra.Limiter.Check(ctx, bucket, 1)
+	if err != nil {
+		return false, nil, err
+	}
+	return !d.Allowed, decisionStatus(bucket, d), nil
+}
+
+// decisionStatus returns the status of a Limiter bucket as of the given
+// decision.
+func decisionStatus(bucket ratelimit.Bucket, d *ratelimit.Decision) *berrors.RateLimitStatus {
+	return &berrors.RateLimitStatus{
+		Limit:      bucket.Burst,
+		Remaining:  d.Remaining,
+		RetryAfter: d.RetryIn,
+		ResetIn:    d.ResetIn,
+	}
+}
+
+// countStatus returns the status of a limit which is enforced by counting past
+// requests in the database. The count doesn't say when the oldest of those
+// requests will leave the window, so a client which has reached the threshold
+// is conservatively told to wait for the whole window.
+func countStatus(limit ratelimit.RateLimitPolicy, threshold int, count int) *berrors.RateLimitStatus {
+	status := &berrors.RateLimitStatus{Limit: int64(threshold)}
+	if count > 0 {
+		status.ResetIn = limit.Window.Duration
+	}
+	if count < threshold {
+		status.Remaining = int64(threshold - count)
+	} else {
+		status.RetryAfter = limit.Window.Duration
+	}
+	return status
+}
+
+// bucketUsage returns the status of a Limiter bucket without spending
+// anything from it.
+func (ra *RegistrationAuthorityImpl) bucketUsage(ctx context.Context, bucket ratelimit.Bucket) (*berrors.RateLimitStatus, error) {
+	// Asking whether one more request would fit tells us how long the client
+	// must wait to make it. The rest of the status is then adjusted so that it
+	// doesn't include that hypothetical request.
+	d, err := ra.Limiter.Check(ctx, bucket, 1)
+	if err != nil {
+		return nil, err
+	}
+	status := decisionStatus(bucket, d)
+	if d.Allowed {
+		status.Remaining++
+		status.ResetIn -= bucket.Period / time.Duration(bucket.Burst)
+	}
+	if status.Remaining < 0 {
+		status.Remaining = 0
+	}
+	if status.ResetIn < 0 {
+		status.ResetIn = 0
+	}
+	return status, nil
+}
+
+// spendBuckets records requests of the given cost against each of the given
+// buckets, if the RA has a Limiter. The requests have already succeeded, so
+// errors are logged rather than returned.
+func (ra *RegistrationAuthorityImpl) spendBuckets(ctx context.Context, cost int64, buckets ...ratelimit.Bucket) {
+	if ra.Limiter == nil {
+		return
+	}
+	for _, bucket := range buckets {
+		_, err := ra.Limiter.Spend(ctx, bucket, cost)
+		if err != nil {
+			ra.log.Warningf("Failed to spend rate limit bucket %q: %s", bucket.Key, err)
+		}
+	}
+}
+
+// refundBuckets returns requests of the given cost to each of the given
+// buckets, if the RA has a Limiter. Errors are logged rather than returned.
+func (ra *RegistrationAuthorityImpl) refundBuckets(ctx context.Context, cost int64, buckets ...ratelimit.Bucket) {
+	if ra.Limiter == nil {
+		return
+	}
+	for _, bucket := range buckets {
+		err := ra.Limiter.Refund(ctx, bucket, cost)
+		if err != nil {
+			ra.log.Warningf("Failed to refund rate limit bucket %q: %s", bucket.Key, err)
+		}
+	}
+}
+
 // NewRegistration constructs a new Registration from a request.
 func (ra *RegistrationAuthorityImpl) NewRegistration(ctx context.Context, init core.Registration) (core.Registration, error) {
 	if err := ra.keyPolicy.GoodKey(ctx, init.Key.Key); err != nil {
@@ -347,9 +576,10 @@ func (ra *RegistrationAuthorityImpl) NewRegistration(ctx context.Context, init c
 	}
 	_ = mergeUpdate(&reg, init)
 
-	// This field isn't updatable by the end user, so it isn't copied by
-	// MergeUpdate. But we need to fill it in for new registrations.
+	// These fields aren't updatable by the end user, so they aren't copied by
+	// MergeUpdate. But we need to fill them in for new registrations.
 	reg.InitialIP = init.InitialIP
+	reg.ExternalAccountID = init.ExternalAccountID
 
 	if err := ra.validateContacts(ctx, reg.Contact); err != nil {
 		return core.Registration{}, err
@@ -360,6 +590,7 @@ func (ra *RegistrationAuthorityImpl) NewRegistration(ctx context.Context, init c
 	if err != nil {
 		return core.Registration{}, err
 	}
+	ra.spendRegistrationLimits(ctx, init.InitialIP)
 
 	ra.newRegCounter.Inc()
 	return reg, nil
@@ -433,25 +664,65 @@ func (ra *RegistrationAuthorityImpl) validateContacts(ctx context.Context, conta
 func (ra *RegistrationAuthorityImpl) checkPendingAuthorizationLimit(ctx context.Context, regID int64) error {
 	limit := ra.rlPolicies.PendingAuthorizationsPerAccount()
 	if limit.Enabled() {
-		countPB, err := ra.SA.CountPendingAuthorizations2(ctx, &sapb.RegistrationID{
-			Id: regID,
-		})
-		if err != nil {
-			return err
+		var exceeded bool
+		var status *berrors.RateLimitStatus
+		if ra.Limiter != nil {
+			var err error
+			exceeded, status, err = ra.bucketExceeded(ctx, pendingAuthorizationsBucket(limit, regID))
+			if err != nil {
+				return err
+			}
+		} else {
+			countPB, err := ra.SA.CountPendingAuthorizations2(ctx, &sapb.RegistrationID{
+				Id: regID,
+			})
+			if err != nil {
+				return err
+			}
+			// Most rate limits have a key for overrides, but there is no meaningful key
+			// here.
+			noKey := ""
+			threshold := limit.GetThreshold(noKey, regID)
+			exceeded = int(countPB.Count) >= threshold
+			status = countStatus(limit, threshold, int(countPB.Count))
 		}
-		// Most rate limits have a key for overrides, but there is no meaningful key
-		// here.
-		noKey := ""
-		if int(countPB.Count) >= limit.GetThreshold(noKey, regID) {
+		if exceeded {
 			ra.rateLimitCounter.WithLabelValues("pending_authorizations_by_registration_id", "exceeded").Inc()
 			ra.log.Infof("Rate limit exceeded, PendingAuthorizationsByRegID, regID: %d", regID)
-			return berrors.RateLimitError("too many currently pending authorizations")
+			return berrors.RateLimitErrorWithStatus(status, "too many currently pending authorizations")
 		}
 		ra.rateLimitCounter.WithLabelValues("pending_authorizations_by_registration_id", "pass").Inc()
 	}
 	return nil
 }
 
+// pendingAuthorizationsBucket returns the Limiter bucket for the
+// PendingAuthorizationsPerAccount limit for the given account. Unlike the
+// other buckets it is refunded, by refundPendingAuthorization, whenever one of
+// the account's authorizations stops being pending, so that it tracks the
+// number currently pending rather than the number recently created.
+func pendingAuthorizationsBucket(limit ratelimit.RateLimitPolicy, regID int64) ratelimit.Bucket {
+	return limit.Bucket(ratelimit.PendingAuthorizationsPerAccountName, strconv.FormatInt(regID, 10), "", regID)
+}
+
+// spendPendingAuthorizations records the creation of count pending
+// authorizations for the given account.
+func (ra *RegistrationAuthorityImpl) spendPendingAuthorizations(ctx context.Context, regID int64, count int) {
+	limit := ra.rlPolicies.PendingAuthorizationsPerAccount()
+	if limit.Enabled() && count > 0 {
+		ra.spendBuckets(ctx, int64(count), pendingAuthorizationsBucket(limit, regID))
+	}
+}
+
+// refundPendingAuthorization records that one of the given account's
+// authorizations is no longer pending.
+func (ra *RegistrationAuthorityImpl) refundPendingAuthorization(ctx context.Context, regID int64) {
+	limit := ra.rlPolicies.PendingAuthorizationsPerAccount()
+	if limit.Enabled() {
+		ra.refundBuckets(ctx, 1, pendingAuthorizationsBucket(limit, regID))
+	}
+}
+
 // checkInvalidAuthorizationLimits checks the failed validation limit for each
 // of the provided hostnames. It returns the first error.
 func (ra *RegistrationAuthorityImpl) checkInvalidAuthorizationLimits(ctx context.Context, regID int64, hostnames []string) error {
@@ -477,6 +748,17 @@ func (ra *RegistrationAuthorityImpl) checkInvalidAuthorizationLimit(ctx context.
 	if !limit.Enabled() {
 		return nil
 	}
+	if ra.Limiter != nil {
+		exceeded, status, err := ra.bucketExceeded(ctx, invalidAuthorizationsBucket(limit, regID, hostname))
+		if err != nil {
+			return err
+		}
+		if exceeded {
+			ra.log.Infof("Rate limit exceeded, InvalidAuthorizationsByRegID, regID: %d", regID)
+			return berrors.RateLimitErrorWithStatus(status, "too many failed authorizations recently")
+		}
+		return nil
+	}
 	latest := ra.clk.Now().Add(ra.pendingAuthorizationLifetime)
 	earliest := latest.Add(-limit.Window.Duration)
 	req := &sapb.CountInvalidAuthorizationsRequest{
@@ -494,13 +776,20 @@ func (ra *RegistrationAuthorityImpl) checkInvalidAuthorizationLimit(ctx context.
 	// Most rate limits have a key for overrides, but there is no meaningful key
 	// here.
 	noKey := ""
-	if count.Count >= int64(limit.GetThreshold(noKey, regID)) {
+	threshold := limit.GetThreshold(noKey, regID)
+	if count.Count >= int64(threshold) {
 		ra.log.Infof("Rate limit exceeded, InvalidAuthorizationsByRegID, regID: %d", regID)
-		return berrors.RateLimitError("too many failed authorizations recently")
+		return berrors.RateLimitErrorWithStatus(countStatus(limit, threshold, int(count.Count)), "too many failed authorizations recently")
 	}
 	return nil
 }
 
+// invalidAuthorizationsBucket returns the Limiter bucket for the
+// InvalidAuthorizationsPerAccount limit for the given account and hostname.
+func invalidAuthorizationsBucket(limit ratelimit.RateLimitPolicy, regID int64, hostname string) ratelimit.Bucket {
+	return limit.Bucket(ratelimit.InvalidAuthorizationsPerAccountName, fmt.Sprintf("%d:%s", regID, hostname), "", regID)
+}
+
 // checkNewOrdersPerAccountLimit enforces the rlPolicies `NewOrdersPerAccount`
 // rate limit. This rate limit ensures a client can not create more than the
 // specified threshold of new orders within the specified time window.
@@ -509,26 +798,68 @@ func (ra *RegistrationAuthorityImpl) checkNewOrdersPerAccountLimit(ctx context.C
 	if !limit.Enabled() {
 		return nil
 	}
-	latest := ra.clk.Now()
-	earliest := latest.Add(-limit.Window.Duration)
-	count, err := ra.SA.CountOrders(ctx, acctID, earliest, latest)
-	if err != nil {
-		return err
+	var exceeded bool
+	var status *berrors.RateLimitStatus
+	if ra.Limiter != nil {
+		var err error
+		exceeded, status, err = ra.bucketExceeded(ctx, newOrdersBucket(limit, acctID))
+		if err != nil {
+			return err
+		}
+	} else {
+		latest := ra.clk.Now()
+		earliest := latest.Add(-limit.Window.Duration)
+		count, err := ra.SA.CountOrders(ctx, acctID, earliest, latest)
+		if err != nil {
+			return err
+		}
+		// There is no meaningful override key to use for this rate limit
+		noKey := ""
+		threshold := limit.GetThreshold(noKey, acctID)
+		exceeded = count >= threshold
+		status = countStatus(limit, threshold, count)
 	}
-	// There is no meaningful override key to use for this rate limit
-	noKey := ""
-	if count >= limit.GetThreshold(noKey, acctID) {
+	if exceeded {
 		ra.rateLimitCounter.WithLabelValues("new_order_by_registration_id", "exceeded").Inc()
-		return berrors.RateLimitError("too many new orders recently")
+		return berrors.RateLimitErrorWithStatus(status, "too many new orders recently")
 	}
 	ra.rateLimitCounter.WithLabelValues("new_order_by_registration_id", "pass").Inc()
 	return nil
 }
 
+// newOrdersBucket returns the Limiter bucket for the NewOrdersPerAccount limit
+// for the given account.
+func newOrdersBucket(limit ratelimit.RateLimitPolicy, acctID int64) ratelimit.Bucket {
+	return limit.Bucket(ratelimit.NewOrdersPerAccountName, strconv.FormatInt(acctID, 10), "", acctID)
+}
+
 // NewAuthorization constructs a new Authz from a request. Values (domains) in
 // request.Identifier will be lowercased before storage.
 func (ra *RegistrationAuthorityImpl) NewAuthorization(ctx context.Context, request core.Authorization, regID int64) (core.Authorization, error) {
-	identifier := request.Identifier
+	return ra.newAuthorization(ctx, request.Identifier, regID, true)
+}
+
+// NewPreAuthorization creates a standalone pending authorization for an ACME v2
+// account, as described in RFC 8555 Section 7.4.1. Like the authorizations
+// created for orders it is stored as an authz2 row, so once it is valid it is
+// reused by later orders for the same identifier.
+func (ra *RegistrationAuthorityImpl) NewPreAuthorization(ctx context.Context, req *rapb.NewPreAuthorizationRequest) (*corepb.Authorization, error) {
+	authz, err := ra.newAuthorization(ctx, identifier.ACMEIdentifier{
+		Type:  identifier.IdentifierType(req.IdentifierType),
+		Value: req.IdentifierValue,
+	}, req.RegistrationID, false)
+	if err != nil {
+		return nil, err
+	}
+	return bgrpc.AuthzToPB(authz)
+}
+
+// newAuthorization returns a valid or pending authorization for the
+// identifier, reusing an existing one where possible and otherwise creating a
+// new pending authorization subject to the pending and invalid authorization
+// rate limits. If v1 is true the restrictions on new validations in the V1 API
+// also apply.
+func (ra *RegistrationAuthorityImpl) newAuthorization(ctx context.Context, identifier identifier.ACMEIdentifier, regID int64, v1 bool) (core.Authorization, error) {
 	identifier.Value = strings.ToLower(identifier.Value)
 
 	// Check that the identifier is present and appropriate
@@ -597,7 +928,7 @@ func (ra *RegistrationAuthorityImpl) NewAuthorization(ctx context.Context, reque
 		return bgrpc.PBToAuthz(pendingPB)
 	}
 
-	if features.Enabled(features.V1DisableNewValidations) {
+	if v1 && features.Enabled(features.V1DisableNewValidations) {
 		exists, err := ra.SA.PreviousCertificateExists(ctx, &sapb.PreviousCertificateExistsRequest{
 			Domain: identifier.Value,
 			RegID:  regID,
@@ -624,6 +955,7 @@ func (ra *RegistrationAuthorityImpl) NewAuthorization(ctx context.Context, reque
 	if len(authzIDs.Ids) != 1 {
 		return core.Authorization{}, berrors.InternalServerError("unexpected number of authorization IDs returned from NewAuthorizations2: expected 1, got %d", len(authzIDs.Ids))
 	}
+	ra.spendPendingAuthorizations(ctx, regID, 1)
 	// The current internal authorization objects use a string for the ID, the new
 	// storage format uses a integer ID. In order to maintain compatibility we
 	// convert the integer ID to a string.
@@ -632,7 +964,7 @@ func (ra *RegistrationAuthorityImpl) NewAuthorization(ctx context.Context, reque
 }
 
 // MatchesCSR tests the contents of a generated certificate to make sure
-// that the PublicKey, CommonName, and DNSNames match those provided in
+// that the PublicKey, CommonName, DNSNames, and IPAddresses match those provided in
 // the CSR that was used to generate the certificate. It also checks the
 // following fields for:
 //		* notBefore is not more than 24 hours ago
@@ -644,8 +976,14 @@ func (ra *RegistrationAuthorityImpl) MatchesCSR(parsedCertificate *x509.Certific
 	// Check issued certificate matches what was expected from the CSR
 	hostNames := make([]string, len(csr.DNSNames))
 	copy(hostNames, csr.DNSNames)
+	csrIPs := make([]net.IP, len(csr.IPAddresses))
+	copy(csrIPs, csr.IPAddresses)
 	if len(csr.Subject.CommonName) > 0 {
-		hostNames = append(hostNames, csr.Subject.CommonName)
+		if ip := net.ParseIP(csr.Subject.CommonName); ip != nil {
+			csrIPs = append(csrIPs, ip)
+		} else {
+			hostNames = append(hostNames, csr.Subject.CommonName)
+		}
 	}
 	hostNames = core.UniqueLowerNames(hostNames)
 
@@ -656,13 +994,16 @@ func (ra *RegistrationAuthorityImpl) MatchesCSR(parsedCertificate *x509.Certific
 		return berrors.InternalServerError("generated certificate CommonName doesn't match CSR CommonName")
 	}
 	// Sort both slices of names before comparison.
-	parsedNames := parsedCertificate.DNSNames
+	parsedNames := make([]string, len(parsedCertificate.DNSNames))
+	copy(parsedNames, parsedCertificate.DNSNames)
 	sort.Strings(parsedNames)
 	sort.Strings(hostNames)
 	if !reflect.DeepEqual(parsedNames, hostNames) {
 		return berrors.InternalServerError("generated certificate DNSNames don't match CSR DNSNames")
 	}
-	if !reflect.DeepEqual(parsedCertificate.IPAddresses, csr.IPAddresses) {
+	// Compare IP addresses by their textual form, since the same address may be
+	// represented by either a 4 or 16 byte net.IP.
+	if !reflect.DeepEqual(core.UniqueLowerNamesAndIPs(nil, parsedCertificate.IPAddresses), core.UniqueLowerNamesAndIPs(nil, csrIPs)) {
 		return berrors.InternalServerError("generated certificate IPAddresses don't match CSR IPAddresses")
 	}
 	if !reflect.DeepEqual(parsedCertificate.EmailAddresses, csr.EmailAddresses) {
@@ -855,6 +1196,15 @@ func (ra *RegistrationAuthorityImpl) recheckCAA(ctx context.Context, authzs []*c
 					"Internal error rechecking CAA for authorization ID %v (%v)",
 					authz.ID, name,
 				)
+			} else if resp.Problem != nil && resp.Problem.ProblemType == string(probs.ServerInternalProblem) {
+				// The VA couldn't complete the check, for instance because too
+				// many remote VAs failed to respond, which says nothing about
+				// the CAA records themselves.
+				ra.log.AuditErrf("Rechecking CAA: %s", resp.Problem.Detail)
+				err = berrors.InternalServerError(
+					"Internal error rechecking CAA for authorization ID %v (%v)",
+					authz.ID, name,
+				)
 			} else if resp.Problem != nil {
 				err = berrors.CAAError(resp.Problem.Detail)
 			}
@@ -962,7 +1312,7 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 
 	// Dedupe, lowercase and sort both the names from the CSR and the names in the
 	// order.
-	csrNames := core.UniqueLowerNames(csrOb.DNSNames)
+	csrNames := core.UniqueLowerNamesAndIPs(csrOb.DNSNames, csrOb.IPAddresses)
 	orderNames := core.UniqueLowerNames(order.Names)
 
 	// Immediately reject the request if the number of names differ
@@ -977,9 +1327,9 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 		}
 	}
 
-	// Update the order to be status processing - we issue synchronously at the
-	// present time so this is somewhat artificial/unnecessary but allows planning
-	// for the future.
+	// Update the order to be status processing. Unless the AsyncFinalize
+	// feature is enabled we issue synchronously, so clients only see this status
+	// if they poll the order while the request is in flight.
 	//
 	// NOTE(@cpu): After this point any errors that are encountered must update
 	// the state of the order to invalid by setting the order's error field.
@@ -1002,7 +1352,60 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 	// We use IssuerNameID 0 here because (as of now) only the v1 flow sets this
 	// field. This v2 flow allows the CA to select the issuer based on the CSR's
 	// PublicKeyAlgorithm.
-	cert, err := ra.issueCertificate(ctx, issueReq, accountID(order.RegistrationID), orderID(order.Id), issuance.IssuerNameID(0))
+	opts := issuanceOptions{
+		certProfileName: order.CertificateProfileName,
+		notBefore:       order.NotBefore,
+		notAfter:        order.NotAfter,
+	}
+
+	if features.Enabled(features.AsyncFinalize) {
+		// The request's context is canceled as soon as we return, so issuance
+		// gets its own context, bounded by finalizeTimeout. The order is copied
+		// since the caller may still be using the one we return.
+		asyncOrder := proto.Clone(order).(*corepb.Order)
+		ra.finalizeWG.Add(1)
+		ra.inflightFinalizes.Inc()
+		go func() {
+			defer ra.finalizeWG.Done()
+			defer ra.inflightFinalizes.Dec()
+			ctx, cancel := context.WithTimeout(context.Background(), ra.finalizeTimeout)
+			defer cancel()
+			_, err := ra.issueCertificateForOrder(ctx, asyncOrder, issueReq, opts)
+			if err != nil {
+				ra.log.AuditErrf("Asynchronous finalization failed: order ID %d, account ID %d: %s",
+					asyncOrder.Id, asyncOrder.RegistrationID, err)
+			}
+		}()
+		order.Status = string(core.StatusProcessing)
+		return order, nil
+	}
+
+	return ra.issueCertificateForOrder(ctx, order, issueReq, opts)
+}
+
+// orderUpdateTimeout bounds the update recording the result of issuance on an
+// order.
+const orderUpdateTimeout = 15 * time.Second
+
+// orderUpdateContext returns a context for recording the result of issuance on
+// an order. It is independent of the context issuance ran with, since the
+// order must be updated even if issuance failed because that context expired:
+// otherwise it would be left processing, and could never be finalized again.
+func orderUpdateContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), orderUpdateTimeout)
+}
+
+// issueCertificateForOrder issues a certificate for an order which has been set
+// to processing, and records the result on the order: either its certificate
+// serial, with the order returned as valid, or the problem which caused it to
+// fail.
+func (ra *RegistrationAuthorityImpl) issueCertificateForOrder(
+	ctx context.Context,
+	order *corepb.Order,
+	issueReq core.CertificateRequest,
+	opts issuanceOptions,
+) (*corepb.Order, error) {
+	cert, err := ra.issueCertificate(ctx, issueReq, accountID(order.RegistrationID), orderID(order.Id), issuance.IssuerNameID(0), opts)
 	if err != nil {
 		// Fail the order. The problem is computed using
 		// `web.ProblemDetailsForError`, the same function the WFE uses to convert
@@ -1010,25 +1413,30 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 		// berrors.UnauthorizedError into the correct
 		// `urn:ietf:params:acme:error:unauthorized` problem while not letting
 		// anything like a server internal error through with sensitive info.
-		ra.failOrder(ctx, order, web.ProblemDetailsForError(err, "Error finalizing order"))
+		updateCtx, cancel := orderUpdateContext()
+		defer cancel()
+		ra.failOrder(updateCtx, order, web.ProblemDetailsForError(err, "Error finalizing order"))
 		return nil, err
 	}
 
+	updateCtx, cancel := orderUpdateContext()
+	defer cancel()
+
 	// Parse the issued certificate to get the serial
 	parsedCertificate, err := x509.ParseCertificate([]byte(cert.DER))
 	if err != nil {
 		// Fail the order with a server internal error. The certificate we failed
 		// to parse was from our own CA. Bad news!
-		ra.failOrder(ctx, order, probs.ServerInternal("Error parsing certificate DER"))
+		ra.failOrder(updateCtx, order, probs.ServerInternal("Error parsing certificate DER"))
 		return nil, err
 	}
 
 	// Finalize the order with its new CertificateSerial
 	order.CertificateSerial = core.SerialToString(parsedCertificate.SerialNumber)
-	if err := ra.SA.FinalizeOrder(ctx, order); err != nil {
+	if err := ra.SA.FinalizeOrder(updateCtx, order); err != nil {
 		// Fail the order with a server internal error. We weren't able to persist
 		// the certificate serial and that's unexpected & weird.
-		ra.failOrder(ctx, order, probs.ServerInternal("Error persisting finalized order"))
+		ra.failOrder(updateCtx, order, probs.ServerInternal("Error persisting finalized order"))
 		return nil, err
 	}
 
@@ -1043,6 +1451,13 @@ func (ra *RegistrationAuthorityImpl) FinalizeOrder(ctx context.Context, req *rap
 	return order, nil
 }
 
+// DrainFinalize blocks until all issuance started in the background by
+// FinalizeOrder has completed. It should be called after the RA's gRPC server
+// has stopped accepting requests.
+func (ra *RegistrationAuthorityImpl) DrainFinalize() {
+	ra.finalizeWG.Wait()
+}
+
 // NewCertificate requests the issuance of a certificate for the v1 flow.
 func (ra *RegistrationAuthorityImpl) NewCertificate(ctx context.Context, req core.CertificateRequest, regID int64, issuerNameID int64) (core.Certificate, error) {
 	// Verify the CSR
@@ -1052,7 +1467,9 @@ func (ra *RegistrationAuthorityImpl) NewCertificate(ctx context.Context, req cor
 	// NewCertificate provides an order ID of 0, indicating this is a classic ACME
 	// v1 issuance request from the new certificate endpoint that is not
 	// associated with an ACME v2 order.
-	return ra.issueCertificate(ctx, req, accountID(regID), orderID(0), issuance.IssuerNameID(issuerNameID))
+	// ACME v1 has no way to request a certificate profile or validity window,
+	// so the CA's defaults are always used.
+	return ra.issueCertificate(ctx, req, accountID(regID), orderID(0), issuance.IssuerNameID(issuerNameID), issuanceOptions{})
 }
 
 // To help minimize the chance that an accountID would be used as an order ID
@@ -1061,6 +1478,17 @@ func (ra *RegistrationAuthorityImpl) NewCertificate(ctx context.Context, req cor
 type accountID int64
 type orderID int64
 
+// issuanceOptions are the choices made in an order which affect the
+// certificate issued for it, beyond its names and key. The zero value selects
+// the CA's defaults.
+type issuanceOptions struct {
+	certProfileName string
+	// notBefore and notAfter are the requested validity window, in Unix
+	// nanoseconds, or zero if none was requested.
+	notBefore int64
+	notAfter  int64
+}
+
 // issueCertificate sets up a log event structure and captures any errors
 // encountered during issuance, then calls issueCertificateInner.
 // Used by both v1's NewCertificate and v2's FinalizeOrder.
@@ -1069,7 +1497,8 @@ func (ra *RegistrationAuthorityImpl) issueCertificate(
 	req core.CertificateRequest,
 	acctID accountID,
 	oID orderID,
-	issuerNameID issuance.IssuerNameID) (core.Certificate, error) {
+	issuerNameID issuance.IssuerNameID,
+	opts issuanceOptions) (core.Certificate, error) {
 	// Construct the log event
 	logEvent := certificateRequestEvent{
 		ID:          core.NewToken(),
@@ -1078,7 +1507,7 @@ func (ra *RegistrationAuthorityImpl) issueCertificate(
 		RequestTime: ra.clk.Now(),
 	}
 	var result string
-	cert, err := ra.issueCertificateInner(ctx, req, acctID, oID, issuerNameID, &logEvent)
+	cert, err := ra.issueCertificateInner(ctx, req, acctID, oID, issuerNameID, opts, &logEvent)
 	if err != nil {
 		logEvent.Error = err.Error()
 		result = "error"
@@ -1110,6 +1539,7 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 	acctID accountID,
 	oID orderID,
 	issuerNameID issuance.IssuerNameID,
+	opts issuanceOptions,
 	logEvent *certificateRequestEvent) (core.Certificate, error) {
 	emptyCert := core.Certificate{}
 	if acctID <= 0 {
@@ -1129,11 +1559,11 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 
 	csr := req.CSR
 	logEvent.CommonName = csr.Subject.CommonName
-	logEvent.Names = csr.DNSNames
 
-	// Validate that authorization key is authorized for all domains in the CSR
-	names := make([]string, len(csr.DNSNames))
-	copy(names, csr.DNSNames)
+	// Validate that authorization key is authorized for all domains and IP
+	// addresses in the CSR
+	names := core.UniqueLowerNamesAndIPs(csr.DNSNames, csr.IPAddresses)
+	logEvent.Names = names
 
 	if core.KeyDigestEquals(csr.PublicKey, account.Key) {
 		return emptyCert, berrors.MalformedError("certificate public key must be different than account key")
@@ -1191,6 +1621,10 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 		RegistrationID: int64(acctID),
 		OrderID:        int64(oID),
 		IssuerNameID:   int64(issuerNameID),
+		// An empty CertificateProfileName selects the CA's default profile.
+		CertificateProfileName: opts.certProfileName,
+		NotBefore:              opts.notBefore,
+		NotAfter:               opts.notAfter,
 	}
 
 	// wrapError adds a prefix to an error. If the error is a boulder error then
@@ -1204,27 +1638,40 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 		return fmt.Errorf("%s: %s", prefix, e)
 	}
 
-	precert, err := ra.CA.IssuePrecertificate(ctx, issueReq)
-	if err != nil {
-		return emptyCert, wrapError(err, "issuing precertificate")
-	}
-	parsedPrecert, err := x509.ParseCertificate(precert.DER)
-	if err != nil {
-		return emptyCert, wrapError(err, "parsing precertificate")
-	}
-	scts, err := ra.getSCTs(ctx, precert.DER, parsedPrecert.NotAfter)
-	if err != nil {
-		return emptyCert, wrapError(err, "getting SCTs")
-	}
-	cert, err := ra.CA.IssueCertificateForPrecertificate(ctx, &capb.IssueCertificateForPrecertificateRequest{
-		DER:            precert.DER,
-		SCTs:           scts,
-		RegistrationID: int64(acctID),
-		OrderID:        int64(oID),
-	})
-	if err != nil {
-		return emptyCert, wrapError(err, "issuing certificate for precertificate")
+	// Certificates under a profile with CT disabled are never logged, so the CA
+	// issues them directly rather than by way of a precertificate.
+	ctDisabled := ra.CTDisabledProfiles[opts.certProfileName]
+	var cert *corepb.Certificate
+	if ctDisabled {
+		cert, err = ra.CA.IssueCertificate(ctx, issueReq)
+		if err != nil {
+			return emptyCert, wrapError(err, "issuing certificate")
+		}
+	} else {
+		precert, err := ra.CA.IssuePrecertificate(ctx, issueReq)
+		if err != nil {
+			return emptyCert, wrapError(err, "issuing precertificate")
+		}
+		parsedPrecert, err := x509.ParseCertificate(precert.DER)
+		if err != nil {
+			return emptyCert, wrapError(err, "parsing precertificate")
+		}
+		scts, err := ra.getSCTs(ctx, precert.DER, parsedPrecert.NotAfter)
+		if err != nil {
+			return emptyCert, wrapError(err, "getting SCTs")
+		}
+		cert, err = ra.CA.IssueCertificateForPrecertificate(ctx, &capb.IssueCertificateForPrecertificateRequest{
+			DER:                    precert.DER,
+			SCTs:                   scts,
+			RegistrationID:         int64(acctID),
+			OrderID:                int64(oID),
+			CertificateProfileName: opts.certProfileName,
+		})
+		if err != nil {
+			return emptyCert, wrapError(err, "issuing certificate for precertificate")
+		}
 	}
+	ra.spendIssuanceLimits(ctx, names, account.ID)
 
 	parsedCertificate, err := x509.ParseCertificate([]byte(cert.Der))
 	if err != nil {
@@ -1234,7 +1681,9 @@ func (ra *RegistrationAuthorityImpl) issueCertificateInner(
 	}
 
 	// Asynchronously submit the final certificate to any configured logs
-	go ra.ctpolicy.SubmitFinalCert(cert.Der, parsedCertificate.NotAfter)
+	if !ctDisabled {
+		go ra.ctpolicy.SubmitFinalCert(cert.Der, parsedCertificate.NotAfter)
+	}
 
 	err = ra.MatchesCSR(parsedCertificate, csr)
 	if err != nil {
@@ -1279,10 +1728,15 @@ func (ra *RegistrationAuthorityImpl) getSCTs(ctx context.Context, cert []byte, e
 
 // domainsForRateLimiting transforms a list of FQDNs into a list of eTLD+1's
 // for the purpose of rate limiting. It also de-duplicates the output
-// domains. Exact public suffix matches are included.
+// domains. Exact public suffix matches are included, as are IP addresses,
+// which are each rate limited on their own.
 func domainsForRateLimiting(names []string) ([]string, error) {
 	var domains []string
 	for _, name := range names {
+		if net.ParseIP(name) != nil {
+			domains = append(domains, name)
+			continue
+		}
 		domain, err := publicsuffix.Domain(name)
 		if err != nil {
 			// The only possible errors are:
@@ -1297,30 +1751,53 @@ func domainsForRateLimiting(names []string) ([]string, error) {
 	return core.UniqueLowerNames(domains), nil
 }
 
-// enforceNameCounts uses the provided count RPC to find a count of certificates
-// for each of the names. If the count for any of the names exceeds the limit
-// for the given registration then the names out of policy are returned to be
-// used for a rate limit error.
+// enforceNameCounts uses the provided count RPC, or the Limiter if the RA has
+// one, to find a count of certificates for each of the names. If the count for
+// any of the names exceeds the limit for the given registration then the names
+// out of policy are returned to be used for a rate limit error, along with the
+// status of whichever of them will be out of policy the longest.
 func (ra *RegistrationAuthorityImpl) enforceNameCounts(
 	ctx context.Context,
 	names []string,
 	limit ratelimit.RateLimitPolicy,
-	regID int64) ([]string, error) {
+	regID int64) ([]string, *berrors.RateLimitStatus, error) {
+
+	var badNames []string
+	var worst *berrors.RateLimitStatus
+	addBadName := func(name string, status *berrors.RateLimitStatus) {
+		badNames = append(badNames, name)
+		if worst == nil || status.RetryAfter > worst.RetryAfter {
+			worst = status
+		}
+	}
+
+	if ra.Limiter != nil {
+		for _, name := range names {
+			exceeded, status, err := ra.bucketExceeded(ctx, certificatesPerNameBucket(limit, name, regID))
+			if err != nil {
+				return nil, nil, err
+			}
+			if exceeded {
+				addBadName(name, status)
+			}
+		}
+		return badNames, worst, nil
+	}
 
 	now := ra.clk.Now()
 	windowBegin := limit.WindowBegin(now)
 	counts, err := ra.SA.CountCertificatesByNames(ctx, names, windowBegin, now)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var badNames []string
 	for _, entry := range counts {
-		if int(entry.Count) >= limit.GetThreshold(entry.Name, regID) {
-			badNames = append(badNames, entry.Name)
+		threshold := limit.GetThreshold(entry.Name, regID)
+		if int(entry.Count) >= threshold {
+			addBadName(entry.Name, countStatus(limit, threshold, int(entry.Count)))
 		}
 	}
-	return badNames, nil
+	return badNames, worst, nil
 }
 
 func (ra *RegistrationAuthorityImpl) checkCertificatesPerNameLimit(ctx context.Context, names []string, limit ratelimit.RateLimitPolicy, regID int64) error {
@@ -1341,7 +1818,7 @@ func (ra *RegistrationAuthorityImpl) checkCertificatesPerNameLimit(ctx context.C
 		return err
 	}
 
-	namesOutOfLimit, err := ra.enforceNameCounts(ctx, tldNames, limit, regID)
+	namesOutOfLimit, status, err := ra.enforceNameCounts(ctx, tldNames, limit, regID)
 	if err != nil {
 		return fmt.Errorf("checking certificates per name limit for %q: %s",
 			names, err)
@@ -1366,13 +1843,13 @@ func (ra *RegistrationAuthorityImpl) checkCertificatesPerNameLimit(ctx context.C
 			var subErrors []berrors.SubBoulderError
 			for _, name := range namesOutOfLimit {
 				subErrors = append(subErrors, berrors.SubBoulderError{
-					Identifier:   identifier.DNSIdentifier(name),
+					Identifier:   identifier.FromName(name),
 					BoulderError: berrors.RateLimitError("too many certificates already issued").(*berrors.BoulderError),
 				})
 			}
-			return berrors.RateLimitError("too many certificates already issued for multiple names (%s and %d others)", namesOutOfLimit[0], len(namesOutOfLimit)).(*berrors.BoulderError).WithSubErrors(subErrors)
+			return berrors.RateLimitErrorWithStatus(status, "too many certificates already issued for multiple names (%s and %d others)", namesOutOfLimit[0], len(namesOutOfLimit)).(*berrors.BoulderError).WithSubErrors(subErrors)
 		}
-		return berrors.RateLimitError("too many certificates already issued for: %s", namesOutOfLimit[0])
+		return berrors.RateLimitErrorWithStatus(status, "too many certificates already issued for: %s", namesOutOfLimit[0])
 	}
 	ra.rateLimitCounter.WithLabelValues("certificates_for_domain", "pass").Inc()
 
@@ -1380,13 +1857,27 @@ func (ra *RegistrationAuthorityImpl) checkCertificatesPerNameLimit(ctx context.C
 }
 
 func (ra *RegistrationAuthorityImpl) checkCertificatesPerFQDNSetLimit(ctx context.Context, names []string, limit ratelimit.RateLimitPolicy, regID int64) error {
-	count, err := ra.SA.CountFQDNSets(ctx, limit.Window.Duration, names)
-	if err != nil {
-		return fmt.Errorf("checking duplicate certificate limit for %q: %s", names, err)
+	var exceeded bool
+	var status *berrors.RateLimitStatus
+	if ra.Limiter != nil {
+		var err error
+		exceeded, status, err = ra.bucketExceeded(ctx, certificatesPerFQDNSetBucket(limit, names, regID))
+		if err != nil {
+			return fmt.Errorf("checking duplicate certificate limit for %q: %s", names, err)
+		}
+	} else {
+		count, err := ra.SA.CountFQDNSets(ctx, limit.Window.Duration, names)
+		if err != nil {
+			return fmt.Errorf("checking duplicate certificate limit for %q: %s", names, err)
+		}
+		threshold := limit.GetThreshold(strings.Join(core.UniqueLowerNames(names), ","), regID)
+		exceeded = int(count) >= threshold
+		status = countStatus(limit, threshold, int(count))
 	}
 	names = core.UniqueLowerNames(names)
-	if int(count) >= limit.GetThreshold(strings.Join(names, ","), regID) {
-		return berrors.RateLimitError(
+	if exceeded {
+		return berrors.RateLimitErrorWithStatus(
+			status,
 			"too many certificates already issued for exact set of domains: %s",
 			strings.Join(names, ","),
 		)
@@ -1413,6 +1904,208 @@ func (ra *RegistrationAuthorityImpl) checkLimits(ctx context.Context, names []st
 	return nil
 }
 
+// certificatesPerNameBucket returns the Limiter bucket for the
+// CertificatesPerName limit for the given registered domain.
+func certificatesPerNameBucket(limit ratelimit.RateLimitPolicy, name string, regID int64) ratelimit.Bucket {
+	return limit.Bucket(ratelimit.CertificatesPerNameName, name, name, regID)
+}
+
+// certificatesPerFQDNSetBucket returns the Limiter bucket for the
+// CertificatesPerFQDNSet limit for the given set of names. The bucket is keyed
+// on a hash of the names, computed the same way as the SA's fqdnSets table,
+// since the set can be large.
+func certificatesPerFQDNSetBucket(limit ratelimit.RateLimitPolicy, names []string, regID int64) ratelimit.Bucket {
+	joined := strings.Join(core.UniqueLowerNames(names), ",")
+	hash := sha256.Sum256([]byte(joined))
+	return limit.Bucket(ratelimit.CertificatesPerFQDNSetName, hex.EncodeToString(hash[:]), joined, regID)
+}
+
+// spendIssuanceLimits records the issuance of a certificate for the given
+// names against the certificate limits which apply to them.
+func (ra *RegistrationAuthorityImpl) spendIssuanceLimits(ctx context.Context, names []string, regID int64) {
+	if ra.Limiter == nil {
+		return
+	}
+	certNameLimits := ra.rlPolicies.CertificatesPerName()
+	if certNameLimits.Enabled() {
+		tldNames, err := domainsForRateLimiting(names)
+		if err != nil {
+			ra.log.Warningf("Failed to find registered domains for %q: %s", names, err)
+		}
+		for _, name := range tldNames {
+			ra.spendBuckets(ctx, 1, certificatesPerNameBucket(certNameLimits, name, regID))
+		}
+	}
+	fqdnLimits := ra.rlPolicies.CertificatesPerFQDNSet()
+	if fqdnLimits.Enabled() {
+		ra.spendBuckets(ctx, 1, certificatesPerFQDNSetBucket(fqdnLimits, names, regID))
+	}
+}
+
+// GetRateLimitUsage reports how much of each rate limit which applies to the
+// given account has been used, without using any of it. The limits counted
+// against the account as a whole are always reported. If names are given, the
+// CertificatesPerName limit for each of their registered domains and the
+// CertificatesPerFQDNSet limit for the set of them are reported too. The
+// InvalidAuthorizationsPerAccount limit is reported for each of the names and
+// for any other hostname the account has recently failed to validate. Disabled
+// limits are omitted, as are the registration limits, which are counted against
+// IPs rather than accounts.
+func (ra *RegistrationAuthorityImpl) GetRateLimitUsage(ctx context.Context, req *rapb.GetRateLimitUsageRequest) (*rapb.RateLimitUsages, error) {
+	regID := req.RegistrationID
+	names := core.UniqueLowerNames(req.Names)
+	if len(names) > 0 {
+		err := ra.checkOrderNames(names)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp := &rapb.RateLimitUsages{}
+	addUsage := func(limitName, key string, limit ratelimit.RateLimitPolicy, status *berrors.RateLimitStatus) {
+		resp.Usages = append(resp.Usages, &rapb.RateLimitUsage{
+			LimitName:  limitName,
+			Key:        key,
+			Limit:      status.Limit,
+			Remaining:  status.Remaining,
+			Window:     limit.Window.Duration.Nanoseconds(),
+			RetryAfter: status.RetryAfter.Nanoseconds(),
+			ResetIn:    status.ResetIn.Nanoseconds(),
+		})
+	}
+	// There is no meaningful override key for the limits counted against the
+	// account as a whole.
+	noKey := ""
+	now := ra.clk.Now()
+
+	newOrdersLimit := ra.rlPolicies.NewOrdersPerAccount()
+	if newOrdersLimit.Enabled() {
+		var status *berrors.RateLimitStatus
+		var err error
+		if ra.Limiter != nil {
+			status, err = ra.bucketUsage(ctx, newOrdersBucket(newOrdersLimit, regID))
+		} else {
+			var count int
+			count, err = ra.SA.CountOrders(ctx, regID, now.Add(-newOrdersLimit.Window.Duration), now)
+			status = countStatus(newOrdersLimit, newOrdersLimit.GetThreshold(noKey, regID), count)
+		}
+		if err != nil {
+			return nil, err
+		}
+		addUsage(ratelimit.NewOrdersPerAccountName, noKey, newOrdersLimit, status)
+	}
+
+	pendingAuthzLimit := ra.rlPolicies.PendingAuthorizationsPerAccount()
+	if pendingAuthzLimit.Enabled() {
+		var status *berrors.RateLimitStatus
+		var err error
+		if ra.Limiter != nil {
+			status, err = ra.bucketUsage(ctx, pendingAuthorizationsBucket(pendingAuthzLimit, regID))
+		} else {
+			var count *sapb.Count
+			count, err = ra.SA.CountPendingAuthorizations2(ctx, &sapb.RegistrationID{Id: regID})
+			if err == nil {
+				status = countStatus(pendingAuthzLimit, pendingAuthzLimit.GetThreshold(noKey, regID), int(count.Count))
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+		addUsage(ratelimit.PendingAuthorizationsPerAccountName, noKey, pendingAuthzLimit, status)
+	}
+
+	invalidAuthzLimit := ra.rlPolicies.InvalidAuthorizationsPerAccount()
+	if invalidAuthzLimit.Enabled() {
+		// As in checkInvalidAuthorizationLimit, failed authorizations are
+		// counted by when they would have expired had they stayed pending.
+		latest := now.Add(ra.pendingAuthorizationLifetime)
+		counts, err := ra.SA.CountInvalidAuthorizationsByName(ctx, &sapb.CountInvalidAuthorizationsByNameRequest{
+			RegistrationID: regID,
+			Range: &sapb.Range{
+				Earliest: latest.Add(-invalidAuthzLimit.Window.Duration).UnixNano(),
+				Latest:   latest.UnixNano(),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		countsByHostname := make(map[string]int)
+		for _, name := range names {
+			countsByHostname[strings.TrimPrefix(name, "*.")] = 0
+		}
+		for _, count := range counts.CountByNames {
+			countsByHostname[count.Name] = int(count.Count)
+		}
+		hostnames := make([]string, 0, len(countsByHostname))
+		for hostname := range countsByHostname {
+			hostnames = append(hostnames, hostname)
+		}
+		sort.Strings(hostnames)
+		for _, hostname := range hostnames {
+			var status *berrors.RateLimitStatus
+			if ra.Limiter != nil {
+				status, err = ra.bucketUsage(ctx, invalidAuthorizationsBucket(invalidAuthzLimit, regID, hostname))
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				status = countStatus(invalidAuthzLimit, invalidAuthzLimit.GetThreshold(noKey, regID), countsByHostname[hostname])
+			}
+			addUsage(ratelimit.InvalidAuthorizationsPerAccountName, hostname, invalidAuthzLimit, status)
+		}
+	}
+
+	if len(names) == 0 {
+		return resp, nil
+	}
+
+	certNameLimit := ra.rlPolicies.CertificatesPerName()
+	if certNameLimit.Enabled() {
+		tldNames, err := domainsForRateLimiting(names)
+		if err != nil {
+			return nil, err
+		}
+		if ra.Limiter != nil {
+			for _, name := range tldNames {
+				status, err := ra.bucketUsage(ctx, certificatesPerNameBucket(certNameLimit, name, regID))
+				if err != nil {
+					return nil, err
+				}
+				addUsage(ratelimit.CertificatesPerNameName, name, certNameLimit, status)
+			}
+		} else {
+			counts, err := ra.SA.CountCertificatesByNames(ctx, tldNames, certNameLimit.WindowBegin(now), now)
+			if err != nil {
+				return nil, err
+			}
+			for _, count := range counts {
+				status := countStatus(certNameLimit, certNameLimit.GetThreshold(count.Name, regID), int(count.Count))
+				addUsage(ratelimit.CertificatesPerNameName, count.Name, certNameLimit, status)
+			}
+		}
+	}
+
+	fqdnLimit := ra.rlPolicies.CertificatesPerFQDNSet()
+	if fqdnLimit.Enabled() {
+		joined := strings.Join(names, ",")
+		var status *berrors.RateLimitStatus
+		var err error
+		if ra.Limiter != nil {
+			status, err = ra.bucketUsage(ctx, certificatesPerFQDNSetBucket(fqdnLimit, names, regID))
+		} else {
+			var count int64
+			count, err = ra.SA.CountFQDNSets(ctx, fqdnLimit.Window.Duration, names)
+			status = countStatus(fqdnLimit, fqdnLimit.GetThreshold(joined, regID), int(count))
+		}
+		if err != nil {
+			return nil, err
+		}
+		addUsage(ratelimit.CertificatesPerFQDNSetName, joined, fqdnLimit, status)
+	}
+
+	return resp, nil
+}
+
 // UpdateRegistration updates an existing Registration with new values. Caller
 // is responsible for making sure that update.Key is only different from base.Key
 // if it is being called from the WFE key change endpoint.
@@ -1673,6 +2366,14 @@ func (ra *RegistrationAuthorityImpl) PerformValidation(
 		if err := ra.recordValidation(vaCtx, authz.ID, authz.Expires, challenge); err != nil {
 			ra.log.AuditErrf("Could not record updated validation: err=[%s] regID=[%d] authzID=[%s]",
 				err, authz.RegistrationID, authz.ID)
+			return
+		}
+		ra.refundPendingAuthorization(vaCtx, authz.RegistrationID)
+		if challenge.Status == core.StatusInvalid {
+			limit := ra.rlPolicies.InvalidAuthorizationsPerAccount()
+			if limit.Enabled() {
+				ra.spendBuckets(vaCtx, 1, invalidAuthorizationsBucket(limit, authz.RegistrationID, authz.Identifier.Value))
+			}
 		}
 	}(authz)
 	return bgrpc.AuthzToPB(authz)
@@ -1783,6 +2484,158 @@ func (ra *RegistrationAuthorityImpl) RevokeCertificateWithReg(ctx context.Contex
 	return nil
 }
 
+// RevokeCertificatesByName revokes every unexpired certificate naming the given
+// domain, on behalf of an account which holds a valid authorization for it,
+// whether or not that account requested the certificates. This lets a new
+// domain owner invalidate certificates obtained by the previous owner.
+// Certificates for the wildcard directly below the domain are revoked too,
+// since an authorization for a domain is what allows a wildcard to be issued
+// for it. It returns the serials of the certificates it revoked. If any of them can't be
+// revoked an error is returned once the rest have been, and the request can
+// be retried to revoke those that remain.
+func (ra *RegistrationAuthorityImpl) RevokeCertificatesByName(ctx context.Context, req *rapb.RevokeCertificatesByNameRequest) (*rapb.RevokedSerials, error) {
+	domain := strings.ToLower(req.Domain)
+	if strings.HasPrefix(domain, "*.") {
+		return nil, berrors.MalformedError("revoke certificates for %q by its base domain instead", domain)
+	}
+	now := ra.clk.Now()
+
+	authzMapPB, err := ra.SA.GetValidAuthorizations2(ctx, &sapb.GetValidAuthorizationsRequest{
+		RegistrationID: req.RegistrationID,
+		Domains:        []string{domain},
+		Now:            now.UnixNano(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	authzs, err := bgrpc.PBToAuthzMap(authzMapPB)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := authzs[domain]; !ok {
+		return nil, berrors.UnauthorizedError("account does not hold a valid authorization for %q", domain)
+	}
+
+	serials, err := ra.SA.GetUnexpiredSerialsForName(ctx, &sapb.GetUnexpiredSerialsForNameRequest{
+		Domain: domain,
+		Now:    now.UnixNano(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ra.revokeSerials(ctx, serials.Serials, revocation.Reason(req.Code), req.RegistrationID)
+}
+
+// KeyCompromiseCSRCommonName is the Subject common name which a CSR must have
+// to be accepted by RevokeCertificatesByKey. It stops a CSR made for some
+// other purpose, such as one a subscriber has shared while seeking help, from
+// being used to revoke their certificates.
+const KeyCompromiseCSRCommonName = "This key is compromised"
+
+// RevokeCertificatesByKey accepts a CSR signed by a compromised key as proof
+// of possession of that key, blocks the key from future issuance, and revokes
+// every unexpired certificate with that key for keyCompromise. It returns the
+// serials of the certificates it revoked. If any of them can't be revoked an
+// error is returned once the rest have been, and the request can be retried to
+// revoke those that remain.
+func (ra *RegistrationAuthorityImpl) RevokeCertificatesByKey(ctx context.Context, req *rapb.RevokeCertificatesByKeyRequest) (*rapb.RevokedSerials, error) {
+	csr, err := x509.ParseCertificateRequest(req.Csr)
+	if err != nil {
+		return nil, berrors.BadCSRError("unable to parse CSR: %s", err)
+	}
+	err = csr.CheckSignature()
+	if err != nil {
+		return nil, berrors.BadCSRError("invalid signature on CSR: %s", err)
+	}
+	if csr.Subject.CommonName != KeyCompromiseCSRCommonName {
+		return nil, berrors.BadCSRError("CSR common name must be %q", KeyCompromiseCSRCommonName)
+	}
+	digest, err := core.KeyDigest(csr.PublicKey)
+	if err != nil {
+		return nil, berrors.BadCSRError("unable to hash CSR public key: %s", err)
+	}
+	now := ra.clk.Now()
+
+	// Block the key before revoking anything, so that it can't be used for new
+	// certificates even if some revocations fail.
+	_, err = ra.SA.AddBlockedKey(ctx, &sapb.AddBlockedKeyRequest{
+		KeyHash: digest[:],
+		Added:   now.UnixNano(),
+		Source:  "API",
+		Comment: "reported with proof of possession",
+	})
+	if err != nil {
+		return nil, err
+	}
+	ra.log.AuditInfof("Blocked key with SPKI hash %x, reported with proof of possession", digest)
+
+	serials, err := ra.SA.GetUnexpiredSerialsForKey(ctx, &sapb.GetUnexpiredSerialsForKeyRequest{
+		KeyHash: digest[:],
+		Now:     now.UnixNano(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ra.revokeSerials(ctx, serials.Serials, ocsp.KeyCompromise, 0)
+}
+
+// revokeSerials revokes each of the given certificates, and returns the serials
+// of those which it revoked. Certificates which were revoked by some other
+// request in the meantime are skipped. If any can't be revoked the rest are
+// still attempted, and an error is returned.
+func (ra *RegistrationAuthorityImpl) revokeSerials(ctx context.Context, serials []string, code revocation.Reason, regID int64) (*rapb.RevokedSerials, error) {
+	revoked := &rapb.RevokedSerials{}
+	var failed int
+	for _, serial := range serials {
+		der, err := ra.getCertificateDER(ctx, serial)
+		if err != nil {
+			ra.log.AuditErrf("Failed to get certificate %s to revoke: %s", serial, err)
+			failed++
+			continue
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			ra.log.AuditErrf("Failed to parse certificate %s to revoke: %s", serial, err)
+			failed++
+			continue
+		}
+		err = ra.RevokeCertificateWithReg(ctx, *cert, code, regID)
+		if errors.Is(err, berrors.Duplicate) {
+			// The certificate was revoked by another request since we looked.
+			continue
+		} else if err != nil {
+			ra.log.AuditErrf("Failed to revoke certificate %s: %s", serial, err)
+			failed++
+			continue
+		}
+		revoked.Serials = append(revoked.Serials, serial)
+	}
+	if failed > 0 {
+		return nil, berrors.InternalServerError("failed to revoke %d of %d certificates", failed, len(serials))
+	}
+	return revoked, nil
+}
+
+// getCertificateDER returns the DER of the precertificate with the given
+// serial or, if there is none because the certificate was issued directly
+// without one, the DER of the final certificate.
+func (ra *RegistrationAuthorityImpl) getCertificateDER(ctx context.Context, serial string) ([]byte, error) {
+	precert, err := ra.SA.GetPrecertificate(ctx, &sapb.Serial{Serial: serial})
+	if err == nil {
+		return precert.Der, nil
+	}
+	if !errors.Is(err, berrors.NotFound) {
+		return nil, err
+	}
+	cert, err := ra.SA.GetCertificate(ctx, serial)
+	if err != nil {
+		return nil, err
+	}
+	return cert.DER, nil
+}
+
 // AdministrativelyRevokeCertificate terminates trust in the certificate provided and
 // does not require the registration ID of the requester since this method is only
 // called from the admin-revoker tool.
@@ -1816,6 +2669,74 @@ func (ra *RegistrationAuthorityImpl) AdministrativelyRevokeCertificate(ctx conte
 	return nil
 }
 
+// RenewalInfo returns the suggested renewal window for the certificate with the
+// given issuer and serial. Ordinarily this is a short window centered two
+// thirds of the way through the certificate's validity period. If the
+// certificate has been revoked (e.g. by admin-revoker or bad-key-revoker), or
+// its key has been blocked but bad-key-revoker hasn't gotten to it yet, the
+// window is instead placed in the past so that clients renew immediately.
+func (ra *RegistrationAuthorityImpl) RenewalInfo(ctx context.Context, req *rapb.RenewalInfoRequest) (*rapb.RenewalInfo, error) {
+	status, err := ra.SA.GetCertificateStatusByIssuer(ctx, &sapb.IssuerSerial{
+		IssuerID: req.IssuerID,
+		Serial:   req.Serial,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := ra.getCertificateDER(ctx, req.Serial)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, berrors.InternalServerError("failed to parse stored certificate %q: %s", req.Serial, err)
+	}
+
+	if core.OCSPStatus(status.Status) == core.OCSPStatusRevoked {
+		return renewalWindowImmediate(ra.clk.Now()), nil
+	}
+	digest, err := core.KeyDigest(cert.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	blocked, err := ra.SA.KeyBlocked(ctx, &sapb.KeyBlockedRequest{KeyHash: digest[:]})
+	if err != nil {
+		return nil, err
+	}
+	if blocked.Exists {
+		return renewalWindowImmediate(ra.clk.Now()), nil
+	}
+
+	return renewalWindow(cert.NotBefore, cert.NotAfter), nil
+}
+
+// renewalWindow returns a window centered two thirds of the way through the
+// validity period [notBefore, notAfter], whose width is two percent of that
+// validity period. This spreads renewals of certificates issued at similar
+// times across a reasonable span, rather than concentrating them.
+func renewalWindow(notBefore, notAfter time.Time) *rapb.RenewalInfo {
+	// The notAfter is inclusive, so the validity period is one second longer
+	// than the difference between the two.
+	validity := notAfter.Add(time.Second).Sub(notBefore)
+	ideal := notAfter.Add(-validity / 3)
+	margin := validity / 100
+	return &rapb.RenewalInfo{
+		WindowStart: ideal.Add(-margin).UnixNano(),
+		WindowEnd:   ideal.Add(margin).UnixNano(),
+	}
+}
+
+// renewalWindowImmediate returns a window which began an hour ago, signalling
+// to clients that they should renew as soon as possible.
+func renewalWindowImmediate(now time.Time) *rapb.RenewalInfo {
+	oneHourAgo := now.Add(-time.Hour)
+	return &rapb.RenewalInfo{
+		WindowStart: oneHourAgo.UnixNano(),
+		WindowEnd:   oneHourAgo.Add(30 * time.Minute).UnixNano(),
+	}
+}
+
 // DeactivateRegistration deactivates a valid registration
 func (ra *RegistrationAuthorityImpl) DeactivateRegistration(ctx context.Context, reg core.Registration) error {
 	if reg.Status != core.StatusValid {
@@ -1840,6 +2761,9 @@ func (ra *RegistrationAuthorityImpl) DeactivateAuthorization(ctx context.Context
 	if _, err := ra.SA.DeactivateAuthorization2(ctx, &sapb.AuthorizationID2{Id: authzID}); err != nil {
 		return err
 	}
+	if auth.Status == core.StatusPending {
+		ra.refundPendingAuthorization(ctx, auth.RegistrationID)
+	}
 	return nil
 }
 
@@ -1850,7 +2774,7 @@ func (ra *RegistrationAuthorityImpl) DeactivateAuthorization(ctx context.Context
 func (ra *RegistrationAuthorityImpl) checkOrderNames(names []string) error {
 	idents := make([]identifier.ACMEIdentifier, len(names))
 	for i, name := range names {
-		idents[i] = identifier.DNSIdentifier(name)
+		idents[i] = identifier.FromName(name)
 	}
 	if err := ra.PA.WillingToIssueWildcards(idents); err != nil {
 		return err
@@ -1858,11 +2782,94 @@ func (ra *RegistrationAuthorityImpl) checkOrderNames(names []string) error {
 	return nil
 }
 
+// onlyDNSChallenges returns true if the given challenges are all of a type
+// which may be used to validate a wildcard name, i.e. DNS-01 or DNS-ACCOUNT-01.
+func onlyDNSChallenges(challs []*corepb.Challenge) bool {
+	if len(challs) == 0 {
+		return false
+	}
+	for _, chall := range challs {
+		switch core.AcmeChallenge(chall.Type) {
+		case core.ChallengeTypeDNS01, core.ChallengeTypeDNSAccount01:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ValidityBounds limits the notBefore and notAfter which may be requested for
+// the certificates issued under a certificate profile. They should match the
+// profile's configuration on the CA.
+type ValidityBounds struct {
+	// MaxValidity is the longest validity window which may be requested.
+	MaxValidity time.Duration
+	// MaxNotBeforeDelay is how far in the future notBefore may be.
+	MaxNotBeforeDelay time.Duration
+}
+
+// checkRequestedValidity returns an error if the notBefore and notAfter, in
+// Unix nanoseconds, requested for an order under the named certificate profile
+// are outside of the profile's bounds. Either may be zero if it wasn't
+// requested.
+func (ra *RegistrationAuthorityImpl) checkRequestedValidity(profileName string, notBefore, notAfter int64) error {
+	bounds, ok := ra.ValidityBounds[profileName]
+	if !ok {
+		return berrors.MalformedError("NotBefore and NotAfter are not supported for this certificate profile")
+	}
+	now := ra.clk.Now()
+	start := now
+	if notBefore != 0 {
+		start = time.Unix(0, notBefore)
+		if start.Before(now) {
+			return berrors.MalformedError("NotBefore must not be in the past")
+		}
+		if start.Sub(now) > bounds.MaxNotBeforeDelay {
+			return berrors.MalformedError("NotBefore must be no more than %s in the future", bounds.MaxNotBeforeDelay)
+		}
+	}
+	if notAfter != 0 {
+		end := time.Unix(0, notAfter)
+		if !end.After(start) {
+			return berrors.MalformedError("NotAfter must be after NotBefore")
+		}
+		if end.Sub(start) > bounds.MaxValidity {
+			return berrors.MalformedError("Requested validity period must be no longer than %s", bounds.MaxValidity)
+		}
+	}
+	return nil
+}
+
 // NewOrder creates a new order object
 func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.NewOrderRequest) (*corepb.Order, error) {
 	order := &corepb.Order{
-		RegistrationID: req.RegistrationID,
-		Names:          core.UniqueLowerNames(req.Names),
+		RegistrationID:         req.RegistrationID,
+		Names:                  core.UniqueLowerNames(req.Names),
+		CertificateProfileName: req.CertificateProfileName,
+		NotBefore:              req.NotBefore,
+		NotAfter:               req.NotAfter,
+	}
+
+	if order.CertificateProfileName != "" && !features.Enabled(features.MultipleCertificateProfiles) {
+		// The SA wouldn't store the profile, and the order would silently be
+		// finalized under the default profile.
+		return nil, berrors.MalformedError("Certificate profiles are not supported")
+	}
+	if order.CertificateProfileName != "" && !ra.certProfileNames[order.CertificateProfileName] {
+		return nil, berrors.MalformedError(
+			"Order requested unrecognized certificate profile %q", order.CertificateProfileName)
+	}
+
+	if (order.NotBefore != 0 || order.NotAfter != 0) && !features.Enabled(features.RequestedOrderValidity) {
+		// The SA wouldn't store the requested window, and the certificate
+		// would silently be issued with the profile's default validity.
+		return nil, berrors.MalformedError("NotBefore and NotAfter are not supported")
+	}
+	if order.NotBefore != 0 || order.NotAfter != 0 {
+		err := ra.checkRequestedValidity(order.CertificateProfileName, order.NotBefore, order.NotAfter)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if len(order.Names) > ra.maxNames {
@@ -1890,8 +2897,12 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 	if err != nil && !errors.Is(err, berrors.NotFound) {
 		return nil, err
 	}
-	// If there was an order, return it
-	if existingOrder != nil {
+	// If there was an order for the same certificate profile and validity
+	// window, return it
+	if existingOrder != nil &&
+		existingOrder.CertificateProfileName == order.CertificateProfileName &&
+		existingOrder.NotBefore == order.NotBefore &&
+		existingOrder.NotAfter == order.NotAfter {
 		return existingOrder, nil
 	}
 
@@ -1948,13 +2959,12 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 			continue
 		}
 		authz := nameToExistingAuthz[name]
-		// If the identifier is a wildcard and the existing authz only has one
-		// DNS-01 type challenge we can reuse it. In theory we will
-		// never get back an authorization for a domain with a wildcard prefix
-		// that doesn't meet this criteria from SA.GetAuthorizations but we verify
-		// again to be safe.
-		if strings.HasPrefix(name, "*.") &&
-			len(authz.Challenges) == 1 && core.AcmeChallenge(authz.Challenges[0].Type) == core.ChallengeTypeDNS01 {
+		// If the identifier is a wildcard and the existing authz only has
+		// DNS-01 or DNS-ACCOUNT-01 type challenges we can reuse it. In theory we
+		// will never get back an authorization for a domain with a wildcard
+		// prefix that doesn't meet this criteria from SA.GetAuthorizations but we
+		// verify again to be safe.
+		if strings.HasPrefix(name, "*.") && onlyDNSChallenges(authz.Challenges) {
 			authzID, err := strconv.ParseInt(authz.Id, 10, 64)
 			if err != nil {
 				return nil, err
@@ -1993,10 +3003,7 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 	// authorization for each.
 	var newAuthzs []*corepb.Authorization
 	for _, name := range missingAuthzNames {
-		pb, err := ra.createPendingAuthz(ctx, order.RegistrationID, identifier.ACMEIdentifier{
-			Type:  identifier.DNS,
-			Value: name,
-		})
+		pb, err := ra.createPendingAuthz(ctx, order.RegistrationID, identifier.FromName(name))
 		if err != nil {
 			return nil, err
 		}
@@ -2032,6 +3039,7 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 		if err != nil {
 			return nil, err
 		}
+		ra.spendPendingAuthorizations(ctx, order.RegistrationID, len(authzIDs.Ids))
 		order.V2Authorizations = append(order.V2Authorizations, authzIDs.Ids...)
 		// If the newly created pending authz's have an expiry closer than the
 		// minExpiry the minExpiry is the pending authz expiry.
@@ -2053,6 +3061,10 @@ func (ra *RegistrationAuthorityImpl) NewOrder(ctx context.Context, req *rapb.New
 	if err != nil {
 		return nil, err
 	}
+	newOrdersLimit := ra.rlPolicies.NewOrdersPerAccount()
+	if newOrdersLimit.Enabled() {
+		ra.spendBuckets(ctx, 1, newOrdersBucket(newOrdersLimit, order.RegistrationID))
+	}
 
 	return storedOrder, nil
 }
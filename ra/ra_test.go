@@ -45,6 +45,7 @@ import (
 	"github.com/letsencrypt/boulder/metrics"
 	"github.com/letsencrypt/boulder/mocks"
 	"github.com/letsencrypt/boulder/policy"
+	"github.com/letsencrypt/boulder/probs"
 	pubpb "github.com/letsencrypt/boulder/publisher/proto"
 	rapb "github.com/letsencrypt/boulder/ra/proto"
 	"github.com/letsencrypt/boulder/ratelimit"
@@ -57,6 +58,7 @@ import (
 	"github.com/weppos/publicsuffix-go/publicsuffix"
 	"golang.org/x/crypto/ocsp"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 	jose "gopkg.in/square/go-jose.v2"
 )
 
@@ -327,7 +329,7 @@ func initAuthorities(t *testing.T) (*DummyValidationAuthority, *sa.SQLStorageAut
 	ra := NewRegistrationAuthorityImpl(fc,
 		log,
 		stats,
-		1, testKeyPolicy, 100, true, 300*24*time.Hour, 7*24*time.Hour, nil, noopCAA{}, 0, ctp, nil, nil)
+		1, testKeyPolicy, 100, true, 300*24*time.Hour, 7*24*time.Hour, nil, noopCAA{}, 0, time.Hour, ctp, nil, nil, nil)
 	ra.SA = ssa
 	ra.VA = va
 	ra.CA = ca
@@ -585,6 +587,56 @@ func TestNewRegistrationRateLimit(t *testing.T) {
 	test.AssertEquals(t, err.Error(), "too many registrations for this IP range: see https://letsencrypt.org/docs/rate-limits/")
 }
 
+func TestNewRegistrationRateLimitWithLimiter(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.Limiter = ratelimit.NewLimiter(fc, ratelimit.NewInmemSource(), metrics.NoopRegisterer)
+	ra.rlPolicies = &dummyRateLimitConfig{
+		RegistrationsPerIPPolicy: ratelimit.RateLimitPolicy{
+			Threshold: 1,
+			Window:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+		},
+		RegistrationsPerIPRangePolicy: ratelimit.RateLimitPolicy{
+			Threshold: 2,
+			Window:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+		},
+	}
+
+	reg := core.Registration{
+		Key:       &jose.JSONWebKey{Key: testKey()},
+		InitialIP: net.ParseIP("7.6.6.5"),
+	}
+	_, err := ra.NewRegistration(ctx, reg)
+	test.AssertNotError(t, err, "Unexpected error adding new IPv4 registration")
+
+	reg.Key = &jose.JSONWebKey{Key: testKey()}
+	_, err = ra.NewRegistration(ctx, reg)
+	test.AssertError(t, err, "No error adding duplicate IPv4 registration")
+	test.AssertEquals(t, err.Error(), "too many registrations for this IP: see https://letsencrypt.org/docs/rate-limits/")
+
+	// Two IPv6 addresses in the same /48 are within both limits, but a third
+	// exceeds the range limit.
+	for _, ip := range []string{"2001:cdba:1234:5678::1", "2001:cdba:1234:5679::1"} {
+		reg.Key = &jose.JSONWebKey{Key: testKey()}
+		reg.InitialIP = net.ParseIP(ip)
+		_, err = ra.NewRegistration(ctx, reg)
+		test.AssertNotError(t, err, "Unexpected error adding IPv6 registration")
+	}
+	reg.Key = &jose.JSONWebKey{Key: testKey()}
+	reg.InitialIP = net.ParseIP("2001:cdba:1234:567a::1")
+	_, err = ra.NewRegistration(ctx, reg)
+	test.AssertError(t, err, "No error adding a third IPv6 registration in the same /48")
+	test.AssertEquals(t, err.Error(), "too many registrations for this IP range: see https://letsencrypt.org/docs/rate-limits/")
+
+	// Once the window has passed the buckets have refilled.
+	fc.Add(24 * time.Hour)
+	reg.Key = &jose.JSONWebKey{Key: testKey()}
+	reg.InitialIP = net.ParseIP("7.6.6.5")
+	_, err = ra.NewRegistration(ctx, reg)
+	test.AssertNotError(t, err, "Unexpected error adding IPv4 registration after the window")
+}
+
 type NoUpdateSA struct {
 	mocks.StorageAuthority
 }
@@ -1361,6 +1413,65 @@ func TestRateLimitLiveReload(t *testing.T) {
 	test.AssertEquals(t, ra.rlPolicies.CertificatesPerFQDNSet().Threshold, 99999)
 }
 
+// mockSAWithOverrides returns a fixed set of rate limit overrides, or an error.
+type mockSAWithOverrides struct {
+	mocks.StorageAuthority
+	overrides []*sapb.RateLimitOverride
+	err       error
+}
+
+func (m *mockSAWithOverrides) GetRateLimitOverrides(_ context.Context, _ *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &sapb.RateLimitOverrides{Overrides: m.overrides}, nil
+}
+
+func TestRefreshRateLimitOverrides(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	err := ra.SetRateLimitPoliciesFile("../test/rate-limit-policies.yml")
+	test.AssertNotError(t, err, "failed to SetRateLimitPoliciesFile")
+	test.AssertEquals(t, ra.rlPolicies.CertificatesPerName().Overrides["le.wtf"], 10000)
+
+	mockSA := &mockSAWithOverrides{
+		overrides: []*sapb.RateLimitOverride{
+			{Id: 1, LimitName: "certificatesPerName", Key: "le.wtf", Threshold: 10},
+			{Id: 2, LimitName: "certificatesPerName", Key: "example.com", Threshold: 500},
+			{Id: 3, LimitName: "certificatesPerName", Key: "example.com", Threshold: 600},
+			{Id: 4, LimitName: "newOrdersPerAccount", RegistrationID: 7, Threshold: 9000},
+		},
+	}
+	ra.SA = mockSA
+	err = ra.RefreshRateLimitOverrides(ctx)
+	test.AssertNotError(t, err, "failed to refresh overrides")
+
+	// Database overrides take precedence over the policy file, and the newest
+	// of several for the same key wins.
+	certsPerName := ra.rlPolicies.CertificatesPerName()
+	test.AssertEquals(t, certsPerName.GetThreshold("le.wtf", 1), 10)
+	test.AssertEquals(t, certsPerName.GetThreshold("example.com", 1), 600)
+	newOrders := ra.rlPolicies.NewOrdersPerAccount()
+	test.AssertEquals(t, newOrders.GetThreshold("", 7), 9000)
+
+	// A failed refresh leaves the previous overrides in place.
+	mockSA.err = fmt.Errorf("database unavailable")
+	err = ra.RefreshRateLimitOverrides(ctx)
+	test.AssertError(t, err, "refresh should fail when the SA does")
+	certsPerName = ra.rlPolicies.CertificatesPerName()
+	test.AssertEquals(t, certsPerName.GetThreshold("example.com", 1), 600)
+
+	// Once an override is no longer returned by the SA, the policy file
+	// applies again.
+	mockSA.err = nil
+	mockSA.overrides = nil
+	err = ra.RefreshRateLimitOverrides(ctx)
+	test.AssertNotError(t, err, "failed to refresh overrides")
+	certsPerName = ra.rlPolicies.CertificatesPerName()
+	test.AssertEquals(t, certsPerName.GetThreshold("le.wtf", 1), 10000)
+}
+
 type mockSAWithNameCounts struct {
 	mocks.StorageAuthority
 	nameCounts map[string]*sapb.CountByNames_MapElement
@@ -1468,6 +1579,166 @@ func TestCheckCertificatesPerNameLimit(t *testing.T) {
 	test.AssertErrorIs(t, err, berrors.RateLimit)
 }
 
+func TestCertificateLimitsWithLimiter(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.Limiter = ratelimit.NewLimiter(fc, ratelimit.NewInmemSource(), metrics.NoopRegisterer)
+	ra.rlPolicies = &dummyRateLimitConfig{
+		CertificatesPerNamePolicy: ratelimit.RateLimitPolicy{
+			Threshold: 2,
+			Window:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+			Overrides: map[string]int{"bigissuer.com": 3},
+		},
+		CertificatesPerFQDNSetPolicy: ratelimit.RateLimitPolicy{
+			Threshold: 1,
+			Window:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+		},
+	}
+	// Any SA counting query would fail the test.
+	ra.SA = &mockSAWithNameCounts{clk: fc, t: t}
+
+	err := ra.checkLimits(ctx, []string{"www.example.com", "bigissuer.com"}, 99)
+	test.AssertNotError(t, err, "rate limited with empty buckets")
+	ra.spendIssuanceLimits(ctx, []string{"www.example.com", "bigissuer.com"}, 99)
+
+	// The same set of names is now over the duplicate certificate limit.
+	err = ra.checkLimits(ctx, []string{"bigissuer.com", "www.example.com"}, 99)
+	test.AssertErrorIs(t, err, berrors.RateLimit)
+	test.AssertContains(t, err.Error(), "too many certificates already issued for exact set of domains")
+
+	ra.spendIssuanceLimits(ctx, []string{"example.com", "www.bigissuer.com"}, 99)
+
+	// example.com has used up its two certificates, but bigissuer.com has an
+	// override allowing a third.
+	err = ra.checkLimits(ctx, []string{"foo.example.com"}, 99)
+	test.AssertErrorIs(t, err, berrors.RateLimit)
+	test.AssertEquals(t, err.Error(), "too many certificates already issued for: example.com: see https://letsencrypt.org/docs/rate-limits/")
+	// The error says when the bucket will next have room.
+	test.AssertDeepEquals(t, err.(*berrors.BoulderError).RateLimitStatus, &berrors.RateLimitStatus{
+		Limit:      2,
+		Remaining:  0,
+		RetryAfter: 12 * time.Hour,
+		ResetIn:    24 * time.Hour,
+	})
+	err = ra.checkLimits(ctx, []string{"foo.bigissuer.com"}, 99)
+	test.AssertNotError(t, err, "incorrectly rate limited bigissuer.com")
+
+	fc.Add(12 * time.Hour)
+	err = ra.checkLimits(ctx, []string{"foo.example.com"}, 99)
+	test.AssertNotError(t, err, "example.com should have refilled by one certificate")
+}
+
+func TestPendingAuthorizationLimitWithLimiter(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.Limiter = ratelimit.NewLimiter(fc, ratelimit.NewInmemSource(), metrics.NoopRegisterer)
+	ra.rlPolicies = &dummyRateLimitConfig{
+		PendingAuthorizationsPerAccountPolicy: ratelimit.RateLimitPolicy{
+			Threshold: 2,
+			Window:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+		},
+	}
+
+	err := ra.checkPendingAuthorizationLimit(ctx, Registration.ID)
+	test.AssertNotError(t, err, "rate limited with no pending authorizations")
+	ra.spendPendingAuthorizations(ctx, Registration.ID, 2)
+	err = ra.checkPendingAuthorizationLimit(ctx, Registration.ID)
+	test.AssertErrorIs(t, err, berrors.RateLimit)
+
+	// Once an authorization is no longer pending there's room for another.
+	ra.refundPendingAuthorization(ctx, Registration.ID)
+	err = ra.checkPendingAuthorizationLimit(ctx, Registration.ID)
+	test.AssertNotError(t, err, "rate limited after an authorization stopped being pending")
+}
+
+func TestCountStatus(t *testing.T) {
+	limit := ratelimit.RateLimitPolicy{
+		Threshold: 10,
+		Window:    cmd.ConfigDuration{Duration: 3 * time.Hour},
+	}
+	test.AssertDeepEquals(t, countStatus(limit, 10, 0), &berrors.RateLimitStatus{
+		Limit:     10,
+		Remaining: 10,
+	})
+	test.AssertDeepEquals(t, countStatus(limit, 10, 4), &berrors.RateLimitStatus{
+		Limit:     10,
+		Remaining: 6,
+		ResetIn:   3 * time.Hour,
+	})
+	test.AssertDeepEquals(t, countStatus(limit, 10, 12), &berrors.RateLimitStatus{
+		Limit:      10,
+		Remaining:  0,
+		RetryAfter: 3 * time.Hour,
+		ResetIn:    3 * time.Hour,
+	})
+}
+
+func TestGetRateLimitUsageWithLimiter(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.Limiter = ratelimit.NewLimiter(fc, ratelimit.NewInmemSource(), metrics.NoopRegisterer)
+	ra.rlPolicies = &dummyRateLimitConfig{
+		NewOrdersPerAccountPolicy: ratelimit.RateLimitPolicy{
+			Threshold: 10,
+			Window:    cmd.ConfigDuration{Duration: time.Hour},
+		},
+		InvalidAuthorizationsPerAccountPolicy: ratelimit.RateLimitPolicy{
+			Threshold: 3,
+			Window:    cmd.ConfigDuration{Duration: time.Hour},
+		},
+		CertificatesPerNamePolicy: ratelimit.RateLimitPolicy{
+			Threshold: 2,
+			Window:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+		},
+		CertificatesPerFQDNSetPolicy: ratelimit.RateLimitPolicy{
+			Threshold: 1,
+			Window:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+		},
+	}
+	newOrdersLimit := ra.rlPolicies.NewOrdersPerAccount()
+	invalidAuthzLimit := ra.rlPolicies.InvalidAuthorizationsPerAccount()
+	ra.spendBuckets(ctx, 1, newOrdersBucket(newOrdersLimit, 1))
+	ra.spendBuckets(ctx, 3, invalidAuthorizationsBucket(invalidAuthzLimit, 1, "www.example.com"))
+
+	usages, err := ra.GetRateLimitUsage(ctx, &rapb.GetRateLimitUsageRequest{RegistrationID: 1})
+	test.AssertNotError(t, err, "getting rate limit usage")
+	test.AssertEquals(t, len(usages.Usages), 1)
+	test.AssertDeepEquals(t, usages.Usages[0], &rapb.RateLimitUsage{
+		LimitName: ratelimit.NewOrdersPerAccountName,
+		Limit:     10,
+		Remaining: 9,
+		Window:    time.Hour.Nanoseconds(),
+		ResetIn:   (6 * time.Minute).Nanoseconds(),
+	})
+
+	usages, err = ra.GetRateLimitUsage(ctx, &rapb.GetRateLimitUsageRequest{
+		RegistrationID: 1,
+		Names:          []string{"www.example.com", "not-example.com"},
+	})
+	test.AssertNotError(t, err, "getting rate limit usage")
+	byLimitAndKey := make(map[string]*rapb.RateLimitUsage)
+	for _, usage := range usages.Usages {
+		byLimitAndKey[usage.LimitName+":"+usage.Key] = usage
+	}
+	test.AssertEquals(t, len(byLimitAndKey), 6)
+	test.AssertEquals(t, byLimitAndKey["invalidAuthorizationsPerAccount:www.example.com"].Remaining, int64(0))
+	test.AssertEquals(t, byLimitAndKey["invalidAuthorizationsPerAccount:www.example.com"].RetryAfter, (20 * time.Minute).Nanoseconds())
+	test.AssertEquals(t, byLimitAndKey["invalidAuthorizationsPerAccount:not-example.com"].Remaining, int64(3))
+	test.AssertEquals(t, byLimitAndKey["certificatesPerName:example.com"].Remaining, int64(2))
+	test.AssertEquals(t, byLimitAndKey["certificatesPerName:not-example.com"].Remaining, int64(2))
+	test.AssertEquals(t, byLimitAndKey["certificatesPerFQDNSet:not-example.com,www.example.com"].Remaining, int64(1))
+
+	// Names the RA wouldn't issue for are rejected.
+	_, err = ra.GetRateLimitUsage(ctx, &rapb.GetRateLimitUsageRequest{
+		RegistrationID: 1,
+		Names:          []string{"exactblacklist.letsencrypt.org"},
+	})
+	test.AssertErrorIs(t, err, berrors.RejectedIdentifier)
+}
+
 // TestCheckExactCertificateLimit tests that the duplicate certificate limit
 // applied to FQDN sets is respected.
 func TestCheckExactCertificateLimit(t *testing.T) {
@@ -1971,6 +2242,11 @@ func (cf *caaFailer) IsCAAValid(
 		}
 	case "d.com":
 		return nil, fmt.Errorf("Error checking CAA for d.com")
+	case "e.com":
+		cvrpb.Problem = &corepb.ProblemDetails{
+			ProblemType: string(probs.ServerInternalProblem),
+			Detail:      "During secondary CAA checking: Remote IsCAAValid RPC failed",
+		}
 	}
 	return cvrpb, nil
 }
@@ -2068,6 +2344,22 @@ func TestRecheckCAAInternalServerError(t *testing.T) {
 	test.AssertErrorIs(t, err, berrors.InternalServer)
 }
 
+// TestRecheckCAAInternalProblem tests that a VA which couldn't complete the
+// CAA check, for instance because its remote VAs failed, results in an internal
+// error rather than a CAA error.
+func TestRecheckCAAInternalProblem(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+	ra.caa = &caaFailer{}
+	authzs := []*core.Authorization{
+		makeHTTP01Authorization("b.com"),
+		makeHTTP01Authorization("e.com"),
+	}
+	err := ra.recheckCAA(context.Background(), authzs)
+	test.AssertError(t, err, "expected err, got nil")
+	test.AssertErrorIs(t, err, berrors.InternalServer)
+}
+
 func TestNewOrder(t *testing.T) {
 	_, _, ra, fc, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -2275,6 +2567,235 @@ func TestNewOrderReuse(t *testing.T) {
 	}
 }
 
+func TestNewOrderCertificateProfile(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.certProfileNames = map[string]bool{"shortlived": true}
+	ctx := context.Background()
+	names := []string{"profile.zombo.com"}
+
+	// Without the feature, even a recognized profile is rejected, since the SA
+	// wouldn't store it.
+	_, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID:         Registration.ID,
+		Names:                  names,
+		CertificateProfileName: "shortlived",
+	})
+	test.AssertError(t, err, "NewOrder accepted a certificate profile without MultipleCertificateProfiles")
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	err = features.Set(map[string]bool{"MultipleCertificateProfiles": true})
+	test.AssertNotError(t, err, "setting feature flag")
+	defer features.Reset()
+
+	// An unrecognized profile should be rejected outright.
+	_, err = ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID:         Registration.ID,
+		Names:                  names,
+		CertificateProfileName: "longlived",
+	})
+	test.AssertError(t, err, "NewOrder accepted an unrecognized certificate profile")
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	profileOrder, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID:         Registration.ID,
+		Names:                  names,
+		CertificateProfileName: "shortlived",
+	})
+	test.AssertNotError(t, err, "NewOrder failed for a recognized profile")
+	test.AssertEquals(t, profileOrder.CertificateProfileName, "shortlived")
+
+	// An identical request for the same profile should reuse the order.
+	reusedOrder, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID:         Registration.ID,
+		Names:                  names,
+		CertificateProfileName: "shortlived",
+	})
+	test.AssertNotError(t, err, "NewOrder failed for a recognized profile")
+	test.AssertEquals(t, reusedOrder.Id, profileOrder.Id)
+
+	// But the pending order must not be reused for a request for the default
+	// profile.
+	defaultOrder, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: Registration.ID,
+		Names:          names,
+	})
+	test.AssertNotError(t, err, "NewOrder failed for the default profile")
+	test.AssertEquals(t, defaultOrder.CertificateProfileName, "")
+	test.AssertNotEquals(t, defaultOrder.Id, profileOrder.Id)
+}
+
+func TestCheckRequestedValidity(t *testing.T) {
+	fc := clock.NewFake()
+	fc.Set(time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC))
+	now := fc.Now()
+	ra := &RegistrationAuthorityImpl{
+		clk: fc,
+		ValidityBounds: map[string]ValidityBounds{
+			"shortlived": {
+				MaxValidity:       7 * 24 * time.Hour,
+				MaxNotBeforeDelay: 30 * 24 * time.Hour,
+			},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		profile     string
+		notBefore   time.Time
+		notAfter    time.Time
+		expectedErr string
+	}{
+		{
+			name:      "notBefore and notAfter",
+			profile:   "shortlived",
+			notBefore: now.Add(24 * time.Hour),
+			notAfter:  now.Add(8 * 24 * time.Hour),
+		},
+		{
+			name:      "notBefore only",
+			profile:   "shortlived",
+			notBefore: now.Add(30 * 24 * time.Hour),
+		},
+		{
+			name:     "notAfter only",
+			profile:  "shortlived",
+			notAfter: now.Add(24 * time.Hour),
+		},
+		{
+			name:        "profile without bounds",
+			profile:     "",
+			notAfter:    now.Add(24 * time.Hour),
+			expectedErr: "NotBefore and NotAfter are not supported for this certificate profile",
+		},
+		{
+			name:        "notBefore in the past",
+			profile:     "shortlived",
+			notBefore:   now.Add(-time.Hour),
+			expectedErr: "NotBefore must not be in the past",
+		},
+		{
+			name:        "notBefore too far in the future",
+			profile:     "shortlived",
+			notBefore:   now.Add(31 * 24 * time.Hour),
+			expectedErr: "NotBefore must be no more than 720h0m0s in the future",
+		},
+		{
+			name:        "notAfter before notBefore",
+			profile:     "shortlived",
+			notBefore:   now.Add(48 * time.Hour),
+			notAfter:    now.Add(24 * time.Hour),
+			expectedErr: "NotAfter must be after NotBefore",
+		},
+		{
+			name:        "notAfter in the past",
+			profile:     "shortlived",
+			notAfter:    now.Add(-time.Hour),
+			expectedErr: "NotAfter must be after NotBefore",
+		},
+		{
+			name:        "validity too long",
+			profile:     "shortlived",
+			notBefore:   now.Add(24 * time.Hour),
+			notAfter:    now.Add(9 * 24 * time.Hour),
+			expectedErr: "Requested validity period must be no longer than 168h0m0s",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var notBefore, notAfter int64
+			if !tc.notBefore.IsZero() {
+				notBefore = tc.notBefore.UnixNano()
+			}
+			if !tc.notAfter.IsZero() {
+				notAfter = tc.notAfter.UnixNano()
+			}
+			err := ra.checkRequestedValidity(tc.profile, notBefore, notAfter)
+			if tc.expectedErr == "" {
+				test.AssertNotError(t, err, "checkRequestedValidity failed")
+				return
+			}
+			test.AssertError(t, err, "checkRequestedValidity should have failed")
+			test.AssertErrorIs(t, err, berrors.Malformed)
+			test.AssertEquals(t, err.Error(), tc.expectedErr)
+		})
+	}
+}
+
+func TestNewOrderValidity(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	ra.ValidityBounds = map[string]ValidityBounds{
+		"": {MaxValidity: 90 * 24 * time.Hour, MaxNotBeforeDelay: 30 * 24 * time.Hour},
+	}
+	ctx := context.Background()
+	names := []string{"validity.zombo.com"}
+	notBefore := fc.Now().Add(24 * time.Hour).Truncate(time.Second).UnixNano()
+	notAfter := fc.Now().Add(48 * time.Hour).Truncate(time.Second).UnixNano()
+
+	// Without the feature, a window is rejected, since the SA wouldn't store
+	// it.
+	_, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: Registration.ID,
+		Names:          names,
+		NotBefore:      notBefore,
+		NotAfter:       notAfter,
+	})
+	test.AssertError(t, err, "NewOrder accepted a validity window without RequestedOrderValidity")
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	err = features.Set(map[string]bool{"RequestedOrderValidity": true})
+	test.AssertNotError(t, err, "setting feature flag")
+	defer features.Reset()
+
+	// A window outside of the bounds is rejected.
+	_, err = ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: Registration.ID,
+		Names:          names,
+		NotBefore:      fc.Now().Add(60 * 24 * time.Hour).UnixNano(),
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	order, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: Registration.ID,
+		Names:          names,
+		NotBefore:      notBefore,
+		NotAfter:       notAfter,
+	})
+	test.AssertNotError(t, err, "NewOrder failed with a validity window")
+	test.AssertEquals(t, order.NotBefore, notBefore)
+	test.AssertEquals(t, order.NotAfter, notAfter)
+
+	// An identical request should reuse the order.
+	reusedOrder, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: Registration.ID,
+		Names:          names,
+		NotBefore:      notBefore,
+		NotAfter:       notAfter,
+	})
+	test.AssertNotError(t, err, "NewOrder failed with a validity window")
+	test.AssertEquals(t, reusedOrder.Id, order.Id)
+
+	// But a request for a different window, or for none, must not.
+	otherOrder, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: Registration.ID,
+		Names:          names,
+		NotAfter:       notAfter,
+	})
+	test.AssertNotError(t, err, "NewOrder failed with a validity window")
+	test.AssertNotEquals(t, otherOrder.Id, order.Id)
+	test.AssertEquals(t, otherOrder.NotBefore, int64(0))
+	defaultOrder, err := ra.NewOrder(ctx, &rapb.NewOrderRequest{
+		RegistrationID: Registration.ID,
+		Names:          names,
+	})
+	test.AssertNotError(t, err, "NewOrder failed without a validity window")
+	test.AssertNotEquals(t, defaultOrder.Id, order.Id)
+	test.AssertNotEquals(t, defaultOrder.Id, otherOrder.Id)
+}
+
 func TestNewOrderReuseInvalidAuthz(t *testing.T) {
 	_, _, ra, _, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -3216,6 +3737,242 @@ func TestFinalizeOrderWildcard(t *testing.T) {
 		"wildcard order")
 }
 
+type mockSAFinalizeOrder struct {
+	mocks.StorageAuthority
+
+	failed    *corepb.Order
+	finalized *corepb.Order
+}
+
+func (msa *mockSAFinalizeOrder) GetValidOrderAuthorizations2(context.Context, *sapb.GetValidOrderAuthorizationsRequest) (*sapb.Authorizations, error) {
+	return &sapb.Authorizations{}, nil
+}
+
+func (msa *mockSAFinalizeOrder) SetOrderError(_ context.Context, order *corepb.Order) error {
+	msa.failed = order
+	return nil
+}
+
+func (msa *mockSAFinalizeOrder) FinalizeOrder(_ context.Context, order *corepb.Order) error {
+	msa.finalized = order
+	return nil
+}
+
+func TestFinalizeOrderAsync(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	// The mock SA has no valid authorizations for the order, so issuance
+	// will fail once it gets underway.
+	order := &corepb.Order{
+		Id:               1,
+		RegistrationID:   Registration.ID,
+		Expires:          fc.Now().Add(time.Hour).UnixNano(),
+		Names:            []string{"not-example.com", "www.not-example.com"},
+		V2Authorizations: []int64{1, 2},
+		Status:           string(core.StatusReady),
+	}
+
+	// Without AsyncFinalize the failure is returned to the caller.
+	mockSA := &mockSAFinalizeOrder{}
+	ra.SA = mockSA
+	_, err := ra.FinalizeOrder(context.Background(), &rapb.FinalizeOrderRequest{
+		Order: proto.Clone(order).(*corepb.Order),
+		Csr:   ExampleCSR.Raw,
+	})
+	test.AssertError(t, err, "FinalizeOrder succeeded without authorizations")
+	test.AssertErrorIs(t, err, berrors.Unauthorized)
+	test.Assert(t, mockSA.failed != nil, "order was not failed")
+
+	err = features.Set(map[string]bool{"AsyncFinalize": true})
+	test.AssertNotError(t, err, "setting AsyncFinalize")
+	defer features.Reset()
+
+	// With AsyncFinalize the order is returned as processing, and the failure
+	// is only recorded on the order once issuance has been attempted.
+	mockSA = &mockSAFinalizeOrder{}
+	ra.SA = mockSA
+	ctx, cancel := context.WithCancel(context.Background())
+	result, err := ra.FinalizeOrder(ctx, &rapb.FinalizeOrderRequest{
+		Order: proto.Clone(order).(*corepb.Order),
+		Csr:   ExampleCSR.Raw,
+	})
+	// Issuance must not depend on the request's context.
+	cancel()
+	test.AssertNotError(t, err, "FinalizeOrder failed with AsyncFinalize")
+	test.AssertEquals(t, result.Status, string(core.StatusProcessing))
+
+	ra.DrainFinalize()
+	test.Assert(t, mockSA.finalized == nil, "order was finalized without authorizations")
+	test.Assert(t, mockSA.failed != nil, "order was not failed")
+	test.AssertEquals(t, mockSA.failed.Id, order.Id)
+	test.AssertContains(t, mockSA.failed.Error.Detail, "authorizations for these names not found or expired")
+	test.AssertMetricWithLabelsEquals(t, ra.inflightFinalizes, nil, 0)
+}
+
+// mockSAFinalizeOrderAuthorized is a mockSAFinalizeOrder with valid
+// authorizations for every name in ExampleCSR, which records whether the
+// context it was asked to fail the order with was still usable.
+type mockSAFinalizeOrderAuthorized struct {
+	mockSAFinalizeOrder
+	clk          clock.Clock
+	failedCtxErr error
+}
+
+func (msa *mockSAFinalizeOrderAuthorized) GetValidOrderAuthorizations2(context.Context, *sapb.GetValidOrderAuthorizationsRequest) (*sapb.Authorizations, error) {
+	expires := msa.clk.Now().Add(300 * 24 * time.Hour)
+	authzs := &sapb.Authorizations{}
+	for i, name := range ExampleCSR.DNSNames {
+		authzPB, err := bgrpc.AuthzToPB(core.Authorization{
+			ID:             fmt.Sprintf("%d", i+1),
+			Identifier:     identifier.DNSIdentifier(name),
+			RegistrationID: Registration.ID,
+			Status:         core.StatusValid,
+			Expires:        &expires,
+			Challenges: []core.Challenge{{
+				Type:   core.ChallengeTypeHTTP01,
+				Status: core.StatusValid,
+				Token:  core.NewToken(),
+			}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		authzs.Authz = append(authzs.Authz, &sapb.Authorizations_MapElement{Domain: name, Authz: authzPB})
+	}
+	return authzs, nil
+}
+
+func (msa *mockSAFinalizeOrderAuthorized) SetOrderError(ctx context.Context, order *corepb.Order) error {
+	msa.failedCtxErr = ctx.Err()
+	return msa.mockSAFinalizeOrder.SetOrderError(ctx, order)
+}
+
+// mockCABlocking is a mock CA whose issuance never completes before the
+// request's context is done.
+type mockCABlocking struct {
+	mocks.MockCA
+}
+
+func (ca *mockCABlocking) IssuePrecertificate(ctx context.Context, _ *capb.IssueCertificateRequest, _ ...grpc.CallOption) (*capb.IssuePrecertificateResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// TestFinalizeOrderAsyncTimeout tests that an order whose issuance outlasts
+// the finalize timeout is still failed, rather than being left processing.
+func TestFinalizeOrderAsyncTimeout(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	err := features.Set(map[string]bool{"AsyncFinalize": true})
+	test.AssertNotError(t, err, "setting AsyncFinalize")
+	defer features.Reset()
+
+	mockSA := &mockSAFinalizeOrderAuthorized{clk: fc}
+	ra.SA = mockSA
+	ra.CA = &mockCABlocking{}
+	ra.finalizeTimeout = 10 * time.Millisecond
+
+	result, err := ra.FinalizeOrder(context.Background(), &rapb.FinalizeOrderRequest{
+		Order: &corepb.Order{
+			Id:               1,
+			RegistrationID:   Registration.ID,
+			Expires:          fc.Now().Add(time.Hour).UnixNano(),
+			Names:            []string{"not-example.com", "www.not-example.com"},
+			V2Authorizations: []int64{1, 2},
+			Status:           string(core.StatusReady),
+		},
+		Csr: ExampleCSR.Raw,
+	})
+	test.AssertNotError(t, err, "FinalizeOrder failed with AsyncFinalize")
+	test.AssertEquals(t, result.Status, string(core.StatusProcessing))
+
+	ra.DrainFinalize()
+	test.Assert(t, mockSA.finalized == nil, "order was finalized despite the CA timing out")
+	test.Assert(t, mockSA.failed != nil, "order was not failed")
+	test.AssertNotError(t, mockSA.failedCtxErr, "order was failed with an expired context")
+}
+
+// mockCAIssueDirect is a mock CA which issues certificates for CSRs directly,
+// as it would from a profile with CT disabled, and records which of its
+// issuance methods were called.
+type mockCAIssueDirect struct {
+	mocks.MockCA
+	precerts int
+	certs    int
+}
+
+func (ca *mockCAIssueDirect) IssuePrecertificate(context.Context, *capb.IssueCertificateRequest, ...grpc.CallOption) (*capb.IssuePrecertificateResponse, error) {
+	ca.precerts++
+	return nil, fmt.Errorf("precertificates are not issued with CT disabled")
+}
+
+func (ca *mockCAIssueDirect) IssueCertificate(_ context.Context, req *capb.IssueCertificateRequest, _ ...grpc.CallOption) (*corepb.Certificate, error) {
+	ca.certs++
+	csr, err := x509.ParseCertificateRequest(req.Csr)
+	if err != nil {
+		return nil, err
+	}
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: csr.Subject.CommonName},
+		DNSNames:              csr.DNSNames,
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, csr.PublicKey, k)
+	if err != nil {
+		return nil, err
+	}
+	return &corepb.Certificate{
+		RegistrationID: req.RegistrationID,
+		Serial:         core.SerialToString(template.SerialNumber),
+		Der:            der,
+		Digest:         core.Fingerprint256(der),
+		Issued:         template.NotBefore.UnixNano(),
+		Expires:        template.NotAfter.UnixNano(),
+	}, nil
+}
+
+func TestIssueCertificateCTDisabled(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "ecdsa.GenerateKey failed")
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "not-an-example.com"},
+		DNSNames: []string{"not-an-example.com"},
+	}, k)
+	test.AssertNotError(t, err, "x509.CreateCertificateRequest failed")
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	test.AssertNotError(t, err, "x509.ParseCertificateRequest failed")
+	req := core.CertificateRequest{Bytes: csrDER, CSR: csr}
+
+	mockCA := &mockCAIssueDirect{}
+	ra.CA = mockCA
+	ra.CTDisabledProfiles = map[string]bool{"private": true}
+
+	// Profiles which aren't CT-disabled still get a precertificate.
+	_, err = ra.issueCertificateInner(ctx, req, accountID(1), orderID(0), issuance.IssuerNameID(0), issuanceOptions{}, &certificateRequestEvent{})
+	test.AssertError(t, err, "issuance under the default profile should have used a precertificate")
+	test.AssertEquals(t, mockCA.precerts, 1)
+	test.AssertEquals(t, mockCA.certs, 0)
+
+	cert, err := ra.issueCertificateInner(ctx, req, accountID(1), orderID(0), issuance.IssuerNameID(0), issuanceOptions{certProfileName: "private"}, &certificateRequestEvent{})
+	test.AssertNotError(t, err, "issuance under a CT-disabled profile failed")
+	test.AssertEquals(t, mockCA.precerts, 1)
+	test.AssertEquals(t, mockCA.certs, 1)
+	test.AssertEquals(t, cert.Serial, core.SerialToString(big.NewInt(1)))
+}
+
 func TestIssueCertificateAuditLog(t *testing.T) {
 	_, sa, ra, _, cleanUp := initAuthorities(t)
 	defer cleanUp()
@@ -3472,7 +4229,7 @@ func TestCTPolicyMeasurements(t *testing.T) {
 	ra := NewRegistrationAuthorityImpl(fc,
 		log,
 		stats,
-		1, testKeyPolicy, 0, true, 300*24*time.Hour, 7*24*time.Hour, nil, noopCAA{}, 0, ctp, nil, nil)
+		1, testKeyPolicy, 0, true, 300*24*time.Hour, 7*24*time.Hour, nil, noopCAA{}, 0, time.Hour, ctp, nil, nil, nil)
 	ra.SA = ssa
 	ra.CA = ca
 
@@ -3486,11 +4243,23 @@ func TestCTPolicyMeasurements(t *testing.T) {
 
 	_, err := ra.issueCertificate(ctx, core.CertificateRequest{
 		CSR: ExampleCSR,
-	}, accountID(Registration.ID), 0, 0)
+	}, accountID(Registration.ID), 0, 0, issuanceOptions{})
 	test.AssertError(t, err, "ra.issueCertificate didn't fail when CTPolicy.GetSCTs timed out")
 	test.AssertMetricWithLabelsEquals(t, ra.ctpolicyResults, prometheus.Labels{"result": "failure"}, 1)
 }
 
+func TestOnlyDNSChallenges(t *testing.T) {
+	dns01 := &corepb.Challenge{Type: string(core.ChallengeTypeDNS01)}
+	dnsAccount01 := &corepb.Challenge{Type: string(core.ChallengeTypeDNSAccount01)}
+	http01 := &corepb.Challenge{Type: string(core.ChallengeTypeHTTP01)}
+
+	test.Assert(t, !onlyDNSChallenges(nil), "no challenges shouldn't be reusable for a wildcard")
+	test.Assert(t, onlyDNSChallenges([]*corepb.Challenge{dns01}), "DNS-01 should be reusable for a wildcard")
+	test.Assert(t, onlyDNSChallenges([]*corepb.Challenge{dnsAccount01}), "DNS-ACCOUNT-01 should be reusable for a wildcard")
+	test.Assert(t, onlyDNSChallenges([]*corepb.Challenge{dns01, dnsAccount01}), "DNS challenges should be reusable for a wildcard")
+	test.Assert(t, !onlyDNSChallenges([]*corepb.Challenge{dns01, http01}), "HTTP-01 shouldn't be reusable for a wildcard")
+}
+
 func TestWildcardOverlap(t *testing.T) {
 	err := wildcardOverlap([]string{
 		"*.example.com",
@@ -3702,7 +4471,7 @@ func TestIssueCertificateInnerErrs(t *testing.T) {
 			// Mock the CA
 			ra.CA = tc.Mock
 			// Attempt issuance
-			_, err = ra.issueCertificateInner(ctx, req, accountID(Registration.ID), orderID(order.Id), issuance.IssuerNameID(0), logEvent)
+			_, err = ra.issueCertificateInner(ctx, req, accountID(Registration.ID), orderID(order.Id), issuance.IssuerNameID(0), issuanceOptions{}, logEvent)
 			// We expect all of the testcases to fail because all use mocked CAs that deliberately error
 			test.AssertError(t, err, "issueCertificateInner with failing mock CA did not fail")
 			// If there is an expected `error` then match the error message
@@ -3769,6 +4538,73 @@ func TestDisableNewV1Validations(t *testing.T) {
 	}, 1)
 	test.AssertError(t, err, "ra.NewAuthorization failed")
 	test.AssertEquals(t, err.Error(), "Validations for new domains are disabled in the V1 API (https://community.letsencrypt.org/t/end-of-life-plan-for-acmev1/88430)")
+
+	// Pre-authorization in the V2 API isn't affected.
+	authz, err := ra.NewPreAuthorization(context.Background(), &rapb.NewPreAuthorizationRequest{
+		RegistrationID:  1,
+		IdentifierType:  string(identifier.DNS),
+		IdentifierValue: "bloop-not-example.com",
+	})
+	test.AssertNotError(t, err, "ra.NewPreAuthorization failed")
+	test.AssertEquals(t, authz.Id, "1")
+	test.AssertEquals(t, authz.Identifier, "bloop-not-example.com")
+	test.AssertEquals(t, authz.Status, string(core.StatusPending))
+}
+
+func TestNewPreAuthorization(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+	ra.SA = &mockSAPreviousValidations{}
+
+	// Wildcards and names the PA won't issue for are rejected.
+	for _, name := range []string{"*.not-example.com", "exactblacklist.letsencrypt.org"} {
+		_, err := ra.NewPreAuthorization(context.Background(), &rapb.NewPreAuthorizationRequest{
+			RegistrationID:  1,
+			IdentifierType:  string(identifier.DNS),
+			IdentifierValue: name,
+		})
+		test.AssertError(t, err, fmt.Sprintf("pre-authorization of %q should have failed", name))
+	}
+
+	// The pending authorization limit applies.
+	ra.rlPolicies = &dummyRateLimitConfig{
+		PendingAuthorizationsPerAccountPolicy: ratelimit.RateLimitPolicy{
+			Threshold: 1,
+			Window:    cmd.ConfigDuration{Duration: 24 * time.Hour},
+		},
+	}
+	ra.SA = &mockSAWithPendingAuthzCount{
+		mockSAPreviousValidations: mockSAPreviousValidations{},
+		count:                     1,
+	}
+	_, err := ra.NewPreAuthorization(context.Background(), &rapb.NewPreAuthorizationRequest{
+		RegistrationID:  1,
+		IdentifierType:  string(identifier.DNS),
+		IdentifierValue: "Not-Example.com",
+	})
+	test.AssertErrorIs(t, err, berrors.RateLimit)
+
+	ra.SA = &mockSAWithPendingAuthzCount{
+		mockSAPreviousValidations: mockSAPreviousValidations{},
+		count:                     0,
+	}
+	authz, err := ra.NewPreAuthorization(context.Background(), &rapb.NewPreAuthorizationRequest{
+		RegistrationID:  1,
+		IdentifierType:  string(identifier.DNS),
+		IdentifierValue: "Not-Example.com",
+	})
+	test.AssertNotError(t, err, "ra.NewPreAuthorization failed")
+	test.AssertEquals(t, authz.Identifier, "not-example.com")
+	test.AssertEquals(t, authz.RegistrationID, int64(1))
+}
+
+type mockSAWithPendingAuthzCount struct {
+	mockSAPreviousValidations
+	count int64
+}
+
+func (ms *mockSAWithPendingAuthzCount) CountPendingAuthorizations2(_ context.Context, _ *sapb.RegistrationID) (*sapb.Count, error) {
+	return &sapb.Count{Count: ms.count}, nil
 }
 
 func TestNewOrderMaxNames(t *testing.T) {
@@ -3917,3 +4753,296 @@ func TestRevocationAddBlockedKey(t *testing.T) {
 	test.AssertMetricWithLabelsEquals(
 		t, ra.revocationReasonCounter, prometheus.Labels{"reason": "keyCompromise"}, 2)
 }
+
+type mockSARevokeByName struct {
+	mocks.StorageAuthority
+
+	serials []string
+	certs   map[string][]byte
+	revoked []string
+	// direct holds the serials of certs which were issued without a
+	// precertificate.
+	direct map[string]bool
+}
+
+func (msa *mockSARevokeByName) GetUnexpiredSerialsForName(_ context.Context, req *sapb.GetUnexpiredSerialsForNameRequest) (*sapb.Serials, error) {
+	if req.Domain != "not-an-example.com" {
+		return &sapb.Serials{}, nil
+	}
+	return &sapb.Serials{Serials: msa.serials}, nil
+}
+
+func (msa *mockSARevokeByName) GetPrecertificate(_ context.Context, req *sapb.Serial) (*corepb.Certificate, error) {
+	der, ok := msa.certs[req.Serial]
+	if !ok || msa.direct[req.Serial] {
+		return nil, berrors.NotFoundError("no such precertificate")
+	}
+	return &corepb.Certificate{Der: der}, nil
+}
+
+func (msa *mockSARevokeByName) GetCertificate(_ context.Context, serial string) (core.Certificate, error) {
+	der, ok := msa.certs[serial]
+	if !ok {
+		return core.Certificate{}, berrors.NotFoundError("no such certificate")
+	}
+	return core.Certificate{Serial: serial, DER: der}, nil
+}
+
+func (msa *mockSARevokeByName) RevokeCertificate(_ context.Context, req *sapb.RevokeCertificateRequest) error {
+	for _, serial := range msa.revoked {
+		if serial == req.Serial {
+			return berrors.DuplicateError("certificate already revoked")
+		}
+	}
+	msa.revoked = append(msa.revoked, req.Serial)
+	return nil
+}
+
+func TestRevokeCertificatesByName(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "ecdsa.GenerateKey failed")
+	issuerTemplate := x509.Certificate{
+		Subject:               pkix.Name{CommonName: "issuer"},
+		SerialNumber:          big.NewInt(1),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	issuerDER, err := x509.CreateCertificate(rand.Reader, &issuerTemplate, &issuerTemplate, k.Public(), k)
+	test.AssertNotError(t, err, "x509.CreateCertificate failed")
+	issuerCert, err := x509.ParseCertificate(issuerDER)
+	test.AssertNotError(t, err, "x509.ParseCertificate failed")
+	ic := issuance.Certificate{Certificate: issuerCert}
+	ra.issuers = map[issuance.IssuerNameID]*issuance.Certificate{
+		ic.NameID(): &ic,
+	}
+
+	mockSA := &mockSARevokeByName{certs: make(map[string][]byte)}
+	for i := int64(2); i <= 4; i++ {
+		template := x509.Certificate{
+			SerialNumber: big.NewInt(i),
+			DNSNames:     []string{"not-an-example.com"},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, &template, issuerCert, k.Public(), k)
+		test.AssertNotError(t, err, "x509.CreateCertificate failed")
+		serial := core.SerialToString(big.NewInt(i))
+		mockSA.serials = append(mockSA.serials, serial)
+		mockSA.certs[serial] = der
+	}
+	// The second certificate was issued directly, without a precertificate.
+	mockSA.direct = map[string]bool{mockSA.serials[1]: true}
+	// The third certificate is revoked by someone else in the meantime.
+	mockSA.revoked = []string{mockSA.serials[2]}
+	ra.SA = mockSA
+	ra.CA = &mockCAOCSP{}
+	ra.purger = &mockPurger{}
+
+	// An account without an authorization for the name can't revoke anything.
+	_, err = ra.RevokeCertificatesByName(context.Background(), &rapb.RevokeCertificatesByNameRequest{
+		RegistrationID: 2,
+		Domain:         "not-an-example.com",
+		Code:           int64(ocsp.CessationOfOperation),
+	})
+	test.AssertErrorIs(t, err, berrors.Unauthorized)
+	test.AssertEquals(t, len(mockSA.revoked), 1)
+
+	// Wildcards are revoked by their base domain.
+	_, err = ra.RevokeCertificatesByName(context.Background(), &rapb.RevokeCertificatesByNameRequest{
+		RegistrationID: 1,
+		Domain:         "*.not-an-example.com",
+		Code:           int64(ocsp.CessationOfOperation),
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+	test.AssertEquals(t, len(mockSA.revoked), 1)
+
+	resp, err := ra.RevokeCertificatesByName(context.Background(), &rapb.RevokeCertificatesByNameRequest{
+		RegistrationID: 1,
+		Domain:         "Not-An-Example.com",
+		Code:           int64(ocsp.CessationOfOperation),
+	})
+	test.AssertNotError(t, err, "RevokeCertificatesByName failed")
+	test.AssertDeepEquals(t, resp.Serials, mockSA.serials[:2])
+	test.AssertEquals(t, len(mockSA.revoked), 3)
+	test.AssertMetricWithLabelsEquals(
+		t, ra.revocationReasonCounter, prometheus.Labels{"reason": "cessationOfOperation"}, 2)
+
+	// A certificate which can't be found is reported once the rest have been
+	// revoked.
+	mockSA.serials = append(mockSA.serials, "missing")
+	mockSA.revoked = nil
+	_, err = ra.RevokeCertificatesByName(context.Background(), &rapb.RevokeCertificatesByNameRequest{
+		RegistrationID: 1,
+		Domain:         "not-an-example.com",
+		Code:           int64(ocsp.CessationOfOperation),
+	})
+	test.AssertErrorIs(t, err, berrors.InternalServer)
+	test.AssertEquals(t, len(mockSA.revoked), 3)
+}
+
+type mockSARevokeByKey struct {
+	mockSARevokeByName
+
+	keyHash []byte
+	blocked [][]byte
+}
+
+func (msa *mockSARevokeByKey) AddBlockedKey(_ context.Context, req *sapb.AddBlockedKeyRequest) (*corepb.Empty, error) {
+	msa.blocked = append(msa.blocked, req.KeyHash)
+	return &corepb.Empty{}, nil
+}
+
+func (msa *mockSARevokeByKey) GetUnexpiredSerialsForKey(_ context.Context, req *sapb.GetUnexpiredSerialsForKeyRequest) (*sapb.Serials, error) {
+	if !bytes.Equal(req.KeyHash, msa.keyHash) {
+		return &sapb.Serials{}, nil
+	}
+	return &sapb.Serials{Serials: msa.serials}, nil
+}
+
+func TestRevokeCertificatesByKey(t *testing.T) {
+	_, _, ra, _, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "ecdsa.GenerateKey failed")
+	issuerTemplate := x509.Certificate{
+		Subject:               pkix.Name{CommonName: "issuer"},
+		SerialNumber:          big.NewInt(1),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	issuerDER, err := x509.CreateCertificate(rand.Reader, &issuerTemplate, &issuerTemplate, k.Public(), k)
+	test.AssertNotError(t, err, "x509.CreateCertificate failed")
+	issuerCert, err := x509.ParseCertificate(issuerDER)
+	test.AssertNotError(t, err, "x509.ParseCertificate failed")
+	ic := issuance.Certificate{Certificate: issuerCert}
+	ra.issuers = map[issuance.IssuerNameID]*issuance.Certificate{
+		ic.NameID(): &ic,
+	}
+
+	compromised, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "ecdsa.GenerateKey failed")
+	digest, err := core.KeyDigest(compromised.Public())
+	test.AssertNotError(t, err, "core.KeyDigest failed")
+
+	mockSA := &mockSARevokeByKey{
+		mockSARevokeByName: mockSARevokeByName{certs: make(map[string][]byte)},
+		keyHash:            digest[:],
+	}
+	for i := int64(2); i <= 3; i++ {
+		template := x509.Certificate{
+			SerialNumber: big.NewInt(i),
+			DNSNames:     []string{"not-an-example.com"},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, &template, issuerCert, compromised.Public(), k)
+		test.AssertNotError(t, err, "x509.CreateCertificate failed")
+		serial := core.SerialToString(big.NewInt(i))
+		mockSA.serials = append(mockSA.serials, serial)
+		mockSA.certs[serial] = der
+	}
+	ra.SA = mockSA
+	ra.CA = &mockCAOCSP{}
+	ra.purger = &mockPurger{}
+
+	makeCSR := func(cn string) []byte {
+		csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+			Subject: pkix.Name{CommonName: cn},
+		}, compromised)
+		test.AssertNotError(t, err, "x509.CreateCertificateRequest failed")
+		return csr
+	}
+
+	// A CSR which wasn't made for the purpose of reporting the key is refused.
+	_, err = ra.RevokeCertificatesByKey(context.Background(), &rapb.RevokeCertificatesByKeyRequest{
+		Csr: makeCSR("not-an-example.com"),
+	})
+	test.AssertErrorIs(t, err, berrors.BadCSR)
+
+	// So is one whose signature doesn't verify.
+	badSig := makeCSR(KeyCompromiseCSRCommonName)
+	badSig[len(badSig)-1] ^= 0xff
+	_, err = ra.RevokeCertificatesByKey(context.Background(), &rapb.RevokeCertificatesByKeyRequest{
+		Csr: badSig,
+	})
+	test.AssertErrorIs(t, err, berrors.BadCSR)
+	test.AssertEquals(t, len(mockSA.blocked), 0)
+	test.AssertEquals(t, len(mockSA.revoked), 0)
+
+	resp, err := ra.RevokeCertificatesByKey(context.Background(), &rapb.RevokeCertificatesByKeyRequest{
+		Csr: makeCSR(KeyCompromiseCSRCommonName),
+	})
+	test.AssertNotError(t, err, "RevokeCertificatesByKey failed")
+	test.AssertDeepEquals(t, resp.Serials, mockSA.serials)
+	// The key is blocked up front, and again as each certificate is revoked
+	// for keyCompromise.
+	test.AssertEquals(t, len(mockSA.blocked), 3)
+	for _, blocked := range mockSA.blocked {
+		test.AssertByteEquals(t, blocked, digest[:])
+	}
+	test.AssertEquals(t, len(mockSA.revoked), 2)
+	test.AssertMetricWithLabelsEquals(
+		t, ra.revocationReasonCounter, prometheus.Labels{"reason": "keyCompromise"}, 2)
+}
+
+type mockSARenewalInfo struct {
+	mocks.StorageAuthority
+
+	status  string
+	der     []byte
+	blocked bool
+}
+
+func (msari *mockSARenewalInfo) GetCertificateStatusByIssuer(_ context.Context, req *sapb.IssuerSerial) (*corepb.CertificateStatus, error) {
+	return &corepb.CertificateStatus{Serial: req.Serial, Status: msari.status}, nil
+}
+
+func (msari *mockSARenewalInfo) GetPrecertificate(_ context.Context, _ *sapb.Serial) (*corepb.Certificate, error) {
+	return &corepb.Certificate{Der: msari.der}, nil
+}
+
+func (msari *mockSARenewalInfo) KeyBlocked(_ context.Context, _ *sapb.KeyBlockedRequest) (*sapb.Exists, error) {
+	return &sapb.Exists{Exists: msari.blocked}, nil
+}
+
+func TestRenewalInfo(t *testing.T) {
+	_, _, ra, fc, cleanUp := initAuthorities(t)
+	defer cleanUp()
+
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "ecdsa.GenerateKey failed")
+	notBefore := fc.Now().Add(-24 * time.Hour)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(257),
+		NotBefore:    notBefore,
+		NotAfter:     notBefore.Add(90*24*time.Hour - time.Second),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, k.Public(), k)
+	test.AssertNotError(t, err, "x509.CreateCertificate failed")
+
+	mockSA := &mockSARenewalInfo{status: string(core.OCSPStatusGood), der: der}
+	ra.SA = mockSA
+	req := &rapb.RenewalInfoRequest{IssuerID: 1, Serial: "000000000000000000000000000000000101"}
+
+	// A good certificate should get a window two thirds of the way through its
+	// validity period.
+	resp, err := ra.RenewalInfo(context.Background(), req)
+	test.AssertNotError(t, err, "RenewalInfo failed")
+	ideal := notBefore.Add(60 * 24 * time.Hour)
+	test.Assert(t, time.Unix(0, resp.WindowStart).Before(ideal), "window should start before the ideal renewal time")
+	test.Assert(t, time.Unix(0, resp.WindowEnd).After(ideal), "window should end after the ideal renewal time")
+
+	// A certificate whose key has been blocked but which hasn't been revoked
+	// yet should be renewed immediately.
+	mockSA.blocked = true
+	resp, err = ra.RenewalInfo(context.Background(), req)
+	test.AssertNotError(t, err, "RenewalInfo failed")
+	test.Assert(t, time.Unix(0, resp.WindowEnd).Before(fc.Now()), "window should be in the past for a blocked key")
+
+	// As should a revoked certificate.
+	mockSA.blocked = false
+	mockSA.status = string(core.OCSPStatusRevoked)
+	resp, err = ra.RenewalInfo(context.Background(), req)
+	test.AssertNotError(t, err, "RenewalInfo failed")
+	test.Assert(t, time.Unix(0, resp.WindowEnd).Before(fc.Now()), "window should be in the past for a revoked certificate")
+}
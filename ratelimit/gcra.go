@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"time"
+)
+
+// Bucket describes a single token bucket: the Burst requests it holds when
+// full, and the Period over which an empty bucket refills completely. This
+// mirrors a RateLimitPolicy, which allows Threshold requests per Window.
+type Bucket struct {
+	// Key uniquely identifies the bucket's state in a Source.
+	Key    string
+	Burst  int64
+	Period time.Duration
+}
+
+// Decision is the result of asking whether a bucket has room for a request.
+type Decision struct {
+	// Allowed is true if the bucket had room for the request.
+	Allowed bool
+	// Remaining is the number of requests the bucket has room for after this
+	// one.
+	Remaining int64
+	// RetryIn is how long the caller must wait before the request would be
+	// allowed. It is zero if Allowed is true.
+	RetryIn time.Duration
+	// ResetIn is how long until the bucket is full again.
+	ResetIn time.Duration
+
+	// newTAT is the bucket's TAT once the request has been spent.
+	newTAT time.Time
+}
+
+// maybeSpend implements the Generic Cell Rate Algorithm. Given the bucket's
+// current TAT it decides whether a request of the given cost fits within the
+// bucket at time now, and computes the TAT the bucket would have if the
+// request were spent.
+func maybeSpend(now time.Time, b Bucket, tat time.Time, cost int64) *Decision {
+	if b.Burst <= 0 {
+		// A limit of zero never allows anything, and there is nothing to wait
+		// for.
+		return &Decision{Allowed: false, RetryIn: b.Period, newTAT: tat}
+	}
+	emissionInterval := b.Period / time.Duration(b.Burst)
+	burstOffset := emissionInterval * time.Duration(b.Burst)
+
+	if tat.Before(now) {
+		tat = now
+	}
+	newTAT := tat.Add(emissionInterval * time.Duration(cost))
+
+	// The request fits if, after spending it, the bucket still isn't more
+	// than burstOffset ahead of now.
+	difference := now.Sub(newTAT.Add(-burstOffset))
+	if difference < 0 {
+		return &Decision{
+			Allowed:   false,
+			Remaining: int64(now.Sub(tat.Add(-burstOffset)) / emissionInterval),
+			RetryIn:   -difference,
+			ResetIn:   tat.Sub(now),
+			newTAT:    newTAT,
+		}
+	}
+	return &Decision{
+		Allowed:   true,
+		Remaining: int64(difference / emissionInterval),
+		ResetIn:   newTAT.Sub(now),
+		newTAT:    newTAT,
+	}
+}
+
+// maybeRefund returns the TAT the bucket would have if a request of the given
+// cost were returned to it at time now. A bucket can't be refunded past full,
+// so the result is never before now.
+func maybeRefund(now time.Time, b Bucket, tat time.Time, cost int64) time.Time {
+	if b.Burst <= 0 {
+		return now
+	}
+	emissionInterval := b.Period / time.Duration(b.Burst)
+	newTAT := tat.Add(-emissionInterval * time.Duration(cost))
+	if newTAT.Before(now) {
+		return now
+	}
+	return newTAT
+}
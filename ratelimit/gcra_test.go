@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestMaybeSpend(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Ten requests per hour, so one request is refilled every six minutes.
+	b := Bucket{Key: "test", Burst: 10, Period: time.Hour}
+
+	// A full bucket allows a request and has room for nine more.
+	d := maybeSpend(now, b, now, 1)
+	test.Assert(t, d.Allowed, "request to a full bucket should be allowed")
+	test.AssertEquals(t, d.Remaining, int64(9))
+	test.AssertEquals(t, d.RetryIn, time.Duration(0))
+	test.AssertEquals(t, d.ResetIn, 6*time.Minute)
+
+	// A TAT in the past is the same as a full bucket.
+	d = maybeSpend(now, b, now.Add(-24*time.Hour), 1)
+	test.Assert(t, d.Allowed, "request to a long-idle bucket should be allowed")
+	test.AssertEquals(t, d.Remaining, int64(9))
+
+	// Spending the whole bucket at once is allowed, but leaves nothing.
+	d = maybeSpend(now, b, now, 10)
+	test.Assert(t, d.Allowed, "spending the whole bucket should be allowed")
+	test.AssertEquals(t, d.Remaining, int64(0))
+	test.AssertEquals(t, d.ResetIn, time.Hour)
+
+	// An empty bucket denies the next request until one has been refilled.
+	tat := d.newTAT
+	d = maybeSpend(now, b, tat, 1)
+	test.Assert(t, !d.Allowed, "request to an empty bucket should be denied")
+	test.AssertEquals(t, d.Remaining, int64(0))
+	test.AssertEquals(t, d.RetryIn, 6*time.Minute)
+	test.AssertEquals(t, d.ResetIn, time.Hour)
+
+	d = maybeSpend(now.Add(6*time.Minute), b, tat, 1)
+	test.Assert(t, d.Allowed, "request should be allowed once refilled")
+	test.AssertEquals(t, d.Remaining, int64(0))
+
+	// A request costing more than the bucket can hold is never allowed.
+	d = maybeSpend(now, b, now, 11)
+	test.Assert(t, !d.Allowed, "request larger than the bucket should be denied")
+
+	// A zero-sized bucket never allows anything.
+	d = maybeSpend(now, Bucket{Key: "zero", Period: time.Hour}, now, 1)
+	test.Assert(t, !d.Allowed, "request to a zero-sized bucket should be denied")
+	test.AssertEquals(t, d.RetryIn, time.Hour)
+}
+
+func TestMaybeRefund(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := Bucket{Key: "test", Burst: 10, Period: time.Hour}
+
+	test.AssertEquals(t, maybeRefund(now, b, now.Add(time.Hour), 1), now.Add(54*time.Minute))
+	test.AssertEquals(t, maybeRefund(now, b, now.Add(time.Hour), 10), now)
+	// Refunding can't fill the bucket past full.
+	test.AssertEquals(t, maybeRefund(now, b, now.Add(6*time.Minute), 5), now)
+}
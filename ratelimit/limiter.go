@@ -0,0 +1,142 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jmhodges/clock"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Bucket returns the token bucket corresponding to this policy for the given
+// limit name and id. The bucket's size is the policy's threshold, taking into
+// account any overrides for overrideKey or regID, and it refills over the
+// policy's window.
+func (rlp *RateLimitPolicy) Bucket(name, id, overrideKey string, regID int64) Bucket {
+	return Bucket{
+		Key:    name + ":" + id,
+		Burst:  int64(rlp.GetThreshold(overrideKey, regID)),
+		Period: rlp.Window.Duration,
+	}
+}
+
+// LimiterConfig selects the Source in which a Limiter keeps bucket state.
+type LimiterConfig struct {
+	// Redis configures a Redis-compatible server to keep bucket state in, so
+	// that it is shared between instances. If it is omitted, bucket state is
+	// kept in memory.
+	Redis *RedisConfig
+}
+
+// NewSource returns the Source selected by the config.
+func (c LimiterConfig) NewSource() (Source, error) {
+	if c.Redis != nil {
+		return NewRedisSource(*c.Redis)
+	}
+	return NewInmemSource(), nil
+}
+
+// Limiter enforces rate limits using token buckets whose state is kept in a
+// Source. Unlike counting past events in the database, checking a bucket costs
+// a single lookup regardless of how many requests it has seen.
+type Limiter struct {
+	source  Source
+	clk     clock.Clock
+	latency *prometheus.HistogramVec
+}
+
+// NewLimiter returns a Limiter which stores bucket state in the given Source.
+func NewLimiter(clk clock.Clock, source Source, stats prometheus.Registerer) *Limiter {
+	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ratelimits_source_latency",
+		Help:    "Latency of rate limit Source calls, labelled by call and result",
+		Buckets: []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
+	}, []string{"call", "result"})
+	stats.MustRegister(latency)
+
+	return &Limiter{
+		source:  source,
+		clk:     clk,
+		latency: latency,
+	}
+}
+
+func (l *Limiter) observe(call string, start time.Time, err error) {
+	result := "success"
+	if err != nil && !errors.Is(err, ErrBucketNotFound) {
+		result = "failed"
+	}
+	l.latency.With(prometheus.Labels{"call": call, "result": result}).Observe(l.clk.Since(start).Seconds())
+}
+
+// getTAT returns the bucket's current TAT, treating a bucket with no state as
+// full.
+func (l *Limiter) getTAT(ctx context.Context, b Bucket) (time.Time, error) {
+	start := l.clk.Now()
+	tat, err := l.source.Get(ctx, b.Key)
+	l.observe("get", start, err)
+	if errors.Is(err, ErrBucketNotFound) {
+		return l.clk.Now(), nil
+	}
+	return tat, err
+}
+
+// updateTAT atomically replaces the bucket's TAT with the result of calling
+// update on it.
+func (l *Limiter) updateTAT(ctx context.Context, b Bucket, update UpdateFunc) error {
+	start := l.clk.Now()
+	err := l.source.Update(ctx, b.Key, update)
+	l.observe("update", start, err)
+	return err
+}
+
+// Check returns whether the bucket has room for a request of the given cost,
+// without spending anything.
+func (l *Limiter) Check(ctx context.Context, b Bucket, cost int64) (*Decision, error) {
+	tat, err := l.getTAT(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	return maybeSpend(l.clk.Now(), b, tat, cost), nil
+}
+
+// Spend records that requests of the given cost have been made against the
+// bucket. Because it is called once those requests have already succeeded,
+// they are recorded even if the bucket had no room for them; callers should
+// use Check beforehand to decide whether to allow them.
+func (l *Limiter) Spend(ctx context.Context, b Bucket, cost int64) (*Decision, error) {
+	now := l.clk.Now()
+	if b.Burst <= 0 {
+		// There is nothing to record for a bucket which never has room.
+		return maybeSpend(now, b, now, cost), nil
+	}
+	var d *Decision
+	err := l.updateTAT(ctx, b, func(tat time.Time, found bool) (time.Time, time.Duration) {
+		if !found {
+			tat = now
+		}
+		d = maybeSpend(now, b, tat, cost)
+		return d.newTAT, d.newTAT.Sub(now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Refund returns requests of the given cost to the bucket, for instance when
+// the thing being limited no longer exists. The bucket is never refunded past
+// full.
+func (l *Limiter) Refund(ctx context.Context, b Bucket, cost int64) error {
+	now := l.clk.Now()
+	return l.updateTAT(ctx, b, func(tat time.Time, found bool) (time.Time, time.Duration) {
+		if !found {
+			// The bucket is already full.
+			return time.Time{}, 0
+		}
+		// A bucket refunded to full has a TTL of zero, and so no state.
+		newTAT := maybeRefund(now, b, tat, cost)
+		return newTAT, newTAT.Sub(now)
+	})
+}
@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jmhodges/clock"
+
+	"github.com/letsencrypt/boulder/cmd"
+	"github.com/letsencrypt/boulder/metrics"
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestPolicyBucket(t *testing.T) {
+	policy := RateLimitPolicy{
+		Window:                cmd.ConfigDuration{Duration: time.Hour},
+		Threshold:             5,
+		Overrides:             map[string]int{"example.com": 20},
+		RegistrationOverrides: map[int64]int{7: 50},
+	}
+
+	b := policy.Bucket("certificatesPerName", "example.net", "example.net", 1)
+	test.AssertEquals(t, b.Key, "certificatesPerName:example.net")
+	test.AssertEquals(t, b.Burst, int64(5))
+	test.AssertEquals(t, b.Period, time.Hour)
+
+	b = policy.Bucket("certificatesPerName", "example.com", "example.com", 1)
+	test.AssertEquals(t, b.Burst, int64(20))
+
+	b = policy.Bucket("certificatesPerName", "example.com", "example.com", 7)
+	test.AssertEquals(t, b.Burst, int64(50))
+}
+
+// testLimiter exercises a Limiter backed by the given Source.
+func testLimiter(t *testing.T, source Source) {
+	t.Helper()
+	fc := clock.NewFake()
+	fc.Set(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	l := NewLimiter(fc, source, metrics.NoopRegisterer)
+	ctx := context.Background()
+	b := Bucket{Key: "test:1", Burst: 2, Period: time.Hour}
+
+	// Checking doesn't spend anything.
+	for i := 0; i < 3; i++ {
+		d, err := l.Check(ctx, b, 1)
+		test.AssertNotError(t, err, "checking bucket")
+		test.Assert(t, d.Allowed, "check of a full bucket should be allowed")
+		test.AssertEquals(t, d.Remaining, int64(1))
+	}
+
+	d, err := l.Spend(ctx, b, 2)
+	test.AssertNotError(t, err, "spending bucket")
+	test.Assert(t, d.Allowed, "spending a full bucket should be allowed")
+	test.AssertEquals(t, d.Remaining, int64(0))
+
+	d, err = l.Check(ctx, b, 1)
+	test.AssertNotError(t, err, "checking bucket")
+	test.Assert(t, !d.Allowed, "check of an empty bucket should be denied")
+	test.AssertEquals(t, d.RetryIn, 30*time.Minute)
+
+	// Refunding one request makes room for exactly one more.
+	err = l.Refund(ctx, b, 1)
+	test.AssertNotError(t, err, "refunding bucket")
+	d, err = l.Check(ctx, b, 1)
+	test.AssertNotError(t, err, "checking bucket")
+	test.Assert(t, d.Allowed, "check after refund should be allowed")
+	test.AssertEquals(t, d.Remaining, int64(0))
+
+	// Refunding the rest removes the bucket's state entirely.
+	err = l.Refund(ctx, b, 5)
+	test.AssertNotError(t, err, "refunding bucket")
+	_, err = source.Get(ctx, b.Key)
+	test.Assert(t, errors.Is(err, ErrBucketNotFound), "fully refunded bucket should have no state")
+
+	// Refunding a bucket with no state is a no-op.
+	err = l.Refund(ctx, Bucket{Key: "test:2", Burst: 2, Period: time.Hour}, 1)
+	test.AssertNotError(t, err, "refunding a full bucket")
+
+	// Spending past empty is recorded, and the bucket refills with time.
+	_, err = l.Spend(ctx, b, 3)
+	test.AssertNotError(t, err, "spending bucket")
+	d, err = l.Check(ctx, b, 1)
+	test.AssertNotError(t, err, "checking bucket")
+	test.Assert(t, !d.Allowed, "check of an overspent bucket should be denied")
+	test.AssertEquals(t, d.RetryIn, time.Hour)
+	fc.Add(time.Hour)
+	d, err = l.Check(ctx, b, 1)
+	test.AssertNotError(t, err, "checking bucket")
+	test.Assert(t, d.Allowed, "check of a refilled bucket should be allowed")
+}
+
+func TestInmemLimiter(t *testing.T) {
+	testLimiter(t, NewInmemSource())
+}
+
+// testConcurrentSpends spends from a single bucket concurrently through a
+// Limiter for each of the given Sources, which must share their state, and
+// checks that every spend was counted.
+func testConcurrentSpends(t *testing.T, sources []Source) {
+	t.Helper()
+	fc := clock.NewFake()
+	fc.Set(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC))
+	ctx := context.Background()
+	b := Bucket{Key: "test:concurrent", Burst: 100, Period: time.Hour}
+
+	const spendsPerLimiter = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, len(sources)*spendsPerLimiter)
+	for _, source := range sources {
+		l := NewLimiter(fc, source, metrics.NoopRegisterer)
+		for i := 0; i < spendsPerLimiter; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := l.Spend(ctx, b, 1)
+				errs <- err
+			}()
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		test.AssertNotError(t, err, "spending bucket")
+	}
+
+	l := NewLimiter(fc, sources[0], metrics.NoopRegisterer)
+	d, err := l.Check(ctx, b, 1)
+	test.AssertNotError(t, err, "checking bucket")
+	test.AssertEquals(t, d.Remaining, b.Burst-int64(len(sources)*spendsPerLimiter)-1)
+}
+
+func TestInmemLimiterConcurrentSpends(t *testing.T) {
+	testConcurrentSpends(t, []Source{NewInmemSource()})
+}
@@ -0,0 +1,145 @@
+package ratelimit
+
+import (
+	"sync"
+)
+
+// Names of the rate limits, as used for the keys of the policy file and for
+// Overrides.
+const (
+	CertificatesPerNameName             = "certificatesPerName"
+	RegistrationsPerIPName              = "registrationsPerIP"
+	RegistrationsPerIPRangeName         = "registrationsPerIPRange"
+	PendingAuthorizationsPerAccountName = "pendingAuthorizationsPerAccount"
+	InvalidAuthorizationsPerAccountName = "invalidAuthorizationsPerAccount"
+	PendingOrdersPerAccountName         = "pendingOrdersPerAccount"
+	NewOrdersPerAccountName             = "newOrdersPerAccount"
+	CertificatesPerFQDNSetName          = "certificatesPerFQDNSet"
+)
+
+// ValidLimitName returns true if name is the name of a rate limit.
+func ValidLimitName(name string) bool {
+	switch name {
+	case CertificatesPerNameName, RegistrationsPerIPName, RegistrationsPerIPRangeName,
+		PendingAuthorizationsPerAccountName, InvalidAuthorizationsPerAccountName,
+		PendingOrdersPerAccountName, NewOrdersPerAccountName, CertificatesPerFQDNSetName:
+		return true
+	}
+	return false
+}
+
+// Override sets a different threshold for a single key or registration of a
+// rate limit, in the same way as an entry in a RateLimitPolicy's Overrides or
+// RegistrationOverrides. Exactly one of Key and RegistrationID should be set.
+type Override struct {
+	LimitName      string
+	Key            string
+	RegistrationID int64
+	Threshold      int
+}
+
+// OverridableLimits is a Limits whose policies are supplemented by a set of
+// Overrides which can be replaced at any time, for instance by overrides
+// loaded from the database. Where an Override and the policy file both set an
+// override for the same key or registration, the Override wins.
+type OverridableLimits struct {
+	Limits
+
+	sync.RWMutex
+	keyOverrides map[string]map[string]int
+	regOverrides map[string]map[int64]int
+}
+
+// NewOverridable returns an OverridableLimits wrapping the given Limits, with
+// no Overrides.
+func NewOverridable(inner Limits) *OverridableLimits {
+	return &OverridableLimits{Limits: inner}
+}
+
+// SetOverrides replaces the current set of Overrides.
+func (o *OverridableLimits) SetOverrides(overrides []Override) {
+	keyOverrides := make(map[string]map[string]int)
+	regOverrides := make(map[string]map[int64]int)
+	for _, override := range overrides {
+		if override.RegistrationID != 0 {
+			if regOverrides[override.LimitName] == nil {
+				regOverrides[override.LimitName] = make(map[int64]int)
+			}
+			regOverrides[override.LimitName][override.RegistrationID] = override.Threshold
+		} else {
+			if keyOverrides[override.LimitName] == nil {
+				keyOverrides[override.LimitName] = make(map[string]int)
+			}
+			keyOverrides[override.LimitName][override.Key] = override.Threshold
+		}
+	}
+
+	o.Lock()
+	o.keyOverrides = keyOverrides
+	o.regOverrides = regOverrides
+	o.Unlock()
+}
+
+// apply returns a copy of the policy with the current Overrides for the named
+// limit merged into its overrides. The policy's own maps are never modified,
+// since they are shared with every other caller.
+func (o *OverridableLimits) apply(name string, policy RateLimitPolicy) RateLimitPolicy {
+	o.RLock()
+	keyOverrides := o.keyOverrides[name]
+	regOverrides := o.regOverrides[name]
+	o.RUnlock()
+
+	if len(keyOverrides) > 0 {
+		merged := make(map[string]int, len(policy.Overrides)+len(keyOverrides))
+		for k, v := range policy.Overrides {
+			merged[k] = v
+		}
+		for k, v := range keyOverrides {
+			merged[k] = v
+		}
+		policy.Overrides = merged
+	}
+	if len(regOverrides) > 0 {
+		merged := make(map[int64]int, len(policy.RegistrationOverrides)+len(regOverrides))
+		for k, v := range policy.RegistrationOverrides {
+			merged[k] = v
+		}
+		for k, v := range regOverrides {
+			merged[k] = v
+		}
+		policy.RegistrationOverrides = merged
+	}
+	return policy
+}
+
+func (o *OverridableLimits) CertificatesPerName() RateLimitPolicy {
+	return o.apply(CertificatesPerNameName, o.Limits.CertificatesPerName())
+}
+
+func (o *OverridableLimits) RegistrationsPerIP() RateLimitPolicy {
+	return o.apply(RegistrationsPerIPName, o.Limits.RegistrationsPerIP())
+}
+
+func (o *OverridableLimits) RegistrationsPerIPRange() RateLimitPolicy {
+	return o.apply(RegistrationsPerIPRangeName, o.Limits.RegistrationsPerIPRange())
+}
+
+func (o *OverridableLimits) PendingAuthorizationsPerAccount() RateLimitPolicy {
+	return o.apply(PendingAuthorizationsPerAccountName, o.Limits.PendingAuthorizationsPerAccount())
+}
+
+func (o *OverridableLimits) InvalidAuthorizationsPerAccount() RateLimitPolicy {
+	return o.apply(InvalidAuthorizationsPerAccountName, o.Limits.InvalidAuthorizationsPerAccount())
+}
+
+func (o *OverridableLimits) CertificatesPerFQDNSet() RateLimitPolicy {
+	return o.apply(CertificatesPerFQDNSetName, o.Limits.CertificatesPerFQDNSet())
+}
+
+func (o *OverridableLimits) PendingOrdersPerAccount() RateLimitPolicy {
+	return o.apply(PendingOrdersPerAccountName, o.Limits.PendingOrdersPerAccount())
+}
+
+func (o *OverridableLimits) NewOrdersPerAccount() RateLimitPolicy {
+	return o.apply(NewOrdersPerAccountName, o.Limits.NewOrdersPerAccount())
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+func TestOverridableLimits(t *testing.T) {
+	inner := New()
+	err := inner.LoadPolicies([]byte(`
+certificatesPerName:
+  window: 2160h
+  threshold: 2
+  overrides:
+    ratelimit.me: 1
+    le.wtf: 10000
+  registrationOverrides:
+    101: 1000
+newOrdersPerAccount:
+  window: 3h
+  threshold: 1500
+`))
+	test.AssertNotError(t, err, "loading policies")
+
+	limits := NewOverridable(inner)
+	certsPerName := limits.CertificatesPerName()
+	test.AssertEquals(t, certsPerName.GetThreshold("ratelimit.me", 1), 1)
+
+	limits.SetOverrides([]Override{
+		{LimitName: CertificatesPerNameName, Key: "ratelimit.me", Threshold: 50},
+		{LimitName: CertificatesPerNameName, Key: "example.com", Threshold: 20},
+		{LimitName: CertificatesPerNameName, RegistrationID: 101, Threshold: 5},
+		{LimitName: NewOrdersPerAccountName, RegistrationID: 7, Threshold: 3000},
+	})
+
+	certsPerName = limits.CertificatesPerName()
+	// Overrides replace those in the policy file for the same key or
+	// registration, and leave the others alone.
+	test.AssertEquals(t, certsPerName.GetThreshold("ratelimit.me", 1), 50)
+	test.AssertEquals(t, certsPerName.GetThreshold("example.com", 1), 20)
+	test.AssertEquals(t, certsPerName.GetThreshold("le.wtf", 1), 10000)
+	test.AssertEquals(t, certsPerName.GetThreshold("example.net", 101), 5)
+	test.AssertEquals(t, certsPerName.GetThreshold("example.net", 1), 2)
+
+	newOrders := limits.NewOrdersPerAccount()
+	test.AssertEquals(t, newOrders.GetThreshold("", 7), 3000)
+	test.AssertEquals(t, newOrders.GetThreshold("", 8), 1500)
+
+	// The underlying policy's maps are left untouched.
+	orig := inner.CertificatesPerName()
+	test.AssertEquals(t, orig.GetThreshold("ratelimit.me", 1), 1)
+	_, ok := orig.Overrides["example.com"]
+	test.Assert(t, !ok, "override leaked into the underlying policy")
+
+	// Replacing the overrides drops any which are no longer present.
+	limits.SetOverrides(nil)
+	certsPerName = limits.CertificatesPerName()
+	test.AssertEquals(t, certsPerName.GetThreshold("ratelimit.me", 1), 1)
+	test.AssertEquals(t, certsPerName.GetThreshold("example.com", 1), 2)
+}
+
+func TestValidLimitName(t *testing.T) {
+	test.Assert(t, ValidLimitName(CertificatesPerNameName), "certificatesPerName should be valid")
+	test.Assert(t, ValidLimitName("newOrdersPerAccount"), "newOrdersPerAccount should be valid")
+	test.Assert(t, !ValidLimitName("certificatesPerDomain"), "certificatesPerDomain should not be valid")
+	test.Assert(t, !ValidLimitName(""), "empty name should not be valid")
+}
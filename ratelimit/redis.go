@@ -0,0 +1,323 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/letsencrypt/boulder/cmd"
+)
+
+// RedisConfig configures a Source backed by a Redis-compatible server. Any
+// server which speaks RESP and supports GET, SET with PX, DEL, AUTH and
+// transactions (WATCH, MULTI and EXEC) will do.
+type RedisConfig struct {
+	// Addr is the host:port of the server.
+	Addr string
+	// Password, if not empty, is sent with AUTH on every new connection.
+	Password string
+	// Timeout bounds each command, including dialing a new connection if one
+	// is needed. Defaults to one second.
+	Timeout cmd.ConfigDuration
+	// PoolSize is the maximum number of idle connections kept open to the
+	// server. Defaults to ten.
+	PoolSize int
+}
+
+// redisError is an error reply sent by the server.
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// redisConn is a single connection to the server.
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+	w *bufio.Writer
+}
+
+// maxUpdateAttempts bounds how many times redisSource.Update retries a
+// transaction which was aborted by a concurrent update to the same bucket.
+const maxUpdateAttempts = 100
+
+// redisSource is a Source which keeps bucket state in a Redis-compatible
+// server, so that it can be shared by many RAs. TATs are stored as decimal
+// nanoseconds since the epoch, and expire once the bucket is full.
+type redisSource struct {
+	addr     string
+	password string
+	timeout  time.Duration
+	pool     chan *redisConn
+}
+
+// NewRedisSource returns a Source which keeps bucket state in the configured
+// Redis-compatible server. Connections are made lazily.
+func NewRedisSource(c RedisConfig) (Source, error) {
+	if c.Addr == "" {
+		return nil, errors.New("redis source requires an address")
+	}
+	timeout := c.Timeout.Duration
+	if timeout == 0 {
+		timeout = time.Second
+	}
+	poolSize := c.PoolSize
+	if poolSize == 0 {
+		poolSize = 10
+	}
+	return &redisSource{
+		addr:     c.Addr,
+		password: c.Password,
+		timeout:  timeout,
+		pool:     make(chan *redisConn, poolSize),
+	}, nil
+}
+
+func (s *redisSource) dial(ctx context.Context, deadline time.Time) (*redisConn, error) {
+	dialer := &net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return nil, err
+	}
+	rc := &redisConn{Conn: conn, r: bufio.NewReader(conn), w: bufio.NewWriter(conn)}
+	if s.password != "" {
+		err = rc.SetDeadline(deadline)
+		if err == nil {
+			_, err = rc.do("AUTH", s.password)
+		}
+		if err != nil {
+			_ = rc.Close()
+			return nil, err
+		}
+	}
+	return rc, nil
+}
+
+// withConn calls fn with a pooled connection, whose deadline covers every
+// command fn sends. The connection is returned to the pool unless fn fails
+// with anything other than an error reply from the server.
+func (s *redisSource) withConn(ctx context.Context, fn func(*redisConn) error) error {
+	deadline := time.Now().Add(s.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	var conn *redisConn
+	select {
+	case conn = <-s.pool:
+	default:
+		var err error
+		conn, err = s.dial(ctx, deadline)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := conn.SetDeadline(deadline)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	err = fn(conn)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		// The connection is in an unknown state.
+		_ = conn.Close()
+		return err
+	}
+
+	select {
+	case s.pool <- conn:
+	default:
+		_ = conn.Close()
+	}
+	return err
+}
+
+// do runs a single command on a pooled connection and returns its reply.
+func (s *redisSource) do(ctx context.Context, args ...string) (interface{}, error) {
+	var reply interface{}
+	err := s.withConn(ctx, func(conn *redisConn) error {
+		var err error
+		reply, err = conn.do(args...)
+		return err
+	})
+	return reply, err
+}
+
+// parseTAT parses the reply to a GET of the given key.
+func parseTAT(key string, reply interface{}) (time.Time, bool, error) {
+	if reply == nil {
+		return time.Time{}, false, nil
+	}
+	val, ok := reply.([]byte)
+	if !ok {
+		return time.Time{}, false, fmt.Errorf("redis: unexpected reply to GET: %v", reply)
+	}
+	nanos, err := strconv.ParseInt(string(val), 10, 64)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("redis: malformed TAT for %q: %w", key, err)
+	}
+	return time.Unix(0, nanos), true, nil
+}
+
+// Get implements Source.
+func (s *redisSource) Get(ctx context.Context, key string) (time.Time, error) {
+	reply, err := s.do(ctx, "GET", key)
+	if err != nil {
+		return time.Time{}, err
+	}
+	tat, found, err := parseTAT(key, reply)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !found {
+		return time.Time{}, ErrBucketNotFound
+	}
+	return tat, nil
+}
+
+// Update implements Source. The bucket is read under WATCH and written in a
+// MULTI/EXEC transaction, which the server aborts if another client wrote the
+// bucket in between, in which case the update is retried.
+func (s *redisSource) Update(ctx context.Context, key string, update UpdateFunc) error {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		var committed bool
+		err := s.withConn(ctx, func(conn *redisConn) error {
+			_, err := conn.do("WATCH", key)
+			if err != nil {
+				return err
+			}
+			reply, err := conn.do("GET", key)
+			if err != nil {
+				return err
+			}
+			tat, found, err := parseTAT(key, reply)
+			if err != nil {
+				return err
+			}
+
+			newTAT, ttl := update(tat, found)
+			args := []string{"DEL", key}
+			if ttl > 0 {
+				ttlMillis := ttl.Milliseconds()
+				if ttlMillis < 1 {
+					// PX must be positive. Keep the value briefly rather than
+					// not at all, so that a concurrent Get doesn't see a
+					// stale TAT.
+					ttlMillis = 1
+				}
+				args = []string{"SET", key, strconv.FormatInt(newTAT.UnixNano(), 10), "PX", strconv.FormatInt(ttlMillis, 10)}
+			}
+
+			_, err = conn.do("MULTI")
+			if err != nil {
+				return err
+			}
+			// An error reply to a queued command makes the server abort the
+			// transaction, so EXEC is always sent to end it.
+			_, err = conn.do(args...)
+			var redisErr redisError
+			if err != nil && !errors.As(err, &redisErr) {
+				return err
+			}
+			reply, err = conn.do("EXEC")
+			if err != nil {
+				return err
+			}
+			// A null reply means that the watched bucket was written by
+			// another client.
+			committed = reply != nil
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if committed {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return fmt.Errorf("redis: gave up updating %q after %d conflicting attempts", key, maxUpdateAttempts)
+}
+
+// do writes a command to the connection and reads its reply.
+func (c *redisConn) do(args ...string) (interface{}, error) {
+	err := writeCommand(c.w, args...)
+	if err != nil {
+		return nil, err
+	}
+	return readReply(c.r)
+}
+
+// writeCommand writes a command as a RESP array of bulk strings.
+func writeCommand(w *bufio.Writer, args ...string) error {
+	fmt.Fprintf(w, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return w.Flush()
+}
+
+// readReply reads a single RESP reply. Simple strings and bulk strings are
+// returned as []byte, integers as int64, arrays as []interface{}, and a null
+// bulk string or array as nil. Error replies are returned as a redisError.
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply line %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return []byte(body), nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk string length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		_, err = io.ReadFull(r, buf)
+		if err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", body)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		elems := make([]interface{}, n)
+		for i := range elems {
+			elems[i], err = readReply(r)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return elems, nil
+	default:
+		return nil, fmt.Errorf("redis: unknown reply type %q", kind)
+	}
+}
@@ -0,0 +1,274 @@
+package ratelimit
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/letsencrypt/boulder/test"
+)
+
+// fakeRedis is a minimal stand-in for a Redis server which supports only the
+// commands used by redisSource.
+type fakeRedis struct {
+	sync.Mutex
+	listener net.Listener
+	password string
+	values   map[string]string
+	expiries map[string]time.Time
+	// versions counts the writes to each key, so that a transaction can be
+	// aborted if a key it watched has been written since.
+	versions map[string]int
+	// aborted counts the transactions aborted by a conflicting write.
+	aborted int
+}
+
+// fakeRedisTx is the transaction state of a single connection.
+type fakeRedisTx struct {
+	watched map[string]int
+	queued  [][]string
+	inMulti bool
+}
+
+func newFakeRedis(t *testing.T, password string) *fakeRedis {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	test.AssertNotError(t, err, "listening")
+	fr := &fakeRedis{
+		listener: l,
+		password: password,
+		values:   make(map[string]string),
+		expiries: make(map[string]time.Time),
+		versions: make(map[string]int),
+	}
+	t.Cleanup(func() { l.Close() })
+	go fr.serve()
+	return fr
+}
+
+func (fr *fakeRedis) serve() {
+	for {
+		conn, err := fr.listener.Accept()
+		if err != nil {
+			return
+		}
+		go fr.handle(conn)
+	}
+}
+
+func (fr *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	authed := fr.password == ""
+	tx := &fakeRedisTx{watched: make(map[string]int)}
+	for {
+		req, err := readReply(r)
+		if err != nil {
+			return
+		}
+		elems, ok := req.([]interface{})
+		if !ok || len(elems) == 0 {
+			return
+		}
+		var args []string
+		for _, e := range elems {
+			args = append(args, string(e.([]byte)))
+		}
+		if args[0] == "AUTH" {
+			if len(args) == 2 && args[1] == fr.password {
+				authed = true
+				fmt.Fprint(w, "+OK\r\n")
+			} else {
+				fmt.Fprint(w, "-WRONGPASS invalid password\r\n")
+			}
+		} else if !authed {
+			fmt.Fprint(w, "-NOAUTH Authentication required.\r\n")
+		} else {
+			fr.execTx(w, tx, args)
+		}
+		err = w.Flush()
+		if err != nil {
+			return
+		}
+	}
+}
+
+// execTx runs a command on behalf of a connection with the given transaction
+// state.
+func (fr *fakeRedis) execTx(w *bufio.Writer, tx *fakeRedisTx, args []string) {
+	fr.Lock()
+	defer fr.Unlock()
+	switch {
+	case args[0] == "WATCH" && len(args) == 2 && !tx.inMulti:
+		tx.watched[args[1]] = fr.versions[args[1]]
+		fmt.Fprint(w, "+OK\r\n")
+	case args[0] == "UNWATCH" && len(args) == 1 && !tx.inMulti:
+		tx.watched = make(map[string]int)
+		fmt.Fprint(w, "+OK\r\n")
+	case args[0] == "MULTI" && len(args) == 1 && !tx.inMulti:
+		tx.inMulti = true
+		fmt.Fprint(w, "+OK\r\n")
+	case args[0] == "EXEC" && len(args) == 1 && tx.inMulti:
+		conflict := false
+		for key, version := range tx.watched {
+			if fr.versions[key] != version {
+				conflict = true
+			}
+		}
+		if conflict {
+			fr.aborted++
+			fmt.Fprint(w, "*-1\r\n")
+		} else {
+			fmt.Fprintf(w, "*%d\r\n", len(tx.queued))
+			for _, queued := range tx.queued {
+				fr.exec(w, queued)
+			}
+		}
+		*tx = fakeRedisTx{watched: make(map[string]int)}
+	case tx.inMulti:
+		tx.queued = append(tx.queued, args)
+		fmt.Fprint(w, "+QUEUED\r\n")
+	default:
+		fr.exec(w, args)
+	}
+}
+
+// exec runs a single command. The caller must hold the lock.
+func (fr *fakeRedis) exec(w *bufio.Writer, args []string) {
+	switch {
+	case args[0] == "GET" && len(args) == 2:
+		val, ok := fr.values[args[1]]
+		if !ok || time.Now().After(fr.expiries[args[1]]) {
+			fmt.Fprint(w, "$-1\r\n")
+			return
+		}
+		fmt.Fprintf(w, "$%d\r\n%s\r\n", len(val), val)
+	case args[0] == "SET" && len(args) == 5 && args[3] == "PX":
+		ms, err := strconv.Atoi(args[4])
+		if err != nil || ms <= 0 {
+			fmt.Fprint(w, "-ERR invalid expire time in 'set' command\r\n")
+			return
+		}
+		fr.values[args[1]] = args[2]
+		fr.expiries[args[1]] = time.Now().Add(time.Duration(ms) * time.Millisecond)
+		fr.versions[args[1]]++
+		fmt.Fprint(w, "+OK\r\n")
+	case args[0] == "DEL" && len(args) == 2:
+		_, ok := fr.values[args[1]]
+		delete(fr.values, args[1])
+		delete(fr.expiries, args[1])
+		if ok {
+			fr.versions[args[1]]++
+		}
+		if ok {
+			fmt.Fprint(w, ":1\r\n")
+		} else {
+			fmt.Fprint(w, ":0\r\n")
+		}
+	default:
+		fmt.Fprintf(w, "-ERR unknown command '%s'\r\n", args[0])
+	}
+}
+
+func TestRedisLimiter(t *testing.T) {
+	fr := newFakeRedis(t, "hunter2")
+	source, err := NewRedisSource(RedisConfig{Addr: fr.listener.Addr().String(), Password: "hunter2"})
+	test.AssertNotError(t, err, "creating redis source")
+	testLimiter(t, source)
+}
+
+func TestRedisSource(t *testing.T) {
+	fr := newFakeRedis(t, "hunter2")
+	ctx := context.Background()
+
+	_, err := NewRedisSource(RedisConfig{})
+	test.AssertError(t, err, "redis source without an address should fail")
+
+	source, err := NewRedisSource(RedisConfig{Addr: fr.listener.Addr().String(), Password: "wrong"})
+	test.AssertNotError(t, err, "creating redis source")
+	_, err = source.Get(ctx, "foo")
+	test.AssertError(t, err, "get with the wrong password should fail")
+
+	source, err = NewRedisSource(RedisConfig{Addr: fr.listener.Addr().String(), Password: "hunter2"})
+	test.AssertNotError(t, err, "creating redis source")
+
+	_, err = source.Get(ctx, "foo")
+	test.AssertEquals(t, err, ErrBucketNotFound)
+
+	tat := time.Unix(0, 1609459200123456789)
+	err = source.Update(ctx, "foo", func(old time.Time, found bool) (time.Time, time.Duration) {
+		test.Assert(t, !found, "missing bucket should not be found")
+		return tat, time.Hour
+	})
+	test.AssertNotError(t, err, "setting TAT")
+	got, err := source.Get(ctx, "foo")
+	test.AssertNotError(t, err, "getting TAT")
+	test.Assert(t, got.Equal(tat), fmt.Sprintf("expected TAT %s, got %s", tat, got))
+
+	// The update is passed the current TAT.
+	err = source.Update(ctx, "foo", func(old time.Time, found bool) (time.Time, time.Duration) {
+		test.Assert(t, found, "bucket should be found")
+		test.Assert(t, old.Equal(tat), fmt.Sprintf("expected TAT %s, got %s", tat, old))
+		return old.Add(time.Minute), time.Hour
+	})
+	test.AssertNotError(t, err, "updating TAT")
+	got, err = source.Get(ctx, "foo")
+	test.AssertNotError(t, err, "getting TAT")
+	test.Assert(t, got.Equal(tat.Add(time.Minute)), fmt.Sprintf("expected TAT %s, got %s", tat.Add(time.Minute), got))
+
+	// Values expire once their TTL has passed.
+	err = source.Update(ctx, "bar", func(time.Time, bool) (time.Time, time.Duration) {
+		return tat, time.Microsecond
+	})
+	test.AssertNotError(t, err, "setting TAT with a tiny TTL")
+	time.Sleep(5 * time.Millisecond)
+	_, err = source.Get(ctx, "bar")
+	test.AssertEquals(t, err, ErrBucketNotFound)
+
+	// A TTL of zero removes the value.
+	err = source.Update(ctx, "foo", func(time.Time, bool) (time.Time, time.Duration) {
+		return time.Time{}, 0
+	})
+	test.AssertNotError(t, err, "deleting TAT")
+	_, err = source.Get(ctx, "foo")
+	test.AssertEquals(t, err, ErrBucketNotFound)
+
+	// A malformed value is an error, not a missing bucket.
+	fr.Lock()
+	fr.values["baz"] = "not a number"
+	fr.expiries["baz"] = time.Now().Add(time.Hour)
+	fr.Unlock()
+	_, err = source.Get(ctx, "baz")
+	test.AssertError(t, err, "getting a malformed TAT should fail")
+	test.Assert(t, err != ErrBucketNotFound, "malformed TAT should not be reported as missing")
+
+	// Once the server goes away, calls fail rather than hang.
+	fr.listener.Close()
+	source, err = NewRedisSource(RedisConfig{Addr: fr.listener.Addr().String()})
+	test.AssertNotError(t, err, "creating redis source")
+	_, err = source.Get(ctx, "foo")
+	test.AssertError(t, err, "get from a closed server should fail")
+}
+
+func TestRedisLimiterConcurrentSpends(t *testing.T) {
+	fr := newFakeRedis(t, "")
+	// Each source stands in for a separate RA sharing the same server.
+	var sources []Source
+	for i := 0; i < 2; i++ {
+		source, err := NewRedisSource(RedisConfig{Addr: fr.listener.Addr().String()})
+		test.AssertNotError(t, err, "creating redis source")
+		sources = append(sources, source)
+	}
+	testConcurrentSpends(t, sources)
+
+	// The spends really did conflict, and were retried rather than lost.
+	fr.Lock()
+	defer fr.Unlock()
+	test.Assert(t, fr.aborted > 0, "expected some transactions to be aborted")
+}
@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrBucketNotFound is returned by a Source when it has no state for the
+// requested bucket, either because the bucket has never been spent from or
+// because it has been full long enough for its state to expire.
+var ErrBucketNotFound = errors.New("bucket not found")
+
+// UpdateFunc computes a bucket's new TAT from its current one. found is false
+// if the bucket has no state, in which case tat is the zero time. The new TAT
+// may be discarded once ttl has elapsed, since by then the bucket is full; if
+// ttl isn't positive, the bucket's state is removed instead. An UpdateFunc may
+// be called more than once for a single update, so it must not have side
+// effects beyond recording its result.
+type UpdateFunc func(tat time.Time, found bool) (newTAT time.Time, ttl time.Duration)
+
+// Source is the interface implemented by the places a Limiter can store the
+// state of its buckets. The only state stored for a bucket is its theoretical
+// arrival time (TAT): the time at which the bucket will be full again.
+type Source interface {
+	// Get returns the TAT of the bucket with the given key, or
+	// ErrBucketNotFound if it has none.
+	Get(ctx context.Context, key string) (time.Time, error)
+
+	// Update atomically replaces the TAT of the bucket with the given key
+	// with the result of calling update on it, so that concurrent updates,
+	// including those made by other Limiters sharing the Source, are never
+	// lost.
+	Update(ctx context.Context, key string, update UpdateFunc) error
+}
+
+// inmemSource is a Source which keeps bucket state in memory. It is suitable
+// for tests and for deployments with a single RA, since its state is neither
+// shared nor persisted.
+type inmemSource struct {
+	sync.Mutex
+	tats map[string]time.Time
+}
+
+// NewInmemSource returns a Source which keeps bucket state in memory.
+func NewInmemSource() Source {
+	return &inmemSource{tats: make(map[string]time.Time)}
+}
+
+// Get implements Source.
+func (s *inmemSource) Get(_ context.Context, key string) (time.Time, error) {
+	s.Lock()
+	defer s.Unlock()
+	tat, ok := s.tats[key]
+	if !ok {
+		return time.Time{}, ErrBucketNotFound
+	}
+	return tat, nil
+}
+
+// Update implements Source. A positive ttl is otherwise ignored: a TAT in the
+// past is equivalent to a full bucket, so stale entries only cost memory.
+func (s *inmemSource) Update(_ context.Context, key string, update UpdateFunc) error {
+	s.Lock()
+	defer s.Unlock()
+	tat, found := s.tats[key]
+	newTAT, ttl := update(tat, found)
+	if ttl <= 0 {
+		delete(s.tats, key)
+		return nil
+	}
+	s.tats[key] = newTAT
+	return nil
+}
@@ -0,0 +1,26 @@
+
+-- +goose Up
+-- SQL in section 'Up' is executed when this migration is applied
+
+CREATE TABLE `externalAccountKeys` (
+  `id` bigint(20) NOT NULL AUTO_INCREMENT,
+  `keyID` varchar(255) NOT NULL,
+  `macKey` varbinary(255) NOT NULL,
+  `createdAt` datetime NOT NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `keyID` (`keyID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+CREATE TABLE `externalAccountBindings` (
+  `registrationID` bigint(20) NOT NULL,
+  `keyID` varchar(255) NOT NULL,
+  `createdAt` datetime NOT NULL,
+  PRIMARY KEY (`registrationID`),
+  KEY `keyID_idx` (`keyID`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8;
+
+-- +goose Down
+-- SQL section 'Down' is executed when this migration is rolled back
+
+DROP TABLE `externalAccountBindings`;
+DROP TABLE `externalAccountKeys`;
@@ -187,6 +187,8 @@ func initTables(dbMap *gorp.DbMap) {
 	dbMap.AddTableWithName(core.CertificateStatus{}, "certificateStatus").SetKeys(false, "Serial")
 	dbMap.AddTableWithName(core.FQDNSet{}, "fqdnSets").SetKeys(true, "ID")
 	dbMap.AddTableWithName(orderModel{}, "orders").SetKeys(true, "ID")
+	dbMap.AddTableWithName(orderModelv2{}, "orders").SetKeys(true, "ID")
+	dbMap.AddTableWithName(orderModelv3{}, "orders").SetKeys(true, "ID")
 	dbMap.AddTableWithName(orderToAuthzModel{}, "orderToAuthz").SetKeys(false, "OrderID", "AuthzID")
 	dbMap.AddTableWithName(requestedNameModel{}, "requestedNames").SetKeys(false, "OrderID")
 	dbMap.AddTableWithName(orderFQDNSet{}, "orderFqdnSets").SetKeys(true, "ID")
@@ -195,4 +197,7 @@ func initTables(dbMap *gorp.DbMap) {
 	dbMap.AddTableWithName(recordedSerialModel{}, "serials").SetKeys(true, "ID")
 	dbMap.AddTableWithName(precertificateModel{}, "precertificates").SetKeys(true, "ID")
 	dbMap.AddTableWithName(keyHashModel{}, "keyHashToSerial").SetKeys(true, "ID")
+	dbMap.AddTableWithName(externalAccountKeyModel{}, "externalAccountKeys").SetKeys(true, "ID")
+	dbMap.AddTableWithName(externalAccountBindingModel{}, "externalAccountBindings").SetKeys(false, "RegistrationID")
+	dbMap.AddTableWithName(rateLimitOverrideModel{}, "rateLimitOverrides").SetKeys(true, "ID")
 }
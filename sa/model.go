@@ -17,7 +17,9 @@ import (
 	corepb "github.com/letsencrypt/boulder/core/proto"
 	"github.com/letsencrypt/boulder/db"
 	"github.com/letsencrypt/boulder/grpc"
+	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/probs"
+	"github.com/letsencrypt/boulder/revocation"
 )
 
 // errBadJSON is an error type returned when a json.Unmarshal performed by the
@@ -308,6 +310,38 @@ type orderModel struct {
 	BeganProcessing   bool
 }
 
+// orderModelv2 is identical to orderModel, but also includes the
+// certificateProfileName column, which only exists in the db-next schema. It is
+// used in place of orderModel when the MultipleCertificateProfiles feature is
+// enabled.
+type orderModelv2 struct {
+	ID                     int64
+	RegistrationID         int64
+	Expires                time.Time
+	Created                time.Time
+	Error                  []byte
+	CertificateSerial      string
+	BeganProcessing        bool
+	CertificateProfileName *string
+}
+
+// orderModelv3 is identical to orderModelv2, but also includes the notBefore
+// and notAfter columns, which were added to the db-next schema after the
+// certificateProfileName column. It is used in place of the other order models
+// when the RequestedOrderValidity feature is enabled.
+type orderModelv3 struct {
+	ID                     int64
+	RegistrationID         int64
+	Expires                time.Time
+	Created                time.Time
+	Error                  []byte
+	CertificateSerial      string
+	BeganProcessing        bool
+	CertificateProfileName *string
+	NotBefore              *time.Time
+	NotAfter               *time.Time
+}
+
 type requestedNameModel struct {
 	ID           int64
 	OrderID      int64
@@ -365,24 +399,70 @@ func modelToOrder(om *orderModel) (*corepb.Order, error) {
 	return order, nil
 }
 
+func modelToOrderv2(om *orderModelv2) (*corepb.Order, error) {
+	order, err := modelToOrder(&orderModel{
+		ID:                om.ID,
+		RegistrationID:    om.RegistrationID,
+		Expires:           om.Expires,
+		Created:           om.Created,
+		Error:             om.Error,
+		CertificateSerial: om.CertificateSerial,
+		BeganProcessing:   om.BeganProcessing,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if om.CertificateProfileName != nil {
+		order.CertificateProfileName = *om.CertificateProfileName
+	}
+	return order, nil
+}
+
+func modelToOrderv3(om *orderModelv3) (*corepb.Order, error) {
+	order, err := modelToOrderv2(&orderModelv2{
+		ID:                     om.ID,
+		RegistrationID:         om.RegistrationID,
+		Expires:                om.Expires,
+		Created:                om.Created,
+		Error:                  om.Error,
+		CertificateSerial:      om.CertificateSerial,
+		BeganProcessing:        om.BeganProcessing,
+		CertificateProfileName: om.CertificateProfileName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if om.NotBefore != nil {
+		order.NotBefore = om.NotBefore.UnixNano()
+	}
+	if om.NotAfter != nil {
+		order.NotAfter = om.NotAfter.UnixNano()
+	}
+	return order, nil
+}
+
 var challTypeToUint = map[string]uint8{
-	"http-01":     0,
-	"dns-01":      1,
-	"tls-alpn-01": 2,
+	"http-01":        0,
+	"dns-01":         1,
+	"tls-alpn-01":    2,
+	"dns-account-01": 3,
 }
 
 var uintToChallType = map[uint8]string{
 	0: "http-01",
 	1: "dns-01",
 	2: "tls-alpn-01",
+	3: "dns-account-01",
 }
 
 var identifierTypeToUint = map[string]uint8{
 	"dns": 0,
+	"ip":  1,
 }
 
 var uintToIdentifierType = map[uint8]string{
 	0: "dns",
+	1: "ip",
 }
 
 var statusToUint = map[string]uint8{
@@ -442,6 +522,9 @@ func hasMultipleNonPendingChallenges(challenges []*corepb.Challenge) bool {
 // authzModel storage representation.
 func authzPBToModel(authz *corepb.Authorization) (*authzModel, error) {
 	am := &authzModel{
+		// The authorization protobuf carries only the identifier value, from which
+		// the type can be inferred.
+		IdentifierType:  identifierTypeToUint[string(identifier.FromName(authz.Identifier).Type)],
 		IdentifierValue: authz.Identifier,
 		RegistrationID:  authz.RegistrationID,
 		Status:          statusToUint[authz.Status],
@@ -625,7 +708,46 @@ type keyHashModel struct {
 	CertSerial   string
 }
 
+// revokedCertModel holds the subset of certificateStatus columns needed to
+// produce a CRL entry.
+type revokedCertModel struct {
+	Serial        string            `db:"serial"`
+	RevokedReason revocation.Reason `db:"revokedReason"`
+	RevokedDate   time.Time         `db:"revokedDate"`
+}
+
 var stringToSourceInt = map[string]int{
 	"API":           1,
 	"admin-revoker": 2,
 }
+
+// externalAccountKeyModel is the database representation of an external
+// account's key ID and the MAC key used to verify bindings to it.
+type externalAccountKeyModel struct {
+	ID        int64     `db:"id"`
+	KeyID     string    `db:"keyID"`
+	MACKey    []byte    `db:"macKey"`
+	CreatedAt time.Time `db:"createdAt"`
+}
+
+// externalAccountBindingModel records which external account, if any, a
+// registration was bound to when it was created.
+type externalAccountBindingModel struct {
+	RegistrationID int64     `db:"registrationID"`
+	KeyID          string    `db:"keyID"`
+	CreatedAt      time.Time `db:"createdAt"`
+}
+
+// rateLimitOverrideModel is the database representation of a rate limit
+// override. Exactly one of OverrideKey and RegistrationID is set; the other is
+// the zero value.
+type rateLimitOverrideModel struct {
+	ID             int64     `db:"id"`
+	LimitName      string    `db:"limitName"`
+	OverrideKey    string    `db:"overrideKey"`
+	RegistrationID int64     `db:"registrationID"`
+	Threshold      int64     `db:"threshold"`
+	Comment        string    `db:"comment"`
+	CreatedAt      time.Time `db:"createdAt"`
+	Expires        time.Time `db:"expires"`
+}
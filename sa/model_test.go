@@ -2,6 +2,7 @@ package sa
 
 import (
 	"testing"
+	"time"
 
 	"github.com/letsencrypt/boulder/grpc"
 	"github.com/letsencrypt/boulder/probs"
@@ -191,6 +192,52 @@ func TestModelToOrderBadJSON(t *testing.T) {
 	test.AssertEquals(t, string(badJSONErr.json), string(badJSON))
 }
 
+func TestModelToOrderv2(t *testing.T) {
+	profile := "shortlived"
+	order, err := modelToOrderv2(&orderModelv2{
+		ID:                     1,
+		RegistrationID:         2,
+		CertificateSerial:      "serial",
+		CertificateProfileName: &profile,
+	})
+	test.AssertNotError(t, err, "modelToOrderv2 failed")
+	test.AssertEquals(t, order.Id, int64(1))
+	test.AssertEquals(t, order.RegistrationID, int64(2))
+	test.AssertEquals(t, order.CertificateSerial, "serial")
+	test.AssertEquals(t, order.CertificateProfileName, "shortlived")
+
+	order, err = modelToOrderv2(&orderModelv2{ID: 1})
+	test.AssertNotError(t, err, "modelToOrderv2 failed")
+	test.AssertEquals(t, order.CertificateProfileName, "")
+}
+
+func TestModelToOrderv3(t *testing.T) {
+	profile := "shortlived"
+	notBefore := time.Date(2021, 7, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := time.Date(2021, 7, 8, 0, 0, 0, 0, time.UTC)
+	order, err := modelToOrderv3(&orderModelv3{
+		ID:                     1,
+		RegistrationID:         2,
+		CertificateSerial:      "serial",
+		CertificateProfileName: &profile,
+		NotBefore:              &notBefore,
+		NotAfter:               &notAfter,
+	})
+	test.AssertNotError(t, err, "modelToOrderv3 failed")
+	test.AssertEquals(t, order.Id, int64(1))
+	test.AssertEquals(t, order.RegistrationID, int64(2))
+	test.AssertEquals(t, order.CertificateSerial, "serial")
+	test.AssertEquals(t, order.CertificateProfileName, "shortlived")
+	test.AssertEquals(t, order.NotBefore, notBefore.UnixNano())
+	test.AssertEquals(t, order.NotAfter, notAfter.UnixNano())
+
+	order, err = modelToOrderv3(&orderModelv3{ID: 1})
+	test.AssertNotError(t, err, "modelToOrderv3 failed")
+	test.AssertEquals(t, order.CertificateProfileName, "")
+	test.AssertEquals(t, order.NotBefore, int64(0))
+	test.AssertEquals(t, order.NotAfter, int64(0))
+}
+
 // TestPopulateAttemptedFieldsBadJSON tests that populating a challenge from an
 // authz2 model with an invalid validation error or an invalid validation record
 // produces the expected bad JSON error.
@@ -63,36 +63,6 @@ func (ssa *SQLStorageAuthority) AddPrecertificate(ctx context.Context, req *sapb
 			return nil, err
 		}
 
-		certStatusFields := certStatusFields()
-		fieldNames := []string{}
-		for _, fieldName := range certStatusFields {
-			fieldNames = append(fieldNames, ":"+fieldName)
-		}
-		args := map[string]interface{}{
-			"serial":                serialHex,
-			"status":                string(core.OCSPStatusGood),
-			"ocspLastUpdated":       ssa.clk.Now(),
-			"revokedDate":           time.Time{},
-			"revokedReason":         0,
-			"lastExpirationNagSent": time.Time{},
-			"ocspResponse":          req.Ocsp,
-			"notAfter":              parsed.NotAfter,
-			"isExpired":             false,
-			"issuerID":              req.IssuerID,
-		}
-		if len(args) > len(certStatusFields) {
-			return nil, fmt.Errorf("too many arguments inserting row into certificateStatus")
-		}
-
-		_, err = txWithCtx.Exec(fmt.Sprintf(
-			"INSERT INTO certificateStatus (%s) VALUES (%s)",
-			strings.Join(certStatusFields, ","),
-			strings.Join(fieldNames, ","),
-		), args)
-		if err != nil {
-			return nil, err
-		}
-
 		// NOTE(@cpu): When we collect up names to check if an FQDN set exists (e.g.
 		// that it is a renewal) we use just the DNSNames from the certificate and
 		// ignore the Subject Common Name (if any). This is a safe assumption because
@@ -101,25 +71,112 @@ func (ssa *SQLStorageAuthority) AddPrecertificate(ctx context.Context, req *sapb
 		// not for the purpose of rate limiting is the least of our troubles.
 		isRenewal, err := ssa.checkFQDNSetExists(
 			txWithCtx.SelectOne,
-			parsed.DNSNames)
+			core.UniqueLowerNamesAndIPs(parsed.DNSNames, parsed.IPAddresses))
 		if err != nil {
 			return nil, err
 		}
-		if err := addIssuedNames(txWithCtx, parsed, isRenewal); err != nil {
+		return nil, ssa.addCertificateMetadata(txWithCtx, req, parsed, isRenewal)
+	})
+	if overallError != nil {
+		return nil, overallError
+	}
+	return &corepb.Empty{}, nil
+}
+
+// AddCertificateWithoutPrecertificate writes a record of a certificate which
+// was issued directly, without a precertificate, to the DB. Along with the
+// certificate itself it records everything AddPrecertificate would otherwise
+// have recorded for it: its status, its names, and its key.
+func (ssa *SQLStorageAuthority) AddCertificateWithoutPrecertificate(ctx context.Context, req *sapb.AddCertificateRequest) (*corepb.Empty, error) {
+	if core.IsAnyNilOrZero(req.Der, req.Issued, req.RegID, req.IssuerID) {
+		return nil, errIncompleteRequest
+	}
+	parsed, err := x509.ParseCertificate(req.Der)
+	if err != nil {
+		return nil, err
+	}
+
+	cert := &core.Certificate{
+		RegistrationID: req.RegID,
+		Serial:         core.SerialToString(parsed.SerialNumber),
+		Digest:         core.Fingerprint256(req.Der),
+		DER:            req.Der,
+		Issued:         time.Unix(0, req.Issued),
+		Expires:        parsed.NotAfter,
+	}
+
+	isRenewal, overallError := db.WithTransaction(ctx, ssa.dbMap, func(txWithCtx db.Executor) (interface{}, error) {
+		if err := txWithCtx.Insert(cert); err != nil {
+			if db.IsDuplicate(err) {
+				return nil, berrors.DuplicateError("cannot add a duplicate cert")
+			}
 			return nil, err
 		}
-		if err := addKeyHash(txWithCtx, parsed); err != nil {
+
+		// See the note in AddPrecertificate about the names used here.
+		isRenewal, err := ssa.checkFQDNSetExists(
+			txWithCtx.SelectOne,
+			core.UniqueLowerNamesAndIPs(parsed.DNSNames, parsed.IPAddresses))
+		if err != nil {
 			return nil, err
 		}
-
-		return nil, nil
+		return isRenewal, ssa.addCertificateMetadata(txWithCtx, req, parsed, isRenewal)
 	})
 	if overallError != nil {
 		return nil, overallError
 	}
+
+	ssa.updateRateLimitTables(ctx, parsed, isRenewal.(bool))
 	return &corepb.Empty{}, nil
 }
 
+// addCertificateMetadata inserts the certificateStatus, issuedNames, and
+// keyHashToSerial rows for a newly issued certificate or precertificate.
+func (ssa *SQLStorageAuthority) addCertificateMetadata(txWithCtx db.Executor, req *sapb.AddCertificateRequest, parsed *x509.Certificate, isRenewal bool) error {
+	// Certificates issued without an OCSP URL never have an OCSP response. Their
+	// status is stored with a zero ocspLastUpdated, which the ocsp-updater
+	// skips and the ocsp-responder treats as having no response to serve.
+	ocspLastUpdated := ssa.clk.Now()
+	if req.OmitOCSP {
+		ocspLastUpdated = time.Time{}
+	}
+
+	certStatusFields := certStatusFields()
+	fieldNames := []string{}
+	for _, fieldName := range certStatusFields {
+		fieldNames = append(fieldNames, ":"+fieldName)
+	}
+	args := map[string]interface{}{
+		"serial":                core.SerialToString(parsed.SerialNumber),
+		"status":                string(core.OCSPStatusGood),
+		"ocspLastUpdated":       ocspLastUpdated,
+		"revokedDate":           time.Time{},
+		"revokedReason":         0,
+		"lastExpirationNagSent": time.Time{},
+		"ocspResponse":          req.Ocsp,
+		"notAfter":              parsed.NotAfter,
+		"isExpired":             false,
+		"issuerID":              req.IssuerID,
+	}
+	if len(args) > len(certStatusFields) {
+		return fmt.Errorf("too many arguments inserting row into certificateStatus")
+	}
+
+	_, err := txWithCtx.Exec(fmt.Sprintf(
+		"INSERT INTO certificateStatus (%s) VALUES (%s)",
+		strings.Join(certStatusFields, ","),
+		strings.Join(fieldNames, ","),
+	), args)
+	if err != nil {
+		return err
+	}
+
+	if err := addIssuedNames(txWithCtx, parsed, isRenewal); err != nil {
+		return err
+	}
+	return addKeyHash(txWithCtx, parsed)
+}
+
 // GetPrecertificate takes a serial number and returns the corresponding
 // precertificate, or error if it does not exist.
 func (ssa *SQLStorageAuthority) GetPrecertificate(ctx context.Context, reqSerial *sapb.Serial) (*corepb.Certificate, error) {
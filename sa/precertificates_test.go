@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/letsencrypt/boulder/core"
 	"github.com/letsencrypt/boulder/db"
+	berrors "github.com/letsencrypt/boulder/errors"
 	sapb "github.com/letsencrypt/boulder/sa/proto"
 	"github.com/letsencrypt/boulder/sa/satest"
 	"github.com/letsencrypt/boulder/test"
@@ -111,6 +113,30 @@ func TestAddPrecertificateIncomplete(t *testing.T) {
 	test.AssertError(t, err, "Adding precert with no issuer did not fail")
 }
 
+func TestAddPrecertificateOmitOCSP(t *testing.T) {
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+	reg := satest.CreateWorkingRegistration(t, sa)
+
+	serial, testCert := test.ThrowAwayCert(t, 1)
+	_, err := sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:      testCert.Raw,
+		RegID:    reg.ID,
+		Issued:   testCert.NotBefore.UnixNano(),
+		IssuerID: 1,
+		OmitOCSP: true,
+	})
+	test.AssertNotError(t, err, "failed to add precert")
+
+	// The status should be good, but without any record of an OCSP response
+	// ever having been generated.
+	certStatus, err := sa.GetCertificateStatus(ctx, serial)
+	test.AssertNotError(t, err, "Couldn't get status for test cert")
+	test.AssertEquals(t, certStatus.Status, core.OCSPStatusGood)
+	test.Assert(t, certStatus.OCSPLastUpdated.IsZero(), "OCSPLastUpdated should be zero")
+	test.AssertEquals(t, len(certStatus.OCSPResponse), 0)
+}
+
 func TestAddPrecertificateKeyHash(t *testing.T) {
 	sa, _, cleanUp := initSA(t)
 	defer cleanUp()
@@ -135,3 +161,50 @@ func TestAddPrecertificateKeyHash(t *testing.T) {
 	spkiHash := sha256.Sum256(testCert.RawSubjectPublicKeyInfo)
 	test.Assert(t, bytes.Compare(keyHashes[0].KeyHash, spkiHash[:]) == 0, "spki hash mismatch")
 }
+
+func TestAddCertificateWithoutPrecertificate(t *testing.T) {
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+	reg := satest.CreateWorkingRegistration(t, sa)
+
+	serial, testCert := test.ThrowAwayCert(t, 1)
+	req := &sapb.AddCertificateRequest{
+		Der:      testCert.Raw,
+		RegID:    reg.ID,
+		Ocsp:     []byte{1, 2, 3},
+		Issued:   testCert.NotBefore.UnixNano(),
+		IssuerID: 1,
+	}
+	_, err := sa.AddCertificateWithoutPrecertificate(ctx, req)
+	test.AssertNotError(t, err, "failed to add certificate")
+
+	// The certificate is stored as a final certificate, with a status, names,
+	// and key hash, but no precertificate.
+	cert, err := sa.GetCertificate(ctx, serial)
+	test.AssertNotError(t, err, "Couldn't get test cert")
+	test.AssertByteEquals(t, cert.DER, testCert.Raw)
+	certStatus, err := sa.GetCertificateStatus(ctx, serial)
+	test.AssertNotError(t, err, "Couldn't get status for test cert")
+	test.AssertEquals(t, certStatus.Status, core.OCSPStatusGood)
+	test.AssertByteEquals(t, certStatus.OCSPResponse, []byte{1, 2, 3})
+	issuedNamesSerial, err := findIssuedName(sa.dbMap, testCert.DNSNames[0])
+	test.AssertNotError(t, err, "expected no err querying issuedNames")
+	test.AssertEquals(t, issuedNamesSerial, serial)
+	var keyHashes []keyHashModel
+	_, err = sa.dbMap.Select(&keyHashes, "SELECT * FROM keyHashToSerial")
+	test.AssertNotError(t, err, "failed to retrieve rows from keyHashToSerial")
+	test.AssertEquals(t, len(keyHashes), 1)
+	test.AssertEquals(t, keyHashes[0].CertSerial, serial)
+	_, err = sa.GetPrecertificate(ctx, &sapb.Serial{Serial: serial})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	_, err = sa.AddCertificateWithoutPrecertificate(ctx, req)
+	test.AssertErrorIs(t, err, berrors.Duplicate)
+
+	_, err = sa.AddCertificateWithoutPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:    testCert.Raw,
+		RegID:  reg.ID,
+		Issued: testCert.NotBefore.UnixNano(),
+	})
+	test.AssertError(t, err, "Adding certificate with no issuer did not fail")
+}
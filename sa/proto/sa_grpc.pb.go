@@ -40,13 +40,23 @@ type StorageAuthorityClient interface {
 	CountPendingAuthorizations2(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*Count, error)
 	GetValidOrderAuthorizations2(ctx context.Context, in *GetValidOrderAuthorizationsRequest, opts ...grpc.CallOption) (*Authorizations, error)
 	CountInvalidAuthorizations2(ctx context.Context, in *CountInvalidAuthorizationsRequest, opts ...grpc.CallOption) (*Count, error)
+	CountInvalidAuthorizationsByName(ctx context.Context, in *CountInvalidAuthorizationsByNameRequest, opts ...grpc.CallOption) (*CountByNames, error)
 	GetValidAuthorizations2(ctx context.Context, in *GetValidAuthorizationsRequest, opts ...grpc.CallOption) (*Authorizations, error)
 	KeyBlocked(ctx context.Context, in *KeyBlockedRequest, opts ...grpc.CallOption) (*Exists, error)
+	GetRevokedCerts(ctx context.Context, in *GetRevokedCertsRequest, opts ...grpc.CallOption) (*RevokedCerts, error)
+	GetCertificateStatusByIssuer(ctx context.Context, in *IssuerSerial, opts ...grpc.CallOption) (*proto.CertificateStatus, error)
+	GetExternalAccountKey(ctx context.Context, in *ExternalAccountKeyID, opts ...grpc.CallOption) (*ExternalAccountKey, error)
+	GetRateLimitOverrides(ctx context.Context, in *GetRateLimitOverridesRequest, opts ...grpc.CallOption) (*RateLimitOverrides, error)
+	GetOrdersForAccount(ctx context.Context, in *GetByAccountRequest, opts ...grpc.CallOption) (*OrderIDs, error)
+	GetSerialsForAccount(ctx context.Context, in *GetByAccountRequest, opts ...grpc.CallOption) (*AccountSerials, error)
+	GetUnexpiredSerialsForName(ctx context.Context, in *GetUnexpiredSerialsForNameRequest, opts ...grpc.CallOption) (*Serials, error)
+	GetUnexpiredSerialsForKey(ctx context.Context, in *GetUnexpiredSerialsForKeyRequest, opts ...grpc.CallOption) (*Serials, error)
 	// Adders
 	NewRegistration(ctx context.Context, in *proto.Registration, opts ...grpc.CallOption) (*proto.Registration, error)
 	UpdateRegistration(ctx context.Context, in *proto.Registration, opts ...grpc.CallOption) (*proto.Empty, error)
 	AddCertificate(ctx context.Context, in *AddCertificateRequest, opts ...grpc.CallOption) (*AddCertificateResponse, error)
 	AddPrecertificate(ctx context.Context, in *AddCertificateRequest, opts ...grpc.CallOption) (*proto.Empty, error)
+	AddCertificateWithoutPrecertificate(ctx context.Context, in *AddCertificateRequest, opts ...grpc.CallOption) (*proto.Empty, error)
 	AddSerial(ctx context.Context, in *AddSerialRequest, opts ...grpc.CallOption) (*proto.Empty, error)
 	DeactivateRegistration(ctx context.Context, in *RegistrationID, opts ...grpc.CallOption) (*proto.Empty, error)
 	NewOrder(ctx context.Context, in *proto.Order, opts ...grpc.CallOption) (*proto.Order, error)
@@ -60,6 +70,9 @@ type StorageAuthorityClient interface {
 	FinalizeAuthorization2(ctx context.Context, in *FinalizeAuthorizationRequest, opts ...grpc.CallOption) (*proto.Empty, error)
 	DeactivateAuthorization2(ctx context.Context, in *AuthorizationID2, opts ...grpc.CallOption) (*proto.Empty, error)
 	AddBlockedKey(ctx context.Context, in *AddBlockedKeyRequest, opts ...grpc.CallOption) (*proto.Empty, error)
+	AddExternalAccountKey(ctx context.Context, in *ExternalAccountKey, opts ...grpc.CallOption) (*proto.Empty, error)
+	AddRateLimitOverride(ctx context.Context, in *RateLimitOverride, opts ...grpc.CallOption) (*RateLimitOverrideID, error)
+	ExpireRateLimitOverride(ctx context.Context, in *RateLimitOverrideID, opts ...grpc.CallOption) (*proto.Empty, error)
 }
 
 type storageAuthorityClient struct {
@@ -232,6 +245,15 @@ func (c *storageAuthorityClient) CountInvalidAuthorizations2(ctx context.Context
 	return out, nil
 }
 
+func (c *storageAuthorityClient) CountInvalidAuthorizationsByName(ctx context.Context, in *CountInvalidAuthorizationsByNameRequest, opts ...grpc.CallOption) (*CountByNames, error) {
+	out := new(CountByNames)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/CountInvalidAuthorizationsByName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storageAuthorityClient) GetValidAuthorizations2(ctx context.Context, in *GetValidAuthorizationsRequest, opts ...grpc.CallOption) (*Authorizations, error) {
 	out := new(Authorizations)
 	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/GetValidAuthorizations2", in, out, opts...)
@@ -250,6 +272,78 @@ func (c *storageAuthorityClient) KeyBlocked(ctx context.Context, in *KeyBlockedR
 	return out, nil
 }
 
+func (c *storageAuthorityClient) GetRevokedCerts(ctx context.Context, in *GetRevokedCertsRequest, opts ...grpc.CallOption) (*RevokedCerts, error) {
+	out := new(RevokedCerts)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/GetRevokedCerts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetCertificateStatusByIssuer(ctx context.Context, in *IssuerSerial, opts ...grpc.CallOption) (*proto.CertificateStatus, error) {
+	out := new(proto.CertificateStatus)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/GetCertificateStatusByIssuer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetExternalAccountKey(ctx context.Context, in *ExternalAccountKeyID, opts ...grpc.CallOption) (*ExternalAccountKey, error) {
+	out := new(ExternalAccountKey)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/GetExternalAccountKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetRateLimitOverrides(ctx context.Context, in *GetRateLimitOverridesRequest, opts ...grpc.CallOption) (*RateLimitOverrides, error) {
+	out := new(RateLimitOverrides)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/GetRateLimitOverrides", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetOrdersForAccount(ctx context.Context, in *GetByAccountRequest, opts ...grpc.CallOption) (*OrderIDs, error) {
+	out := new(OrderIDs)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/GetOrdersForAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetSerialsForAccount(ctx context.Context, in *GetByAccountRequest, opts ...grpc.CallOption) (*AccountSerials, error) {
+	out := new(AccountSerials)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/GetSerialsForAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetUnexpiredSerialsForName(ctx context.Context, in *GetUnexpiredSerialsForNameRequest, opts ...grpc.CallOption) (*Serials, error) {
+	out := new(Serials)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/GetUnexpiredSerialsForName", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) GetUnexpiredSerialsForKey(ctx context.Context, in *GetUnexpiredSerialsForKeyRequest, opts ...grpc.CallOption) (*Serials, error) {
+	out := new(Serials)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/GetUnexpiredSerialsForKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storageAuthorityClient) NewRegistration(ctx context.Context, in *proto.Registration, opts ...grpc.CallOption) (*proto.Registration, error) {
 	out := new(proto.Registration)
 	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/NewRegistration", in, out, opts...)
@@ -286,6 +380,15 @@ func (c *storageAuthorityClient) AddPrecertificate(ctx context.Context, in *AddC
 	return out, nil
 }
 
+func (c *storageAuthorityClient) AddCertificateWithoutPrecertificate(ctx context.Context, in *AddCertificateRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
+	out := new(proto.Empty)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/AddCertificateWithoutPrecertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storageAuthorityClient) AddSerial(ctx context.Context, in *AddSerialRequest, opts ...grpc.CallOption) (*proto.Empty, error) {
 	out := new(proto.Empty)
 	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/AddSerial", in, out, opts...)
@@ -403,6 +506,33 @@ func (c *storageAuthorityClient) AddBlockedKey(ctx context.Context, in *AddBlock
 	return out, nil
 }
 
+func (c *storageAuthorityClient) AddExternalAccountKey(ctx context.Context, in *ExternalAccountKey, opts ...grpc.CallOption) (*proto.Empty, error) {
+	out := new(proto.Empty)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/AddExternalAccountKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) AddRateLimitOverride(ctx context.Context, in *RateLimitOverride, opts ...grpc.CallOption) (*RateLimitOverrideID, error) {
+	out := new(RateLimitOverrideID)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/AddRateLimitOverride", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageAuthorityClient) ExpireRateLimitOverride(ctx context.Context, in *RateLimitOverrideID, opts ...grpc.CallOption) (*proto.Empty, error) {
+	out := new(proto.Empty)
+	err := c.cc.Invoke(ctx, "/sa.StorageAuthority/ExpireRateLimitOverride", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StorageAuthorityServer is the server API for StorageAuthority service.
 // All implementations must embed UnimplementedStorageAuthorityServer
 // for forward compatibility
@@ -428,13 +558,23 @@ type StorageAuthorityServer interface {
 	CountPendingAuthorizations2(context.Context, *RegistrationID) (*Count, error)
 	GetValidOrderAuthorizations2(context.Context, *GetValidOrderAuthorizationsRequest) (*Authorizations, error)
 	CountInvalidAuthorizations2(context.Context, *CountInvalidAuthorizationsRequest) (*Count, error)
+	CountInvalidAuthorizationsByName(context.Context, *CountInvalidAuthorizationsByNameRequest) (*CountByNames, error)
 	GetValidAuthorizations2(context.Context, *GetValidAuthorizationsRequest) (*Authorizations, error)
 	KeyBlocked(context.Context, *KeyBlockedRequest) (*Exists, error)
+	GetRevokedCerts(context.Context, *GetRevokedCertsRequest) (*RevokedCerts, error)
+	GetCertificateStatusByIssuer(context.Context, *IssuerSerial) (*proto.CertificateStatus, error)
+	GetExternalAccountKey(context.Context, *ExternalAccountKeyID) (*ExternalAccountKey, error)
+	GetRateLimitOverrides(context.Context, *GetRateLimitOverridesRequest) (*RateLimitOverrides, error)
+	GetOrdersForAccount(context.Context, *GetByAccountRequest) (*OrderIDs, error)
+	GetSerialsForAccount(context.Context, *GetByAccountRequest) (*AccountSerials, error)
+	GetUnexpiredSerialsForName(context.Context, *GetUnexpiredSerialsForNameRequest) (*Serials, error)
+	GetUnexpiredSerialsForKey(context.Context, *GetUnexpiredSerialsForKeyRequest) (*Serials, error)
 	// Adders
 	NewRegistration(context.Context, *proto.Registration) (*proto.Registration, error)
 	UpdateRegistration(context.Context, *proto.Registration) (*proto.Empty, error)
 	AddCertificate(context.Context, *AddCertificateRequest) (*AddCertificateResponse, error)
 	AddPrecertificate(context.Context, *AddCertificateRequest) (*proto.Empty, error)
+	AddCertificateWithoutPrecertificate(context.Context, *AddCertificateRequest) (*proto.Empty, error)
 	AddSerial(context.Context, *AddSerialRequest) (*proto.Empty, error)
 	DeactivateRegistration(context.Context, *RegistrationID) (*proto.Empty, error)
 	NewOrder(context.Context, *proto.Order) (*proto.Order, error)
@@ -448,6 +588,9 @@ type StorageAuthorityServer interface {
 	FinalizeAuthorization2(context.Context, *FinalizeAuthorizationRequest) (*proto.Empty, error)
 	DeactivateAuthorization2(context.Context, *AuthorizationID2) (*proto.Empty, error)
 	AddBlockedKey(context.Context, *AddBlockedKeyRequest) (*proto.Empty, error)
+	AddExternalAccountKey(context.Context, *ExternalAccountKey) (*proto.Empty, error)
+	AddRateLimitOverride(context.Context, *RateLimitOverride) (*RateLimitOverrideID, error)
+	ExpireRateLimitOverride(context.Context, *RateLimitOverrideID) (*proto.Empty, error)
 	mustEmbedUnimplementedStorageAuthorityServer()
 }
 
@@ -509,12 +652,39 @@ func (UnimplementedStorageAuthorityServer) GetValidOrderAuthorizations2(context.
 func (UnimplementedStorageAuthorityServer) CountInvalidAuthorizations2(context.Context, *CountInvalidAuthorizationsRequest) (*Count, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CountInvalidAuthorizations2 not implemented")
 }
+func (UnimplementedStorageAuthorityServer) CountInvalidAuthorizationsByName(context.Context, *CountInvalidAuthorizationsByNameRequest) (*CountByNames, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountInvalidAuthorizationsByName not implemented")
+}
 func (UnimplementedStorageAuthorityServer) GetValidAuthorizations2(context.Context, *GetValidAuthorizationsRequest) (*Authorizations, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetValidAuthorizations2 not implemented")
 }
 func (UnimplementedStorageAuthorityServer) KeyBlocked(context.Context, *KeyBlockedRequest) (*Exists, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method KeyBlocked not implemented")
 }
+func (UnimplementedStorageAuthorityServer) GetRevokedCerts(context.Context, *GetRevokedCertsRequest) (*RevokedCerts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRevokedCerts not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetCertificateStatusByIssuer(context.Context, *IssuerSerial) (*proto.CertificateStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCertificateStatusByIssuer not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetExternalAccountKey(context.Context, *ExternalAccountKeyID) (*ExternalAccountKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExternalAccountKey not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetRateLimitOverrides(context.Context, *GetRateLimitOverridesRequest) (*RateLimitOverrides, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateLimitOverrides not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetOrdersForAccount(context.Context, *GetByAccountRequest) (*OrderIDs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrdersForAccount not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetSerialsForAccount(context.Context, *GetByAccountRequest) (*AccountSerials, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSerialsForAccount not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetUnexpiredSerialsForName(context.Context, *GetUnexpiredSerialsForNameRequest) (*Serials, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnexpiredSerialsForName not implemented")
+}
+func (UnimplementedStorageAuthorityServer) GetUnexpiredSerialsForKey(context.Context, *GetUnexpiredSerialsForKeyRequest) (*Serials, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUnexpiredSerialsForKey not implemented")
+}
 func (UnimplementedStorageAuthorityServer) NewRegistration(context.Context, *proto.Registration) (*proto.Registration, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewRegistration not implemented")
 }
@@ -527,6 +697,9 @@ func (UnimplementedStorageAuthorityServer) AddCertificate(context.Context, *AddC
 func (UnimplementedStorageAuthorityServer) AddPrecertificate(context.Context, *AddCertificateRequest) (*proto.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddPrecertificate not implemented")
 }
+func (UnimplementedStorageAuthorityServer) AddCertificateWithoutPrecertificate(context.Context, *AddCertificateRequest) (*proto.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCertificateWithoutPrecertificate not implemented")
+}
 func (UnimplementedStorageAuthorityServer) AddSerial(context.Context, *AddSerialRequest) (*proto.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddSerial not implemented")
 }
@@ -566,6 +739,15 @@ func (UnimplementedStorageAuthorityServer) DeactivateAuthorization2(context.Cont
 func (UnimplementedStorageAuthorityServer) AddBlockedKey(context.Context, *AddBlockedKeyRequest) (*proto.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddBlockedKey not implemented")
 }
+func (UnimplementedStorageAuthorityServer) AddExternalAccountKey(context.Context, *ExternalAccountKey) (*proto.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddExternalAccountKey not implemented")
+}
+func (UnimplementedStorageAuthorityServer) AddRateLimitOverride(context.Context, *RateLimitOverride) (*RateLimitOverrideID, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRateLimitOverride not implemented")
+}
+func (UnimplementedStorageAuthorityServer) ExpireRateLimitOverride(context.Context, *RateLimitOverrideID) (*proto.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExpireRateLimitOverride not implemented")
+}
 func (UnimplementedStorageAuthorityServer) mustEmbedUnimplementedStorageAuthorityServer() {}
 
 // UnsafeStorageAuthorityServer may be embedded to opt out of forward compatibility for this service.
@@ -903,6 +1085,24 @@ func _StorageAuthority_CountInvalidAuthorizations2_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageAuthority_CountInvalidAuthorizationsByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountInvalidAuthorizationsByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).CountInvalidAuthorizationsByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/CountInvalidAuthorizationsByName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).CountInvalidAuthorizationsByName(ctx, req.(*CountInvalidAuthorizationsByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StorageAuthority_GetValidAuthorizations2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetValidAuthorizationsRequest)
 	if err := dec(in); err != nil {
@@ -939,6 +1139,150 @@ func _StorageAuthority_KeyBlocked_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageAuthority_GetRevokedCerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRevokedCertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetRevokedCerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/GetRevokedCerts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetRevokedCerts(ctx, req.(*GetRevokedCertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetCertificateStatusByIssuer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssuerSerial)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetCertificateStatusByIssuer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/GetCertificateStatusByIssuer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetCertificateStatusByIssuer(ctx, req.(*IssuerSerial))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetExternalAccountKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalAccountKeyID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetExternalAccountKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/GetExternalAccountKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetExternalAccountKey(ctx, req.(*ExternalAccountKeyID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetRateLimitOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRateLimitOverridesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetRateLimitOverrides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/GetRateLimitOverrides",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetRateLimitOverrides(ctx, req.(*GetRateLimitOverridesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetOrdersForAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetOrdersForAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/GetOrdersForAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetOrdersForAccount(ctx, req.(*GetByAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetSerialsForAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetSerialsForAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/GetSerialsForAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetSerialsForAccount(ctx, req.(*GetByAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetUnexpiredSerialsForName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnexpiredSerialsForNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetUnexpiredSerialsForName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/GetUnexpiredSerialsForName",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetUnexpiredSerialsForName(ctx, req.(*GetUnexpiredSerialsForNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_GetUnexpiredSerialsForKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnexpiredSerialsForKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).GetUnexpiredSerialsForKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/GetUnexpiredSerialsForKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).GetUnexpiredSerialsForKey(ctx, req.(*GetUnexpiredSerialsForKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StorageAuthority_NewRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(proto.Registration)
 	if err := dec(in); err != nil {
@@ -1011,6 +1355,24 @@ func _StorageAuthority_AddPrecertificate_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageAuthority_AddCertificateWithoutPrecertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).AddCertificateWithoutPrecertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/AddCertificateWithoutPrecertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).AddCertificateWithoutPrecertificate(ctx, req.(*AddCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StorageAuthority_AddSerial_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddSerialRequest)
 	if err := dec(in); err != nil {
@@ -1245,6 +1607,60 @@ func _StorageAuthority_AddBlockedKey_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StorageAuthority_AddExternalAccountKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExternalAccountKey)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).AddExternalAccountKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/AddExternalAccountKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).AddExternalAccountKey(ctx, req.(*ExternalAccountKey))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_AddRateLimitOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateLimitOverride)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).AddRateLimitOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/AddRateLimitOverride",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).AddRateLimitOverride(ctx, req.(*RateLimitOverride))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageAuthority_ExpireRateLimitOverride_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RateLimitOverrideID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageAuthorityServer).ExpireRateLimitOverride(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sa.StorageAuthority/ExpireRateLimitOverride",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageAuthorityServer).ExpireRateLimitOverride(ctx, req.(*RateLimitOverrideID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StorageAuthority_ServiceDesc is the grpc.ServiceDesc for StorageAuthority service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1324,6 +1740,10 @@ var StorageAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CountInvalidAuthorizations2",
 			Handler:    _StorageAuthority_CountInvalidAuthorizations2_Handler,
 		},
+		{
+			MethodName: "CountInvalidAuthorizationsByName",
+			Handler:    _StorageAuthority_CountInvalidAuthorizationsByName_Handler,
+		},
 		{
 			MethodName: "GetValidAuthorizations2",
 			Handler:    _StorageAuthority_GetValidAuthorizations2_Handler,
@@ -1332,6 +1752,38 @@ var StorageAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "KeyBlocked",
 			Handler:    _StorageAuthority_KeyBlocked_Handler,
 		},
+		{
+			MethodName: "GetRevokedCerts",
+			Handler:    _StorageAuthority_GetRevokedCerts_Handler,
+		},
+		{
+			MethodName: "GetCertificateStatusByIssuer",
+			Handler:    _StorageAuthority_GetCertificateStatusByIssuer_Handler,
+		},
+		{
+			MethodName: "GetExternalAccountKey",
+			Handler:    _StorageAuthority_GetExternalAccountKey_Handler,
+		},
+		{
+			MethodName: "GetRateLimitOverrides",
+			Handler:    _StorageAuthority_GetRateLimitOverrides_Handler,
+		},
+		{
+			MethodName: "GetOrdersForAccount",
+			Handler:    _StorageAuthority_GetOrdersForAccount_Handler,
+		},
+		{
+			MethodName: "GetSerialsForAccount",
+			Handler:    _StorageAuthority_GetSerialsForAccount_Handler,
+		},
+		{
+			MethodName: "GetUnexpiredSerialsForName",
+			Handler:    _StorageAuthority_GetUnexpiredSerialsForName_Handler,
+		},
+		{
+			MethodName: "GetUnexpiredSerialsForKey",
+			Handler:    _StorageAuthority_GetUnexpiredSerialsForKey_Handler,
+		},
 		{
 			MethodName: "NewRegistration",
 			Handler:    _StorageAuthority_NewRegistration_Handler,
@@ -1348,6 +1800,10 @@ var StorageAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddPrecertificate",
 			Handler:    _StorageAuthority_AddPrecertificate_Handler,
 		},
+		{
+			MethodName: "AddCertificateWithoutPrecertificate",
+			Handler:    _StorageAuthority_AddCertificateWithoutPrecertificate_Handler,
+		},
 		{
 			MethodName: "AddSerial",
 			Handler:    _StorageAuthority_AddSerial_Handler,
@@ -1400,6 +1856,18 @@ var StorageAuthority_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddBlockedKey",
 			Handler:    _StorageAuthority_AddBlockedKey_Handler,
 		},
+		{
+			MethodName: "AddExternalAccountKey",
+			Handler:    _StorageAuthority_AddExternalAccountKey_Handler,
+		},
+		{
+			MethodName: "AddRateLimitOverride",
+			Handler:    _StorageAuthority_AddRateLimitOverride_Handler,
+		},
+		{
+			MethodName: "ExpireRateLimitOverride",
+			Handler:    _StorageAuthority_ExpireRateLimitOverride_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "sa.proto",
@@ -107,7 +107,7 @@ func (ssa *SQLStorageAuthority) GetRegistration(ctx context.Context, id int64) (
 		return core.Registration{}, err
 	}
 
-	return modelToRegistration(model)
+	return ssa.modelToRegistrationWithBinding(ctx, model)
 }
 
 // GetRegistrationByKey obtains a Registration by JWK
@@ -128,7 +128,31 @@ func (ssa *SQLStorageAuthority) GetRegistrationByKey(ctx context.Context, key *j
 		return core.Registration{}, err
 	}
 
-	return modelToRegistration(model)
+	return ssa.modelToRegistrationWithBinding(ctx, model)
+}
+
+// modelToRegistrationWithBinding converts a regModel to a core.Registration
+// and, if the ExternalAccountBinding feature is enabled, populates its
+// ExternalAccountID from the externalAccountBindings table.
+func (ssa *SQLStorageAuthority) modelToRegistrationWithBinding(ctx context.Context, model *regModel) (core.Registration, error) {
+	reg, err := modelToRegistration(model)
+	if err != nil {
+		return core.Registration{}, err
+	}
+	if !features.Enabled(features.ExternalAccountBinding) {
+		return reg, nil
+	}
+	var keyID string
+	err = ssa.dbMap.WithContext(ctx).SelectOne(
+		&keyID,
+		"SELECT keyID FROM externalAccountBindings WHERE registrationID = ?",
+		reg.ID,
+	)
+	if err != nil && !db.IsNoRows(err) {
+		return core.Registration{}, err
+	}
+	reg.ExternalAccountID = keyID
+	return reg, nil
 }
 
 // incrementIP returns a copy of `ip` incremented at a bit index `index`,
@@ -336,6 +360,35 @@ func (ssa *SQLStorageAuthority) GetCertificateStatus(ctx context.Context, serial
 	return certStatus, nil
 }
 
+// GetCertificateStatusByIssuer returns the status of the certificate with the
+// given serial, but only if it was issued by the issuer with the given
+// IssuerID. This allows certificates to be identified by issuer and serial, as
+// ACME Renewal Information requests do, without trusting the serial alone.
+func (ssa *SQLStorageAuthority) GetCertificateStatusByIssuer(ctx context.Context, req *sapb.IssuerSerial) (*corepb.CertificateStatus, error) {
+	if req == nil || req.IssuerID == 0 || req.Serial == "" {
+		return nil, errIncompleteRequest
+	}
+	if !core.ValidSerial(req.Serial) {
+		return nil, fmt.Errorf("Invalid certificate serial %s", req.Serial)
+	}
+
+	var certStatus core.CertificateStatus
+	err := ssa.dbMap.WithContext(ctx).SelectOne(
+		&certStatus,
+		certStatusFieldsSelect("WHERE serial = ? AND issuerID = ?"),
+		req.Serial,
+		req.IssuerID,
+	)
+	if err != nil {
+		if db.IsNoRows(err) {
+			return nil, berrors.NotFoundError("certificate status for issuer %d and serial %q not found", req.IssuerID, req.Serial)
+		}
+		return nil, err
+	}
+
+	return bgrpc.CertStatusToPB(certStatus), nil
+}
+
 // NewRegistration stores a new Registration
 func (ssa *SQLStorageAuthority) NewRegistration(ctx context.Context, reg core.Registration) (core.Registration, error) {
 	reg.CreatedAt = ssa.clk.Now()
@@ -343,7 +396,24 @@ func (ssa *SQLStorageAuthority) NewRegistration(ctx context.Context, reg core.Re
 	if err != nil {
 		return reg, err
 	}
-	err = ssa.dbMap.WithContext(ctx).Insert(rm)
+	if reg.ExternalAccountID != "" && features.Enabled(features.ExternalAccountBinding) {
+		// The registration and its binding must be written together, so that
+		// no registration which was required to be bound is ever stored
+		// without its binding.
+		_, err = db.WithTransaction(ctx, ssa.dbMap, func(txWithCtx db.Executor) (interface{}, error) {
+			err := txWithCtx.Insert(rm)
+			if err != nil {
+				return nil, err
+			}
+			return nil, txWithCtx.Insert(&externalAccountBindingModel{
+				RegistrationID: rm.ID,
+				KeyID:          reg.ExternalAccountID,
+				CreatedAt:      reg.CreatedAt,
+			})
+		})
+	} else {
+		err = ssa.dbMap.WithContext(ctx).Insert(rm)
+	}
 	if err != nil {
 		if db.IsDuplicate(err) {
 			// duplicate entry error can only happen when jwk_sha256 collides, indicate
@@ -352,7 +422,14 @@ func (ssa *SQLStorageAuthority) NewRegistration(ctx context.Context, reg core.Re
 		}
 		return reg, err
 	}
-	return modelToRegistration(rm)
+	newReg, err := modelToRegistration(rm)
+	if err != nil {
+		return reg, err
+	}
+	if features.Enabled(features.ExternalAccountBinding) {
+		newReg.ExternalAccountID = reg.ExternalAccountID
+	}
+	return newReg, nil
 }
 
 // UpdateRegistration stores an updated Registration
@@ -432,7 +509,7 @@ func (ssa *SQLStorageAuthority) AddCertificate(
 		// not for the purpose of rate limiting is the least of our troubles.
 		isRenewal, err := ssa.checkFQDNSetExists(
 			txWithCtx.SelectOne,
-			parsedCertificate.DNSNames)
+			core.UniqueLowerNamesAndIPs(parsedCertificate.DNSNames, parsedCertificate.IPAddresses))
 		if err != nil {
 			return nil, err
 		}
@@ -454,16 +531,22 @@ func (ssa *SQLStorageAuthority) AddCertificate(
 		isRenewal = boolVal
 	}
 
-	// In a separate transaction perform the work required to update tables used
-	// for rate limits. Since the effects of failing these writes is slight
-	// miscalculation of rate limits we choose to not fail the AddCertificate
-	// operation if the rate limit update transaction fails.
+	ssa.updateRateLimitTables(ctx, parsedCertificate, isRenewal)
+	return digest, nil
+}
+
+// updateRateLimitTables performs, in a separate transaction from the one which
+// stored the certificate, the work required to update tables used for rate
+// limits. Since the effects of failing these writes is slight miscalculation
+// of rate limits we choose to not fail the certificate's addition if the rate
+// limit update transaction fails.
+func (ssa *SQLStorageAuthority) updateRateLimitTables(ctx context.Context, parsedCertificate *x509.Certificate, isRenewal bool) {
 	_, rlTransactionErr := db.WithTransaction(ctx, ssa.dbMap, func(txWithCtx db.Executor) (interface{}, error) {
 		// Add to the rate limit table, but only for new certificates. Renewals
 		// don't count against the certificatesPerName limit.
 		if !isRenewal {
 			timeToTheHour := parsedCertificate.NotBefore.Round(time.Hour)
-			if err := ssa.addCertificatesPerName(ctx, txWithCtx, parsedCertificate.DNSNames, timeToTheHour); err != nil {
+			if err := ssa.addCertificatesPerName(ctx, txWithCtx, core.UniqueLowerNamesAndIPs(parsedCertificate.DNSNames, parsedCertificate.IPAddresses), timeToTheHour); err != nil {
 				return nil, err
 			}
 		}
@@ -472,7 +555,7 @@ func (ssa *SQLStorageAuthority) AddCertificate(
 		// limits are calculated correctly.
 		if err := addFQDNSet(
 			txWithCtx,
-			parsedCertificate.DNSNames,
+			core.UniqueLowerNamesAndIPs(parsedCertificate.DNSNames, parsedCertificate.IPAddresses),
 			core.SerialToString(parsedCertificate.SerialNumber),
 			parsedCertificate.NotBefore,
 			parsedCertificate.NotAfter,
@@ -488,8 +571,6 @@ func (ssa *SQLStorageAuthority) AddCertificate(
 		ssa.rateLimitWriteErrors.Inc()
 		ssa.log.AuditErrf("failed AddCertificate ratelimit update transaction: %v", rlTransactionErr)
 	}
-
-	return digest, nil
 }
 
 func (ssa *SQLStorageAuthority) CountOrders(ctx context.Context, acctID int64, earliest, latest time.Time) (int, error) {
@@ -576,12 +657,12 @@ func deleteOrderFQDNSet(
 }
 
 func addIssuedNames(db db.Execer, cert *x509.Certificate, isRenewal bool) error {
-	if len(cert.DNSNames) == 0 {
-		return berrors.InternalServerError("certificate has no DNSNames")
+	if len(cert.DNSNames) == 0 && len(cert.IPAddresses) == 0 {
+		return berrors.InternalServerError("certificate has no DNSNames or IPAddresses")
 	}
 	var qmarks []string
 	var values []interface{}
-	for _, name := range cert.DNSNames {
+	for _, name := range core.UniqueLowerNamesAndIPs(cert.DNSNames, cert.IPAddresses) {
 		values = append(values,
 			ReverseName(name),
 			core.SerialToString(cert.SerialNumber),
@@ -848,7 +929,41 @@ func (ssa *SQLStorageAuthority) NewOrder(ctx context.Context, req *corepb.Order)
 			Created:        ssa.clk.Now(),
 		}
 
-		if err := txWithCtx.Insert(order); err != nil {
+		var profileName *string
+		if req.CertificateProfileName != "" {
+			profileName = &req.CertificateProfileName
+		}
+		if features.Enabled(features.RequestedOrderValidity) {
+			omv3 := &orderModelv3{
+				RegistrationID:         order.RegistrationID,
+				Expires:                order.Expires,
+				Created:                order.Created,
+				CertificateProfileName: profileName,
+			}
+			if req.NotBefore != 0 {
+				notBefore := time.Unix(0, req.NotBefore)
+				omv3.NotBefore = &notBefore
+			}
+			if req.NotAfter != 0 {
+				notAfter := time.Unix(0, req.NotAfter)
+				omv3.NotAfter = &notAfter
+			}
+			if err := txWithCtx.Insert(omv3); err != nil {
+				return nil, err
+			}
+			order.ID = omv3.ID
+		} else if features.Enabled(features.MultipleCertificateProfiles) {
+			omv2 := &orderModelv2{
+				RegistrationID:         order.RegistrationID,
+				Expires:                order.Expires,
+				Created:                order.Created,
+				CertificateProfileName: profileName,
+			}
+			if err := txWithCtx.Insert(omv2); err != nil {
+				return nil, err
+			}
+			order.ID = omv2.ID
+		} else if err := txWithCtx.Insert(order); err != nil {
 			return nil, err
 		}
 
@@ -908,6 +1023,13 @@ func (ssa *SQLStorageAuthority) NewOrder(ctx context.Context, req *corepb.Order)
 		// A new order is never processing because it can't have been finalized yet.
 		BeganProcessing: false,
 	}
+	if features.Enabled(features.MultipleCertificateProfiles) || features.Enabled(features.RequestedOrderValidity) {
+		res.CertificateProfileName = req.CertificateProfileName
+	}
+	if features.Enabled(features.RequestedOrderValidity) {
+		res.NotBefore = req.NotBefore
+		res.NotAfter = req.NotAfter
+	}
 
 	// Calculate the order status before returning it. Since it may have reused all
 	// valid authorizations the order may be "born" in a ready status.
@@ -1038,7 +1160,13 @@ func (ssa *SQLStorageAuthority) namesForOrder(ctx context.Context, orderID int64
 
 // GetOrder is used to retrieve an already existing order object
 func (ssa *SQLStorageAuthority) GetOrder(ctx context.Context, req *sapb.OrderRequest) (*corepb.Order, error) {
-	omObj, err := ssa.dbMap.WithContext(ctx).Get(orderModel{}, req.Id)
+	var model interface{} = orderModel{}
+	if features.Enabled(features.RequestedOrderValidity) {
+		model = orderModelv3{}
+	} else if features.Enabled(features.MultipleCertificateProfiles) {
+		model = orderModelv2{}
+	}
+	omObj, err := ssa.dbMap.WithContext(ctx).Get(model, req.Id)
 	if err != nil {
 		if db.IsNoRows(err) {
 			return nil, berrors.NotFoundError("no order found for ID %d", req.Id)
@@ -1048,7 +1176,15 @@ func (ssa *SQLStorageAuthority) GetOrder(ctx context.Context, req *sapb.OrderReq
 	if omObj == nil {
 		return nil, berrors.NotFoundError("no order found for ID %d", req.Id)
 	}
-	order, err := modelToOrder(omObj.(*orderModel))
+	var order *corepb.Order
+	switch om := omObj.(type) {
+	case *orderModel:
+		order, err = modelToOrder(om)
+	case *orderModelv2:
+		order, err = modelToOrderv2(om)
+	case *orderModelv3:
+		order, err = modelToOrderv3(om)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1379,7 +1515,7 @@ func authzModelMapToPB(m map[string]authzModel) (*sapb.Authorizations, error) {
 // This method will look in both the v2 and v1 authorizations tables for authorizations but will
 // always prefer v2 authorizations. This method will only return authorizations created using the
 // WFE v2 API (in GetAuthorizations this feature was, now somewhat confusingly, called RequireV2Authzs).
-// This method is intended to deprecate GetAuthorizations. This method only supports DNS identifier types.
+// This method is intended to deprecate GetAuthorizations. This method supports DNS and IP identifier types.
 func (ssa *SQLStorageAuthority) GetAuthorizations2(ctx context.Context, req *sapb.GetAuthorizationsRequest) (*sapb.Authorizations, error) {
 	var authzModels []authzModel
 	params := []interface{}{
@@ -1388,6 +1524,7 @@ func (ssa *SQLStorageAuthority) GetAuthorizations2(ctx context.Context, req *sap
 		statusUint(core.StatusPending),
 		time.Unix(0, req.Now),
 		identifierTypeToUint[string(identifier.DNS)],
+		identifierTypeToUint[string(identifier.IP)],
 	}
 	qmarks := make([]string, len(req.Domains))
 	for i, n := range req.Domains {
@@ -1400,7 +1537,7 @@ func (ssa *SQLStorageAuthority) GetAuthorizations2(ctx context.Context, req *sap
 			WHERE registrationID = ? AND
 			status IN (?,?) AND
 			expires > ? AND
-			identifierType = ? AND
+			identifierType IN (?,?) AND
 			identifierValue IN (%s)`,
 		authzFields,
 		strings.Join(qmarks, ","),
@@ -1582,7 +1719,7 @@ func (ssa *SQLStorageAuthority) RevokeCertificate(ctx context.Context, req *sapb
 
 // GetPendingAuthorization2 returns the most recent Pending authorization with
 // the given identifier, if available. This method is intended to deprecate
-// GetPendingAuthorization. This method only supports DNS identifier types.
+// GetPendingAuthorization. This method supports DNS and IP identifier types.
 func (ssa *SQLStorageAuthority) GetPendingAuthorization2(ctx context.Context, req *sapb.GetPendingAuthorizationRequest) (*corepb.Authorization, error) {
 	var am authzModel
 	err := ssa.dbMap.WithContext(ctx).SelectOne(
@@ -1591,7 +1728,7 @@ func (ssa *SQLStorageAuthority) GetPendingAuthorization2(ctx context.Context, re
 			registrationID = :regID AND
 			status = :status AND
 			expires > :validUntil AND
-			identifierType = :dnsType AND
+			identifierType = :identType AND
 			identifierValue = :ident
 			ORDER BY expires ASC
 			LIMIT 1 `, authzFields),
@@ -1599,7 +1736,7 @@ func (ssa *SQLStorageAuthority) GetPendingAuthorization2(ctx context.Context, re
 			"regID":      req.RegistrationID,
 			"status":     statusUint(core.StatusPending),
 			"validUntil": time.Unix(0, req.ValidUntil),
-			"dnsType":    identifierTypeToUint[string(identifier.DNS)],
+			"identType":  identifierTypeToUint[req.IdentifierType],
 			"ident":      req.IdentifierValue,
 		},
 	)
@@ -1661,7 +1798,7 @@ func (ssa *SQLStorageAuthority) GetValidOrderAuthorizations2(ctx context.Context
 
 	byName := make(map[string]authzModel)
 	for _, am := range ams {
-		if uintToIdentifierType[am.IdentifierType] != string(identifier.DNS) {
+		if _, ok := uintToIdentifierType[am.IdentifierType]; !ok {
 			return nil, fmt.Errorf("unknown identifier type: %q on authz id %d", am.IdentifierType, am.ID)
 		}
 		existing, present := byName[am.IdentifierValue]
@@ -1675,7 +1812,7 @@ func (ssa *SQLStorageAuthority) GetValidOrderAuthorizations2(ctx context.Context
 
 // CountInvalidAuthorizations2 counts invalid authorizations for a user expiring
 // in a given time range. This method is intended to deprecate CountInvalidAuthorizations.
-// This method only supports DNS identifier types.
+// This method supports DNS and IP identifier types.
 func (ssa *SQLStorageAuthority) CountInvalidAuthorizations2(ctx context.Context, req *sapb.CountInvalidAuthorizationsRequest) (*sapb.Count, error) {
 	var count int64
 	err := ssa.dbMap.WithContext(ctx).SelectOne(
@@ -1685,11 +1822,11 @@ func (ssa *SQLStorageAuthority) CountInvalidAuthorizations2(ctx context.Context,
 		status = :status AND
 		expires > :expiresEarliest AND
 		expires <= :expiresLatest AND
-		identifierType = :dnsType AND
+		identifierType = :identType AND
 		identifierValue = :ident`,
 		map[string]interface{}{
 			"regID":           req.RegistrationID,
-			"dnsType":         identifierTypeToUint[string(identifier.DNS)],
+			"identType":       identifierTypeToUint[string(identifier.FromName(req.Hostname).Type)],
 			"ident":           req.Hostname,
 			"expiresEarliest": time.Unix(0, req.Range.Earliest),
 			"expiresLatest":   time.Unix(0, req.Range.Latest),
@@ -1702,10 +1839,46 @@ func (ssa *SQLStorageAuthority) CountInvalidAuthorizations2(ctx context.Context,
 	return &sapb.Count{Count: count}, nil
 }
 
+// CountInvalidAuthorizationsByName counts invalid authorizations for a user
+// expiring in a given time range, grouped by the name they were for. Names
+// with no invalid authorizations in the range are omitted.
+func (ssa *SQLStorageAuthority) CountInvalidAuthorizationsByName(ctx context.Context, req *sapb.CountInvalidAuthorizationsByNameRequest) (*sapb.CountByNames, error) {
+	var counts []struct {
+		Name  string `db:"identifierValue"`
+		Count int64  `db:"count"`
+	}
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&counts,
+		`SELECT identifierValue, COUNT(1) AS count FROM authz2 WHERE
+		registrationID = :regID AND
+		status = :status AND
+		expires > :expiresEarliest AND
+		expires <= :expiresLatest
+		GROUP BY identifierValue`,
+		map[string]interface{}{
+			"regID":           req.RegistrationID,
+			"expiresEarliest": time.Unix(0, req.Range.Earliest),
+			"expiresLatest":   time.Unix(0, req.Range.Latest),
+			"status":          statusUint(core.StatusInvalid),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp := &sapb.CountByNames{}
+	for _, count := range counts {
+		resp.CountByNames = append(resp.CountByNames, &sapb.CountByNames_MapElement{
+			Name:  count.Name,
+			Count: count.Count,
+		})
+	}
+	return resp, nil
+}
+
 // GetValidAuthorizations2 returns the latest authorization for all
 // domain names that the account has authorizations for. This method is
-// intended to deprecate GetValidAuthorizations. This method only supports
-// DNS identifier types.
+// intended to deprecate GetValidAuthorizations. This method supports DNS and
+// IP identifier types.
 func (ssa *SQLStorageAuthority) GetValidAuthorizations2(ctx context.Context, req *sapb.GetValidAuthorizationsRequest) (*sapb.Authorizations, error) {
 	var authzModels []authzModel
 	params := []interface{}{
@@ -1713,6 +1886,7 @@ func (ssa *SQLStorageAuthority) GetValidAuthorizations2(ctx context.Context, req
 		statusUint(core.StatusValid),
 		time.Unix(0, req.Now),
 		identifierTypeToUint[string(identifier.DNS)],
+		identifierTypeToUint[string(identifier.IP)],
 	}
 	qmarks := make([]string, len(req.Domains))
 	for i, n := range req.Domains {
@@ -1726,7 +1900,7 @@ func (ssa *SQLStorageAuthority) GetValidAuthorizations2(ctx context.Context, req
 			registrationID = ? AND
 			status = ? AND
 			expires > ? AND
-			identifierType = ? AND
+			identifierType IN (?,?) AND
 			identifierValue IN (%s)`,
 			authzFields,
 			strings.Join(qmarks, ","),
@@ -1739,8 +1913,8 @@ func (ssa *SQLStorageAuthority) GetValidAuthorizations2(ctx context.Context, req
 
 	authzMap := make(map[string]authzModel, len(authzModels))
 	for _, am := range authzModels {
-		// Only allow DNS identifiers
-		if uintToIdentifierType[am.IdentifierType] != string(identifier.DNS) {
+		// Only allow known identifier types
+		if _, ok := uintToIdentifierType[am.IdentifierType]; !ok {
 			continue
 		}
 		// If there is an existing authorization in the map only replace it with one
@@ -1820,3 +1994,309 @@ func (ssa *SQLStorageAuthority) KeyBlocked(ctx context.Context, req *sapb.KeyBlo
 	exists = true
 	return &sapb.Exists{Exists: exists}, nil
 }
+
+// GetRevokedCerts returns the serial, revocation reason, and revocation time
+// of every revoked certificate from the given issuer whose notAfter falls
+// within [ExpiresAfter, ExpiresBefore). It is used by the crl-updater to
+// gather the entries for a single CRL shard.
+func (ssa *SQLStorageAuthority) GetRevokedCerts(ctx context.Context, req *sapb.GetRevokedCertsRequest) (*sapb.RevokedCerts, error) {
+	if req == nil || req.IssuerID == 0 || req.ExpiresBefore == 0 {
+		return nil, errIncompleteRequest
+	}
+	var statuses []revokedCertModel
+	_, err := ssa.dbMap.Select(
+		&statuses,
+		`SELECT serial, revokedReason, revokedDate FROM certificateStatus
+		WHERE issuerID = ?
+		AND status = ?
+		AND notAfter >= ?
+		AND notAfter < ?`,
+		req.IssuerID,
+		string(core.OCSPStatusRevoked),
+		time.Unix(0, req.ExpiresAfter),
+		time.Unix(0, req.ExpiresBefore),
+	)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*corepb.CRLEntry, len(statuses))
+	for i, s := range statuses {
+		entries[i] = &corepb.CRLEntry{
+			Serial:    s.Serial,
+			Reason:    int32(s.RevokedReason),
+			RevokedAt: s.RevokedDate.UnixNano(),
+		}
+	}
+	return &sapb.RevokedCerts{Entries: entries}, nil
+}
+
+// AddExternalAccountKey stores a new external account key ID and the MAC key
+// which newAccount requests must use to bind to it.
+func (ssa *SQLStorageAuthority) AddExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKey) (*corepb.Empty, error) {
+	if req == nil || req.KeyID == "" || len(req.MacKey) == 0 || req.CreatedAt == 0 {
+		return nil, errIncompleteRequest
+	}
+	err := ssa.dbMap.WithContext(ctx).Insert(&externalAccountKeyModel{
+		KeyID:     req.KeyID,
+		MACKey:    req.MacKey,
+		CreatedAt: time.Unix(0, req.CreatedAt),
+	})
+	if err != nil {
+		if db.IsDuplicate(err) {
+			return nil, berrors.DuplicateError("external account key ID %q already exists", req.KeyID)
+		}
+		return nil, err
+	}
+	return &corepb.Empty{}, nil
+}
+
+// GetExternalAccountKey returns the MAC key for the given external account
+// key ID.
+func (ssa *SQLStorageAuthority) GetExternalAccountKey(ctx context.Context, req *sapb.ExternalAccountKeyID) (*sapb.ExternalAccountKey, error) {
+	if req == nil || req.KeyID == "" {
+		return nil, errIncompleteRequest
+	}
+	var model externalAccountKeyModel
+	err := ssa.dbMap.WithContext(ctx).SelectOne(
+		&model,
+		"SELECT id, keyID, macKey, createdAt FROM externalAccountKeys WHERE keyID = ?",
+		req.KeyID,
+	)
+	if err != nil {
+		if db.IsNoRows(err) {
+			return nil, berrors.NotFoundError("no external account key with ID %q", req.KeyID)
+		}
+		return nil, err
+	}
+	return &sapb.ExternalAccountKey{
+		KeyID:     model.KeyID,
+		MacKey:    model.MACKey,
+		CreatedAt: model.CreatedAt.UnixNano(),
+	}, nil
+}
+
+// AddRateLimitOverride stores a new rate limit override and returns its ID.
+// Overrides are never replaced: a newer override for the same limit and key or
+// registration takes precedence over an older one until it expires.
+func (ssa *SQLStorageAuthority) AddRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverride) (*sapb.RateLimitOverrideID, error) {
+	if req == nil || req.LimitName == "" || req.Comment == "" || req.Expires == 0 {
+		return nil, errIncompleteRequest
+	}
+	if (req.Key == "") == (req.RegistrationID == 0) {
+		return nil, berrors.MalformedError("rate limit override must have exactly one of a key or a registration ID")
+	}
+	if req.Threshold < 0 {
+		return nil, berrors.MalformedError("rate limit override threshold must not be negative")
+	}
+	model := &rateLimitOverrideModel{
+		LimitName:      req.LimitName,
+		OverrideKey:    req.Key,
+		RegistrationID: req.RegistrationID,
+		Threshold:      req.Threshold,
+		Comment:        req.Comment,
+		CreatedAt:      ssa.clk.Now(),
+		Expires:        time.Unix(0, req.Expires),
+	}
+	err := ssa.dbMap.WithContext(ctx).Insert(model)
+	if err != nil {
+		return nil, err
+	}
+	return &sapb.RateLimitOverrideID{Id: model.ID}, nil
+}
+
+// GetRateLimitOverrides returns the rate limit overrides which have not yet
+// expired, or every override if req.IncludeExpired is set, ordered from oldest
+// to newest.
+func (ssa *SQLStorageAuthority) GetRateLimitOverrides(ctx context.Context, req *sapb.GetRateLimitOverridesRequest) (*sapb.RateLimitOverrides, error) {
+	if req == nil {
+		return nil, errIncompleteRequest
+	}
+	query := `SELECT id, limitName, overrideKey, registrationID, threshold, comment, createdAt, expires
+		FROM rateLimitOverrides`
+	params := map[string]interface{}{}
+	if !req.IncludeExpired {
+		query += ` WHERE expires > :now`
+		params["now"] = ssa.clk.Now()
+	}
+	query += ` ORDER BY id`
+
+	var models []rateLimitOverrideModel
+	_, err := ssa.dbMap.WithContext(ctx).Select(&models, query, params)
+	if err != nil {
+		return nil, err
+	}
+	overrides := make([]*sapb.RateLimitOverride, len(models))
+	for i, m := range models {
+		overrides[i] = &sapb.RateLimitOverride{
+			Id:             m.ID,
+			LimitName:      m.LimitName,
+			Key:            m.OverrideKey,
+			RegistrationID: m.RegistrationID,
+			Threshold:      m.Threshold,
+			Comment:        m.Comment,
+			CreatedAt:      m.CreatedAt.UnixNano(),
+			Expires:        m.Expires.UnixNano(),
+		}
+	}
+	return &sapb.RateLimitOverrides{Overrides: overrides}, nil
+}
+
+// GetOrdersForAccount returns the IDs of the orders created by the given
+// account, in the order they were created, starting after the given order ID.
+// All orders are included, whatever their status and even once they have
+// expired.
+func (ssa *SQLStorageAuthority) GetOrdersForAccount(ctx context.Context, req *sapb.GetByAccountRequest) (*sapb.OrderIDs, error) {
+	if req == nil || req.RegistrationID == 0 || req.Limit == 0 {
+		return nil, errIncompleteRequest
+	}
+	var ids []int64
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&ids,
+		`SELECT id FROM orders
+		WHERE registrationID = :regID AND id > :afterID
+		ORDER BY id
+		LIMIT :limit`,
+		map[string]interface{}{
+			"regID":   req.RegistrationID,
+			"afterID": req.AfterID,
+			"limit":   req.Limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &sapb.OrderIDs{Ids: ids}, nil
+}
+
+// GetSerialsForAccount returns the serials of the certificates issued to the
+// given account, in the order they were issued, starting after the
+// certificates row with the given ID. Expired and revoked certificates are
+// included.
+func (ssa *SQLStorageAuthority) GetSerialsForAccount(ctx context.Context, req *sapb.GetByAccountRequest) (*sapb.AccountSerials, error) {
+	if req == nil || req.RegistrationID == 0 || req.Limit == 0 {
+		return nil, errIncompleteRequest
+	}
+	var rows []struct {
+		ID     int64
+		Serial string
+	}
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&rows,
+		`SELECT id, serial FROM certificates
+		WHERE registrationID = :regID AND id > :afterID
+		ORDER BY id
+		LIMIT :limit`,
+		map[string]interface{}{
+			"regID":   req.RegistrationID,
+			"afterID": req.AfterID,
+			"limit":   req.Limit,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	resp := &sapb.AccountSerials{}
+	for _, row := range rows {
+		resp.Serials = append(resp.Serials, row.Serial)
+		resp.LastID = row.ID
+	}
+	return resp, nil
+}
+
+// GetUnexpiredSerialsForName returns the serials of every certificate or
+// precertificate naming the given domain, or the wildcard directly below it,
+// which expires after the given time and has not been revoked. Since a
+// wildcard can only be issued for a domain's base name, whoever controls the
+// domain also controls the wildcard, so a request for example.com includes
+// certificates for *.example.com, but one for www.example.com doesn't.
+func (ssa *SQLStorageAuthority) GetUnexpiredSerialsForName(ctx context.Context, req *sapb.GetUnexpiredSerialsForNameRequest) (*sapb.Serials, error) {
+	if req == nil || req.Domain == "" || req.Now == 0 {
+		return nil, errIncompleteRequest
+	}
+	if strings.HasPrefix(req.Domain, "*.") {
+		return nil, berrors.MalformedError("domain must not be a wildcard")
+	}
+	var serials []string
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&serials,
+		`SELECT cs.serial FROM issuedNames AS i
+		JOIN certificateStatus AS cs ON cs.serial = i.serial
+		WHERE i.reversedName IN (:reversedName, :reversedWildcard)
+		AND cs.notAfter > :now
+		AND cs.status != :revoked
+		ORDER BY i.notBefore`,
+		map[string]interface{}{
+			"reversedName":     ReverseName(req.Domain),
+			"reversedWildcard": ReverseName("*." + req.Domain),
+			"now":              time.Unix(0, req.Now),
+			"revoked":          string(core.OCSPStatusRevoked),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	// A certificate naming both the domain and its wildcard matches twice.
+	seen := make(map[string]bool, len(serials))
+	resp := &sapb.Serials{}
+	for _, serial := range serials {
+		if !seen[serial] {
+			seen[serial] = true
+			resp.Serials = append(resp.Serials, serial)
+		}
+	}
+	return resp, nil
+}
+
+// GetUnexpiredSerialsForKey returns the serials of every certificate or
+// precertificate with the given SPKI hash which expires after the given time
+// and has not been revoked.
+func (ssa *SQLStorageAuthority) GetUnexpiredSerialsForKey(ctx context.Context, req *sapb.GetUnexpiredSerialsForKeyRequest) (*sapb.Serials, error) {
+	if req == nil || len(req.KeyHash) == 0 || req.Now == 0 {
+		return nil, errIncompleteRequest
+	}
+	var serials []string
+	_, err := ssa.dbMap.WithContext(ctx).Select(
+		&serials,
+		`SELECT k.certSerial FROM keyHashToSerial AS k
+		JOIN certificateStatus AS cs ON cs.serial = k.certSerial
+		WHERE k.keyHash = :keyHash
+		AND k.certNotAfter > :now
+		AND cs.status != :revoked
+		ORDER BY k.id`,
+		map[string]interface{}{
+			"keyHash": req.KeyHash,
+			"now":     time.Unix(0, req.Now),
+			"revoked": string(core.OCSPStatusRevoked),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &sapb.Serials{Serials: serials}, nil
+}
+
+// ExpireRateLimitOverride expires the rate limit override with the given ID
+// immediately. The override itself is kept, for the record.
+func (ssa *SQLStorageAuthority) ExpireRateLimitOverride(ctx context.Context, req *sapb.RateLimitOverrideID) (*corepb.Empty, error) {
+	if req == nil || req.Id == 0 {
+		return nil, errIncompleteRequest
+	}
+	now := ssa.clk.Now()
+	result, err := ssa.dbMap.WithContext(ctx).Exec(
+		`UPDATE rateLimitOverrides SET expires = ? WHERE id = ? AND expires > ?`,
+		now,
+		req.Id,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, berrors.NotFoundError("no unexpired rate limit override with ID %d", req.Id)
+	}
+	return &corepb.Empty{}, nil
+}
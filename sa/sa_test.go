@@ -183,6 +183,164 @@ func TestNoSuchRegistrationErrors(t *testing.T) {
 	test.AssertErrorIs(t, err, berrors.NotFound)
 }
 
+func TestExternalAccountBinding(t *testing.T) {
+	sa, clk, cleanUp := initSA(t)
+	defer cleanUp()
+
+	_ = features.Set(map[string]bool{"ExternalAccountBinding": true})
+	defer features.Reset()
+
+	_, err := sa.GetExternalAccountKey(ctx, &sapb.ExternalAccountKeyID{KeyID: "kid"})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	key := &sapb.ExternalAccountKey{
+		KeyID:     "kid",
+		MacKey:    []byte("secret"),
+		CreatedAt: clk.Now().UnixNano(),
+	}
+	_, err = sa.AddExternalAccountKey(ctx, key)
+	test.AssertNotError(t, err, "Couldn't add external account key")
+	_, err = sa.AddExternalAccountKey(ctx, key)
+	test.AssertErrorIs(t, err, berrors.Duplicate)
+
+	stored, err := sa.GetExternalAccountKey(ctx, &sapb.ExternalAccountKeyID{KeyID: "kid"})
+	test.AssertNotError(t, err, "Couldn't get external account key")
+	test.AssertDeepEquals(t, stored.MacKey, key.MacKey)
+	test.AssertEquals(t, stored.CreatedAt, key.CreatedAt)
+
+	jwk := satest.GoodJWK()
+	reg, err := sa.NewRegistration(ctx, core.Registration{
+		Key:               jwk,
+		InitialIP:         net.ParseIP("43.34.43.34"),
+		ExternalAccountID: "kid",
+	})
+	test.AssertNotError(t, err, "Couldn't create new registration")
+	test.AssertEquals(t, reg.ExternalAccountID, "kid")
+
+	dbReg, err := sa.GetRegistration(ctx, reg.ID)
+	test.AssertNotError(t, err, "Couldn't get registration")
+	test.AssertEquals(t, dbReg.ExternalAccountID, "kid")
+	dbReg, err = sa.GetRegistrationByKey(ctx, jwk)
+	test.AssertNotError(t, err, "Couldn't get registration by key")
+	test.AssertEquals(t, dbReg.ExternalAccountID, "kid")
+}
+
+func TestRateLimitOverrides(t *testing.T) {
+	sa, clk, cleanUp := initSA(t)
+	defer cleanUp()
+
+	_, err := sa.AddRateLimitOverride(ctx, &sapb.RateLimitOverride{
+		LimitName:      "certificatesPerName",
+		Key:            "example.com",
+		RegistrationID: 1,
+		Threshold:      10,
+		Comment:        "both key and registration",
+		Expires:        clk.Now().Add(time.Hour).UnixNano(),
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+
+	keyID, err := sa.AddRateLimitOverride(ctx, &sapb.RateLimitOverride{
+		LimitName: "certificatesPerName",
+		Key:       "example.com",
+		Threshold: 100,
+		Comment:   "hosting provider",
+		Expires:   clk.Now().Add(time.Hour).UnixNano(),
+	})
+	test.AssertNotError(t, err, "Couldn't add key override")
+	regID, err := sa.AddRateLimitOverride(ctx, &sapb.RateLimitOverride{
+		LimitName:      "newOrdersPerAccount",
+		RegistrationID: 42,
+		Threshold:      5000,
+		Comment:        "large integrator",
+		Expires:        clk.Now().Add(48 * time.Hour).UnixNano(),
+	})
+	test.AssertNotError(t, err, "Couldn't add registration override")
+
+	overrides, err := sa.GetRateLimitOverrides(ctx, &sapb.GetRateLimitOverridesRequest{})
+	test.AssertNotError(t, err, "Couldn't get overrides")
+	test.AssertEquals(t, len(overrides.Overrides), 2)
+	test.AssertEquals(t, overrides.Overrides[0].Id, keyID.Id)
+	test.AssertEquals(t, overrides.Overrides[0].Key, "example.com")
+	test.AssertEquals(t, overrides.Overrides[0].Threshold, int64(100))
+	test.AssertEquals(t, overrides.Overrides[0].Comment, "hosting provider")
+	test.AssertEquals(t, overrides.Overrides[1].Id, regID.Id)
+	test.AssertEquals(t, overrides.Overrides[1].RegistrationID, int64(42))
+
+	// Once the key override has expired it is only returned on request.
+	clk.Add(2 * time.Hour)
+	overrides, err = sa.GetRateLimitOverrides(ctx, &sapb.GetRateLimitOverridesRequest{})
+	test.AssertNotError(t, err, "Couldn't get overrides")
+	test.AssertEquals(t, len(overrides.Overrides), 1)
+	test.AssertEquals(t, overrides.Overrides[0].Id, regID.Id)
+	overrides, err = sa.GetRateLimitOverrides(ctx, &sapb.GetRateLimitOverridesRequest{IncludeExpired: true})
+	test.AssertNotError(t, err, "Couldn't get overrides")
+	test.AssertEquals(t, len(overrides.Overrides), 2)
+
+	_, err = sa.ExpireRateLimitOverride(ctx, keyID)
+	test.AssertErrorIs(t, err, berrors.NotFound)
+	_, err = sa.ExpireRateLimitOverride(ctx, regID)
+	test.AssertNotError(t, err, "Couldn't expire override")
+	overrides, err = sa.GetRateLimitOverrides(ctx, &sapb.GetRateLimitOverridesRequest{})
+	test.AssertNotError(t, err, "Couldn't get overrides")
+	test.AssertEquals(t, len(overrides.Overrides), 0)
+}
+
+func TestGetOrdersAndSerialsForAccount(t *testing.T) {
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+	otherReg, err := sa.NewRegistration(ctx, core.Registration{
+		Key:       &jose.JSONWebKey{Key: &rsa.PublicKey{N: big.NewInt(1), E: 1}},
+		InitialIP: net.ParseIP("43.34.43.34"),
+	})
+	test.AssertNotError(t, err, "Couldn't create new registration")
+
+	var orderIDs []int64
+	for i, regID := range []int64{reg.ID, otherReg.ID, reg.ID, reg.ID} {
+		order, err := sa.NewOrder(ctx, &corepb.Order{
+			RegistrationID:   regID,
+			Expires:          fc.Now().Add(time.Hour).UnixNano(),
+			Names:            []string{fmt.Sprintf("%d.example.com", i)},
+			V2Authorizations: []int64{1},
+		})
+		test.AssertNotError(t, err, "sa.NewOrder failed")
+		if regID == reg.ID {
+			orderIDs = append(orderIDs, order.Id)
+		}
+	}
+
+	orders, err := sa.GetOrdersForAccount(ctx, &sapb.GetByAccountRequest{RegistrationID: reg.ID, Limit: 2})
+	test.AssertNotError(t, err, "sa.GetOrdersForAccount failed")
+	test.AssertDeepEquals(t, orders.Ids, orderIDs[:2])
+	orders, err = sa.GetOrdersForAccount(ctx, &sapb.GetByAccountRequest{RegistrationID: reg.ID, AfterID: orders.Ids[1], Limit: 2})
+	test.AssertNotError(t, err, "sa.GetOrdersForAccount failed")
+	test.AssertDeepEquals(t, orders.Ids, orderIDs[2:])
+
+	var serials []string
+	for _, file := range []string{"www.eff.org.der", "test-cert.der", "test-cert2.der"} {
+		certDER, err := ioutil.ReadFile(file)
+		test.AssertNotError(t, err, "Couldn't read example cert DER")
+		issued := fc.Now()
+		_, err = sa.AddCertificate(ctx, certDER, reg.ID, nil, &issued)
+		test.AssertNotError(t, err, "Couldn't add certificate")
+		cert, err := x509.ParseCertificate(certDER)
+		test.AssertNotError(t, err, "Couldn't parse certificate")
+		serials = append(serials, core.SerialToString(cert.SerialNumber))
+	}
+
+	page, err := sa.GetSerialsForAccount(ctx, &sapb.GetByAccountRequest{RegistrationID: reg.ID, Limit: 2})
+	test.AssertNotError(t, err, "sa.GetSerialsForAccount failed")
+	test.AssertDeepEquals(t, page.Serials, serials[:2])
+	page, err = sa.GetSerialsForAccount(ctx, &sapb.GetByAccountRequest{RegistrationID: reg.ID, AfterID: page.LastID, Limit: 2})
+	test.AssertNotError(t, err, "sa.GetSerialsForAccount failed")
+	test.AssertDeepEquals(t, page.Serials, serials[2:])
+
+	page, err = sa.GetSerialsForAccount(ctx, &sapb.GetByAccountRequest{RegistrationID: otherReg.ID, Limit: 2})
+	test.AssertNotError(t, err, "sa.GetSerialsForAccount failed")
+	test.AssertEquals(t, len(page.Serials), 0)
+}
+
 func TestAddCertificate(t *testing.T) {
 	sa, clk, cleanUp := initSA(t)
 	defer cleanUp()
@@ -949,6 +1107,95 @@ func TestNewOrder(t *testing.T) {
 	test.AssertDeepEquals(t, names, []string{"com.example", "com.example.another.just"})
 }
 
+func TestNewOrderCertificateProfile(t *testing.T) {
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	err := features.Set(map[string]bool{"MultipleCertificateProfiles": true})
+	test.AssertNotError(t, err, "failed to set features")
+	defer features.Reset()
+
+	reg, err := sa.NewRegistration(ctx, core.Registration{
+		Key:       &jose.JSONWebKey{Key: &rsa.PublicKey{N: big.NewInt(1), E: 1}},
+		InitialIP: net.ParseIP("42.42.42.42"),
+	})
+	test.AssertNotError(t, err, "Couldn't create test registration")
+
+	order, err := sa.NewOrder(ctx, &corepb.Order{
+		RegistrationID:         reg.ID,
+		Expires:                fc.Now().Add(time.Hour).UnixNano(),
+		Names:                  []string{"example.com"},
+		V2Authorizations:       []int64{1},
+		CertificateProfileName: "shortlived",
+	})
+	test.AssertNotError(t, err, "sa.NewOrder failed")
+	test.AssertEquals(t, order.CertificateProfileName, "shortlived")
+
+	storedOrder, err := sa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "sa.GetOrder failed")
+	test.AssertEquals(t, storedOrder.CertificateProfileName, "shortlived")
+
+	// An order without a profile should come back without one.
+	order, err = sa.NewOrder(ctx, &corepb.Order{
+		RegistrationID:   reg.ID,
+		Expires:          fc.Now().Add(time.Hour).UnixNano(),
+		Names:            []string{"example.org"},
+		V2Authorizations: []int64{1},
+	})
+	test.AssertNotError(t, err, "sa.NewOrder failed")
+	storedOrder, err = sa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "sa.GetOrder failed")
+	test.AssertEquals(t, storedOrder.CertificateProfileName, "")
+}
+
+func TestNewOrderValidity(t *testing.T) {
+	sa, fc, cleanup := initSA(t)
+	defer cleanup()
+
+	err := features.Set(map[string]bool{"RequestedOrderValidity": true})
+	test.AssertNotError(t, err, "failed to set features")
+	defer features.Reset()
+
+	reg, err := sa.NewRegistration(ctx, core.Registration{
+		Key:       &jose.JSONWebKey{Key: &rsa.PublicKey{N: big.NewInt(1), E: 1}},
+		InitialIP: net.ParseIP("42.42.42.42"),
+	})
+	test.AssertNotError(t, err, "Couldn't create test registration")
+
+	// An order without a validity window should come back without one.
+	order, err := sa.NewOrder(ctx, &corepb.Order{
+		RegistrationID:   reg.ID,
+		Expires:          fc.Now().Add(time.Hour).UnixNano(),
+		Names:            []string{"example.org"},
+		V2Authorizations: []int64{1},
+	})
+	test.AssertNotError(t, err, "sa.NewOrder failed")
+	storedOrder, err := sa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "sa.GetOrder failed")
+	test.AssertEquals(t, storedOrder.NotBefore, int64(0))
+	test.AssertEquals(t, storedOrder.NotAfter, int64(0))
+
+	// The requested validity window is stored alongside the profile.
+	notBefore := fc.Now().Add(24 * time.Hour).Truncate(time.Second)
+	notAfter := notBefore.Add(72 * time.Hour)
+	order, err = sa.NewOrder(ctx, &corepb.Order{
+		RegistrationID:         reg.ID,
+		Expires:                fc.Now().Add(time.Hour).UnixNano(),
+		Names:                  []string{"example.net"},
+		V2Authorizations:       []int64{1},
+		CertificateProfileName: "shortlived",
+		NotBefore:              notBefore.UnixNano(),
+		NotAfter:               notAfter.UnixNano(),
+	})
+	test.AssertNotError(t, err, "sa.NewOrder failed")
+	test.AssertEquals(t, order.NotBefore, notBefore.UnixNano())
+	storedOrder, err = sa.GetOrder(ctx, &sapb.OrderRequest{Id: order.Id})
+	test.AssertNotError(t, err, "sa.GetOrder failed")
+	test.AssertEquals(t, storedOrder.CertificateProfileName, "shortlived")
+	test.AssertEquals(t, storedOrder.NotBefore, notBefore.UnixNano())
+	test.AssertEquals(t, storedOrder.NotAfter, notAfter.UnixNano())
+}
+
 func TestSetOrderProcessing(t *testing.T) {
 	sa, fc, cleanup := initSA(t)
 	defer cleanup()
@@ -1611,6 +1858,219 @@ func TestRevokeCertificate(t *testing.T) {
 	test.AssertError(t, err, "RevokeCertificate should've failed when certificate already revoked")
 }
 
+func TestGetRevokedCerts(t *testing.T) {
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+	certDER, err := ioutil.ReadFile("www.eff.org.der")
+	test.AssertNotError(t, err, "Couldn't read example cert DER")
+	cert, err := x509.ParseCertificate(certDER)
+	test.AssertNotError(t, err, "Couldn't parse example cert DER")
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:      certDER,
+		RegID:    reg.ID,
+		Issued:   sa.clk.Now().UnixNano(),
+		IssuerID: 1,
+	})
+	test.AssertNotError(t, err, "Couldn't add www.eff.org.der")
+
+	countRevokedCerts := func(req *sapb.GetRevokedCertsRequest) int {
+		t.Helper()
+		resp, err := sa.GetRevokedCerts(ctx, req)
+		test.AssertNotError(t, err, "GetRevokedCerts failed")
+		return len(resp.Entries)
+	}
+
+	// An unrevoked certificate should not be returned.
+	window := &sapb.GetRevokedCertsRequest{
+		IssuerID:      1,
+		ExpiresAfter:  cert.NotAfter.Add(-time.Hour).UnixNano(),
+		ExpiresBefore: cert.NotAfter.Add(time.Hour).UnixNano(),
+	}
+	test.AssertEquals(t, countRevokedCerts(window), 0)
+
+	revokedAt := sa.clk.Now()
+	err = sa.RevokeCertificate(ctx, &sapb.RevokeCertificateRequest{
+		Serial: core.SerialToString(cert.SerialNumber),
+		Date:   revokedAt.UnixNano(),
+		Reason: 1,
+	})
+	test.AssertNotError(t, err, "RevokeCertificate failed")
+
+	resp, err := sa.GetRevokedCerts(ctx, window)
+	test.AssertNotError(t, err, "GetRevokedCerts failed")
+	test.AssertEquals(t, len(resp.Entries), 1)
+	test.AssertEquals(t, resp.Entries[0].Serial, core.SerialToString(cert.SerialNumber))
+	test.AssertEquals(t, resp.Entries[0].Reason, int32(1))
+	test.AssertEquals(t, resp.Entries[0].RevokedAt, revokedAt.UnixNano())
+
+	// A different issuer, or a window which doesn't cover the certificate's
+	// expiry, should not return it.
+	test.AssertEquals(t, countRevokedCerts(&sapb.GetRevokedCertsRequest{
+		IssuerID:      2,
+		ExpiresAfter:  window.ExpiresAfter,
+		ExpiresBefore: window.ExpiresBefore,
+	}), 0)
+	test.AssertEquals(t, countRevokedCerts(&sapb.GetRevokedCertsRequest{
+		IssuerID:      1,
+		ExpiresAfter:  cert.NotAfter.Add(time.Second).UnixNano(),
+		ExpiresBefore: window.ExpiresBefore,
+	}), 0)
+	test.AssertEquals(t, countRevokedCerts(&sapb.GetRevokedCertsRequest{
+		IssuerID:      1,
+		ExpiresAfter:  window.ExpiresAfter,
+		ExpiresBefore: cert.NotAfter.Truncate(time.Second).UnixNano(),
+	}), 0)
+}
+
+func TestGetUnexpiredSerialsForName(t *testing.T) {
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+	certDER, err := ioutil.ReadFile("www.eff.org.der")
+	test.AssertNotError(t, err, "Couldn't read example cert DER")
+	cert, err := x509.ParseCertificate(certDER)
+	test.AssertNotError(t, err, "Couldn't parse example cert DER")
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:      certDER,
+		RegID:    reg.ID,
+		Issued:   sa.clk.Now().UnixNano(),
+		IssuerID: 1,
+	})
+	test.AssertNotError(t, err, "Couldn't add www.eff.org.der")
+	serial := core.SerialToString(cert.SerialNumber)
+
+	getSerials := func(domain string, now time.Time) []string {
+		t.Helper()
+		resp, err := sa.GetUnexpiredSerialsForName(ctx, &sapb.GetUnexpiredSerialsForNameRequest{
+			Domain: domain,
+			Now:    now.UnixNano(),
+		})
+		test.AssertNotError(t, err, "GetUnexpiredSerialsForName failed")
+		return resp.Serials
+	}
+
+	beforeExpiry := cert.NotAfter.Add(-time.Hour)
+	test.AssertDeepEquals(t, getSerials(cert.DNSNames[0], beforeExpiry), []string{serial})
+	test.AssertEquals(t, len(getSerials("not-eff.org", beforeExpiry)), 0)
+	// Expired certificates are not returned.
+	test.AssertEquals(t, len(getSerials(cert.DNSNames[0], cert.NotAfter.Add(time.Hour))), 0)
+
+	// Nor are revoked ones.
+	err = sa.RevokeCertificate(ctx, &sapb.RevokeCertificateRequest{
+		Serial: serial,
+		Date:   sa.clk.Now().UnixNano(),
+		Reason: 1,
+	})
+	test.AssertNotError(t, err, "RevokeCertificate failed")
+	test.AssertEquals(t, len(getSerials(cert.DNSNames[0], beforeExpiry)), 0)
+}
+
+func TestGetUnexpiredSerialsForNameWildcard(t *testing.T) {
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+	testKey, err := rsa.GenerateKey(rand.Reader, 512)
+	test.AssertNotError(t, err, "error generating test key")
+
+	addCert := func(serial int64, names ...string) string {
+		t.Helper()
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			DNSNames:     names,
+			NotBefore:    fc.Now().Add(time.Duration(serial) * time.Minute),
+			NotAfter:     fc.Now().Add(90 * 24 * time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, testKey.Public(), testKey)
+		test.AssertNotError(t, err, "Failed to create test cert")
+		_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+			Der:      der,
+			RegID:    reg.ID,
+			Issued:   fc.Now().UnixNano(),
+			IssuerID: 1,
+		})
+		test.AssertNotError(t, err, "Couldn't add test cert")
+		return core.SerialToString(big.NewInt(serial))
+	}
+	both := addCert(1, "wildcard.com", "*.wildcard.com")
+	wildcard := addCert(2, "*.wildcard.com")
+	subWildcard := addCert(3, "*.sub.wildcard.com")
+	addCert(4, "www.wildcard.com")
+
+	getSerials := func(domain string) []string {
+		t.Helper()
+		resp, err := sa.GetUnexpiredSerialsForName(ctx, &sapb.GetUnexpiredSerialsForNameRequest{
+			Domain: domain,
+			Now:    fc.Now().UnixNano(),
+		})
+		test.AssertNotError(t, err, "GetUnexpiredSerialsForName failed")
+		return resp.Serials
+	}
+
+	// The base domain covers the wildcard directly below it, and a certificate
+	// naming both is only returned once. Names and wildcards further down are
+	// not covered.
+	test.AssertDeepEquals(t, getSerials("wildcard.com"), []string{both, wildcard})
+	test.AssertDeepEquals(t, getSerials("sub.wildcard.com"), []string{subWildcard})
+	test.AssertEquals(t, len(getSerials("other.wildcard.com")), 0)
+
+	// Wildcards must be looked up by their base domain.
+	_, err = sa.GetUnexpiredSerialsForName(ctx, &sapb.GetUnexpiredSerialsForNameRequest{
+		Domain: "*.wildcard.com",
+		Now:    fc.Now().UnixNano(),
+	})
+	test.AssertErrorIs(t, err, berrors.Malformed)
+}
+
+func TestGetUnexpiredSerialsForKey(t *testing.T) {
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+	certDER, err := ioutil.ReadFile("www.eff.org.der")
+	test.AssertNotError(t, err, "Couldn't read example cert DER")
+	cert, err := x509.ParseCertificate(certDER)
+	test.AssertNotError(t, err, "Couldn't parse example cert DER")
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:      certDER,
+		RegID:    reg.ID,
+		Issued:   sa.clk.Now().UnixNano(),
+		IssuerID: 1,
+	})
+	test.AssertNotError(t, err, "Couldn't add www.eff.org.der")
+	serial := core.SerialToString(cert.SerialNumber)
+	digest, err := core.KeyDigest(cert.PublicKey)
+	test.AssertNotError(t, err, "core.KeyDigest failed")
+
+	getSerials := func(keyHash []byte, now time.Time) []string {
+		t.Helper()
+		resp, err := sa.GetUnexpiredSerialsForKey(ctx, &sapb.GetUnexpiredSerialsForKeyRequest{
+			KeyHash: keyHash,
+			Now:     now.UnixNano(),
+		})
+		test.AssertNotError(t, err, "GetUnexpiredSerialsForKey failed")
+		return resp.Serials
+	}
+
+	beforeExpiry := cert.NotAfter.Add(-time.Hour)
+	test.AssertDeepEquals(t, getSerials(digest[:], beforeExpiry), []string{serial})
+	test.AssertEquals(t, len(getSerials(make([]byte, 32), beforeExpiry)), 0)
+	// Expired certificates are not returned.
+	test.AssertEquals(t, len(getSerials(digest[:], cert.NotAfter.Add(time.Hour))), 0)
+
+	// Nor are revoked ones.
+	err = sa.RevokeCertificate(ctx, &sapb.RevokeCertificateRequest{
+		Serial: serial,
+		Date:   sa.clk.Now().UnixNano(),
+		Reason: 1,
+	})
+	test.AssertNotError(t, err, "RevokeCertificate failed")
+	test.AssertEquals(t, len(getSerials(digest[:], beforeExpiry)), 0)
+}
+
 func TestAddCertificateRenewalBit(t *testing.T) {
 	sa, fc, cleanUp := initSA(t)
 	defer cleanUp()
@@ -2140,6 +2600,39 @@ func TestCountInvalidAuthorizations2(t *testing.T) {
 	test.AssertEquals(t, count.Count, int64(1))
 }
 
+func TestCountInvalidAuthorizationsByName(t *testing.T) {
+	sa, fc, cleanUp := initSA(t)
+	defer cleanUp()
+
+	fc.Add(time.Hour)
+	reg := satest.CreateWorkingRegistration(t, sa)
+	expiresA := fc.Now().Add(time.Hour).UTC()
+	expiresB := fc.Now().Add(time.Hour * 3).UTC()
+	expiresC := fc.Now().Add(time.Hour * 10).UTC()
+	attemptedAt := fc.Now()
+	_ = createFinalizedAuthorization(t, sa, "aaa", expiresA, "invalid", attemptedAt)
+	_ = createFinalizedAuthorization(t, sa, "aaa", expiresB, "invalid", attemptedAt)
+	_ = createFinalizedAuthorization(t, sa, "bbb", expiresA, "invalid", attemptedAt)
+	_ = createFinalizedAuthorization(t, sa, "ccc", expiresA, "valid", attemptedAt)
+	_ = createFinalizedAuthorization(t, sa, "ddd", expiresC, "invalid", attemptedAt)
+	_ = createPendingAuthorization(t, sa, "bbb", expiresB)
+
+	earliest, latest := fc.Now().Add(-time.Hour).UTC().UnixNano(), fc.Now().Add(time.Hour*5).UTC().UnixNano()
+	counts, err := sa.CountInvalidAuthorizationsByName(context.Background(), &sapb.CountInvalidAuthorizationsByNameRequest{
+		RegistrationID: reg.ID,
+		Range: &sapb.Range{
+			Earliest: earliest,
+			Latest:   latest,
+		},
+	})
+	test.AssertNotError(t, err, "sa.CountInvalidAuthorizationsByName failed")
+	got := make(map[string]int64)
+	for _, count := range counts.CountByNames {
+		got[count.Name] = count.Count
+	}
+	test.AssertDeepEquals(t, got, map[string]int64{"aaa": 2, "bbb": 1})
+}
+
 func TestGetValidAuthorizations2(t *testing.T) {
 	sa, fc, cleanUp := initSA(t)
 	defer cleanUp()
@@ -2275,3 +2768,34 @@ func TestBlockedKeyRevokedBy(t *testing.T) {
 	})
 	test.AssertNotError(t, err, "AddBlockedKey failed")
 }
+
+func TestGetCertificateStatusByIssuer(t *testing.T) {
+	sa, _, cleanUp := initSA(t)
+	defer cleanUp()
+
+	reg := satest.CreateWorkingRegistration(t, sa)
+	certDER, err := ioutil.ReadFile("www.eff.org.der")
+	test.AssertNotError(t, err, "Couldn't read example cert DER")
+	cert, err := x509.ParseCertificate(certDER)
+	test.AssertNotError(t, err, "Couldn't parse example cert DER")
+	serial := core.SerialToString(cert.SerialNumber)
+	_, err = sa.AddPrecertificate(ctx, &sapb.AddCertificateRequest{
+		Der:      certDER,
+		RegID:    reg.ID,
+		Issued:   sa.clk.Now().UnixNano(),
+		IssuerID: 1,
+	})
+	test.AssertNotError(t, err, "Couldn't add www.eff.org.der")
+
+	status, err := sa.GetCertificateStatusByIssuer(ctx, &sapb.IssuerSerial{IssuerID: 1, Serial: serial})
+	test.AssertNotError(t, err, "GetCertificateStatusByIssuer failed")
+	test.AssertEquals(t, status.Serial, serial)
+	test.AssertEquals(t, status.Status, string(core.OCSPStatusGood))
+
+	// The same serial from a different issuer should not be found.
+	_, err = sa.GetCertificateStatusByIssuer(ctx, &sapb.IssuerSerial{IssuerID: 2, Serial: serial})
+	test.AssertErrorIs(t, err, berrors.NotFound)
+
+	_, err = sa.GetCertificateStatusByIssuer(ctx, &sapb.IssuerSerial{IssuerID: 1, Serial: "not a serial"})
+	test.AssertError(t, err, "GetCertificateStatusByIssuer should fail for an invalid serial")
+}
@@ -33,11 +33,12 @@ func main() {
 	err := cmd.ReadConfigFile(*configFile, &c)
 	cmd.FailOnError(err, "failed to read json config")
 
-	if c.GRPC.ServerAddress == "" && *serverAddr == "" {
+	configured := c.GRPC.ServerAddress != "" || c.GRPC.SRVLookup != nil
+	if !configured && *serverAddr == "" {
 		cmd.Fail("must specify either -addr flag or client.ServerAddress config")
-	} else if c.GRPC.ServerAddress != "" && *serverAddr != "" {
+	} else if configured && *serverAddr != "" {
 		cmd.Fail("cannot specify both -addr flag and client.ServerAddress config")
-	} else if c.GRPC.ServerAddress == "" {
+	} else if !configured {
 		c.GRPC.ServerAddress = *serverAddr
 	}
 
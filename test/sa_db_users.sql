@@ -32,6 +32,9 @@ GRANT SELECT,INSERT ON precertificates TO 'sa'@'localhost';
 GRANT SELECT,INSERT ON keyHashToSerial TO 'sa'@'localhost';
 GRANT SELECT,INSERT ON blockedKeys TO 'sa'@'localhost';
 GRANT SELECT,INSERT,UPDATE ON newOrdersRL TO 'sa'@'localhost';
+GRANT SELECT,INSERT ON externalAccountKeys TO 'sa'@'localhost';
+GRANT SELECT,INSERT ON externalAccountBindings TO 'sa'@'localhost';
+GRANT SELECT,INSERT,UPDATE ON rateLimitOverrides TO 'sa'@'localhost';
 
 -- OCSP Responder
 GRANT SELECT ON certificateStatus TO 'ocsp_resp'@'localhost';
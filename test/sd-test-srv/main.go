@@ -1,9 +1,11 @@
 // sd-test-srv runs a simple service discovery system; it returns two hardcoded
-// IP addresses for every A query.
+// IP addresses for every A query, SRV records for the DNS servers and gRPC
+// services, and DNS-SD PTR records for the gRPC services.
 package main
 
 import (
 	"flag"
+	"fmt"
 	"log"
 	"net"
 	"strings"
@@ -12,6 +14,75 @@ import (
 	"github.com/miekg/dns"
 )
 
+// grpcPorts holds the port of each gRPC service which has two backends, named
+// <service>1.boulder and <service>2.boulder in docker-compose.yml. The CA
+// serves three services from the same hosts.
+var grpcPorts = map[string]struct {
+	host string
+	port uint16
+}{
+	"sa":        {"sa", 9095},
+	"ra":        {"ra", 9094},
+	"ca":        {"ca", 9093},
+	"ocsp":      {"ca", 9096},
+	"crl":       {"ca", 9106},
+	"va":        {"va", 9092},
+	"publisher": {"publisher", 9091},
+	"nonce":     {"nonce", 9101},
+}
+
+// grpcSRV returns the SRV records for a gRPC service, looked up either as
+// _service._tcp.boulder or, for DNS-SD, as the instance name
+// <host><n>._service._tcp.boulder.
+func grpcSRV(hdr dns.RR_Header) []dns.RR {
+	labels := dns.SplitDomainName(hdr.Name)
+	instance := ""
+	if len(labels) == 4 {
+		instance, labels = labels[0], labels[1:]
+	}
+	if len(labels) != 3 || labels[1] != "_tcp" || !strings.HasPrefix(labels[0], "_") {
+		return nil
+	}
+	svc, ok := grpcPorts[labels[0][1:]]
+	if !ok {
+		return nil
+	}
+	var rrs []dns.RR
+	for _, n := range []int{1, 2} {
+		host := fmt.Sprintf("%s%d", svc.host, n)
+		if instance != "" && instance != host {
+			continue
+		}
+		rrs = append(rrs, &dns.SRV{
+			Target: host + ".boulder.",
+			Port:   svc.port,
+			Hdr:    hdr,
+		})
+	}
+	return rrs
+}
+
+// grpcPTR returns the DNS-SD PTR records for a gRPC service, looked up as
+// _service._tcp.boulder.
+func grpcPTR(hdr dns.RR_Header) []dns.RR {
+	labels := dns.SplitDomainName(hdr.Name)
+	if len(labels) != 3 || labels[1] != "_tcp" || !strings.HasPrefix(labels[0], "_") {
+		return nil
+	}
+	svc, ok := grpcPorts[labels[0][1:]]
+	if !ok {
+		return nil
+	}
+	var rrs []dns.RR
+	for _, n := range []int{1, 2} {
+		rrs = append(rrs, &dns.PTR{
+			Ptr: fmt.Sprintf("%s%d.%s", svc.host, n, hdr.Name),
+			Hdr: hdr,
+		})
+	}
+	return rrs
+}
+
 func dnsHandler(w dns.ResponseWriter, r *dns.Msg) {
 	m := new(dns.Msg)
 	m.SetReply(r)
@@ -68,10 +139,14 @@ func dnsHandler(w dns.ResponseWriter, r *dns.Msg) {
 			Class:  dns.ClassINET,
 			Ttl:    0,
 		}
-		// These two hardcoded IPs correspond to the configured addresses for boulder
-		// in docker-compose.yml. In our Docker setup, boulder is present on two
-		// networks, rednet and bluenet, with a different IP address on each. This
-		// allows us to test load balance across gRPC backends.
+		if rrs := grpcSRV(hdr); rrs != nil {
+			m.Answer = append(m.Answer, rrs...)
+			err := w.WriteMsg(m)
+			if err != nil {
+				log.Printf("ERROR: Failed to write message %q: %v", m, err)
+			}
+			return
+		}
 		// These two hardcoded names:port combos correspond to the configured names
 		// in docker-compose.yml, which in turn point to the local IPs on which our
 		// local resolver runs.
@@ -91,7 +166,21 @@ func dnsHandler(w dns.ResponseWriter, r *dns.Msg) {
 		return
 	}
 
-	// Just return a NOERROR message for non-A, non-SRV questions
+	if r.Question[0].Qtype == dns.TypePTR {
+		m.Answer = append(m.Answer, grpcPTR(dns.RR_Header{
+			Name:   r.Question[0].Name,
+			Rrtype: dns.TypePTR,
+			Class:  dns.ClassINET,
+			Ttl:    0,
+		})...)
+		err := w.WriteMsg(m)
+		if err != nil {
+			log.Printf("ERROR: Failed to write message %q: %v", m, err)
+		}
+		return
+	}
+
+	// Just return a NOERROR message for non-A, non-SRV, non-PTR questions
 	err := w.WriteMsg(m)
 	if err != nil {
 		log.Printf("ERROR: Failed to write message %q: %v", m, err)
@@ -64,11 +64,11 @@ SERVICES = (
         ('sd-test-srv', 'boulder-remoteva-a', 'boulder-remoteva-b')),
     Service('boulder-ca-a',
         8001, 'ca1.boulder:9093',
-        ('./bin/boulder-ca', '--config', os.path.join(config_dir, 'ca-a.json'), '--ca-addr', 'ca1.boulder:9093', '--ocsp-addr', 'ca1.boulder:9096', '--debug-addr', ':8001'),
+        ('./bin/boulder-ca', '--config', os.path.join(config_dir, 'ca-a.json'), '--ca-addr', 'ca1.boulder:9093', '--ocsp-addr', 'ca1.boulder:9096', '--crl-addr', 'ca1.boulder:9106', '--debug-addr', ':8001'),
         ('sd-test-srv', 'boulder-sa-1', 'boulder-sa-2')),
     Service('boulder-ca-b',
         8101, 'ca2.boulder:9093',
-        ('./bin/boulder-ca', '--config', os.path.join(config_dir, 'ca-b.json'), '--ca-addr', 'ca2.boulder:9093', '--ocsp-addr', 'ca2.boulder:9096', '--debug-addr', ':8101'),
+        ('./bin/boulder-ca', '--config', os.path.join(config_dir, 'ca-b.json'), '--ca-addr', 'ca2.boulder:9093', '--ocsp-addr', 'ca2.boulder:9096', '--crl-addr', 'ca2.boulder:9106', '--debug-addr', ':8101'),
         ('sd-test-srv', 'boulder-sa-1', 'boulder-sa-2')),
     Service('akamai-test-srv',
         6789, None,
@@ -82,6 +82,14 @@ SERVICES = (
         8006, None,
         ('./bin/ocsp-updater', '--config', os.path.join(config_dir, 'ocsp-updater.json')),
         ('boulder-ca-a', 'boulder-ca-b')),
+    Service('crl-storer',
+        8022, None,
+        ('./bin/crl-storer', '--config', os.path.join(config_dir, 'crl-storer.json')),
+        None),
+    Service('crl-updater',
+        8021, None,
+        ('./bin/crl-updater', '--config', os.path.join(config_dir, 'crl-updater.json')),
+        ('boulder-ca-a', 'boulder-ca-b', 'boulder-sa-1', 'boulder-sa-2', 'crl-storer')),
     Service('boulder-ra-1',
         8002, 'ra1.boulder:9094',
         ('./bin/boulder-ra', '--config', os.path.join(config_dir, 'ra.json'), '--addr', 'ra1.boulder:9094', '--debug-addr', ':8002'),
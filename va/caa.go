@@ -2,16 +2,23 @@ package va
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
 	"strings"
 	"sync"
 
+	"github.com/letsencrypt/boulder/bdns"
+	"github.com/letsencrypt/boulder/canceled"
 	corepb "github.com/letsencrypt/boulder/core/proto"
 	"github.com/letsencrypt/boulder/features"
+	bgrpc "github.com/letsencrypt/boulder/grpc"
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/probs"
 	vapb "github.com/letsencrypt/boulder/va/proto"
 	"github.com/miekg/dns"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type caaParams struct {
@@ -19,11 +26,21 @@ type caaParams struct {
 	validationMethod string
 }
 
+// IsCAAValid checks CAA for the domain in the request, outside of a
+// validation. If remote VAs are configured and the EnforceMultiCAA or
+// MultiCAAFullResults features are enabled, they check CAA from their own
+// perspectives too, and their results are treated like those of remote
+// validations. Validations don't need this, since remote VAs check CAA as part
+// of PerformValidation.
 func (va *ValidationAuthorityImpl) IsCAAValid(ctx context.Context, req *vapb.IsCAAValidRequest) (*vapb.IsCAAValidResponse, error) {
-	acmeID := identifier.ACMEIdentifier{
-		Type:  identifier.DNS,
-		Value: req.Domain,
+	var remoteResults chan *remoteValidationResult
+	if remoteVACount := len(va.remoteVAs); remoteVACount > 0 &&
+		(features.Enabled(features.EnforceMultiCAA) || features.Enabled(features.MultiCAAFullResults)) {
+		remoteResults = make(chan *remoteValidationResult, remoteVACount)
+		go va.performRemoteCAACheck(ctx, req, remoteResults)
 	}
+
+	acmeID := identifier.FromName(req.Domain)
 	params := &caaParams{
 		accountURIID:     req.AccountURIID,
 		validationMethod: req.ValidationMethod,
@@ -36,17 +53,195 @@ func (va *ValidationAuthorityImpl) IsCAAValid(ctx context.Context, req *vapb.IsC
 			},
 		}, nil
 	}
+
+	if remoteResults != nil {
+		if !features.Enabled(features.EnforceMultiCAA) {
+			// Log the differentials without blocking the primary VA's result.
+			go func() {
+				_ = va.processRemoteCAAResults(req.Domain, req.AccountURIID, req.ValidationMethod, remoteResults)
+			}()
+		} else if remoteProb := va.processRemoteCAAResults(
+			req.Domain, req.AccountURIID, req.ValidationMethod, remoteResults); remoteProb != nil {
+			va.log.Infof("CAA check failed due to remote failures: identifier=%v err=%s", req.Domain, remoteProb)
+			va.metrics.remoteCAACheckFailures.Inc()
+			return &vapb.IsCAAValidResponse{
+				Problem: &corepb.ProblemDetails{
+					ProblemType: string(remoteProb.Type),
+					Detail:      remoteProb.Detail,
+				},
+			}, nil
+		}
+	}
 	return &vapb.IsCAAValidResponse{}, nil
 }
 
+// performRemoteCAACheck calls `IsCAAValid` for each of the configured remote
+// VAs in a random order, and writes each result to the `results` chan, which
+// should have an equal size to the number of remote VAs. As in
+// `performRemoteValidation`, a problem is written for a failed RPC.
+func (va *ValidationAuthorityImpl) performRemoteCAACheck(
+	ctx context.Context,
+	req *vapb.IsCAAValidRequest,
+	results chan *remoteValidationResult) {
+	for _, i := range rand.Perm(len(va.remoteVAs)) {
+		go func(rva RemoteVA) {
+			result := &remoteValidationResult{
+				VAHostname:  rva.Address,
+				Perspective: rva.Perspective,
+			}
+			res, err := rva.IsCAAValid(ctx, req)
+			if err != nil && canceled.Is(err) {
+				// As in performRemoteValidation, the result is no longer
+				// needed, so don't log.
+				result.Problem = probs.ServerInternal("Remote IsCAAValid RPC canceled")
+			} else if err != nil {
+				va.log.Errf("Remote VA %q.IsCAAValid failed: %s", rva.Address, err)
+				result.Problem = probs.ServerInternal("Remote IsCAAValid RPC failed")
+			} else if res.Problem != nil {
+				prob, err := bgrpc.PBToProblemDetails(res.Problem)
+				if err != nil {
+					va.log.Infof("Remote VA %q.IsCAAValid returned malformed problem: %s", rva.Address, err)
+					result.Problem = probs.ServerInternal(
+						fmt.Sprintf("Remote IsCAAValid RPC returned malformed result: %s", err))
+				} else {
+					va.log.Infof("Remote VA %q.IsCAAValid returned problem: %s", rva.Address, prob)
+					result.Problem = prob
+				}
+			}
+			results <- result
+		}(va.remoteVAs[i])
+	}
+}
+
+// processRemoteCAAResults reads the remote VAs' CAA results, once the primary
+// VA's CAA check has passed, and returns a problem if they fail to meet the
+// same threshold as remote validations, see `remoteQuorum`. Like
+// `processRemoteResults`, it returns as soon as the threshold is met or can no
+// longer be met, unless the `MultiCAAFullResults` feature is enabled, in which
+// case it waits for every remote VA and logs the differential between their
+// results and the primary VA's.
+func (va *ValidationAuthorityImpl) processRemoteCAAResults(
+	domain string,
+	acctID int64,
+	validationMethod string,
+	remoteResultsChan chan *remoteValidationResult) *probs.ProblemDetails {
+
+	state := "failure"
+	start := va.clk.Now()
+
+	defer func() {
+		va.metrics.remoteCAACheckTime.With(prometheus.Labels{
+			"result": state,
+		}).Observe(va.clk.Since(start).Seconds())
+	}()
+
+	numRemoteVAs := len(va.remoteVAs)
+	var remoteResults []*remoteValidationResult
+	var firstProb *probs.ProblemDetails
+	for result := range remoteResultsChan {
+		remoteResults = append(remoteResults, result)
+		if firstProb == nil && result.Problem != nil {
+			firstProb = result.Problem
+		}
+		if !features.Enabled(features.MultiCAAFullResults) {
+			met, failed := va.remoteQuorum(remoteResults, numRemoteVAs)
+			if met {
+				state = "success"
+				return nil
+			} else if failed {
+				break
+			}
+		}
+		if len(remoteResults) == numRemoteVAs {
+			break
+		}
+	}
+
+	if features.Enabled(features.MultiCAAFullResults) {
+		va.logRemoteCAADifferentials(domain, acctID, validationMethod, remoteResults)
+	}
+
+	met, failed := va.remoteQuorum(remoteResults, numRemoteVAs)
+	if met {
+		state = "success"
+		return nil
+	} else if failed {
+		modifiedProblem := *firstProb
+		modifiedProblem.Detail = "During secondary CAA checking: " + firstProb.Detail
+		return &modifiedProblem
+	}
+	return probs.ServerInternal("Too few remote IsCAAValid RPC results")
+}
+
+// logRemoteCAADifferentials is called by `processRemoteCAAResults` when the
+// `MultiCAAFullResults` feature flag is enabled. Like
+// `logRemoteValidationDifferentials`, it produces a JSON log line containing
+// the result each remote VA returned if any of them disagree with the primary
+// VA, whose CAA check passed.
+func (va *ValidationAuthorityImpl) logRemoteCAADifferentials(
+	domain string,
+	acctID int64,
+	validationMethod string,
+	remoteResults []*remoteValidationResult) {
+
+	var successes int
+	var failures []*remoteValidationResult
+	for _, result := range remoteResults {
+		if result.Problem == nil {
+			successes++
+		} else {
+			failures = append(failures, result)
+		}
+	}
+	if len(failures) == 0 {
+		return
+	}
+
+	// Count the CAA checks which would have failed if
+	// features.EnforceMultiCAA were enabled.
+	if _, failed := va.remoteQuorum(remoteResults, len(remoteResults)); failed {
+		va.metrics.prospectiveRemoteCAACheckFailures.Inc()
+	}
+
+	logOb := struct {
+		Domain           string
+		AccountID        int64
+		ValidationMethod string
+		RemoteSuccesses  int
+		RemoteFailures   []*remoteValidationResult
+	}{
+		Domain:           domain,
+		AccountID:        acctID,
+		ValidationMethod: validationMethod,
+		RemoteSuccesses:  successes,
+		RemoteFailures:   failures,
+	}
+
+	logJSON, err := json.Marshal(logOb)
+	if err != nil {
+		va.log.Warningf("Could not marshal log object in "+
+			"logRemoteCAADifferentials: %s", err)
+		return
+	}
+
+	va.log.Infof("remoteCAADifferentials JSON=%s", string(logJSON))
+}
+
 // checkCAA performs a CAA lookup & validation for the provided identifier. If
-// the CAA lookup & validation fail a problem is returned.
+// the CAA lookup & validation fail a problem is returned. CAA is only defined
+// for domain names (RFC 8738, Section 7), so IP identifiers always pass.
 func (va *ValidationAuthorityImpl) checkCAA(
 	ctx context.Context,
-	identifier identifier.ACMEIdentifier,
+	ident identifier.ACMEIdentifier,
 	params *caaParams) *probs.ProblemDetails {
-	present, valid, response, err := va.checkCAARecords(ctx, identifier, params)
+	if ident.Type == identifier.IP {
+		return nil
+	}
+	present, valid, response, err := va.checkCAARecords(ctx, ident, params)
 	if err != nil {
+		if errors.Is(err, bdns.ErrDNSSECBogus) {
+			return probs.DNSSECBogus(err.Error())
+		}
 		return probs.DNS(err.Error())
 	}
 
@@ -59,9 +254,9 @@ func (va *ValidationAuthorityImpl) checkCAA(
 	}
 
 	va.log.AuditInfof("Checked CAA records for %s, [Present: %t, Account ID: %s, Challenge: %s, Valid for issuance: %t] Response=%q",
-		identifier.Value, present, accountID, validationMethod, valid, response)
+		ident.Value, present, accountID, validationMethod, valid, response)
 	if !valid {
-		return probs.CAA(fmt.Sprintf("CAA record for %s prevents issuance", identifier.Value))
+		return probs.CAA(fmt.Sprintf("CAA record for %s prevents issuance", ident.Value))
 	}
 	return nil
 }
@@ -141,7 +336,7 @@ func (va *ValidationAuthorityImpl) parallelCAALookup(ctx context.Context, name s
 		// Start the concurrent DNS lookup.
 		wg.Add(1)
 		go func(name string, r *caaResult) {
-			r.records, r.response, r.err = va.dnsClient.LookupCAA(ctx, name)
+			r.records, r.response, _, r.err = va.dnsClient.LookupCAA(ctx, name)
 			wg.Done()
 		}(strings.Join(labels[i:], "."), &results[i])
 	}
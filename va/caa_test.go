@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/miekg/dns"
+	"google.golang.org/grpc"
 
+	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
+	corepb "github.com/letsencrypt/boulder/core/proto"
 	"github.com/letsencrypt/boulder/features"
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/probs"
@@ -25,21 +29,23 @@ import (
 // answers for CAA queries.
 type caaMockDNS struct{}
 
-func (mock caaMockDNS) LookupTXT(_ context.Context, hostname string) ([]string, error) {
-	return nil, nil
+func (mock caaMockDNS) LookupTXT(_ context.Context, hostname string) ([]string, bdns.DNSSECStatus, error) {
+	return nil, bdns.DNSSECInsecure, nil
 }
 
-func (mock caaMockDNS) LookupHost(_ context.Context, hostname string) ([]net.IP, error) {
+func (mock caaMockDNS) LookupHost(_ context.Context, hostname string) ([]net.IP, bdns.DNSSECStatus, error) {
 	ip := net.ParseIP("127.0.0.1")
-	return []net.IP{ip}, nil
+	return []net.IP{ip}, bdns.DNSSECInsecure, nil
 }
 
-func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, string, error) {
+func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA, string, bdns.DNSSECStatus, error) {
 	var results []*dns.CAA
 	var record dns.CAA
 	switch strings.TrimRight(domain, ".") {
 	case "caa-timeout.com":
-		return nil, "", fmt.Errorf("error")
+		return nil, "", "", fmt.Errorf("error")
+	case "dnssec-bogus.com":
+		return nil, "", bdns.DNSSECBogus, fmt.Errorf("DNS problem: %w: no signature for CAA dnssec-bogus.com. looking up CAA for dnssec-bogus.com", bdns.ErrDNSSECBogus)
 	case "reserved.com":
 		record.Tag = "issue"
 		record.Value = "ca.com"
@@ -59,9 +65,9 @@ func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA,
 		results = append(results, &record)
 	case "com":
 		// com has no CAA records.
-		return nil, "", nil
+		return nil, "", bdns.DNSSECInsecure, nil
 	case "servfail.com", "servfail.present.com":
-		return results, "", fmt.Errorf("SERVFAIL")
+		return results, "", "", fmt.Errorf("SERVFAIL")
 	case "multi-crit-present.com":
 		record.Flag = 1
 		record.Tag = "issue"
@@ -183,7 +189,7 @@ func (mock caaMockDNS) LookupCAA(_ context.Context, domain string) ([]*dns.CAA,
 	if len(results) > 0 {
 		response = "foo"
 	}
-	return results, response, nil
+	return results, response, bdns.DNSSECInsecure, nil
 }
 
 func TestCAATimeout(t *testing.T) {
@@ -199,6 +205,15 @@ func TestCAATimeout(t *testing.T) {
 	}
 }
 
+func TestCAADNSSECBogus(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+	va.dnsClient = caaMockDNS{}
+	prob := va.checkCAA(ctx, identifier.DNSIdentifier("dnssec-bogus.com"), nil)
+	test.AssertNotNil(t, prob, "checkCAA succeeded despite a bogus answer")
+	test.AssertEquals(t, prob.Type, probs.DNSSECBogusProblem)
+	test.AssertContains(t, prob.Detail, "DNSSEC validation failed")
+}
+
 func TestCAAChecking(t *testing.T) {
 	testCases := []struct {
 		Name    string
@@ -853,3 +868,141 @@ func TestExtractIssuerDomainAndParameters(t *testing.T) {
 		})
 	}
 }
+
+// caaRemoteVA is a mock for the vapb.CAAClient interface which returns the
+// given problem, or error, for every IsCAAValid call.
+type caaRemoteVA struct {
+	prob *corepb.ProblemDetails
+	err  error
+}
+
+func (v caaRemoteVA) IsCAAValid(_ context.Context, _ *vapb.IsCAAValidRequest, _ ...grpc.CallOption) (*vapb.IsCAAValidResponse, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	return &vapb.IsCAAValidResponse{Problem: v.prob}, nil
+}
+
+func TestMultiCAA(t *testing.T) {
+	pass := caaRemoteVA{}
+	fail := caaRemoteVA{prob: &corepb.ProblemDetails{
+		ProblemType: string(probs.CAAProblem),
+		Detail:      "While processing CAA for present.com: CAA record for present.com prevents issuance",
+	}}
+	broken := caaRemoteVA{err: errBrokenRemoteVA}
+
+	enforce := map[string]bool{"EnforceMultiCAA": true}
+	enforceFull := map[string]bool{"EnforceMultiCAA": true, "MultiCAAFullResults": true}
+	fullOnly := map[string]bool{"MultiCAAFullResults": true}
+
+	testCases := []struct {
+		name              string
+		domain            string
+		remoteVAs         []RemoteVA
+		maxRemoteFailures int
+		perspectiveQuorum int
+		features          map[string]bool
+		expectedProbType  probs.ProblemType
+		expectedDetail    string
+		expectedLog       string
+	}{
+		{
+			name:      "all remote VAs pass",
+			domain:    "present.com",
+			remoteVAs: []RemoteVA{{CAAClient: pass, Address: "a"}, {CAAClient: pass, Address: "b"}},
+			features:  enforce,
+		},
+		{
+			name:             "primary VA fails",
+			domain:           "reserved.com",
+			remoteVAs:        []RemoteVA{{CAAClient: pass, Address: "a"}},
+			features:         enforce,
+			expectedProbType: probs.CAAProblem,
+			expectedDetail:   "While processing CAA for reserved.com: ",
+		},
+		{
+			name:             "remote VA fails",
+			domain:           "present.com",
+			remoteVAs:        []RemoteVA{{CAAClient: pass, Address: "a"}, {CAAClient: fail, Address: "b"}},
+			features:         enforce,
+			expectedProbType: probs.CAAProblem,
+			expectedDetail:   "During secondary CAA checking: While processing CAA for present.com: ",
+		},
+		{
+			name:              "remote VA fails within maxRemoteFailures",
+			domain:            "present.com",
+			remoteVAs:         []RemoteVA{{CAAClient: pass, Address: "a"}, {CAAClient: fail, Address: "b"}},
+			maxRemoteFailures: 1,
+			features:          enforceFull,
+			expectedLog:       `remoteCAADifferentials JSON={"Domain":"present.com","AccountID":1,"ValidationMethod":"http-01","RemoteSuccesses":1,"RemoteFailures":[{"VAHostname":"b","Problem":{"type":"caa","detail":"While processing CAA for present.com: CAA record for present.com prevents issuance"}}]}`,
+		},
+		{
+			name:              "remote VA RPCs fail",
+			domain:            "present.com",
+			remoteVAs:         []RemoteVA{{CAAClient: broken, Address: "a"}, {CAAClient: broken, Address: "b"}},
+			maxRemoteFailures: 1,
+			features:          enforce,
+			expectedProbType:  probs.ServerInternalProblem,
+			expectedDetail:    "During secondary CAA checking: Remote IsCAAValid RPC failed",
+		},
+		{
+			name:      "remote VA fails without EnforceMultiCAA",
+			domain:    "present.com",
+			remoteVAs: []RemoteVA{{CAAClient: fail, Address: "a"}},
+			features:  fullOnly,
+		},
+		{
+			name:   "one remote VA in each perspective passes",
+			domain: "present.com",
+			remoteVAs: []RemoteVA{
+				{CAAClient: fail, Address: "a", Perspective: "ARIN"},
+				{CAAClient: pass, Address: "b", Perspective: "ARIN"},
+				{CAAClient: pass, Address: "c", Perspective: "RIPE"},
+			},
+			perspectiveQuorum: 2,
+			features:          enforce,
+		},
+		{
+			name:   "only one perspective passes",
+			domain: "present.com",
+			remoteVAs: []RemoteVA{
+				{CAAClient: pass, Address: "a", Perspective: "ARIN"},
+				{CAAClient: pass, Address: "b", Perspective: "ARIN"},
+				{CAAClient: fail, Address: "c", Perspective: "RIPE"},
+			},
+			maxRemoteFailures: 1,
+			perspectiveQuorum: 2,
+			features:          enforce,
+			expectedProbType:  probs.CAAProblem,
+			expectedDetail:    "During secondary CAA checking: ",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			va, mockLog := setup(nil, tc.maxRemoteFailures, "", tc.remoteVAs)
+			va.dnsClient = caaMockDNS{}
+			va.perspectiveQuorum = tc.perspectiveQuorum
+			err := features.Set(tc.features)
+			test.AssertNotError(t, err, "setting feature flags")
+			defer features.Reset()
+
+			resp, err := va.IsCAAValid(ctx, &vapb.IsCAAValidRequest{
+				Domain:           tc.domain,
+				ValidationMethod: string(core.ChallengeTypeHTTP01),
+				AccountURIID:     1,
+			})
+			test.AssertNotError(t, err, "IsCAAValid failed")
+			if tc.expectedProbType == "" {
+				test.Assert(t, resp.Problem == nil, fmt.Sprintf("unexpected problem: %v", resp.Problem))
+			} else {
+				test.AssertNotNil(t, resp.Problem, "expected a problem from IsCAAValid")
+				test.AssertEquals(t, resp.Problem.ProblemType, string(tc.expectedProbType))
+				test.Assert(t, strings.HasPrefix(resp.Problem.Detail, tc.expectedDetail),
+					fmt.Sprintf("expected detail starting %q, got %q", tc.expectedDetail, resp.Problem.Detail))
+			}
+			if tc.expectedLog != "" {
+				test.AssertEquals(t, len(mockLog.GetAllMatching(regexp.QuoteMeta(tc.expectedLog))), 1)
+			}
+		})
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"crypto/sha256"
 	"crypto/subtle"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net"
 
+	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/identifier"
@@ -19,18 +21,28 @@ import (
 // resolved. This is the same choice made by the Go internal resolution library
 // used by net/http. If there is an error resolving the hostname, or if no
 // usable IP addresses are available then a berrors.DNSError instance is
-// returned with a nil net.IP slice.
-func (va ValidationAuthorityImpl) getAddrs(ctx context.Context, hostname string) ([]net.IP, error) {
-	addrs, err := va.dnsClient.LookupHost(ctx, hostname)
+// returned with a nil net.IP slice, unless the answer failed DNSSEC validation,
+// in which case the error wraps bdns.ErrDNSSECBogus. The DNSSEC status of the
+// answer is returned too. If hostname is an IP address, as it is for IP
+// identifiers, that address is returned without querying DNS, and with no
+// DNSSEC status.
+func (va ValidationAuthorityImpl) getAddrs(ctx context.Context, hostname string) ([]net.IP, bdns.DNSSECStatus, error) {
+	if ip := net.ParseIP(hostname); ip != nil {
+		return []net.IP{ip}, "", nil
+	}
+	addrs, status, err := va.dnsClient.LookupHost(ctx, hostname)
 	if err != nil {
-		return nil, berrors.DNSError("%v", err)
+		if errors.Is(err, bdns.ErrDNSSECBogus) {
+			return nil, status, err
+		}
+		return nil, status, berrors.DNSError("%v", err)
 	}
 
 	if len(addrs) == 0 {
-		return nil, berrors.DNSError("No valid IP addresses found for %s", hostname)
+		return nil, status, berrors.DNSError("No valid IP addresses found for %s", hostname)
 	}
 	va.log.Debugf("Resolved addresses for %s: %s", hostname, addrs)
-	return addrs, nil
+	return addrs, status, nil
 }
 
 // availableAddresses takes a ValidationRecord and splits the AddressesResolved
@@ -52,15 +64,51 @@ func (va *ValidationAuthorityImpl) validateDNS01(ctx context.Context, ident iden
 		return nil, probs.Malformed("Identifier type for DNS was not itself DNS")
 	}
 
+	// Look for the required record in the DNS
+	challengeSubdomain := fmt.Sprintf("%s.%s", core.DNSPrefix, ident.Value)
+	return va.validateTXT(ctx, ident, challengeSubdomain, challenge.ProvidedKeyAuthorization)
+}
+
+// validateDNSAccount01 is like validateDNS01, except that the TXT record is
+// looked for beneath an additional label derived from the URL of the account
+// which owns the authorization. The VA doesn't know which of the configured
+// account URI prefixes the client's account URL uses, so, just as for the CAA
+// accounturi parameter, a record at the label for any of them is accepted.
+func (va *ValidationAuthorityImpl) validateDNSAccount01(ctx context.Context, ident identifier.ACMEIdentifier, regid int64, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
+	if ident.Type != identifier.DNS {
+		va.log.Infof("Identifier type for DNS-ACCOUNT-01 challenge was not DNS: %s", ident)
+		return nil, probs.Malformed("Identifier type for DNS-ACCOUNT-01 was not itself DNS")
+	}
+	if len(va.accountURIPrefixes) == 0 {
+		return nil, probs.ServerInternal("No account URI prefixes configured for DNS-ACCOUNT-01")
+	}
+
+	var prob *probs.ProblemDetails
+	for _, prefix := range va.accountURIPrefixes {
+		accountURL := fmt.Sprintf("%s%d", prefix, regid)
+		challengeSubdomain := fmt.Sprintf("%s.%s.%s", core.DNSAccountLabel(accountURL), core.DNSPrefix, ident.Value)
+		var records []core.ValidationRecord
+		records, prob = va.validateTXT(ctx, ident, challengeSubdomain, challenge.ProvidedKeyAuthorization)
+		if prob == nil {
+			return records, nil
+		}
+	}
+	return nil, prob
+}
+
+// validateTXT looks up the TXT records at challengeSubdomain and succeeds if
+// any of them is the digest of the given key authorization.
+func (va *ValidationAuthorityImpl) validateTXT(ctx context.Context, ident identifier.ACMEIdentifier, challengeSubdomain string, keyAuthorization string) ([]core.ValidationRecord, *probs.ProblemDetails) {
 	// Compute the digest of the key authorization file
 	h := sha256.New()
-	h.Write([]byte(challenge.ProvidedKeyAuthorization))
+	h.Write([]byte(keyAuthorization))
 	authorizedKeysDigest := base64.RawURLEncoding.EncodeToString(h.Sum(nil))
 
-	// Look for the required record in the DNS
-	challengeSubdomain := fmt.Sprintf("%s.%s", core.DNSPrefix, ident.Value)
-	txts, err := va.dnsClient.LookupTXT(ctx, challengeSubdomain)
+	txts, status, err := va.dnsClient.LookupTXT(ctx, challengeSubdomain)
 	if err != nil {
+		if errors.Is(err, bdns.ErrDNSSECBogus) {
+			return nil, probs.DNSSECBogus(err.Error())
+		}
 		return nil, probs.DNS(err.Error())
 	}
 
@@ -74,7 +122,7 @@ func (va *ValidationAuthorityImpl) validateDNS01(ctx context.Context, ident iden
 	for _, element := range txts {
 		if subtle.ConstantTimeCompare([]byte(element), []byte(authorizedKeysDigest)) == 1 {
 			// Successful challenge validation
-			return []core.ValidationRecord{{Hostname: ident.Value}}, nil
+			return []core.ValidationRecord{{Hostname: ident.Value, DNSSEC: string(status)}}, nil
 		}
 	}
 
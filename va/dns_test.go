@@ -2,6 +2,7 @@ package va
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
@@ -95,7 +96,7 @@ func TestDNSValidationNotSane(t *testing.T) {
 
 	chall := dnsChallenge()
 	chall.Token = ""
-	_, prob := va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("localhost"), 1, chall)
 	if prob.Type != probs.MalformedProblem {
 		t.Errorf("Got wrong error type: expected %s, got %s",
 			prob.Type, probs.MalformedProblem)
@@ -105,7 +106,7 @@ func TestDNSValidationNotSane(t *testing.T) {
 	}
 
 	chall.Token = "yfCBb-bRTLz8Wd1C0lTUQK3qlKj3-t2tYGwx5Hj7r_"
-	_, prob = va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob = va.validateChallenge(ctx, dnsi("localhost"), 1, chall)
 	if prob.Type != probs.MalformedProblem {
 		t.Errorf("Got wrong error type: expected %s, got %s",
 			prob.Type, probs.MalformedProblem)
@@ -115,7 +116,7 @@ func TestDNSValidationNotSane(t *testing.T) {
 	}
 
 	chall.ProvidedKeyAuthorization = "a"
-	_, prob = va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob = va.validateChallenge(ctx, dnsi("localhost"), 1, chall)
 	if prob.Type != probs.MalformedProblem {
 		t.Errorf("Got wrong error type: expected %s, got %s",
 			prob.Type, probs.MalformedProblem)
@@ -129,7 +130,7 @@ func TestDNSValidationNotSane(t *testing.T) {
 func TestDNSValidationServFail(t *testing.T) {
 	va, _ := setup(nil, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("servfail.com"), dnsChallenge())
+	_, prob := va.validateChallenge(ctx, dnsi("servfail.com"), 1, dnsChallenge())
 
 	test.AssertEquals(t, prob.Type, probs.DNSProblem)
 }
@@ -144,7 +145,7 @@ func TestDNSValidationNoServer(t *testing.T) {
 		1,
 		log)
 
-	_, prob := va.validateChallenge(ctx, dnsi("localhost"), dnsChallenge())
+	_, prob := va.validateChallenge(ctx, dnsi("localhost"), 1, dnsChallenge())
 
 	test.AssertEquals(t, prob.Type, probs.DNSProblem)
 }
@@ -152,19 +153,89 @@ func TestDNSValidationNoServer(t *testing.T) {
 func TestDNSValidationOK(t *testing.T) {
 	va, _ := setup(nil, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("good-dns01.com"), dnsChallenge())
+	records, prob := va.validateChallenge(ctx, dnsi("good-dns01.com"), 1, dnsChallenge())
 
 	test.Assert(t, prob == nil, "Should be valid.")
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].DNSSEC, "secure")
+}
+
+func TestDNSValidationDNSSECBogus(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+
+	_, prob := va.validateChallenge(ctx, dnsi("dnssec-bogus.com"), 1, dnsChallenge())
+
+	test.AssertEquals(t, prob.Type, probs.DNSSECBogusProblem)
+	test.AssertContains(t, prob.Detail, "DNSSEC validation failed")
+}
+
+func TestGetAddrsDNSSECBogus(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+
+	_, status, err := va.getAddrs(ctx, "dnssec-bogus.com")
+	test.AssertEquals(t, status, bdns.DNSSECBogus)
+	test.Assert(t, errors.Is(err, bdns.ErrDNSSECBogus), "bogus answer wasn't reported as such")
+	prob := detailedError(err)
+	test.AssertEquals(t, prob.Type, probs.DNSSECBogusProblem)
+	test.AssertContains(t, prob.Detail, "DNSSEC validation failed")
+
+	_, status, err = va.getAddrs(ctx, "always.timeout")
+	test.AssertError(t, err, "getAddrs didn't fail")
+	test.Assert(t, !errors.Is(err, bdns.ErrDNSSECBogus), "timeout was reported as a bogus answer")
+	test.AssertEquals(t, detailedError(err).Detail, "DNS problem: query timed out looking up A for always.timeout")
+	test.AssertEquals(t, status, bdns.DNSSECStatus(""))
 }
 
 func TestDNSValidationNoAuthorityOK(t *testing.T) {
 	va, _ := setup(nil, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("no-authority-dns01.com"), dnsChallenge())
+	_, prob := va.validateChallenge(ctx, dnsi("no-authority-dns01.com"), 1, dnsChallenge())
 
 	test.Assert(t, prob == nil, "Should be valid.")
 }
 
+func dnsAccountChallenge() core.Challenge {
+	return createChallenge(core.ChallengeTypeDNSAccount01)
+}
+
+func TestDNSAccountValidationOK(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+
+	records, prob := va.validateChallenge(ctx, dnsi("good-dns-account01.com"), 1, dnsAccountChallenge())
+
+	test.Assert(t, prob == nil, "Should be valid.")
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].Hostname, "good-dns-account01.com")
+}
+
+func TestDNSAccountValidationWrongAccount(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+
+	// The record is provisioned beneath the label for account 1, so account 2
+	// must not be able to use it.
+	_, prob := va.validateChallenge(ctx, dnsi("good-dns-account01.com"), 2, dnsAccountChallenge())
+
+	test.AssertEquals(t, prob.Type, probs.UnauthorizedProblem)
+	test.AssertContains(t, prob.Detail, "_lqyglbefwm3rlvzy._acme-challenge.good-dns-account01.com")
+}
+
+func TestDNSAccountValidationIgnoresDNS01Record(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+
+	// good-dns01.com only has a record at the shared _acme-challenge label.
+	_, prob := va.validateChallenge(ctx, dnsi("good-dns01.com"), 1, dnsAccountChallenge())
+
+	test.AssertEquals(t, prob.Type, probs.UnauthorizedProblem)
+}
+
+func TestDNSAccountValidationNotDNS(t *testing.T) {
+	va, _ := setup(nil, 0, "", nil)
+
+	_, prob := va.validateDNSAccount01(ctx, identifier.IPIdentifier(net.ParseIP("127.0.0.1")), 1, dnsAccountChallenge())
+
+	test.AssertEquals(t, prob.Type, probs.MalformedProblem)
+}
+
 func TestAvailableAddresses(t *testing.T) {
 	v6a := net.ParseIP("::1")
 	v6b := net.ParseIP("2001:db8::2:1") // 2001:DB8 is reserved for docs (RFC 3849)
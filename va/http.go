@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/letsencrypt/boulder/bdns"
 	"github.com/letsencrypt/boulder/core"
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/iana"
@@ -180,6 +181,8 @@ type httpValidationTarget struct {
 	next []net.IP
 	// the current IP address being used for validation (if any)
 	cur net.IP
+	// the DNSSEC status of the answer the available addresses came from
+	dnssec bdns.DNSSECStatus
 }
 
 // nextIP changes the cur IP by removing the first entry from the next slice and
@@ -215,7 +218,7 @@ func (va *ValidationAuthorityImpl) newHTTPValidationTarget(
 	path string,
 	query string) (*httpValidationTarget, error) {
 	// Resolve IP addresses for the hostname
-	addrs, err := va.getAddrs(ctx, host)
+	addrs, status, err := va.getAddrs(ctx, host)
 	if err != nil {
 		return nil, err
 	}
@@ -226,6 +229,7 @@ func (va *ValidationAuthorityImpl) newHTTPValidationTarget(
 		path:      path,
 		query:     query,
 		available: addrs,
+		dnssec:    status,
 	}
 
 	// Separate the addresses into the available v4 and v6 addresses
@@ -369,6 +373,7 @@ func (va *ValidationAuthorityImpl) setupHTTPValidation(
 		Port:              strconv.Itoa(target.port),
 		AddressesResolved: target.available,
 		URL:               reqURL,
+		DNSSEC:            string(target.dnssec),
 	}
 
 	// Get the target IP to build a preresolved dialer with
@@ -436,10 +441,15 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 		return nil, nil, err
 	}
 
-	// Create an initial GET Request
+	// Create an initial GET Request. An IPv6 address used as the host of an IP
+	// identifier must be enclosed in brackets in the URL.
+	urlHost := host
+	if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+		urlHost = "[" + host + "]"
+	}
 	initialURL := url.URL{
 		Scheme: "http",
-		Host:   host,
+		Host:   urlHost,
 		Path:   path,
 	}
 	initialReq, err := http.NewRequest("GET", initialURL.String(), nil)
@@ -631,9 +641,9 @@ func (va *ValidationAuthorityImpl) processHTTPValidation(
 }
 
 func (va *ValidationAuthorityImpl) validateHTTP01(ctx context.Context, ident identifier.ACMEIdentifier, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
-	if ident.Type != identifier.DNS {
-		va.log.Infof("Got non-DNS identifier for HTTP validation: %s", ident)
-		return nil, probs.Malformed("Identifier type for HTTP validation was not DNS")
+	if ident.Type != identifier.DNS && ident.Type != identifier.IP {
+		va.log.Infof("Got non-DNS, non-IP identifier for HTTP validation: %s", ident)
+		return nil, probs.Malformed("Identifier type for HTTP validation was not DNS or IP")
 	}
 
 	// Perform the fetch
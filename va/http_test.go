@@ -434,6 +434,7 @@ func TestSetupHTTPValidation(t *testing.T) {
 				URL:               "http://ipv4.and.ipv6.localhost/yellow/brick/road",
 				AddressesResolved: []net.IP{net.ParseIP("::1"), net.ParseIP("127.0.0.1")},
 				AddressUsed:       net.ParseIP("::1"),
+				DNSSEC:            "insecure",
 			},
 			ExpectedDialer: &preresolvedDialer{
 				ip:      net.ParseIP("::1"),
@@ -451,6 +452,7 @@ func TestSetupHTTPValidation(t *testing.T) {
 				URL:               "https://ipv4.and.ipv6.localhost/yellow/brick/road",
 				AddressesResolved: []net.IP{net.ParseIP("::1"), net.ParseIP("127.0.0.1")},
 				AddressUsed:       net.ParseIP("::1"),
+				DNSSEC:            "insecure",
 			},
 			ExpectedDialer: &preresolvedDialer{
 				ip:      net.ParseIP("::1"),
@@ -771,6 +773,7 @@ func TestFetchHTTP(t *testing.T) {
 				URL:               url,
 				AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 				AddressUsed:       net.ParseIP("127.0.0.1"),
+				DNSSEC:            "insecure",
 			})
 	}
 
@@ -791,6 +794,7 @@ func TestFetchHTTP(t *testing.T) {
 				URL:               url,
 				AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 				AddressUsed:       net.ParseIP("127.0.0.1"),
+				DNSSEC:            "insecure",
 			})
 	}
 
@@ -831,6 +835,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/timeout",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -865,6 +870,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/redir-bad-proto",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -882,6 +888,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/redir-bad-port",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -899,6 +906,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/redir-bad-host",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -915,6 +923,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/redir-path-too-long",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -932,6 +941,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/bad-status-code",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -948,6 +958,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/other",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -966,6 +977,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/resp-too-big",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -982,6 +994,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://ipv6.localhost/ok",
 					AddressesResolved: []net.IP{net.ParseIP("::1")},
 					AddressUsed:       net.ParseIP("::1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -998,6 +1011,7 @@ func TestFetchHTTP(t *testing.T) {
 					AddressesResolved: []net.IP{net.ParseIP("::1"), net.ParseIP("127.0.0.1")},
 					// The first validation record should have used the IPv6 addr
 					AddressUsed: net.ParseIP("::1"),
+					DNSSEC:      "insecure",
 				},
 				{
 					Hostname:          "ipv4.and.ipv6.localhost",
@@ -1006,6 +1020,7 @@ func TestFetchHTTP(t *testing.T) {
 					AddressesResolved: []net.IP{net.ParseIP("::1"), net.ParseIP("127.0.0.1")},
 					// The second validation record should have used the IPv4 addr as a fallback
 					AddressUsed: net.ParseIP("127.0.0.1"),
+					DNSSEC:      "insecure",
 				},
 			},
 		},
@@ -1021,6 +1036,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/ok",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -1036,6 +1052,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/redir-uppercase-publicsuffix",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 				{
 					Hostname:          "example.com",
@@ -1043,6 +1060,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/ok",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -1064,6 +1082,7 @@ func TestFetchHTTP(t *testing.T) {
 					URL:               "http://example.com/printf-verbs",
 					AddressesResolved: []net.IP{net.ParseIP("127.0.0.1")},
 					AddressUsed:       net.ParseIP("127.0.0.1"),
+					DNSSEC:            "insecure",
 				},
 			},
 		},
@@ -1300,10 +1319,10 @@ func TestHTTP(t *testing.T) {
 	test.AssertEquals(t, len(matchedValidRedirect), 1)
 	test.AssertEquals(t, len(matchedMovedRedirect), 1)
 
-	ipIdentifier := identifier.ACMEIdentifier{Type: identifier.IdentifierType("ip"), Value: "127.0.0.1"}
-	_, prob = va.validateHTTP01(ctx, ipIdentifier, chall)
+	emailIdentifier := identifier.ACMEIdentifier{Type: identifier.IdentifierType("email"), Value: "admin@localhost"}
+	_, prob = va.validateHTTP01(ctx, emailIdentifier, chall)
 	if prob == nil {
-		t.Fatalf("IdentifierType IP shouldn't have worked.")
+		t.Fatalf("IdentifierType email shouldn't have worked.")
 	}
 	test.AssertEquals(t, prob.Type, probs.MalformedProblem)
 
@@ -1359,8 +1378,8 @@ type dnsMockReturnsUnroutable struct {
 	*bdns.MockClient
 }
 
-func (mock dnsMockReturnsUnroutable) LookupHost(_ context.Context, hostname string) ([]net.IP, error) {
-	return []net.IP{net.ParseIP("198.51.100.1")}, nil
+func (mock dnsMockReturnsUnroutable) LookupHost(_ context.Context, hostname string) ([]net.IP, bdns.DNSSECStatus, error) {
+	return []net.IP{net.ParseIP("198.51.100.1")}, bdns.DNSSECInsecure, nil
 }
 
 // TestHTTPDialTimeout tests that we give the proper "Timeout during connect"
@@ -1533,10 +1552,26 @@ func TestValidateHTTP(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("localhost"), 1, chall)
 	test.Assert(t, prob == nil, "validation failed")
 }
 
+func TestValidateHTTPIP(t *testing.T) {
+	chall := core.HTTPChallenge01("")
+	setChallengeToken(&chall, core.NewToken())
+
+	hs := httpSrv(t, chall.Token)
+	defer hs.Close()
+
+	va, _ := setup(hs, 0, "", nil)
+
+	records, prob := va.validateChallenge(ctx, identifier.IPIdentifier(net.ParseIP("127.0.0.1")), 1, chall)
+	test.Assert(t, prob == nil, "validation failed")
+	test.AssertEquals(t, len(records), 1)
+	test.AssertEquals(t, records[0].Hostname, "127.0.0.1")
+	test.Assert(t, records[0].AddressUsed.Equal(net.ParseIP("127.0.0.1")), "Wrong address used")
+}
+
 func TestLimitedReader(t *testing.T) {
 	chall := core.HTTPChallenge01("")
 	setChallengeToken(&chall, core.NewToken())
@@ -1545,7 +1580,7 @@ func TestLimitedReader(t *testing.T) {
 	va, _ := setup(hs, 0, "", nil)
 	defer hs.Close()
 
-	_, prob := va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("localhost"), 1, chall)
 
 	test.AssertEquals(t, prob.Type, probs.UnauthorizedProblem)
 	test.Assert(t, strings.HasPrefix(prob.Detail, "Invalid response from "),
@@ -39,28 +39,55 @@ var (
 )
 
 // certNames collects up all of a certificate's subject names (Subject CN and
-// Subject Alternate Names) and reduces them to a unique, sorted set, typically for an
-// error message
+// Subject Alternate Names, including IP addresses) and reduces them to a unique,
+// sorted set, typically for an error message
 func certNames(cert *x509.Certificate) []string {
 	var names []string
 	if cert.Subject.CommonName != "" {
 		names = append(names, cert.Subject.CommonName)
 	}
 	names = append(names, cert.DNSNames...)
-	names = core.UniqueLowerNames(names)
+	names = core.UniqueLowerNamesAndIPs(names, cert.IPAddresses)
 	return names
 }
 
+// reverseName returns the ARPA-style reverse mapping name for the given IP
+// address, without a trailing dot. This is the server name that RFC 8738,
+// Section 6 requires be sent in the SNI extension when validating an IP
+// identifier with TLS-ALPN-01.
+func reverseName(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		return fmt.Sprintf("%d.%d.%d.%d.in-addr.arpa", ip4[3], ip4[2], ip4[1], ip4[0])
+	}
+	var b strings.Builder
+	for i := len(ip) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "%x.%x.", ip[i]&0xf, ip[i]>>4)
+	}
+	b.WriteString("ip6.arpa")
+	return b.String()
+}
+
+// certIdentifierMatches returns true if the given certificate contains exactly
+// one dNSName (for DNS identifiers) or iPAddress (for IP identifiers) subject
+// alternative name, and it is the given identifier.
+func certIdentifierMatches(cert *x509.Certificate, ident identifier.ACMEIdentifier) bool {
+	if ident.Type == identifier.IP {
+		return len(cert.IPAddresses) == 1 && cert.IPAddresses[0].Equal(net.ParseIP(ident.Value))
+	}
+	return len(cert.DNSNames) == 1 && strings.EqualFold(cert.DNSNames[0], ident.Value)
+}
+
 func (va *ValidationAuthorityImpl) tryGetTLSCerts(ctx context.Context,
 	identifier identifier.ACMEIdentifier, challenge core.Challenge,
 	tlsConfig *tls.Config) ([]*x509.Certificate, *tls.ConnectionState, []core.ValidationRecord, *probs.ProblemDetails) {
 
-	allAddrs, err := va.getAddrs(ctx, identifier.Value)
+	allAddrs, status, err := va.getAddrs(ctx, identifier.Value)
 	validationRecords := []core.ValidationRecord{
 		{
 			Hostname:          identifier.Value,
 			AddressesResolved: allAddrs,
 			Port:              strconv.Itoa(va.tlsPort),
+			DNSSEC:            string(status),
 		},
 	}
 	if err != nil {
@@ -172,14 +199,23 @@ func (va *ValidationAuthorityImpl) tlsDial(ctx context.Context, hostPort string,
 }
 
 func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identifier identifier.ACMEIdentifier, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
-	if identifier.Type != "dns" {
-		va.log.Info(fmt.Sprintf("Identifier type for TLS-ALPN-01 was not DNS: %s", identifier))
-		return nil, probs.Malformed("Identifier type for TLS-ALPN-01 was not DNS")
+	serverName := identifier.Value
+	switch identifier.Type {
+	case "dns":
+	case "ip":
+		ip := net.ParseIP(identifier.Value)
+		if ip == nil {
+			return nil, probs.Malformed("Identifier value for TLS-ALPN-01 was not an IP address")
+		}
+		serverName = reverseName(ip)
+	default:
+		va.log.Info(fmt.Sprintf("Identifier type for TLS-ALPN-01 was not DNS or IP: %s", identifier))
+		return nil, probs.Malformed("Identifier type for TLS-ALPN-01 was not DNS or IP")
 	}
 
 	certs, cs, validationRecords, problem := va.tryGetTLSCerts(ctx, identifier, challenge, &tls.Config{
 		NextProtos: []string{ACMETLS1Protocol},
-		ServerName: identifier.Value,
+		ServerName: serverName,
 	})
 	if problem != nil {
 		return validationRecords, problem
@@ -196,8 +232,9 @@ func (va *ValidationAuthorityImpl) validateTLSALPN01(ctx context.Context, identi
 
 	leafCert := certs[0]
 
-	// Verify SNI - certificate returned must be issued only for the domain we are verifying.
-	if len(leafCert.DNSNames) != 1 || !strings.EqualFold(leafCert.DNSNames[0], identifier.Value) {
+	// Verify SNI - certificate returned must be issued only for the domain or IP
+	// address we are verifying.
+	if !certIdentifierMatches(leafCert, identifier) {
 		hostPort := net.JoinHostPort(validationRecords[0].AddressUsed.String(), validationRecords[0].Port)
 		names := certNames(leafCert)
 		errText := fmt.Sprintf(
@@ -167,6 +167,59 @@ func TestTLSALPN01FailIP(t *testing.T) {
 	test.AssertEquals(t, prob.Type, probs.MalformedProblem)
 }
 
+func TestTLSALPN01SuccessIP(t *testing.T) {
+	chall := tlsalpnChallenge()
+	ip := net.ParseIP("127.0.0.1")
+
+	template := tlsCertTemplate(nil)
+	template.IPAddresses = []net.IP{ip}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &TheKey.PublicKey, &TheKey)
+	test.AssertNotError(t, err, "Error creating certificate")
+	cert := &tls.Certificate{Certificate: [][]byte{certBytes}, PrivateKey: &TheKey}
+
+	shasum := sha256.Sum256([]byte(chall.ProvidedKeyAuthorization))
+	encHash, err := asn1.Marshal(shasum[:])
+	test.AssertNotError(t, err, "Error marshalling key authorization digest")
+	template.ExtraExtensions = []pkix.Extension{{Id: IdPeAcmeIdentifier, Critical: true, Value: encHash}}
+	certBytes, err = x509.CreateCertificate(rand.Reader, template, template, &TheKey.PublicKey, &TheKey)
+	test.AssertNotError(t, err, "Error creating certificate")
+	acmeCert := &tls.Certificate{Certificate: [][]byte{certBytes}, PrivateKey: &TheKey}
+
+	// The server only presents a certificate when the SNI is the reverse
+	// mapping name of the address.
+	hs := tlsalpn01SrvWithCert(t, chall, IdPeAcmeIdentifier, []string{"1.0.0.127.in-addr.arpa"}, cert, acmeCert, 0)
+	va, _ := setup(hs, 0, "", nil)
+
+	records, prob := va.validateChallenge(ctx, identifier.IPIdentifier(ip), 1, chall)
+	if prob != nil {
+		t.Fatalf("Validation failed: %v", prob)
+	}
+	test.AssertEquals(t, len(records), 1)
+	test.Assert(t, records[0].AddressUsed.Equal(ip), "Wrong address used")
+
+	// A certificate for a different IP address shouldn't work.
+	hs.Close()
+	template.IPAddresses = []net.IP{net.ParseIP("127.0.0.2")}
+	certBytes, err = x509.CreateCertificate(rand.Reader, template, template, &TheKey.PublicKey, &TheKey)
+	test.AssertNotError(t, err, "Error creating certificate")
+	acmeCert = &tls.Certificate{Certificate: [][]byte{certBytes}, PrivateKey: &TheKey}
+	hs = tlsalpn01SrvWithCert(t, chall, IdPeAcmeIdentifier, []string{"1.0.0.127.in-addr.arpa"}, cert, acmeCert, 0)
+	defer hs.Close()
+	va, _ = setup(hs, 0, "", nil)
+
+	_, prob = va.validateChallenge(ctx, identifier.IPIdentifier(ip), 1, chall)
+	if prob == nil {
+		t.Fatalf("Validation should have failed for a certificate with the wrong IP address")
+	}
+	test.AssertEquals(t, prob.Type, probs.UnauthorizedProblem)
+}
+
+func TestReverseName(t *testing.T) {
+	test.AssertEquals(t, reverseName(net.ParseIP("192.0.2.1")), "1.2.0.192.in-addr.arpa")
+	test.AssertEquals(t, reverseName(net.ParseIP("2001:db8::1")),
+		"1.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.0.8.b.d.0.1.0.0.2.ip6.arpa")
+}
+
 func slowTLSSrv() *httptest.Server {
 	server := httptest.NewUnstartedServer(http.DefaultServeMux)
 	server.TLS = &tls.Config{
@@ -389,7 +442,7 @@ func TestTLSALPN01Success(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("localhost"), 1, chall)
 	if prob != nil {
 		t.Errorf("Validation failed: %v", prob)
 	}
@@ -403,7 +456,7 @@ func TestTLSALPN01Success(t *testing.T) {
 
 	va, _ = setup(hs, 0, "", nil)
 
-	_, prob = va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob = va.validateChallenge(ctx, dnsi("localhost"), 1, chall)
 	if prob != nil {
 		t.Errorf("Validation failed: %v", prob)
 	}
@@ -541,7 +594,7 @@ func TestTLSALPN01TLS13(t *testing.T) {
 
 	va, _ := setup(hs, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("localhost"), chall)
+	_, prob := va.validateChallenge(ctx, dnsi("localhost"), 1, chall)
 	// Validation should not fail
 	if prob != nil {
 		t.Errorf("Validation failed: %v", prob)
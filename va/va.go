@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -66,13 +67,18 @@ var (
 	h2SettingsFrameErrRegex = regexp.MustCompile(`(?:net\/http\: HTTP\/1\.x transport connection broken: )?malformed HTTP response \"\\x00\\x00\\x[a-f0-9]{2}\\x04\\x00\\x00\\x00\\x00\\x00.*"`)
 )
 
-// RemoteVA wraps the vapb.VAClient interface and adds a field containing the
-// address of the remote gRPC server since the underlying gRPC client doesn't
-// provide a way to extract this metadata which is useful for debugging gRPC
-// connection issues.
+// RemoteVA wraps the vapb.VAClient and vapb.CAAClient interfaces of a remote
+// VA and adds a field containing the address of the remote gRPC server since
+// the underlying gRPC client doesn't provide a way to extract this metadata
+// which is useful for debugging gRPC connection issues.
 type RemoteVA struct {
 	vapb.VAClient
+	vapb.CAAClient
 	Address string
+	// Perspective is the region or RIR which the remote VA validates from.
+	// Remote VAs which share a perspective are not independent of each other,
+	// so a remote perspective quorum counts them as one.
+	Perspective string
 }
 
 type vaMetrics struct {
@@ -81,6 +87,9 @@ type vaMetrics struct {
 	remoteValidationTime                *prometheus.HistogramVec
 	remoteValidationFailures            prometheus.Counter
 	prospectiveRemoteValidationFailures prometheus.Counter
+	remoteCAACheckTime                  *prometheus.HistogramVec
+	remoteCAACheckFailures              prometheus.Counter
+	prospectiveRemoteCAACheckFailures   prometheus.Counter
 	tlsALPNOIDCounter                   *prometheus.CounterVec
 	http01Fallbacks                     prometheus.Counter
 	http01Redirects                     prometheus.Counter
@@ -125,6 +134,26 @@ func initMetrics(stats prometheus.Registerer) *vaMetrics {
 			Help: "Number of validations that would have failed due to remote VAs returning failure if consesus were enforced",
 		})
 	stats.MustRegister(prospectiveRemoteValidationFailures)
+	remoteCAACheckTime := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "remote_caa_check_time",
+			Help:    "Time taken to remotely check CAA outside of a validation",
+			Buckets: metrics.InternetFacingBuckets,
+		},
+		[]string{"result"})
+	stats.MustRegister(remoteCAACheckTime)
+	remoteCAACheckFailures := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "remote_caa_check_failures",
+			Help: "Number of CAA checks failed due to remote VAs returning failure when consensus is enforced",
+		})
+	stats.MustRegister(remoteCAACheckFailures)
+	prospectiveRemoteCAACheckFailures := prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "prospective_remote_caa_check_failures",
+			Help: "Number of CAA checks that would have failed due to remote VAs returning failure if consensus were enforced",
+		})
+	stats.MustRegister(prospectiveRemoteCAACheckFailures)
 	tlsALPNOIDCounter := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "tls_alpn_oid_usage",
@@ -162,6 +191,9 @@ func initMetrics(stats prometheus.Registerer) *vaMetrics {
 		localValidationTime:                 localValidationTime,
 		remoteValidationFailures:            remoteValidationFailures,
 		prospectiveRemoteValidationFailures: prospectiveRemoteValidationFailures,
+		remoteCAACheckTime:                  remoteCAACheckTime,
+		remoteCAACheckFailures:              remoteCAACheckFailures,
+		prospectiveRemoteCAACheckFailures:   prospectiveRemoteCAACheckFailures,
 		tlsALPNOIDCounter:                   tlsALPNOIDCounter,
 		http01Fallbacks:                     http01Fallbacks,
 		http01Redirects:                     http01Redirects,
@@ -184,18 +216,23 @@ type ValidationAuthorityImpl struct {
 	clk                clock.Clock
 	remoteVAs          []RemoteVA
 	maxRemoteFailures  int
+	perspectiveQuorum  int
 	accountURIPrefixes []string
 	singleDialTimeout  time.Duration
 
 	metrics *vaMetrics
 }
 
-// NewValidationAuthorityImpl constructs a new VA
+// NewValidationAuthorityImpl constructs a new VA. When remote VAs are used,
+// validation requires that no more than maxRemoteFailures of them fail, or, if
+// perspectiveQuorum is non-zero, that remote VAs in at least that many distinct
+// perspectives succeed.
 func NewValidationAuthorityImpl(
 	pc *cmd.PortConfig,
 	resolver bdns.Client,
 	remoteVAs []RemoteVA,
 	maxRemoteFailures int,
+	perspectiveQuorum int,
 	userAgent string,
 	issuerDomain string,
 	stats prometheus.Registerer,
@@ -217,6 +254,20 @@ func NewValidationAuthorityImpl(
 		return nil, errors.New("no account URI prefixes configured")
 	}
 
+	if perspectiveQuorum > 0 {
+		perspectives := make(map[string]bool)
+		for _, rva := range remoteVAs {
+			if rva.Perspective == "" {
+				return nil, fmt.Errorf("remote VA %q has no perspective, which a perspective quorum requires", rva.Address)
+			}
+			perspectives[rva.Perspective] = true
+		}
+		if len(perspectives) < perspectiveQuorum {
+			return nil, fmt.Errorf("perspective quorum of %d can't be met by remote VAs in %d perspectives",
+				perspectiveQuorum, len(perspectives))
+		}
+	}
+
 	va := &ValidationAuthorityImpl{
 		log:                logger,
 		dnsClient:          resolver,
@@ -229,6 +280,7 @@ func NewValidationAuthorityImpl(
 		metrics:            initMetrics(stats),
 		remoteVAs:          remoteVAs,
 		maxRemoteFailures:  maxRemoteFailures,
+		perspectiveQuorum:  perspectiveQuorum,
 		accountURIPrefixes: accountURIPrefixes,
 		// singleDialTimeout specifies how long an individual `DialContext` operation may take
 		// before timing out. This timeout ignores the base RPC timeout and is strictly
@@ -256,6 +308,9 @@ type verificationRequestEvent struct {
 // meaningful. It additionally handles `berrors.ConnectionFailure` errors by
 // passing through the detailed message.
 func detailedError(err error) *probs.ProblemDetails {
+	if errors.Is(err, bdns.ErrDNSSECBogus) {
+		return probs.DNSSECBogus(err.Error())
+	}
 	// net/http wraps net.OpError in a url.Error. Unwrap them.
 	var urlErr *url.Error
 	if errors.As(err, &urlErr) {
@@ -346,7 +401,7 @@ func (va *ValidationAuthorityImpl) validate(
 	}()
 
 	// TODO(#1292): send into another goroutine
-	validationRecords, err := va.validateChallenge(ctx, baseIdentifier, challenge)
+	validationRecords, err := va.validateChallenge(ctx, baseIdentifier, regid, challenge)
 	if err != nil {
 		return validationRecords, err
 	}
@@ -359,7 +414,7 @@ func (va *ValidationAuthorityImpl) validate(
 	return validationRecords, nil
 }
 
-func (va *ValidationAuthorityImpl) validateChallenge(ctx context.Context, identifier identifier.ACMEIdentifier, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
+func (va *ValidationAuthorityImpl) validateChallenge(ctx context.Context, identifier identifier.ACMEIdentifier, regid int64, challenge core.Challenge) ([]core.ValidationRecord, *probs.ProblemDetails) {
 	if err := challenge.CheckConsistencyForValidation(); err != nil {
 		return nil, probs.Malformed("Challenge failed consistency check: %s", err)
 	}
@@ -370,6 +425,8 @@ func (va *ValidationAuthorityImpl) validateChallenge(ctx context.Context, identi
 		return va.validateDNS01(ctx, identifier, challenge)
 	case core.ChallengeTypeTLSALPN01:
 		return va.validateTLSALPN01(ctx, identifier, challenge)
+	case core.ChallengeTypeDNSAccount01:
+		return va.validateDNSAccount01(ctx, identifier, regid, challenge)
 	}
 	return nil, probs.Malformed("invalid challenge type %s", challenge.Type)
 }
@@ -390,7 +447,8 @@ func (va *ValidationAuthorityImpl) performRemoteValidation(
 		remoteVA := va.remoteVAs[i]
 		go func(rva RemoteVA, index int) {
 			result := &remoteValidationResult{
-				VAHostname: rva.Address,
+				VAHostname:  rva.Address,
+				Perspective: rva.Perspective,
 			}
 			res, err := rva.PerformValidation(ctx, req)
 			if err != nil && canceled.Is(err) {
@@ -414,6 +472,16 @@ func (va *ValidationAuthorityImpl) performRemoteValidation(
 					result.Problem = prob
 				}
 			}
+			if err == nil {
+				for _, recordPB := range res.Records {
+					record, err := bgrpc.PBToValidationRecord(recordPB)
+					if err != nil {
+						va.log.Infof("Remote VA %q.PerformValidation returned malformed record: %s", rva.Address, err)
+						continue
+					}
+					result.Records = append(result.Records, record)
+				}
+			}
 			results <- result
 		}(remoteVA, i)
 	}
@@ -422,7 +490,8 @@ func (va *ValidationAuthorityImpl) performRemoteValidation(
 // processRemoteResults evaluates a primary VA result, and a channel of remote
 // VA problems to produce a single overall validation result based on configured
 // feature flags. The overall result is calculated based on the VA's configured
-// `maxRemoteFailures` value.
+// `maxRemoteFailures` or `perspectiveQuorum` value, see `remoteQuorum`. The
+// remote results which were read are returned along with it.
 //
 // If the `MultiVAFullResults` feature is enabled then `processRemoteResults`
 // will expect to read a result from the `remoteErrors` channel for each VA and
@@ -441,7 +510,7 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 	challengeType string,
 	primaryResult *probs.ProblemDetails,
 	remoteResultsChan chan *remoteValidationResult,
-	numRemoteVAs int) *probs.ProblemDetails {
+	numRemoteVAs int) (*probs.ProblemDetails, []*remoteValidationResult) {
 
 	state := "failure"
 	start := va.clk.Now()
@@ -453,10 +522,6 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 		}).Observe(va.clk.Since(start).Seconds())
 	}()
 
-	required := numRemoteVAs - va.maxRemoteFailures
-	good := 0
-	bad := 0
-
 	var remoteResults []*remoteValidationResult
 	var firstProb *probs.ProblemDetails
 	// Due to channel behavior this could block indefinitely and we rely on gRPC
@@ -465,11 +530,6 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 	for result := range remoteResultsChan {
 		// Add the result to the slice
 		remoteResults = append(remoteResults, result)
-		if result.Problem == nil {
-			good++
-		} else {
-			bad++
-		}
 
 		// Store the first non-nil problem to return later (if `MultiVAFullResults`
 		// is enabled).
@@ -480,13 +540,14 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 		// If MultiVAFullResults isn't enabled then return early whenever the
 		// success or failure threshold is met.
 		if !features.Enabled(features.MultiVAFullResults) {
-			if good >= required {
+			met, failed := va.remoteQuorum(remoteResults, numRemoteVAs)
+			if met {
 				state = "success"
-				return nil
-			} else if bad > va.maxRemoteFailures {
+				return nil, remoteResults
+			} else if failed {
 				modifiedProblem := *result.Problem
 				modifiedProblem.Detail = "During secondary validation: " + firstProb.Detail
-				return &modifiedProblem
+				return &modifiedProblem, remoteResults
 			}
 		}
 
@@ -508,18 +569,96 @@ func (va *ValidationAuthorityImpl) processRemoteResults(
 		remoteResults)
 
 	// Based on the threshold of good/bad return nil or a problem.
-	if good >= required {
+	met, failed := va.remoteQuorum(remoteResults, numRemoteVAs)
+	if met {
 		state = "success"
-		return nil
-	} else if bad > va.maxRemoteFailures {
+		return nil, remoteResults
+	} else if failed {
 		modifiedProblem := *firstProb
 		modifiedProblem.Detail = "During secondary validation: " + firstProb.Detail
-		return &modifiedProblem
+		return &modifiedProblem, remoteResults
 	}
 
 	// This condition should not occur - it indicates the good/bad counts didn't
 	// meet either the required threshold or the maxRemoteFailures threshold.
-	return probs.ServerInternal("Too few remote PerformValidation RPC results")
+	return probs.ServerInternal("Too few remote PerformValidation RPC results"), remoteResults
+}
+
+// remoteQuorum reports whether the remote results received so far meet the
+// VA's threshold for corroborating the primary VA, or whether the threshold can
+// no longer be met. Without a perspective quorum, at most `maxRemoteFailures`
+// of the `numRemoteVAs` remote VAs may fail. With one, remote VAs in at least
+// `perspectiveQuorum` distinct perspectives must succeed, since a failure such
+// as an outage shared by remote VAs in one region says nothing independent
+// about the domain.
+func (va *ValidationAuthorityImpl) remoteQuorum(remoteResults []*remoteValidationResult, numRemoteVAs int) (bool, bool) {
+	if va.perspectiveQuorum == 0 {
+		good, bad := 0, 0
+		for _, result := range remoteResults {
+			if result.Problem == nil {
+				good++
+			} else {
+				bad++
+			}
+		}
+		return good >= numRemoteVAs-va.maxRemoteFailures, bad > va.maxRemoteFailures
+	}
+
+	// A perspective counts towards the quorum once one of its remote VAs
+	// succeeds, and still might while any of them haven't responded.
+	pending := make(map[string]int)
+	for _, rva := range va.remoteVAs {
+		pending[rva.Perspective]++
+	}
+	succeeded := make(map[string]bool)
+	for _, result := range remoteResults {
+		pending[result.Perspective]--
+		if result.Problem == nil {
+			succeeded[result.Perspective] = true
+		}
+	}
+	possible := 0
+	for perspective, n := range pending {
+		if succeeded[perspective] || n > 0 {
+			possible++
+		}
+	}
+	return len(succeeded) >= va.perspectiveQuorum, possible < va.perspectiveQuorum
+}
+
+// remoteValidationRecords returns the validation records of each remote VA,
+// marked with the remote VA, its perspective, and its problem, if any, so that
+// the result from every perspective is recorded alongside the primary VA's. A
+// remote VA which returned no records, for instance because its RPC failed, is
+// represented by a record of just its result.
+func remoteValidationRecords(domain string, remoteResults []*remoteValidationResult) []core.ValidationRecord {
+	sorted := make([]*remoteValidationResult, len(remoteResults))
+	copy(sorted, remoteResults)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Perspective != sorted[j].Perspective {
+			return sorted[i].Perspective < sorted[j].Perspective
+		}
+		return sorted[i].VAHostname < sorted[j].VAHostname
+	})
+
+	var records []core.ValidationRecord
+	for _, result := range sorted {
+		var remoteProblem string
+		if result.Problem != nil {
+			remoteProblem = result.Problem.Detail
+		}
+		resultRecords := result.Records
+		if len(resultRecords) == 0 {
+			resultRecords = []core.ValidationRecord{{Hostname: domain}}
+		}
+		for _, record := range resultRecords {
+			record.RemoteVA = result.VAHostname
+			record.Perspective = result.Perspective
+			record.RemoteProblem = remoteProblem
+			records = append(records, record)
+		}
+	}
+	return records
 }
 
 // logRemoteValidationDifferentials is called by `processRemoteResults` when the
@@ -555,7 +694,7 @@ func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 	// If the primary result was OK and there were more failures than the allowed
 	// threshold increment a stat that indicates this overall validation will have
 	// failed if features.EnforceMultiVA is enabled.
-	if primaryResult == nil && len(failures) > va.maxRemoteFailures {
+	if _, failed := va.remoteQuorum(remoteResults, len(remoteResults)); primaryResult == nil && failed {
 		va.metrics.prospectiveRemoteValidationFailures.Inc()
 	}
 
@@ -589,10 +728,13 @@ func (va *ValidationAuthorityImpl) logRemoteValidationDifferentials(
 }
 
 // remoteValidationResult is a struct that combines a problem details instance
-// (that may be nil) with the remote VA hostname that produced it.
+// (that may be nil) with the remote VA hostname and perspective that produced
+// it, and the validation records it returned.
 type remoteValidationResult struct {
-	VAHostname string
-	Problem    *probs.ProblemDetails
+	VAHostname  string
+	Perspective string `json:",omitempty"`
+	Problem     *probs.ProblemDetails
+	Records     []core.ValidationRecord `json:"-"`
 }
 
 // PerformValidation validates the challenge for the domain in the request.
@@ -620,7 +762,7 @@ func (va *ValidationAuthorityImpl) PerformValidation(ctx context.Context, req *v
 		return nil, probs.ServerInternal("Challenge failed to deserialize")
 	}
 
-	records, prob := va.validate(ctx, identifier.DNSIdentifier(req.Domain), req.Authz.RegID, challenge)
+	records, prob := va.validate(ctx, identifier.FromName(req.Domain), req.Authz.RegID, challenge)
 	challenge.ValidationRecord = records
 	localValidationLatency := time.Since(vStart)
 
@@ -641,7 +783,7 @@ func (va *ValidationAuthorityImpl) PerformValidation(ctx context.Context, req *v
 			// differentials then collect and log the remote results in a separate go
 			// routine to avoid blocking the primary VA.
 			go func() {
-				_ = va.processRemoteResults(
+				_, _ = va.processRemoteResults(
 					req.Domain,
 					req.Authz.RegID,
 					string(challenge.Type),
@@ -654,13 +796,17 @@ func (va *ValidationAuthorityImpl) PerformValidation(ctx context.Context, req *v
 			// validationTime metrics increment has the correct result label.
 			challenge.Status = core.StatusValid
 		} else if features.Enabled(features.EnforceMultiVA) {
-			remoteProb := va.processRemoteResults(
+			remoteProb, results := va.processRemoteResults(
 				req.Domain,
 				req.Authz.RegID,
 				string(challenge.Type),
 				prob,
 				remoteResults,
 				len(va.remoteVAs))
+			if features.Enabled(features.RecordRemoteValidations) {
+				records = append(records, remoteValidationRecords(req.Domain, results)...)
+				challenge.ValidationRecord = records
+			}
 
 			// If the remote result was a non-nil problem then fail the validation
 			if remoteProb != nil {
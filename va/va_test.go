@@ -137,6 +137,7 @@ func setup(srv *httptest.Server, maxRemoteFailures int, userAgent string, remote
 		&bdns.MockClient{Log: logger},
 		nil,
 		maxRemoteFailures,
+		0,
 		userAgent,
 		"letsencrypt.org",
 		metrics.NoopRegisterer,
@@ -236,7 +237,7 @@ func (lrva localRemoteVA) PerformValidation(ctx context.Context, req *vapb.Perfo
 func TestValidateMalformedChallenge(t *testing.T) {
 	va, _ := setup(nil, 0, "", nil)
 
-	_, prob := va.validateChallenge(ctx, dnsi("example.com"), createChallenge("fake-type-01"))
+	_, prob := va.validateChallenge(ctx, dnsi("example.com"), 1, createChallenge("fake-type-01"))
 
 	test.AssertEquals(t, prob.Type, probs.MalformedProblem)
 }
@@ -332,8 +333,8 @@ func TestMultiVA(t *testing.T) {
 	remoteVA2, _ := setupRemote(ms.Server, 0, remoteUA2)
 
 	remoteVAs := []RemoteVA{
-		{remoteVA1, remoteUA1},
-		{remoteVA2, remoteUA2},
+		{VAClient: remoteVA1, Address: remoteUA1},
+		{VAClient: remoteVA2, Address: remoteUA2},
 	}
 
 	enforceMultiVA := map[string]bool{
@@ -401,8 +402,8 @@ func TestMultiVA(t *testing.T) {
 			// If a remote VA fails with an internal err it should fail when enforcing multi VA
 			Name: "Local VA ok, remote VA internal err, enforce multi VA",
 			RemoteVAs: []RemoteVA{
-				{remoteVA1, remoteUA1},
-				{&brokenRemoteVA{}, "broken"},
+				{VAClient: remoteVA1, Address: remoteUA1},
+				{VAClient: &brokenRemoteVA{}, Address: "broken"},
 			},
 			AllowedUAs:   allowedUAs,
 			Features:     enforceMultiVA,
@@ -415,8 +416,8 @@ func TestMultiVA(t *testing.T) {
 			// enforcing multi VA
 			Name: "Local VA ok, remote VA internal err, no enforce multi VA",
 			RemoteVAs: []RemoteVA{
-				{remoteVA1, remoteUA1},
-				{&brokenRemoteVA{}, "broken"},
+				{VAClient: remoteVA1, Address: remoteUA1},
+				{VAClient: &brokenRemoteVA{}, Address: "broken"},
 			},
 			AllowedUAs: allowedUAs,
 			Features:   noEnforceMultiVA,
@@ -446,8 +447,8 @@ func TestMultiVA(t *testing.T) {
 			// When enforcing multi-VA, any cancellations are a problem.
 			Name: "Local VA and one remote VA OK, one cancelled VA, enforce multi VA",
 			RemoteVAs: []RemoteVA{
-				{remoteVA1, remoteUA1},
-				{cancelledVA{}, remoteUA2},
+				{VAClient: remoteVA1, Address: remoteUA1},
+				{VAClient: cancelledVA{}, Address: remoteUA2},
 			},
 			AllowedUAs:   allowedUAs,
 			Features:     enforceMultiVA,
@@ -457,8 +458,8 @@ func TestMultiVA(t *testing.T) {
 			// When enforcing multi-VA, any cancellations are a problem.
 			Name: "Local VA OK, two cancelled remote VAs, enforce multi VA",
 			RemoteVAs: []RemoteVA{
-				{cancelledVA{}, remoteUA1},
-				{cancelledVA{}, remoteUA2},
+				{VAClient: cancelledVA{}, Address: remoteUA1},
+				{VAClient: cancelledVA{}, Address: remoteUA2},
 			},
 			AllowedUAs:   allowedUAs,
 			Features:     enforceMultiVA,
@@ -537,8 +538,8 @@ func TestMultiVAEarlyReturn(t *testing.T) {
 	remoteVA2, _ := setupRemote(ms.Server, 0, remoteUA2)
 
 	remoteVAs := []RemoteVA{
-		{remoteVA1, remoteUA1},
-		{remoteVA2, remoteUA2},
+		{VAClient: remoteVA1, Address: remoteUA1},
+		{VAClient: remoteVA2, Address: remoteUA2},
 	}
 
 	// Create a local test VA with the two remote VAs
@@ -625,8 +626,8 @@ func TestMultiVAPolicy(t *testing.T) {
 	remoteVA2, _ := setupRemote(ms.Server, 0, remoteUA2)
 
 	remoteVAs := []RemoteVA{
-		{remoteVA1, remoteUA1},
-		{remoteVA2, remoteUA2},
+		{VAClient: remoteVA1, Address: remoteUA1},
+		{VAClient: remoteVA2, Address: remoteUA2},
 	}
 
 	// Create a local test VA with the two remote VAs
@@ -650,6 +651,198 @@ func TestMultiVAPolicy(t *testing.T) {
 	}
 }
 
+func TestMultiVAPerspectiveQuorum(t *testing.T) {
+	const (
+		remoteUA1 = "remote 1"
+		remoteUA2 = "remote 2"
+		remoteUA3 = "remote 3"
+		localUA   = "local 1"
+	)
+	ms := httpMultiSrv(t, expectedToken, nil)
+	defer ms.Close()
+
+	remoteVA1, _ := setupRemote(ms.Server, 0, remoteUA1)
+	remoteVA2, _ := setupRemote(ms.Server, 0, remoteUA2)
+	remoteVA3, _ := setupRemote(ms.Server, 0, remoteUA3)
+
+	// Two of the remote VAs share a perspective.
+	remoteVAs := []RemoteVA{
+		{VAClient: remoteVA1, Address: remoteUA1, Perspective: "ARIN"},
+		{VAClient: remoteVA2, Address: remoteUA2, Perspective: "ARIN"},
+		{VAClient: remoteVA3, Address: remoteUA3, Perspective: "RIPE"},
+	}
+
+	testCases := []struct {
+		name           string
+		allowedUAs     map[string]bool
+		expectProb     bool
+		recordRemote   bool
+		remoteProblems map[string]bool
+	}{
+		{
+			name:         "all perspectives succeed",
+			allowedUAs:   map[string]bool{localUA: true, remoteUA1: true, remoteUA2: true, remoteUA3: true},
+			recordRemote: true,
+		},
+		{
+			name:           "one remote VA in each perspective succeeds",
+			allowedUAs:     map[string]bool{localUA: true, remoteUA2: true, remoteUA3: true},
+			recordRemote:   true,
+			remoteProblems: map[string]bool{remoteUA1: true},
+		},
+		{
+			// Two of the three remote VAs succeeded, which MaxRemoteFailures
+			// of 1 would allow, but they are in the same perspective.
+			name:           "only one perspective succeeds",
+			allowedUAs:     map[string]bool{localUA: true, remoteUA1: true, remoteUA2: true},
+			expectProb:     true,
+			recordRemote:   true,
+			remoteProblems: map[string]bool{remoteUA3: true},
+		},
+		{
+			// Without RecordRemoteValidations only the primary VA's record is
+			// returned, as RAs and WFEs which predate remote records expect.
+			name:       "remote records not recorded",
+			allowedUAs: map[string]bool{localUA: true, remoteUA1: true, remoteUA2: true, remoteUA3: true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ms.setAllowedUAs(tc.allowedUAs)
+			localVA, _ := setup(ms.Server, 1, localUA, remoteVAs)
+			localVA.perspectiveQuorum = 2
+			err := features.Set(map[string]bool{
+				"EnforceMultiVA":          true,
+				"MultiVAFullResults":      true,
+				"RecordRemoteValidations": tc.recordRemote,
+			})
+			test.AssertNotError(t, err, "setting feature flags")
+			defer features.Reset()
+
+			req := createValidationRequest("letsencrypt.org", core.ChallengeTypeHTTP01)
+			res, err := localVA.PerformValidation(ctx, req)
+			test.AssertNotError(t, err, "PerformValidation failed")
+			if tc.expectProb {
+				test.Assert(t, res.Problems != nil, "expected a problem from PerformValidation")
+				test.AssertContains(t, res.Problems.Detail, "During secondary validation: ")
+			} else {
+				test.Assert(t, res.Problems == nil, fmt.Sprintf("unexpected problem: %v", res.Problems))
+			}
+
+			if !tc.recordRemote {
+				test.AssertEquals(t, len(res.Records), 1)
+				test.AssertEquals(t, res.Records[0].RemoteVA, "")
+				return
+			}
+			// The primary VA's record is followed by one from each remote VA,
+			// ordered by perspective.
+			test.AssertEquals(t, len(res.Records), 4)
+			test.AssertEquals(t, res.Records[0].RemoteVA, "")
+			for i, expected := range []RemoteVA{remoteVAs[0], remoteVAs[1], remoteVAs[2]} {
+				record := res.Records[i+1]
+				test.AssertEquals(t, record.RemoteVA, expected.Address)
+				test.AssertEquals(t, record.Perspective, expected.Perspective)
+				test.AssertEquals(t, record.RemoteProblem != "", tc.remoteProblems[expected.Address])
+			}
+		})
+	}
+}
+
+func TestRemoteQuorum(t *testing.T) {
+	va, _ := setup(nil, 1, "", []RemoteVA{
+		{Address: "a", Perspective: "ARIN"},
+		{Address: "b", Perspective: "ARIN"},
+		{Address: "c", Perspective: "RIPE"},
+		{Address: "d", Perspective: "APNIC"},
+	})
+	ok := func(addr, perspective string) *remoteValidationResult {
+		return &remoteValidationResult{VAHostname: addr, Perspective: perspective}
+	}
+	bad := func(addr, perspective string) *remoteValidationResult {
+		return &remoteValidationResult{VAHostname: addr, Perspective: perspective, Problem: probs.ConnectionFailure("refused")}
+	}
+
+	testCases := []struct {
+		name      string
+		quorum    int
+		results   []*remoteValidationResult
+		expectMet bool
+		expectBad bool
+	}{
+		{
+			name:      "count: too few results yet",
+			results:   []*remoteValidationResult{ok("a", "ARIN"), bad("c", "RIPE")},
+			expectMet: false,
+			expectBad: false,
+		},
+		{
+			name:      "count: enough successes",
+			results:   []*remoteValidationResult{ok("a", "ARIN"), ok("b", "ARIN"), ok("c", "RIPE")},
+			expectMet: true,
+		},
+		{
+			name:      "count: too many failures",
+			results:   []*remoteValidationResult{bad("a", "ARIN"), bad("b", "ARIN")},
+			expectBad: true,
+		},
+		{
+			name:    "quorum: one perspective isn't enough",
+			quorum:  2,
+			results: []*remoteValidationResult{ok("a", "ARIN"), ok("b", "ARIN")},
+		},
+		{
+			name:      "quorum: two perspectives",
+			quorum:    2,
+			results:   []*remoteValidationResult{ok("a", "ARIN"), bad("b", "ARIN"), ok("d", "APNIC")},
+			expectMet: true,
+		},
+		{
+			name:    "quorum: a failure in a perspective with results pending",
+			quorum:  3,
+			results: []*remoteValidationResult{bad("a", "ARIN"), ok("c", "RIPE"), ok("d", "APNIC")},
+		},
+		{
+			name:      "quorum: a perspective with every remote VA failed",
+			quorum:    3,
+			results:   []*remoteValidationResult{bad("a", "ARIN"), bad("b", "ARIN")},
+			expectBad: true,
+		},
+		{
+			name:      "quorum: too few perspectives left",
+			quorum:    2,
+			results:   []*remoteValidationResult{ok("a", "ARIN"), bad("c", "RIPE"), bad("d", "APNIC")},
+			expectBad: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			va.perspectiveQuorum = tc.quorum
+			met, failed := va.remoteQuorum(tc.results, len(va.remoteVAs))
+			test.AssertEquals(t, met, tc.expectMet)
+			test.AssertEquals(t, failed, tc.expectBad)
+		})
+	}
+}
+
+func TestNewValidationAuthorityImplPerspectiveQuorum(t *testing.T) {
+	newVA := func(remoteVAs []RemoteVA, quorum int) error {
+		_, err := NewValidationAuthorityImpl(&cmd.PortConfig{}, &bdns.MockClient{}, remoteVAs, 0, quorum,
+			"user agent 1.0", "letsencrypt.org", metrics.NoopRegisterer, clock.NewFake(), blog.NewMock(), accountURIPrefixes)
+		return err
+	}
+
+	err := newVA([]RemoteVA{{Address: "a", Perspective: "ARIN"}, {Address: "b"}}, 1)
+	test.AssertError(t, err, "remote VA without a perspective accepted")
+	test.AssertEquals(t, err.Error(), `remote VA "b" has no perspective, which a perspective quorum requires`)
+
+	err = newVA([]RemoteVA{{Address: "a", Perspective: "ARIN"}, {Address: "b", Perspective: "ARIN"}}, 2)
+	test.AssertError(t, err, "unachievable quorum accepted")
+	test.AssertEquals(t, err.Error(), "perspective quorum of 2 can't be met by remote VAs in 1 perspectives")
+
+	err = newVA([]RemoteVA{{Address: "a", Perspective: "ARIN"}, {Address: "b", Perspective: "RIPE"}}, 2)
+	test.AssertNotError(t, err, "valid perspective quorum rejected")
+}
+
 func TestDetailedError(t *testing.T) {
 	cases := []struct {
 		err      error
@@ -692,9 +885,9 @@ func TestLogRemoteValidationDifferentials(t *testing.T) {
 	remoteVA2, _ := setupRemote(nil, 0, "remote 2")
 	remoteVA3, _ := setupRemote(nil, 0, "remote 3")
 	remoteVAs := []RemoteVA{
-		{remoteVA1, "remote 1"},
-		{remoteVA2, "remote 2"},
-		{remoteVA3, "remote 3"},
+		{VAClient: remoteVA1, Address: "remote 1"},
+		{VAClient: remoteVA2, Address: "remote 2"},
+		{VAClient: remoteVA3, Address: "remote 3"},
 	}
 
 	// Set up a local VA that allows a max of 2 remote failures.
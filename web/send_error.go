@@ -3,13 +3,18 @@ package web
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 
+	berrors "github.com/letsencrypt/boulder/errors"
 	blog "github.com/letsencrypt/boulder/log"
 	"github.com/letsencrypt/boulder/probs"
 )
@@ -19,6 +24,8 @@ import (
 //  - If the ProblemDetails provided is a ServerInternalProblem, audit logs the
 //    internal error.
 //  - Prefixes the Type field of the ProblemDetails with a namespace.
+//  - If the internal error is a rate limit error with a status, adds headers
+//    telling the user when they may retry and how much of the limit remains.
 //  - Sends an HTTP response containing the error and an error code to the user.
 func SendError(
 	log blog.Logger,
@@ -59,7 +66,7 @@ func SendError(
 
 	// Set the proper namespace for the problem and any
 	// sub-problems
-	prob.Type = probs.ProblemType(namespace) + prob.Type
+	prob.Type = probs.ProblemType(namespace) + probs.ACMEType(prob.Type)
 	for i := range prob.SubProblems {
 		prob.SubProblems[i].Type = prob.Type
 	}
@@ -69,8 +76,29 @@ func SendError(
 		problemDoc = []byte("{\"detail\": \"Problem marshalling error message.\"}")
 	}
 
+	var berr *berrors.BoulderError
+	if errors.As(ierr, &berr) && berr.Type == berrors.RateLimit && berr.RateLimitStatus != nil {
+		setRateLimitHeaders(response, berr.RateLimitStatus)
+	}
+
 	// Write the JSON problem response
 	response.Header().Set("Content-Type", "application/problem+json")
 	response.WriteHeader(code)
 	response.Write(problemDoc)
 }
+
+// setRateLimitHeaders sets a Retry-After header, and the RateLimit-Limit,
+// RateLimit-Remaining, and RateLimit-Reset headers described by
+// draft-ietf-httpapi-ratelimit-headers, from the given status. Durations are
+// rounded up to whole seconds so that clients never retry too early.
+func setRateLimitHeaders(response http.ResponseWriter, status *berrors.RateLimitStatus) {
+	seconds := func(d time.Duration) string {
+		return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+	}
+	if status.RetryAfter > 0 {
+		response.Header().Set("Retry-After", seconds(status.RetryAfter))
+	}
+	response.Header().Set("RateLimit-Limit", strconv.FormatInt(status.Limit, 10))
+	response.Header().Set("RateLimit-Remaining", strconv.FormatInt(status.Remaining, 10))
+	response.Header().Set("RateLimit-Reset", seconds(status.ResetIn))
+}
@@ -4,10 +4,12 @@ import (
 	"errors"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/identifier"
 	"github.com/letsencrypt/boulder/log"
+	"github.com/letsencrypt/boulder/probs"
 	"github.com/letsencrypt/boulder/test"
 )
 
@@ -65,6 +67,19 @@ func TestSendErrorSubProblemNamespace(t *testing.T) {
 	  }`)
 }
 
+func TestSendErrorDNSSECBogus(t *testing.T) {
+	rw := httptest.NewRecorder()
+	prob := probs.DNSSECBogus("DNSSEC validation failed")
+	SendError(log.NewMock(), "namespace:test:", rw, &RequestEvent{}, prob, nil)
+
+	// ACME has no type for DNSSEC failures, so they are sent as DNS problems.
+	test.AssertUnmarshaledEquals(t, rw.Body.String(), `{
+		"type": "namespace:test:dns",
+		"detail": "DNSSEC validation failed",
+		"status": 400
+	}`)
+}
+
 func TestSendErrorSubProbLogging(t *testing.T) {
 	rw := httptest.NewRecorder()
 	prob := ProblemDetailsForError((&berrors.BoulderError{
@@ -94,3 +109,29 @@ func TestSendErrorSubProbLogging(t *testing.T) {
 
 	test.AssertEquals(t, logEvent.Error, `400 :: malformed :: dfoop :: bad ["example.com :: malformed :: dfoop :: nop", "what about example.com :: malformed :: dfoop :: nah"]`)
 }
+
+func TestSendErrorRateLimitHeaders(t *testing.T) {
+	rw := httptest.NewRecorder()
+	err := berrors.RateLimitErrorWithStatus(&berrors.RateLimitStatus{
+		Limit:      50,
+		Remaining:  0,
+		RetryAfter: 90*time.Minute + 500*time.Millisecond,
+		ResetIn:    7 * 24 * time.Hour,
+	}, "too many certificates")
+	prob := ProblemDetailsForError(err, "dfoop")
+	SendError(log.NewMock(), "namespace:test:", rw, &RequestEvent{}, prob, err)
+
+	test.AssertEquals(t, rw.Code, 429)
+	test.AssertEquals(t, rw.Header().Get("Retry-After"), "5401")
+	test.AssertEquals(t, rw.Header().Get("RateLimit-Limit"), "50")
+	test.AssertEquals(t, rw.Header().Get("RateLimit-Remaining"), "0")
+	test.AssertEquals(t, rw.Header().Get("RateLimit-Reset"), "604800")
+
+	// A rate limit error without a status gets no headers.
+	rw = httptest.NewRecorder()
+	err = berrors.RateLimitError("too many certificates")
+	prob = ProblemDetailsForError(err, "dfoop")
+	SendError(log.NewMock(), "namespace:test:", rw, &RequestEvent{}, prob, err)
+	test.AssertEquals(t, rw.Header().Get("Retry-After"), "")
+	test.AssertEquals(t, rw.Header().Get("RateLimit-Limit"), "")
+}
@@ -1126,13 +1126,17 @@ func (wfe *WebFrontEndImpl) prepChallengeForDisplay(request *http.Request, authz
 	// Update the challenge URI to be relative to the HTTP request Host
 	challenge.URI = web.RelativeEndpoint(request, fmt.Sprintf("%s%s/%s", challengePath, authz.ID, challenge.StringID()))
 
+	// The records made by remote VAs are kept for auditing, but aren't
+	// shown to the subscriber.
+	challenge.ValidationRecord = challenge.PrimaryValidationRecords()
+
 	// Historically the Type field of a problem was always prefixed with a static
 	// error namespace. To support the V2 API and migrating to the correct IETF
 	// namespace we now prefix the Type with the correct namespace at runtime when
 	// we write the problem JSON to the user. We skip this process if the
 	// challenge error type has already been prefixed with the V1ErrorNS.
 	if challenge.Error != nil && !strings.HasPrefix(string(challenge.Error.Type), probs.V1ErrorNS) {
-		challenge.Error.Type = probs.V1ErrorNS + challenge.Error.Type
+		challenge.Error.Type = probs.V1ErrorNS + probs.ACMEType(challenge.Error.Type)
 	}
 
 	// If the authz has been marked invalid, consider all challenges on that authz
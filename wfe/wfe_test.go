@@ -255,6 +255,26 @@ func (ra *MockRegistrationAuthority) FinalizeOrder(ctx context.Context, _ *rapb.
 	return nil, nil
 }
 
+func (ra *MockRegistrationAuthority) RenewalInfo(ctx context.Context, _ *rapb.RenewalInfoRequest) (*rapb.RenewalInfo, error) {
+	return nil, nil
+}
+
+func (ra *MockRegistrationAuthority) GetRateLimitUsage(ctx context.Context, _ *rapb.GetRateLimitUsageRequest) (*rapb.RateLimitUsages, error) {
+	return nil, nil
+}
+
+func (ra *MockRegistrationAuthority) NewPreAuthorization(ctx context.Context, _ *rapb.NewPreAuthorizationRequest) (*corepb.Authorization, error) {
+	return nil, nil
+}
+
+func (ra *MockRegistrationAuthority) RevokeCertificatesByName(ctx context.Context, _ *rapb.RevokeCertificatesByNameRequest) (*rapb.RevokedSerials, error) {
+	return nil, nil
+}
+
+func (ra *MockRegistrationAuthority) RevokeCertificatesByKey(ctx context.Context, _ *rapb.RevokeCertificatesByKeyRequest) (*rapb.RevokedSerials, error) {
+	return nil, nil
+}
+
 type mockPA struct{}
 
 func (pa *mockPA) ChallengesFor(identifier identifier.ACMEIdentifier) (challenges []core.Challenge, err error) {
@@ -913,9 +933,11 @@ func TestIssueCertificate(t *testing.T) {
 		nil,
 		noopCAA{},
 		0,
+		time.Hour,
 		ctp,
 		nil,
 		nil,
+		nil,
 	)
 	ra.SA = mocks.NewStorageAuthority(fc)
 	ra.CA = &mocks.MockCA{
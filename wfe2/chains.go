@@ -0,0 +1,127 @@
+package wfe2
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/letsencrypt/boulder/issuance"
+)
+
+// certChain is a single certificate chain which may be served following a
+// certificate from its issuer.
+type certChain struct {
+	// pem contains a leading newline and one or more PEM encoded certificates
+	// separated by a newline, sorted from leaf to root.
+	pem []byte
+	// rootSubject is the Subject CommonName of the root which the chain leads
+	// to. The root itself isn't served, so this is taken from the Issuer of the
+	// chain's last certificate.
+	rootSubject string
+}
+
+// chainSet holds the certificate chains served for each issuer. The chains can
+// be replaced while the WFE is running, for instance to stop serving a chain
+// through a cross-sign which is about to expire, but the issuers cannot.
+type chainSet struct {
+	sync.RWMutex
+	chains map[issuance.IssuerNameID][]certChain
+}
+
+// get returns the chains available for the given issuer. The first chain is
+// the default.
+func (cs *chainSet) get(id issuance.IssuerNameID) []certChain {
+	cs.RLock()
+	defer cs.RUnlock()
+	return cs.chains[id]
+}
+
+func (cs *chainSet) set(chains map[issuance.IssuerNameID][]certChain) {
+	cs.Lock()
+	defer cs.Unlock()
+	cs.chains = chains
+}
+
+// parseChains validates the given PEM chains against the issuer certificates,
+// and returns them ready to serve. Every chain must begin with a certificate
+// for the public key of the issuer it is keyed by, and every issuer must have
+// at least one chain.
+func parseChains(
+	certificateChains map[issuance.IssuerNameID][][]byte,
+	issuerCertificates map[issuance.IssuerNameID]*issuance.Certificate,
+) (map[issuance.IssuerNameID][]certChain, error) {
+	if len(certificateChains) == 0 {
+		return nil, errors.New("must provide at least one certificate chain")
+	}
+	chains := make(map[issuance.IssuerNameID][]certChain, len(certificateChains))
+	for id, pemChains := range certificateChains {
+		issuer, ok := issuerCertificates[id]
+		if !ok {
+			return nil, fmt.Errorf("certificate chain provided for unknown issuer %d", id)
+		}
+		for i, pemChain := range pemChains {
+			certs, err := parsePEMChain(pemChain)
+			if err != nil {
+				return nil, fmt.Errorf("chain %d for issuer %d: %w", i, id, err)
+			}
+			if !bytes.Equal(certs[0].RawSubjectPublicKeyInfo, issuer.RawSubjectPublicKeyInfo) ||
+				!bytes.Equal(certs[0].RawSubject, issuer.RawSubject) {
+				return nil, fmt.Errorf("chain %d for issuer %d does not begin with the issuer's certificate", i, id)
+			}
+			chains[id] = append(chains[id], certChain{
+				pem:         pemChain,
+				rootSubject: certs[len(certs)-1].Issuer.CommonName,
+			})
+		}
+	}
+	for id := range issuerCertificates {
+		if len(chains[id]) == 0 {
+			return nil, fmt.Errorf("no certificate chain provided for issuer %d", id)
+		}
+	}
+	return chains, nil
+}
+
+// parsePEMChain parses every certificate in a PEM chain, which must contain
+// nothing else.
+func parsePEMChain(pemChain []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := pemChain
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("PEM block type incorrect, found %q, expected \"CERTIFICATE\"", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, cert)
+	}
+	if len(bytes.TrimSpace(rest)) != 0 {
+		return nil, errors.New("PEM contents had unused remainder input")
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("chain contains no certificates")
+	}
+	return certs, nil
+}
+
+// SetCertificateChains replaces the certificate chains served for each
+// issuer, after validating them in the same way as NewWebFrontEndImpl. If they
+// aren't valid the current chains are left in place.
+func (wfe *WebFrontEndImpl) SetCertificateChains(certificateChains map[issuance.IssuerNameID][][]byte) error {
+	chains, err := parseChains(certificateChains, wfe.issuerCertificates)
+	if err != nil {
+		return err
+	}
+	wfe.certificateChains.set(chains)
+	return nil
+}
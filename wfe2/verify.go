@@ -20,6 +20,7 @@ import (
 	berrors "github.com/letsencrypt/boulder/errors"
 	"github.com/letsencrypt/boulder/nonce"
 	"github.com/letsencrypt/boulder/probs"
+	sapb "github.com/letsencrypt/boulder/sa/proto"
 	"github.com/letsencrypt/boulder/web"
 )
 
@@ -776,3 +777,103 @@ func (wfe *WebFrontEndImpl) validKeyRollover(
 		NewKey: *jwk,
 	}, nil
 }
+
+// validExternalAccountBinding checks that the externalAccountBinding field of
+// a newAccount request is acceptable per RFC 8555 Section 7.3.4. It is assumed
+// that the outer JWS has already been validated using
+// `validSelfAuthenticatedPOST`, and that accountKey is the JWK it was signed
+// with. This function checks that:
+// 1) the binding is a well formed JWS with a supported HMAC algorithm
+// 2) the binding has a "kid" header identifying a known external account key
+// 3) the binding has no "nonce" header and the same "url" as the outer JWS
+// 4) the binding verifies with the external account's MAC key
+// 5) the binding's payload is the same public key as accountKey
+//
+// If the binding is valid the external account's key ID is returned, otherwise
+// a problem is returned.
+func (wfe *WebFrontEndImpl) validExternalAccountBinding(
+	ctx context.Context,
+	eab []byte,
+	accountKey *jose.JSONWebKey,
+	request *http.Request,
+	logEvent *web.RequestEvent) (string, *probs.ProblemDetails) {
+	eabJWS, prob := wfe.parseJWS(eab)
+	if prob != nil {
+		return "", prob
+	}
+	header := eabJWS.Signatures[0].Header
+
+	switch jose.SignatureAlgorithm(header.Algorithm) {
+	case jose.HS256, jose.HS384, jose.HS512:
+	default:
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "EABAlgorithmInvalid"}).Inc()
+		return "", probs.BadSignatureAlgorithm(
+			"externalAccountBinding JWS algorithm %q is not supported, must be one of HS256, HS384, or HS512",
+			header.Algorithm)
+	}
+
+	if header.KeyID == "" {
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "EABNoKeyID"}).Inc()
+		return "", probs.Malformed("externalAccountBinding JWS header parameter 'kid' required")
+	}
+
+	if header.Nonce != "" {
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "EABNonce"}).Inc()
+		return "", probs.Malformed("externalAccountBinding JWS header parameter 'nonce' not allowed")
+	}
+
+	// The outer JWS' URL was already checked against the request by
+	// validSelfAuthenticatedPOST, so it suffices to check the binding's URL
+	// against the request as well.
+	eabURL, ok := header.ExtraHeaders[jose.HeaderKey("url")].(string)
+	if !ok || len(eabURL) == 0 {
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "EABNoURL"}).Inc()
+		return "", probs.Malformed("externalAccountBinding JWS header parameter 'url' required")
+	}
+	expectedURL := url.URL{
+		Scheme: requestProto(request),
+		Host:   request.Host,
+		Path:   request.RequestURI,
+	}
+	if expectedURL.String() != eabURL {
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "EABMismatchedURL"}).Inc()
+		return "", probs.Malformed(fmt.Sprintf(
+			"externalAccountBinding JWS header parameter 'url' incorrect. Expected %q got %q",
+			expectedURL.String(), eabURL))
+	}
+
+	eabKey, err := wfe.SA.GetExternalAccountKey(ctx, &sapb.ExternalAccountKeyID{KeyID: header.KeyID})
+	if err != nil {
+		if errors.Is(err, berrors.NotFound) {
+			wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "EABUnknownKeyID"}).Inc()
+			return "", probs.Unauthorized(fmt.Sprintf("Unknown externalAccountBinding key ID %q", header.KeyID))
+		}
+
+		// If there was an error and it isn't a "Not Found" error, return
+		// a ServerInternal problem since this is unexpected.
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "EABKeyLookupFailed"}).Inc()
+		// Add an error to the log event with the internal error message
+		logEvent.AddError(fmt.Sprintf("Error calling SA.GetExternalAccountKey: %s", err.Error()))
+		return "", probs.ServerInternal(fmt.Sprintf(
+			"Error retrieving externalAccountBinding key ID %q", header.KeyID))
+	}
+
+	payload, err := eabJWS.Verify(eabKey.MacKey)
+	if err != nil {
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "EABVerifyFailed"}).Inc()
+		return "", probs.Unauthorized("externalAccountBinding JWS verification error")
+	}
+
+	var boundKey jose.JSONWebKey
+	err = boundKey.UnmarshalJSON(payload)
+	if err != nil {
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "EABPayloadInvalid"}).Inc()
+		return "", probs.Malformed("externalAccountBinding JWS payload is not a JWK")
+	}
+	if keysEqual, err := core.PublicKeysEqual(boundKey.Key, accountKey.Key); err != nil || !keysEqual {
+		wfe.stats.joseErrorCount.With(prometheus.Labels{"type": "EABWrongKey"}).Inc()
+		return "", probs.Malformed("externalAccountBinding JWS payload does not match account key")
+	}
+
+	return header.KeyID, nil
+}
@@ -1,13 +1,19 @@
 package wfe2
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
 	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"math"
 	"net"
 	"net/http"
 	"strconv"
@@ -56,6 +62,13 @@ const (
 	newOrderPath      = "/acme/new-order"
 	orderPath         = "/acme/order/"
 	finalizeOrderPath = "/acme/finalize/"
+	renewalInfoPath   = "/acme/renewal-info/"
+	rateLimitsPath    = "/acme/rate-limits"
+	ordersPath        = "/acme/orders/"
+	acctCertsPath     = "/acme/acct-certs/"
+	newAuthzPath      = "/acme/new-authz"
+	revokeNamePath    = "/acme/revoke-name"
+	revokeKeyPath     = "/acme/revoke-key"
 
 	getAPIPrefix     = "/get/"
 	getOrderPath     = getAPIPrefix + "order/"
@@ -64,6 +77,18 @@ const (
 	getCertPath      = getAPIPrefix + "cert/"
 )
 
+// renewalInfoRetryAfter is how long clients are asked to wait before polling
+// the renewalInfo endpoint again for the same certificate.
+const renewalInfoRetryAfter = 6 * time.Hour
+
+// orderRetryAfter is how long clients are asked to wait before polling an
+// order which is still processing.
+const orderRetryAfter = 3 * time.Second
+
+// accountListPageSize is the default number of entries in each page of an
+// account's orders or certificates list.
+const accountListPageSize = 100
+
 // WebFrontEndImpl provides all the logic for Boulder's web-facing interface,
 // i.e., ACME.  Its members configure the paths for various ACME functions,
 // plus a few other data items used in ACME.  Its methods are primarily handlers
@@ -75,11 +100,11 @@ type WebFrontEndImpl struct {
 	clk   clock.Clock
 	stats wfe2Stats
 
-	// certificateChains maps IssuerNameIDs to slice of []byte containing a leading
-	// newline and one or more PEM encoded certificates separated by a newline,
-	// sorted from leaf to root. The first []byte is the default certificate chain,
-	// and any subsequent []byte is an alternate certificate chain.
-	certificateChains map[issuance.IssuerNameID][][]byte
+	// certificateChains holds the certificate chains for each IssuerNameID. The
+	// first chain is the default certificate chain, and any subsequent chain is
+	// an alternate certificate chain. It may be replaced at any time by
+	// SetCertificateChains.
+	certificateChains *chainSet
 
 	// issuerCertificates is a map of IssuerNameIDs to issuer certificates built with the
 	// first entry from each of the certificateChains. These certificates are used
@@ -98,6 +123,12 @@ type WebFrontEndImpl struct {
 	// "website" field.
 	DirectoryWebsite string
 
+	// ExternalAccountRequired is used for the /directory response's "meta"
+	// element's "externalAccountRequired" field. When true, newAccount
+	// requests must include a valid externalAccountBinding. It has no effect
+	// unless the ExternalAccountBinding feature is enabled.
+	ExternalAccountRequired bool
+
 	// Allowed prefix for legacy accounts used by verify.go's `lookupJWK`.
 	// See `cmd/boulder-wfe2/main.go`'s comment on the configuration field
 	// `LegacyKeyIDPrefix` for more information.
@@ -129,6 +160,10 @@ type WebFrontEndImpl struct {
 	// match the ones used by the RA.
 	authorizationLifetime        time.Duration
 	pendingAuthorizationLifetime time.Duration
+
+	// The maximum number of entries in each page of an account's orders or
+	// certificates list.
+	accountListPageSize int64
 }
 
 // NewWebFrontEndImpl constructs a web service for Boulder
@@ -149,15 +184,16 @@ func NewWebFrontEndImpl(
 		return WebFrontEndImpl{}, errors.New("must provide at least one issuer certificate")
 	}
 
-	if certificateChains == nil || len(certificateChains) == 0 {
-		return WebFrontEndImpl{}, errors.New("must provide at least one certificate chain")
+	chains, err := parseChains(certificateChains, issuerCertificates)
+	if err != nil {
+		return WebFrontEndImpl{}, err
 	}
 
 	wfe := WebFrontEndImpl{
 		log:                          logger,
 		clk:                          clk,
 		keyPolicy:                    keyPolicy,
-		certificateChains:            certificateChains,
+		certificateChains:            &chainSet{chains: chains},
 		issuerCertificates:           issuerCertificates,
 		stats:                        initStats(stats),
 		remoteNonceService:           remoteNonceService,
@@ -165,6 +201,7 @@ func NewWebFrontEndImpl(
 		staleTimeout:                 staleTimeout,
 		authorizationLifetime:        authorizationLifetime,
 		pendingAuthorizationLifetime: pendingAuthorizationLifetime,
+		accountListPageSize:          accountListPageSize,
 	}
 
 	if wfe.remoteNonceService == nil {
@@ -370,6 +407,10 @@ func (wfe *WebFrontEndImpl) Handler(stats prometheus.Registerer) http.Handler {
 	wfe.HandleFunc(m, rolloverPath, wfe.KeyRollover, "POST")
 	wfe.HandleFunc(m, newOrderPath, wfe.NewOrder, "POST")
 	wfe.HandleFunc(m, finalizeOrderPath, wfe.FinalizeOrder, "POST")
+	wfe.HandleFunc(m, rateLimitsPath, wfe.RateLimits, "POST")
+	wfe.HandleFunc(m, newAuthzPath, wfe.NewAuthorization, "POST")
+	wfe.HandleFunc(m, revokeNamePath, wfe.RevokeCertificatesByName, "POST")
+	wfe.HandleFunc(m, revokeKeyPath, wfe.RevokeCertificatesByKey, "POST")
 
 	// GETable and POST-as-GETable ACME endpoints
 	wfe.HandleFunc(m, directoryPath, wfe.Directory, "GET", "POST")
@@ -381,11 +422,15 @@ func (wfe *WebFrontEndImpl) Handler(stats prometheus.Registerer) http.Handler {
 	wfe.HandleFunc(m, authzPath, wfe.Authorization, "GET", "POST")
 	wfe.HandleFunc(m, challengePath, wfe.Challenge, "GET", "POST")
 	wfe.HandleFunc(m, certPath, wfe.Certificate, "GET", "POST")
+	wfe.HandleFunc(m, ordersPath, wfe.Orders, "POST")
+	wfe.HandleFunc(m, acctCertsPath, wfe.AccountCertificates, "POST")
 	// Boulder-specific GET-able resource endpoints
 	wfe.HandleFunc(m, getOrderPath, wfe.GetOrder, "GET")
 	wfe.HandleFunc(m, getAuthzPath, wfe.Authorization, "GET")
 	wfe.HandleFunc(m, getChallengePath, wfe.Challenge, "GET")
 	wfe.HandleFunc(m, getCertPath, wfe.Certificate, "GET")
+	// Endpoint for draft-aaron-ari
+	wfe.HandleFunc(m, renewalInfoPath, wfe.RenewalInfo, "GET")
 
 	// We don't use our special HandleFunc for "/" because it matches everything,
 	// meaning we can wind up returning 405 when we mean to return 404. See
@@ -452,6 +497,26 @@ func (wfe *WebFrontEndImpl) Directory(
 		"keyChange":  rolloverPath,
 	}
 
+	if features.Enabled(features.ServeRenewalInfo) {
+		directoryEndpoints["renewalInfo"] = renewalInfoPath
+	}
+
+	if features.Enabled(features.ServeRateLimitUsage) {
+		directoryEndpoints["rateLimits"] = rateLimitsPath
+	}
+
+	if features.Enabled(features.ServeNewAuthz) {
+		directoryEndpoints["newAuthz"] = newAuthzPath
+	}
+
+	if features.Enabled(features.ServeRevokeByName) {
+		directoryEndpoints["revokeName"] = revokeNamePath
+	}
+
+	if features.Enabled(features.ServeRevokeByKey) {
+		directoryEndpoints["revokeKey"] = revokeKeyPath
+	}
+
 	if request.Method == http.MethodPost {
 		acct, prob := wfe.validPOSTAsGETForAccount(request, ctx, logEvent)
 		if prob != nil {
@@ -486,6 +551,11 @@ func (wfe *WebFrontEndImpl) Directory(
 	if wfe.DirectoryWebsite != "" {
 		metaMap["website"] = wfe.DirectoryWebsite
 	}
+	// The "meta" directory entry may also indicate that newAccount requests
+	// must be bound to an external account.
+	if wfe.ExternalAccountRequired && features.Enabled(features.ExternalAccountBinding) {
+		metaMap["externalAccountRequired"] = true
+	}
 	directoryEndpoints["meta"] = metaMap
 
 	response.Header().Set("Content-Type", "application/json")
@@ -559,9 +629,10 @@ func (wfe *WebFrontEndImpl) NewAccount(
 	}
 
 	var accountCreateRequest struct {
-		Contact              *[]string `json:"contact"`
-		TermsOfServiceAgreed bool      `json:"termsOfServiceAgreed"`
-		OnlyReturnExisting   bool      `json:"onlyReturnExisting"`
+		Contact                *[]string       `json:"contact"`
+		TermsOfServiceAgreed   bool            `json:"termsOfServiceAgreed"`
+		OnlyReturnExisting     bool            `json:"onlyReturnExisting"`
+		ExternalAccountBinding json.RawMessage `json:"externalAccountBinding"`
 	}
 
 	err := json.Unmarshal(body, &accountCreateRequest)
@@ -584,7 +655,7 @@ func (wfe *WebFrontEndImpl) NewAccount(
 		logEvent.Requester = acct.ID
 		addRequesterHeader(response, acct.ID)
 
-		prepAccountForDisplay(&acct)
+		prepAccountForDisplay(request, &acct)
 
 		err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, acct)
 		if err != nil {
@@ -618,6 +689,23 @@ func (wfe *WebFrontEndImpl) NewAccount(
 		return
 	}
 
+	var externalAccountID string
+	if features.Enabled(features.ExternalAccountBinding) {
+		if len(accountCreateRequest.ExternalAccountBinding) > 0 {
+			keyID, prob := wfe.validExternalAccountBinding(ctx, accountCreateRequest.ExternalAccountBinding, key, request, logEvent)
+			if prob != nil {
+				wfe.sendError(response, logEvent, prob, nil)
+				return
+			}
+			externalAccountID = keyID
+			logEvent.Extra["ExternalAccountID"] = keyID
+		} else if wfe.ExternalAccountRequired {
+			wfe.sendError(response, logEvent, probs.ExternalAccountRequired(
+				"an externalAccountBinding is required to create a new account"), nil)
+			return
+		}
+	}
+
 	ip, err := extractRequesterIP(request)
 	if err != nil {
 		wfe.sendError(
@@ -630,10 +718,11 @@ func (wfe *WebFrontEndImpl) NewAccount(
 	}
 
 	acct, err := wfe.RA.NewRegistration(ctx, core.Registration{
-		Contact:   accountCreateRequest.Contact,
-		Agreement: wfe.SubscriberAgreementURL,
-		Key:       key,
-		InitialIP: ip,
+		Contact:           accountCreateRequest.Contact,
+		Agreement:         wfe.SubscriberAgreementURL,
+		Key:               key,
+		InitialIP:         ip,
+		ExternalAccountID: externalAccountID,
 	})
 	if err != nil {
 		if errors.Is(err, berrors.Duplicate) {
@@ -664,7 +753,7 @@ func (wfe *WebFrontEndImpl) NewAccount(
 		response.Header().Add("Link", link(wfe.SubscriberAgreementURL, "terms-of-service"))
 	}
 
-	prepAccountForDisplay(&acct)
+	prepAccountForDisplay(request, &acct)
 
 	err = wfe.writeJsonResponse(response, logEvent, http.StatusCreated, acct)
 	if err != nil {
@@ -784,20 +873,9 @@ func (wfe *WebFrontEndImpl) processRevocation(
 	}
 
 	// Verify the revocation reason supplied is allowed
-	reason := revocation.Reason(0)
-	if revokeRequest.Reason != nil {
-		if _, present := revocation.UserAllowedReasons[*revokeRequest.Reason]; !present {
-			reasonStr, ok := revocation.ReasonToString[revocation.Reason(*revokeRequest.Reason)]
-			if !ok {
-				reasonStr = "unknown"
-			}
-			return probs.BadRevocationReason(
-				"unsupported revocation reason code provided: %s (%d). Supported reasons: %s",
-				reasonStr,
-				*revokeRequest.Reason,
-				revocation.UserAllowedReasonsMessage)
-		}
-		reason = *revokeRequest.Reason
+	reason, prob := userRevocationReason(revokeRequest.Reason)
+	if prob != nil {
+		return prob
 	}
 
 	// Revoke the certificate. AcctID may be 0 if there is no associated account
@@ -824,6 +902,27 @@ func (wfe *WebFrontEndImpl) processRevocation(
 	return nil
 }
 
+// userRevocationReason returns the revocation reason supplied in a request,
+// defaulting to unspecified if there was none, or a problem if it is not one
+// which users are allowed to request.
+func userRevocationReason(requested *revocation.Reason) (revocation.Reason, *probs.ProblemDetails) {
+	if requested == nil {
+		return revocation.Reason(0), nil
+	}
+	if _, present := revocation.UserAllowedReasons[*requested]; !present {
+		reasonStr, ok := revocation.ReasonToString[*requested]
+		if !ok {
+			reasonStr = "unknown"
+		}
+		return 0, probs.BadRevocationReason(
+			"unsupported revocation reason code provided: %s (%d). Supported reasons: %s",
+			reasonStr,
+			*requested,
+			revocation.UserAllowedReasonsMessage)
+	}
+	return *requested, nil
+}
+
 // revokeCertByKeyID processes an outer JWS as a revocation request that is
 // authenticated by a KeyID and the associated account.
 func (wfe *WebFrontEndImpl) revokeCertByKeyID(
@@ -872,7 +971,7 @@ func (wfe *WebFrontEndImpl) revokeCertByKeyID(
 			return nil
 		}
 		// Otherwise check if the account, while not the owner, has equivalent authorizations
-		valid, err := wfe.acctHoldsAuthorizations(ctx, acct.ID, parsedCertificate.DNSNames)
+		valid, err := wfe.acctHoldsAuthorizations(ctx, acct.ID, core.UniqueLowerNamesAndIPs(parsedCertificate.DNSNames, parsedCertificate.IPAddresses))
 		if err != nil {
 			return probs.ServerInternal("Failed to retrieve authorizations for names in certificate")
 		}
@@ -1061,7 +1160,12 @@ func (wfe *WebFrontEndImpl) Challenge(
 // for display in a JSON response. Primarily it papers over legacy ACME v1
 // features or non-standard details internal to Boulder we don't want clients to
 // rely on.
-func prepAccountForDisplay(acct *core.Registration) {
+func prepAccountForDisplay(request *http.Request, acct *core.Registration) {
+	if features.Enabled(features.ServeAccountOrders) {
+		acct.Orders = web.RelativeEndpoint(request, fmt.Sprintf("%s%d", ordersPath, acct.ID))
+		acct.Certificates = web.RelativeEndpoint(request, fmt.Sprintf("%s%d", acctCertsPath, acct.ID))
+	}
+
 	// Zero out the account ID so that it isn't marshalled. RFC 8555 specifies
 	// using the Location header for learning the account ID.
 	acct.ID = 0
@@ -1088,13 +1192,17 @@ func (wfe *WebFrontEndImpl) prepChallengeForDisplay(request *http.Request, authz
 	// ACMEv2 never sends the KeyAuthorization back in a challenge object.
 	challenge.ProvidedKeyAuthorization = ""
 
+	// The records made by remote VAs are kept for auditing, but aren't
+	// shown to the subscriber.
+	challenge.ValidationRecord = challenge.PrimaryValidationRecords()
+
 	// Historically the Type field of a problem was always prefixed with a static
 	// error namespace. To support the V2 API and migrating to the correct IETF
 	// namespace we now prefix the Type with the correct namespace at runtime when
 	// we write the problem JSON to the user. We skip this process if the
 	// challenge error type has already been prefixed with the V1ErrorNS.
 	if challenge.Error != nil && !strings.HasPrefix(string(challenge.Error.Type), probs.V1ErrorNS) {
-		challenge.Error.Type = probs.V2ErrorNS + challenge.Error.Type
+		challenge.Error.Type = probs.V2ErrorNS + probs.ACMEType(challenge.Error.Type)
 	}
 
 	// If the authz has been marked invalid, consider all challenges on that authz
@@ -1289,7 +1397,7 @@ func (wfe *WebFrontEndImpl) Account(
 		response.Header().Add("Link", link(wfe.SubscriberAgreementURL, "terms-of-service"))
 	}
 
-	prepAccountForDisplay(currAcct)
+	prepAccountForDisplay(request, currAcct)
 
 	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, currAcct)
 	if err != nil {
@@ -1517,11 +1625,13 @@ func (wfe *WebFrontEndImpl) Certificate(ctx context.Context, logEvent *web.Reque
 	}
 
 	requestedChain := 0
+	explicitChain := false
 	serial := request.URL.Path
 
 	// An alternate chain may be requested with the request path {serial}/{chain}, where chain
 	// is a number - an index into the slice of chains for the issuer. If a specific chain is
-	// not requested, then it defaults to zero - the default certificate chain for the issuer.
+	// not requested, then it defaults to zero - the default certificate chain for the issuer,
+	// unless the preferredChain query parameter names the root of another chain.
 	serialAndChain := strings.SplitN(serial, "/", 2)
 	if len(serialAndChain) == 2 {
 		idx, err := strconv.Atoi(serialAndChain[1])
@@ -1532,6 +1642,7 @@ func (wfe *WebFrontEndImpl) Certificate(ctx context.Context, logEvent *web.Reque
 		}
 		serial = serialAndChain[0]
 		requestedChain = idx
+		explicitChain = true
 	}
 
 	// Certificate paths consist of the CertBase path, plus exactly sixteen hex
@@ -1593,8 +1704,8 @@ func (wfe *WebFrontEndImpl) Certificate(ctx context.Context, logEvent *web.Reque
 		}
 
 		issuerNameID := issuance.GetIssuerNameID(parsedCert)
-		availableChains, ok := wfe.certificateChains[issuerNameID]
-		if !ok || len(availableChains) == 0 {
+		availableChains := wfe.certificateChains.get(issuerNameID)
+		if len(availableChains) == 0 {
 			// If there is no wfe.certificateChains entry for the IssuerNameID then
 			// we can't provide a chain for this cert. If the certificate is expired,
 			// just return the bare cert. If the cert is still valid, then there is
@@ -1610,6 +1721,18 @@ func (wfe *WebFrontEndImpl) Certificate(ctx context.Context, logEvent *web.Reque
 			)
 		}
 
+		// A preferred root is only a preference: if no chain leads to it, the
+		// default chain is served instead.
+		if preferred := request.URL.Query().Get("preferredChain"); preferred != "" && !explicitChain {
+			for chainID, chain := range availableChains {
+				if chain.rootSubject == preferred {
+					requestedChain = chainID
+					logEvent.Extra["PreferredChain"] = preferred
+					break
+				}
+			}
+		}
+
 		// If the requested chain is outside the bounds of the available chains,
 		// then it is an error by the client - not found.
 		if requestedChain < 0 || requestedChain >= len(availableChains) {
@@ -1639,7 +1762,7 @@ func (wfe *WebFrontEndImpl) Certificate(ctx context.Context, logEvent *web.Reque
 		}
 
 		// Prepend the chain with the leaf certificate
-		return append(leafPEM, availableChains[requestedChain]...), nil
+		return append(leafPEM, availableChains[requestedChain].pem...), nil
 	}()
 	if prob != nil {
 		wfe.sendError(response, logEvent, prob, nil)
@@ -1828,7 +1951,7 @@ func (wfe *WebFrontEndImpl) KeyRollover(
 		return
 	}
 
-	prepAccountForDisplay(&updatedAcct)
+	prepAccountForDisplay(request, &updatedAcct)
 
 	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, updatedAcct)
 	if err != nil {
@@ -1844,16 +1967,19 @@ type orderJSON struct {
 	Finalize       string                      `json:"finalize"`
 	Certificate    string                      `json:"certificate,omitempty"`
 	Error          *probs.ProblemDetails       `json:"error,omitempty"`
+	Profile        string                      `json:"profile,omitempty"`
+	NotBefore      *time.Time                  `json:"notBefore,omitempty"`
+	NotAfter       *time.Time                  `json:"notAfter,omitempty"`
 }
 
 // orderToOrderJSON converts a *corepb.Order instance into an orderJSON struct
 // that is returned in HTTP API responses. It will convert the order names to
-// DNS type identifiers and additionally create absolute URLs for the finalize
-// URL and the ceritificate URL as appropriate.
+// DNS or IP type identifiers and additionally create absolute URLs for the
+// finalize URL and the ceritificate URL as appropriate.
 func (wfe *WebFrontEndImpl) orderToOrderJSON(request *http.Request, order *corepb.Order) orderJSON {
 	idents := make([]identifier.ACMEIdentifier, len(order.Names))
 	for i, name := range order.Names {
-		idents[i] = identifier.ACMEIdentifier{Type: identifier.DNS, Value: name}
+		idents[i] = identifier.FromName(name)
 	}
 	finalizeURL := web.RelativeEndpoint(request,
 		fmt.Sprintf("%s%d/%d", finalizeOrderPath, order.RegistrationID, order.Id))
@@ -1862,6 +1988,15 @@ func (wfe *WebFrontEndImpl) orderToOrderJSON(request *http.Request, order *corep
 		Expires:     time.Unix(0, order.Expires).UTC(),
 		Identifiers: idents,
 		Finalize:    finalizeURL,
+		Profile:     order.CertificateProfileName,
+	}
+	if order.NotBefore != 0 {
+		notBefore := time.Unix(0, order.NotBefore).UTC()
+		respObj.NotBefore = &notBefore
+	}
+	if order.NotAfter != 0 {
+		notAfter := time.Unix(0, order.NotAfter).UTC()
+		respObj.NotAfter = &notAfter
 	}
 	// If there is an order error, prefix its type with the V2 namespace
 	if order.Error != nil {
@@ -1898,12 +2033,14 @@ func (wfe *WebFrontEndImpl) NewOrder(
 		return
 	}
 
-	// We only allow specifying Identifiers in a new order request - if the
-	// `notBefore` and/or `notAfter` fields described in Section 7.4 of acme-08
-	// are sent we return a probs.Malformed as we do not support them
+	// A new order request may specify Identifiers, a certificate Profile, and
+	// the `notBefore` and/or `notAfter` fields described in Section 7.4 of RFC
+	// 8555. The Profile and the validity window, if any, are validated by the
+	// RA.
 	var newOrderRequest struct {
 		Identifiers         []identifier.ACMEIdentifier `json:"identifiers"`
 		NotBefore, NotAfter string
+		Profile             string `json:"profile"`
 	}
 	err := json.Unmarshal(body, &newOrderRequest)
 	if err != nil {
@@ -1917,18 +2054,34 @@ func (wfe *WebFrontEndImpl) NewOrder(
 			probs.Malformed("NewOrder request did not specify any identifiers"), nil)
 		return
 	}
-	if newOrderRequest.NotBefore != "" || newOrderRequest.NotAfter != "" {
-		wfe.sendError(response, logEvent, probs.Malformed("NotBefore and NotAfter are not supported"), nil)
-		return
+	var notBefore, notAfter int64
+	if newOrderRequest.NotBefore != "" {
+		t, err := time.Parse(time.RFC3339, newOrderRequest.NotBefore)
+		if err != nil {
+			wfe.sendError(response, logEvent, probs.Malformed("Invalid NotBefore, must be an RFC 3339 timestamp"), err)
+			return
+		}
+		notBefore = t.UnixNano()
+	}
+	if newOrderRequest.NotAfter != "" {
+		t, err := time.Parse(time.RFC3339, newOrderRequest.NotAfter)
+		if err != nil {
+			wfe.sendError(response, logEvent, probs.Malformed("Invalid NotAfter, must be an RFC 3339 timestamp"), err)
+			return
+		}
+		notAfter = t.UnixNano()
 	}
 
-	// Collect up all of the DNS identifier values into a []string for subsequent
-	// layers to process. We reject anything with a non-DNS type identifier here.
+	// Collect up all of the DNS and IP identifier values into a []string for
+	// subsequent layers to process. We reject anything with a non-DNS, non-IP
+	// type identifier here. Subsequent layers infer the identifier type from
+	// the value, so we also reject any identifier whose value doesn't match its
+	// type.
 	names := make([]string, len(newOrderRequest.Identifiers))
 	for i, ident := range newOrderRequest.Identifiers {
-		if ident.Type != identifier.DNS {
+		if ident.Type != identifier.DNS && ident.Type != identifier.IP {
 			wfe.sendError(response, logEvent,
-				probs.Malformed("NewOrder request included invalid non-DNS type identifier: type %q, value %q",
+				probs.Malformed("NewOrder request included unsupported type identifier: type %q, value %q",
 					ident.Type, ident.Value),
 				nil)
 			return
@@ -1937,12 +2090,22 @@ func (wfe *WebFrontEndImpl) NewOrder(
 			wfe.sendError(response, logEvent, probs.Malformed("NewOrder request included empty domain name"), nil)
 			return
 		}
+		if identifier.FromName(ident.Value).Type != ident.Type {
+			wfe.sendError(response, logEvent,
+				probs.Malformed("NewOrder request included %s type identifier with invalid value %q",
+					ident.Type, ident.Value),
+				nil)
+			return
+		}
 		names[i] = ident.Value
 	}
 
 	order, err := wfe.RA.NewOrder(ctx, &rapb.NewOrderRequest{
-		RegistrationID: acct.ID,
-		Names:          names,
+		RegistrationID:         acct.ID,
+		Names:                  names,
+		CertificateProfileName: newOrderRequest.Profile,
+		NotBefore:              notBefore,
+		NotAfter:               notAfter,
 	})
 	if err != nil {
 		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error creating new order"), err)
@@ -1962,6 +2125,217 @@ func (wfe *WebFrontEndImpl) NewOrder(
 	}
 }
 
+// NewAuthorization is used by clients to pre-authorize an identifier before
+// placing an order for it, as described in RFC 8555 Section 7.4.1. Wildcard
+// identifiers can't be pre-authorized, since they are only valid in orders.
+func (wfe *WebFrontEndImpl) NewAuthorization(
+	ctx context.Context,
+	logEvent *web.RequestEvent,
+	response http.ResponseWriter,
+	request *http.Request) {
+	if !features.Enabled(features.ServeNewAuthz) {
+		wfe.sendError(response, logEvent, probs.NotFound("Feature not enabled"), nil)
+		return
+	}
+
+	body, _, acct, prob := wfe.validPOSTForAccount(request, ctx, logEvent)
+	addRequesterHeader(response, logEvent.Requester)
+	if prob != nil {
+		// validPOSTForAccount handles its own setting of logEvent.Errors
+		wfe.sendError(response, logEvent, prob, nil)
+		return
+	}
+
+	var newAuthzRequest struct {
+		Identifier identifier.ACMEIdentifier `json:"identifier"`
+	}
+	err := json.Unmarshal(body, &newAuthzRequest)
+	if err != nil {
+		wfe.sendError(response, logEvent,
+			probs.Malformed("Unable to unmarshal NewAuthorization request body"), err)
+		return
+	}
+
+	ident := newAuthzRequest.Identifier
+	if ident.Type != identifier.DNS && ident.Type != identifier.IP {
+		wfe.sendError(response, logEvent,
+			probs.Malformed("NewAuthorization request included unsupported type identifier: type %q, value %q",
+				ident.Type, ident.Value),
+			nil)
+		return
+	}
+	if ident.Value == "" {
+		wfe.sendError(response, logEvent, probs.Malformed("NewAuthorization request included empty identifier value"), nil)
+		return
+	}
+	if identifier.FromName(ident.Value).Type != ident.Type {
+		wfe.sendError(response, logEvent,
+			probs.Malformed("NewAuthorization request included %s type identifier with invalid value %q",
+				ident.Type, ident.Value),
+			nil)
+		return
+	}
+	if strings.HasPrefix(ident.Value, "*.") {
+		wfe.sendError(response, logEvent,
+			probs.RejectedIdentifier("Wildcard identifiers can't be pre-authorized, use newOrder instead"), nil)
+		return
+	}
+	logEvent.DNSName = ident.Value
+
+	authzPB, err := wfe.RA.NewPreAuthorization(ctx, &rapb.NewPreAuthorizationRequest{
+		RegistrationID:  acct.ID,
+		IdentifierType:  string(ident.Type),
+		IdentifierValue: ident.Value,
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error creating new authorization"), err)
+		return
+	}
+	authz, err := bgrpc.PBToAuthz(authzPB)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error unmarshaling authorization"), err)
+		return
+	}
+	logEvent.Created = authz.ID
+
+	response.Header().Set("Location", urlForAuthz(authz, request))
+	wfe.prepAuthorizationForDisplay(request, &authz)
+
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusCreated, authz)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error marshaling authz"), err)
+		return
+	}
+}
+
+// RevokeCertificatesByName is used by an account which holds a valid
+// authorization for a domain to revoke every unexpired certificate naming that
+// domain, including those requested by other accounts. It responds with the
+// URLs of the certificates which were revoked.
+func (wfe *WebFrontEndImpl) RevokeCertificatesByName(
+	ctx context.Context,
+	logEvent *web.RequestEvent,
+	response http.ResponseWriter,
+	request *http.Request) {
+	if !features.Enabled(features.ServeRevokeByName) {
+		wfe.sendError(response, logEvent, probs.NotFound("Feature not enabled"), nil)
+		return
+	}
+
+	body, _, acct, prob := wfe.validPOSTForAccount(request, ctx, logEvent)
+	addRequesterHeader(response, logEvent.Requester)
+	if prob != nil {
+		// validPOSTForAccount handles its own setting of logEvent.Errors
+		wfe.sendError(response, logEvent, prob, nil)
+		return
+	}
+
+	var revokeRequest struct {
+		Identifier identifier.ACMEIdentifier `json:"identifier"`
+		Reason     *revocation.Reason        `json:"reason"`
+	}
+	err := json.Unmarshal(body, &revokeRequest)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.Malformed("Unable to JSON parse revoke request"), err)
+		return
+	}
+
+	ident := revokeRequest.Identifier
+	if ident.Type != identifier.DNS {
+		wfe.sendError(response, logEvent,
+			probs.Malformed("Revocation by name is only supported for dns type identifiers, not %q", ident.Type), nil)
+		return
+	}
+	if ident.Value == "" || strings.HasPrefix(ident.Value, "*.") {
+		wfe.sendError(response, logEvent,
+			probs.Malformed("Revocation by name requires a non-wildcard identifier value"), nil)
+		return
+	}
+	logEvent.DNSName = ident.Value
+
+	reason, prob := userRevocationReason(revokeRequest.Reason)
+	if prob != nil {
+		wfe.sendError(response, logEvent, prob, nil)
+		return
+	}
+
+	revoked, err := wfe.RA.RevokeCertificatesByName(ctx, &rapb.RevokeCertificatesByNameRequest{
+		RegistrationID: acct.ID,
+		Domain:         ident.Value,
+		Code:           int64(reason),
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Failed to revoke certificates"), err)
+		return
+	}
+	wfe.writeRevokedCertificates(response, logEvent, request, revoked.Serials)
+}
+
+// RevokeCertificatesByKey lets anyone holding a compromised key report it,
+// without needing an ACME account or client. The request body is a CSR, in
+// PEM or DER form, signed by the compromised key as proof of possession, whose
+// common name must be the phrase the RA expects. The RA blocks the key and
+// revokes every unexpired certificate which uses it. It responds with the URLs
+// of the certificates which were revoked.
+func (wfe *WebFrontEndImpl) RevokeCertificatesByKey(
+	ctx context.Context,
+	logEvent *web.RequestEvent,
+	response http.ResponseWriter,
+	request *http.Request) {
+	if !features.Enabled(features.ServeRevokeByKey) {
+		wfe.sendError(response, logEvent, probs.NotFound("Feature not enabled"), nil)
+		return
+	}
+
+	if request.Body == nil {
+		wfe.sendError(response, logEvent, probs.Malformed("No body on POST"), nil)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(nil, request.Body, maxRequestSize))
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.Malformed("Unable to read request body"), err)
+		return
+	}
+	csrDER := body
+	if block, _ := pem.Decode(body); block != nil {
+		csrDER = block.Bytes
+	}
+
+	// Check for a malformed CSR early to avoid unnecessary RPCs. The RA checks
+	// its signature and common name.
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.BadCSR("Error parsing certificate request: %s", err), err)
+		return
+	}
+	logEvent.Extra["KeyType"] = web.KeyTypeToString(csr.PublicKey)
+
+	revoked, err := wfe.RA.RevokeCertificatesByKey(ctx, &rapb.RevokeCertificatesByKeyRequest{Csr: csrDER})
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Failed to revoke certificates"), err)
+		return
+	}
+	wfe.writeRevokedCertificates(response, logEvent, request, revoked.Serials)
+}
+
+// writeRevokedCertificates responds to a request which revoked the
+// certificates with the given serials with a list of their URLs.
+func (wfe *WebFrontEndImpl) writeRevokedCertificates(response http.ResponseWriter, logEvent *web.RequestEvent, request *http.Request, serials []string) {
+	logEvent.Extra["RevokedSerials"] = serials
+
+	certs := make([]string, len(serials))
+	for i, serial := range serials {
+		certs[i] = web.RelativeEndpoint(request, fmt.Sprintf("%s%s", certPath, serial))
+	}
+	err := wfe.writeJsonResponse(response, logEvent, http.StatusOK, struct {
+		Certificates []string `json:"certificates"`
+	}{certs})
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error marshaling revoked certificates"), err)
+		return
+	}
+}
+
 // GetOrder is used to retrieve a existing order object
 func (wfe *WebFrontEndImpl) GetOrder(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
 	if features.Enabled(features.MandatoryPOSTAsGET) && request.Method != http.MethodPost && !requiredStale(request, logEvent) {
@@ -2028,6 +2402,10 @@ func (wfe *WebFrontEndImpl) GetOrder(ctx context.Context, logEvent *web.RequestE
 		return
 	}
 
+	if order.Status == string(core.StatusProcessing) {
+		response.Header().Set("Retry-After", fmt.Sprintf("%d", int(orderRetryAfter.Seconds())))
+	}
+
 	respObj := wfe.orderToOrderJSON(request, order)
 	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, respObj)
 	if err != nil {
@@ -2146,6 +2524,9 @@ func (wfe *WebFrontEndImpl) FinalizeOrder(ctx context.Context, logEvent *web.Req
 	orderURL := web.RelativeEndpoint(request,
 		fmt.Sprintf("%s%d/%d", orderPath, acct.ID, updatedOrder.Id))
 	response.Header().Set("Location", orderURL)
+	if updatedOrder.Status == string(core.StatusProcessing) {
+		response.Header().Set("Retry-After", fmt.Sprintf("%d", int(orderRetryAfter.Seconds())))
+	}
 
 	respObj := wfe.orderToOrderJSON(request, updatedOrder)
 	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, respObj)
@@ -2170,3 +2551,295 @@ func extractRequesterIP(req *http.Request) (net.IP, error) {
 func urlForAuthz(authz core.Authorization, request *http.Request) string {
 	return web.RelativeEndpoint(request, authzPath+string(authz.ID))
 }
+
+// RenewalInfo is used to get information about the suggested renewal window
+// for the given certificate. It only accepts unauthenticated GET requests.
+// Certificates are identified by the hex-encoded SHA-256 hash of their
+// issuer's public key (computed the same way as the issuerKeyHash of an OCSP
+// CertID) and their hex-encoded serial, separated by a slash.
+func (wfe *WebFrontEndImpl) RenewalInfo(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
+	if !features.Enabled(features.ServeRenewalInfo) {
+		wfe.sendError(response, logEvent, probs.NotFound("Feature not enabled"), nil)
+		return
+	}
+
+	keyHashAndSerial := strings.SplitN(request.URL.Path, "/", 2)
+	if len(keyHashAndSerial) != 2 {
+		wfe.sendError(response, logEvent, probs.NotFound("Path must be of the form 'issuerKeyHash/serial'"), nil)
+		return
+	}
+	keyHash, serial := keyHashAndSerial[0], keyHashAndSerial[1]
+
+	if !core.ValidSerial(serial) {
+		wfe.sendError(response, logEvent, probs.NotFound("Certificate not found"),
+			fmt.Errorf("certificate serial provided was not valid: %s", serial))
+		return
+	}
+	logEvent.Extra["RequestedSerial"] = serial
+
+	issuer, err := wfe.issuerByKeyHash(keyHash)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.NotFound("Certificate not found"), err)
+		return
+	}
+
+	renewalInfo, err := wfe.RA.RenewalInfo(ctx, &rapb.RenewalInfoRequest{
+		IssuerID: int64(issuer.ID()),
+		Serial:   serial,
+	})
+	if err != nil {
+		if errors.Is(err, berrors.NotFound) {
+			wfe.sendError(response, logEvent, probs.NotFound("Certificate not found"), err)
+			return
+		}
+		wfe.sendError(response, logEvent, probs.ServerInternal("Failed to get renewal info"), err)
+		return
+	}
+
+	response.Header().Set("Retry-After", fmt.Sprintf("%d", int(renewalInfoRetryAfter.Seconds())))
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, core.RenewalInfo{
+		SuggestedWindow: core.SuggestedWindow{
+			Start: time.Unix(0, renewalInfo.WindowStart).UTC(),
+			End:   time.Unix(0, renewalInfo.WindowEnd).UTC(),
+		},
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error marshalling renewalInfo"), err)
+		return
+	}
+}
+
+// RateLimits reports the requesting account's usage of each of the rate
+// limits which apply to it. A POST-as-GET request reports the limits counted
+// against the account as a whole, and any failed authorization limits it is
+// close to. A POST request may instead list identifiers, in the same form as
+// a newOrder request, to also report the certificate limits which would apply
+// to an order for them.
+func (wfe *WebFrontEndImpl) RateLimits(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
+	if !features.Enabled(features.ServeRateLimitUsage) {
+		wfe.sendError(response, logEvent, probs.NotFound("Feature not enabled"), nil)
+		return
+	}
+
+	body, _, acct, prob := wfe.validPOSTForAccount(request, ctx, logEvent)
+	addRequesterHeader(response, logEvent.Requester)
+	if prob != nil {
+		// validPOSTForAccount handles its own setting of logEvent.Errors
+		wfe.sendError(response, logEvent, prob, nil)
+		return
+	}
+
+	var names []string
+	if len(body) == 0 {
+		logEvent.Method = "POST-as-GET"
+	} else {
+		var rateLimitsRequest struct {
+			Identifiers []identifier.ACMEIdentifier `json:"identifiers"`
+		}
+		err := json.Unmarshal(body, &rateLimitsRequest)
+		if err != nil {
+			wfe.sendError(response, logEvent,
+				probs.Malformed("Unable to unmarshal rateLimits request body"), err)
+			return
+		}
+		for _, ident := range rateLimitsRequest.Identifiers {
+			if (ident.Type != identifier.DNS && ident.Type != identifier.IP) ||
+				identifier.FromName(ident.Value).Type != ident.Type {
+				wfe.sendError(response, logEvent,
+					probs.Malformed("rateLimits request included invalid identifier: type %q, value %q",
+						ident.Type, ident.Value),
+					nil)
+				return
+			}
+			names = append(names, ident.Value)
+		}
+	}
+
+	usages, err := wfe.RA.GetRateLimitUsage(ctx, &rapb.GetRateLimitUsageRequest{
+		RegistrationID: acct.ID,
+		Names:          names,
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, web.ProblemDetailsForError(err, "Error getting rate limit usage"), err)
+		return
+	}
+
+	// Round durations up, so that clients never retry too early.
+	seconds := func(nanos int64) int64 {
+		return int64(math.Ceil(time.Duration(nanos).Seconds()))
+	}
+	resp := core.RateLimitUsages{Limits: make([]core.RateLimitUsage, len(usages.Usages))}
+	for i, usage := range usages.Usages {
+		resp.Limits[i] = core.RateLimitUsage{
+			Name:       usage.LimitName,
+			Key:        usage.Key,
+			Limit:      usage.Limit,
+			Remaining:  usage.Remaining,
+			Window:     seconds(usage.Window),
+			RetryAfter: seconds(usage.RetryAfter),
+			Reset:      seconds(usage.ResetIn),
+		}
+	}
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, resp)
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error marshalling rate limit usage"), err)
+		return
+	}
+}
+
+// accountListPage authenticates a POST-as-GET request for a page of one of the
+// requesting account's lists, whose path is of the form "acctID" for the first
+// page or "acctID/afterID" for later ones. It returns the account and the ID
+// after which the page starts.
+func (wfe *WebFrontEndImpl) accountListPage(
+	ctx context.Context,
+	logEvent *web.RequestEvent,
+	response http.ResponseWriter,
+	request *http.Request) (*core.Registration, int64, *probs.ProblemDetails) {
+	acct, prob := wfe.validPOSTAsGETForAccount(request, ctx, logEvent)
+	addRequesterHeader(response, logEvent.Requester)
+	if prob != nil {
+		return nil, 0, prob
+	}
+
+	fields := strings.SplitN(request.URL.Path, "/", 2)
+	acctID, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return nil, 0, probs.Malformed("Invalid account ID")
+	}
+	if acctID != acct.ID {
+		return nil, 0, probs.Unauthorized("Account ID doesn't match ID for list")
+	}
+	var afterID int64
+	if len(fields) == 2 {
+		afterID, err = strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || afterID <= 0 {
+			return nil, 0, probs.NotFound("Invalid page")
+		}
+	}
+	return acct, afterID, nil
+}
+
+// Orders serves a page of the list of orders created by the requesting
+// account, which is found at the account's "orders" URL (RFC 8555 Section
+// 7.1.2.1). If the page is full, it links to the next with a Link header whose
+// relation is "next". All of the account's orders are listed, whatever their
+// status.
+func (wfe *WebFrontEndImpl) Orders(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
+	if !features.Enabled(features.ServeAccountOrders) {
+		wfe.sendError(response, logEvent, probs.NotFound("Feature not enabled"), nil)
+		return
+	}
+
+	acct, afterID, prob := wfe.accountListPage(ctx, logEvent, response, request)
+	if prob != nil {
+		wfe.sendError(response, logEvent, prob, nil)
+		return
+	}
+
+	orderIDs, err := wfe.SA.GetOrdersForAccount(ctx, &sapb.GetByAccountRequest{
+		RegistrationID: acct.ID,
+		AfterID:        afterID,
+		Limit:          wfe.accountListPageSize,
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error retrieving orders"), err)
+		return
+	}
+
+	orders := make([]string, len(orderIDs.Ids))
+	for i, id := range orderIDs.Ids {
+		orders[i] = web.RelativeEndpoint(request, fmt.Sprintf("%s%d/%d", orderPath, acct.ID, id))
+	}
+	if int64(len(orderIDs.Ids)) == wfe.accountListPageSize {
+		lastID := orderIDs.Ids[len(orderIDs.Ids)-1]
+		response.Header().Add("Link", link(
+			web.RelativeEndpoint(request, fmt.Sprintf("%s%d/%d", ordersPath, acct.ID, lastID)), "next"))
+	}
+
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, struct {
+		Orders []string `json:"orders"`
+	}{orders})
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error marshalling orders"), err)
+		return
+	}
+}
+
+// AccountCertificates serves a page of the list of certificates issued to the
+// requesting account, which is found at the account's "certificates" URL. It
+// is paginated in the same way as the orders list. Expired and revoked
+// certificates are included.
+func (wfe *WebFrontEndImpl) AccountCertificates(ctx context.Context, logEvent *web.RequestEvent, response http.ResponseWriter, request *http.Request) {
+	if !features.Enabled(features.ServeAccountOrders) {
+		wfe.sendError(response, logEvent, probs.NotFound("Feature not enabled"), nil)
+		return
+	}
+
+	acct, afterID, prob := wfe.accountListPage(ctx, logEvent, response, request)
+	if prob != nil {
+		wfe.sendError(response, logEvent, prob, nil)
+		return
+	}
+
+	serials, err := wfe.SA.GetSerialsForAccount(ctx, &sapb.GetByAccountRequest{
+		RegistrationID: acct.ID,
+		AfterID:        afterID,
+		Limit:          wfe.accountListPageSize,
+	})
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error retrieving certificates"), err)
+		return
+	}
+
+	certs := make([]string, len(serials.Serials))
+	for i, serial := range serials.Serials {
+		certs[i] = web.RelativeEndpoint(request, fmt.Sprintf("%s%s", certPath, serial))
+	}
+	if int64(len(serials.Serials)) == wfe.accountListPageSize {
+		response.Header().Add("Link", link(
+			web.RelativeEndpoint(request, fmt.Sprintf("%s%d/%d", acctCertsPath, acct.ID, serials.LastID)), "next"))
+	}
+
+	err = wfe.writeJsonResponse(response, logEvent, http.StatusOK, struct {
+		Certificates []string `json:"certificates"`
+	}{certs})
+	if err != nil {
+		wfe.sendError(response, logEvent, probs.ServerInternal("Error marshalling certificates"), err)
+		return
+	}
+}
+
+// issuerByKeyHash returns the issuer certificate whose public key has the given
+// hex-encoded SHA-256 issuerKeyHash.
+func (wfe *WebFrontEndImpl) issuerByKeyHash(keyHash string) (*issuance.Certificate, error) {
+	hash, err := hex.DecodeString(keyHash)
+	if err != nil || len(hash) != sha256.Size {
+		return nil, fmt.Errorf("issuer key hash provided was not valid: %s", keyHash)
+	}
+	for _, issuer := range wfe.issuerCertificates {
+		issuerHash, err := issuerKeyHash(issuer.Certificate)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(hash, issuerHash) {
+			return issuer, nil
+		}
+	}
+	return nil, fmt.Errorf("no issuer with key hash %s", keyHash)
+}
+
+// issuerKeyHash returns the SHA-256 hash of the issuer's subjectPublicKey bit
+// string, excluding the algorithm identifier, as in an OCSP CertID.
+func issuerKeyHash(issuer *x509.Certificate) ([]byte, error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+	_, err := asn1.Unmarshal(issuer.RawSubjectPublicKeyInfo, &spki)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(spki.PublicKey.RightAlign())
+	return hash[:], nil
+}
@@ -9,6 +9,8 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
 	"encoding/json"
 	"encoding/pem"
 	"errors"
@@ -206,10 +208,12 @@ EeMZ9nWyIM6bktLrE11HnFOnKhAYsM5fZA==
 
 type MockRegistrationAuthority struct {
 	lastRevocationReason revocation.Reason
+	lastRegistration     core.Registration
 }
 
 func (ra *MockRegistrationAuthority) NewRegistration(ctx context.Context, acct core.Registration) (core.Registration, error) {
 	acct.ID = 1
+	ra.lastRegistration = acct
 	return acct, nil
 }
 
@@ -258,12 +262,15 @@ func (ra *MockRegistrationAuthority) DeactivateRegistration(ctx context.Context,
 
 func (ra *MockRegistrationAuthority) NewOrder(ctx context.Context, req *rapb.NewOrderRequest) (*corepb.Order, error) {
 	return &corepb.Order{
-		Id:               1,
-		RegistrationID:   req.RegistrationID,
-		Expires:          0,
-		Names:            req.Names,
-		Status:           string(core.StatusPending),
-		V2Authorizations: []int64{1},
+		Id:                     1,
+		RegistrationID:         req.RegistrationID,
+		Expires:                0,
+		Names:                  req.Names,
+		Status:                 string(core.StatusPending),
+		V2Authorizations:       []int64{1},
+		CertificateProfileName: req.CertificateProfileName,
+		NotBefore:              req.NotBefore,
+		NotAfter:               req.NotAfter,
 	}, nil
 }
 
@@ -272,6 +279,87 @@ func (ra *MockRegistrationAuthority) FinalizeOrder(ctx context.Context, req *rap
 	return req.Order, nil
 }
 
+func (ra *MockRegistrationAuthority) RenewalInfo(ctx context.Context, req *rapb.RenewalInfoRequest) (*rapb.RenewalInfo, error) {
+	if req.Serial != "000000000000000000000000000000000001" {
+		return nil, berrors.NotFoundError("certificate not found")
+	}
+	return &rapb.RenewalInfo{
+		WindowStart: time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC).UnixNano(),
+		WindowEnd:   time.Date(2021, 9, 2, 0, 0, 0, 0, time.UTC).UnixNano(),
+	}, nil
+}
+
+func (ra *MockRegistrationAuthority) GetRateLimitUsage(ctx context.Context, req *rapb.GetRateLimitUsageRequest) (*rapb.RateLimitUsages, error) {
+	resp := &rapb.RateLimitUsages{
+		Usages: []*rapb.RateLimitUsage{
+			{
+				LimitName: "newOrdersPerAccount",
+				Limit:     300,
+				Remaining: 299,
+				Window:    (3 * time.Hour).Nanoseconds(),
+				ResetIn:   (36*time.Second + time.Millisecond).Nanoseconds(),
+			},
+		},
+	}
+	for _, name := range req.Names {
+		if name == "ratelimited.com" {
+			return nil, berrors.RateLimitErrorWithStatus(&berrors.RateLimitStatus{
+				Limit:      50,
+				RetryAfter: time.Hour,
+				ResetIn:    7 * 24 * time.Hour,
+			}, "too many certificates")
+		}
+		resp.Usages = append(resp.Usages, &rapb.RateLimitUsage{
+			LimitName:  "certificatesPerName",
+			Key:        name,
+			Limit:      50,
+			Remaining:  0,
+			Window:     (7 * 24 * time.Hour).Nanoseconds(),
+			RetryAfter: time.Hour.Nanoseconds(),
+			ResetIn:    (7 * 24 * time.Hour).Nanoseconds(),
+		})
+	}
+	return resp, nil
+}
+
+func (ra *MockRegistrationAuthority) NewPreAuthorization(ctx context.Context, req *rapb.NewPreAuthorizationRequest) (*corepb.Authorization, error) {
+	if req.IdentifierValue == "ratelimited.com" {
+		return nil, berrors.RateLimitError("too many currently pending authorizations")
+	}
+	return &corepb.Authorization{
+		Id:             "7",
+		Identifier:     req.IdentifierValue,
+		RegistrationID: req.RegistrationID,
+		Status:         string(core.StatusPending),
+		Expires:        time.Date(2021, 1, 8, 0, 0, 0, 0, time.UTC).UnixNano(),
+		Challenges: []*corepb.Challenge{
+			{
+				Type:   string(core.ChallengeTypeHTTP01),
+				Status: string(core.StatusPending),
+				Token:  "token",
+			},
+		},
+	}, nil
+}
+
+func (ra *MockRegistrationAuthority) RevokeCertificatesByName(ctx context.Context, req *rapb.RevokeCertificatesByNameRequest) (*rapb.RevokedSerials, error) {
+	if req.Domain != "not-an-example.com" {
+		return nil, berrors.UnauthorizedError("account does not hold a valid authorization for %q", req.Domain)
+	}
+	return &rapb.RevokedSerials{Serials: []string{fmt.Sprintf("%036x", 1), fmt.Sprintf("%036x", 2)}}, nil
+}
+
+func (ra *MockRegistrationAuthority) RevokeCertificatesByKey(ctx context.Context, req *rapb.RevokeCertificatesByKeyRequest) (*rapb.RevokedSerials, error) {
+	csr, err := x509.ParseCertificateRequest(req.Csr)
+	if err != nil {
+		return nil, err
+	}
+	if csr.Subject.CommonName != "This key is compromised" {
+		return nil, berrors.BadCSRError("CSR common name must be %q", "This key is compromised")
+	}
+	return &rapb.RevokedSerials{Serials: []string{fmt.Sprintf("%036x", 3)}}, nil
+}
+
 func makeBody(s string) io.ReadCloser {
 	return ioutil.NopCloser(strings.NewReader(s))
 }
@@ -1544,6 +1632,126 @@ func TestNewAccountNoID(t *testing.T) {
 	}`)
 }
 
+// signEAB returns the JSON serialization of an externalAccountBinding JWS for
+// the given account key, MAC'd with the given external account key.
+func signEAB(t *testing.T, keyID string, macKey []byte, url string, accountKey crypto.Signer) string {
+	t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Key: macKey, Algorithm: jose.HS256},
+		&jose.SignerOptions{
+			ExtraHeaders: map[jose.HeaderKey]interface{}{
+				"kid": keyID,
+				"url": url,
+			},
+		},
+	)
+	test.AssertNotError(t, err, "Failed to make EAB signer")
+	jwk, err := (&jose.JSONWebKey{Key: accountKey.Public()}).MarshalJSON()
+	test.AssertNotError(t, err, "Failed to marshal account JWK")
+	jws, err := signer.Sign(jwk)
+	test.AssertNotError(t, err, "Failed to sign EAB")
+	return jws.FullSerialize()
+}
+
+func TestNewAccountExternalAccountBinding(t *testing.T) {
+	wfe, _ := setupWFE(t)
+	mockRA := &MockRegistrationAuthority{}
+	wfe.RA = mockRA
+
+	key := loadKey(t, []byte(testE2KeyPrivatePEM))
+	otherKey := loadKey(t, []byte(test2KeyPrivatePEM))
+	path := newAcctPath
+	signedURL := fmt.Sprintf("http://localhost%s", path)
+	macKey := []byte("this is a very secret eab key!!!")
+
+	newAccount := func(eab string) *httptest.ResponseRecorder {
+		payload := `{"termsOfServiceAgreed":true}`
+		if eab != "" {
+			payload = fmt.Sprintf(`{"termsOfServiceAgreed":true,"externalAccountBinding":%s}`, eab)
+		}
+		_, _, body := signRequestEmbed(t, key, signedURL, payload, wfe.nonceService)
+		responseWriter := httptest.NewRecorder()
+		wfe.NewAccount(ctx, newRequestEvent(), responseWriter, makePostRequestWithPath(path, body))
+		return responseWriter
+	}
+
+	// With the feature disabled, the binding is ignored entirely.
+	wfe.ExternalAccountRequired = true
+	responseWriter := newAccount(signEAB(t, "unknown-key-id", macKey, signedURL, key))
+	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
+	test.AssertEquals(t, mockRA.lastRegistration.ExternalAccountID, "")
+
+	_ = features.Set(map[string]bool{"ExternalAccountBinding": true})
+	defer features.Reset()
+
+	testCases := []struct {
+		name        string
+		eab         string
+		expectedErr string
+	}{
+		{
+			name:        "missing binding",
+			eab:         "",
+			expectedErr: `{"type":"` + probs.V2ErrorNS + `externalAccountRequired","detail":"an externalAccountBinding is required to create a new account","status":403}`,
+		},
+		{
+			name:        "unknown key ID",
+			eab:         signEAB(t, "unknown-key-id", macKey, signedURL, key),
+			expectedErr: `{"type":"` + probs.V2ErrorNS + `unauthorized","detail":"Unknown externalAccountBinding key ID \"unknown-key-id\"","status":403}`,
+		},
+		{
+			name:        "key lookup fails",
+			eab:         signEAB(t, "broken-key-id", macKey, signedURL, key),
+			expectedErr: `{"type":"` + probs.V2ErrorNS + `serverInternal","detail":"Error retrieving externalAccountBinding key ID \"broken-key-id\"","status":500}`,
+		},
+		{
+			name:        "wrong MAC key",
+			eab:         signEAB(t, "eab-key-id", []byte("this is not the right eab key..."), signedURL, key),
+			expectedErr: `{"type":"` + probs.V2ErrorNS + `unauthorized","detail":"externalAccountBinding JWS verification error","status":403}`,
+		},
+		{
+			name:        "wrong URL",
+			eab:         signEAB(t, "eab-key-id", macKey, "http://localhost/acme/new-order", key),
+			expectedErr: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"externalAccountBinding JWS header parameter 'url' incorrect. Expected \"http://localhost/acme/new-acct\" got \"http://localhost/acme/new-order\"","status":400}`,
+		},
+		{
+			name:        "wrong account key",
+			eab:         signEAB(t, "eab-key-id", macKey, signedURL, otherKey),
+			expectedErr: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"externalAccountBinding JWS payload does not match account key","status":400}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responseWriter := newAccount(tc.eab)
+			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.expectedErr)
+		})
+	}
+
+	// The SA's error is logged, rather than returned to the client.
+	_, _, body := signRequestEmbed(t, key, signedURL,
+		fmt.Sprintf(`{"termsOfServiceAgreed":true,"externalAccountBinding":%s}`, signEAB(t, "broken-key-id", macKey, signedURL, key)),
+		wfe.nonceService)
+	logEvent := newRequestEvent()
+	wfe.NewAccount(ctx, logEvent, httptest.NewRecorder(), makePostRequestWithPath(path, body))
+	test.AssertDeepEquals(t, logEvent.InternalErrors, []string{
+		"Error calling SA.GetExternalAccountKey: external account key lookup is broken",
+	})
+
+	responseWriter = newAccount(signEAB(t, "eab-key-id", macKey, signedURL, key))
+	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
+	test.AssertEquals(t, mockRA.lastRegistration.ExternalAccountID, "eab-key-id")
+
+	// The binding is still verified, and recorded, when it isn't required.
+	wfe.ExternalAccountRequired = false
+	mockRA.lastRegistration = core.Registration{}
+	responseWriter = newAccount("")
+	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
+	test.AssertEquals(t, mockRA.lastRegistration.ExternalAccountID, "")
+	responseWriter = newAccount(signEAB(t, "eab-key-id", macKey, signedURL, key))
+	test.AssertEquals(t, responseWriter.Code, http.StatusCreated)
+	test.AssertEquals(t, mockRA.lastRegistration.ExternalAccountID, "eab-key-id")
+}
+
 func TestGetAuthorization(t *testing.T) {
 	wfe, _ := setupWFE(t)
 
@@ -1914,6 +2122,32 @@ func TestGetCertificate(t *testing.T) {
 			ExpectedLink: fmt.Sprintf(`<http://localhost%s/0>;rel="alternate"`, reqPath),
 			ExpectedCert: append(certPemBytes, append([]byte("\n"), chainCrossPemBytes...)...),
 		},
+		{
+			Name: "Valid serial (preferred alternate chain)",
+			Request: &http.Request{URL: &url.URL{
+				Path:     reqPath,
+				RawQuery: url.Values{"preferredChain": {"(TEST) Diaphanous Diamond Root CA X3"}}.Encode(),
+			}, Method: "GET"},
+			ExpectedStatus: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Content-Type": pkixContent,
+			},
+			ExpectedLink: fmt.Sprintf(`<http://localhost%s/0>;rel="alternate"`, reqPath),
+			ExpectedCert: append(certPemBytes, append([]byte("\n"), chainCrossPemBytes...)...),
+		},
+		{
+			Name: "Valid serial (unknown preferred chain)",
+			Request: &http.Request{URL: &url.URL{
+				Path:     reqPath,
+				RawQuery: url.Values{"preferredChain": {"Some Other Root"}}.Encode(),
+			}, Method: "GET"},
+			ExpectedStatus: http.StatusOK,
+			ExpectedHeaders: map[string]string{
+				"Content-Type": pkixContent,
+			},
+			ExpectedLink: fmt.Sprintf(`<http://localhost%s/1>;rel="alternate"`, reqPath),
+			ExpectedCert: append(certPemBytes, append([]byte("\n"), chainPemBytes...)...),
+		},
 		{
 			Name:           "Valid serial (explicit non-existent alternate chain)",
 			Request:        makeGet(reqPath + "/2"),
@@ -2437,12 +2671,80 @@ func TestNewOrder(t *testing.T) {
 		{
 			Name:         "POST, invalid identifier in payload",
 			Request:      signAndPost(t, targetPath, signedURL, nonDNSIdentifierBody, 1, wfe.nonceService),
-			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewOrder request included invalid non-DNS type identifier: type \"fakeID\", value \"www.i-am-21.com\"","status":400}`,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewOrder request included unsupported type identifier: type \"fakeID\", value \"www.i-am-21.com\"","status":400}`,
+		},
+		{
+			Name:         "POST, IP address in DNS type identifier",
+			Request:      signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type":"dns","value":"10.0.0.1"}]}`, 1, wfe.nonceService),
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewOrder request included dns type identifier with invalid value \"10.0.0.1\"","status":400}`,
 		},
 		{
-			Name:         "POST, notAfter and notBefore in payload",
-			Request:      signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "notBefore":"now", "notAfter": "later"}`, 1, wfe.nonceService),
-			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NotBefore and NotAfter are not supported","status":400}`,
+			Name:         "POST, domain name in IP type identifier",
+			Request:      signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type":"ip","value":"not-example.com"}]}`, 1, wfe.nonceService),
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewOrder request included ip type identifier with invalid value \"not-example.com\"","status":400}`,
+		},
+		{
+			Name:    "POST, good payload with IP identifiers",
+			Request: signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type":"dns","value":"not-example.com"},{"type":"ip","value":"10.0.0.1"},{"type":"ip","value":"2001:db8::1"}]}`, 1, wfe.nonceService),
+			ExpectedBody: `
+					{
+						"status": "pending",
+						"expires": "1970-01-01T00:00:00Z",
+						"identifiers": [
+							{ "type": "dns", "value": "not-example.com"},
+							{ "type": "ip", "value": "10.0.0.1"},
+							{ "type": "ip", "value": "2001:db8::1"}
+						],
+						"authorizations": [
+							"http://localhost/acme/authz-v3/1"
+						],
+						"finalize": "http://localhost/acme/finalize/1/1"
+					}`,
+		},
+		{
+			Name:         "POST, invalid notBefore in payload",
+			Request:      signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "notBefore":"now", "notAfter": "2021-01-08T00:00:00Z"}`, 1, wfe.nonceService),
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Invalid NotBefore, must be an RFC 3339 timestamp","status":400}`,
+		},
+		{
+			Name:         "POST, invalid notAfter in payload",
+			Request:      signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type": "dns", "value": "not-example.com"}], "notBefore":"2021-01-02T00:00:00Z", "notAfter": "later"}`, 1, wfe.nonceService),
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Invalid NotAfter, must be an RFC 3339 timestamp","status":400}`,
+		},
+		{
+			Name:    "POST, good payload with notBefore and notAfter",
+			Request: signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type":"dns","value":"not-example.com"}],"notBefore":"2021-01-02T00:00:00Z","notAfter":"2021-01-08T00:00:00Z"}`, 1, wfe.nonceService),
+			ExpectedBody: `
+					{
+						"status": "pending",
+						"expires": "1970-01-01T00:00:00Z",
+						"identifiers": [
+							{ "type": "dns", "value": "not-example.com"}
+						],
+						"authorizations": [
+							"http://localhost/acme/authz-v3/1"
+						],
+						"finalize": "http://localhost/acme/finalize/1/1",
+						"notBefore": "2021-01-02T00:00:00Z",
+						"notAfter": "2021-01-08T00:00:00Z"
+					}`,
+		},
+		{
+			Name:    "POST, good payload with profile",
+			Request: signAndPost(t, targetPath, signedURL, `{"identifiers":[{"type":"dns","value":"not-example.com"}],"profile":"shortlived"}`, 1, wfe.nonceService),
+			ExpectedBody: `
+					{
+						"status": "pending",
+						"expires": "1970-01-01T00:00:00Z",
+						"identifiers": [
+							{ "type": "dns", "value": "not-example.com"}
+						],
+						"authorizations": [
+							"http://localhost/acme/authz-v3/1"
+						],
+						"finalize": "http://localhost/acme/finalize/1/1",
+						"profile": "shortlived"
+					}`,
 		},
 		{
 			Name:    "POST, good payload",
@@ -2488,6 +2790,240 @@ func TestNewOrder(t *testing.T) {
 	}
 }
 
+func TestNewAuthorization(t *testing.T) {
+	wfe, _ := setupWFE(t)
+
+	targetPath := "new-authz"
+	signedURL := fmt.Sprintf("http://localhost/%s", targetPath)
+
+	// Without the feature enabled, the endpoint doesn't exist.
+	responseWriter := httptest.NewRecorder()
+	wfe.NewAuthorization(ctx, newRequestEvent(), responseWriter,
+		signAndPost(t, targetPath, signedURL, `{"identifier":{"type":"dns","value":"not-example.com"}}`, 1, wfe.nonceService))
+	test.AssertEquals(t, responseWriter.Code, http.StatusNotFound)
+
+	_ = features.Set(map[string]bool{"ServeNewAuthz": true})
+	defer features.Reset()
+
+	testCases := []struct {
+		Name             string
+		Payload          string
+		ExpectedCode     int
+		ExpectedBody     string
+		ExpectedLocation string
+	}{
+		{
+			Name:         "payload isn't valid",
+			Payload:      "foo",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Request payload did not parse as JSON","status":400}`,
+		},
+		{
+			Name:         "unsupported identifier type",
+			Payload:      `{"identifier":{"type":"fakeID","value":"www.i-am-21.com"}}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewAuthorization request included unsupported type identifier: type \"fakeID\", value \"www.i-am-21.com\"","status":400}`,
+		},
+		{
+			Name:         "empty identifier value",
+			Payload:      `{"identifier":{"type":"dns","value":""}}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewAuthorization request included empty identifier value","status":400}`,
+		},
+		{
+			Name:         "IP address in DNS type identifier",
+			Payload:      `{"identifier":{"type":"dns","value":"10.0.0.1"}}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"NewAuthorization request included dns type identifier with invalid value \"10.0.0.1\"","status":400}`,
+		},
+		{
+			Name:         "wildcard identifier",
+			Payload:      `{"identifier":{"type":"dns","value":"*.not-example.com"}}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `rejectedIdentifier","detail":"Wildcard identifiers can't be pre-authorized, use newOrder instead","status":400}`,
+		},
+		{
+			Name:         "rate limited",
+			Payload:      `{"identifier":{"type":"dns","value":"ratelimited.com"}}`,
+			ExpectedCode: http.StatusTooManyRequests,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `rateLimited","detail":"Error creating new authorization :: too many currently pending authorizations: see https://letsencrypt.org/docs/rate-limits/","status":429}`,
+		},
+		{
+			Name:         "good payload",
+			Payload:      `{"identifier":{"type":"dns","value":"not-example.com"}}`,
+			ExpectedCode: http.StatusCreated,
+			ExpectedBody: `
+				{
+					"identifier": {"type": "dns", "value": "not-example.com"},
+					"status": "pending",
+					"expires": "2021-01-08T00:00:00Z",
+					"challenges": [
+						{
+							"type": "http-01",
+							"status": "pending",
+							"token": "token",
+							"url": "http://localhost/acme/chall-v3/7/7TyhFQ"
+						}
+					]
+				}`,
+			ExpectedLocation: "http://localhost/acme/authz-v3/7",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			responseWriter := httptest.NewRecorder()
+			wfe.NewAuthorization(ctx, newRequestEvent(), responseWriter,
+				signAndPost(t, targetPath, signedURL, tc.Payload, 1, wfe.nonceService))
+			test.AssertEquals(t, responseWriter.Code, tc.ExpectedCode)
+			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.ExpectedBody)
+			test.AssertEquals(t, responseWriter.Header().Get("Location"), tc.ExpectedLocation)
+		})
+	}
+}
+
+func TestRevokeCertificatesByName(t *testing.T) {
+	wfe, _ := setupWFE(t)
+
+	targetPath := "revoke-name"
+	signedURL := fmt.Sprintf("http://localhost/%s", targetPath)
+
+	// Without the feature enabled, the endpoint doesn't exist.
+	responseWriter := httptest.NewRecorder()
+	wfe.RevokeCertificatesByName(ctx, newRequestEvent(), responseWriter,
+		signAndPost(t, targetPath, signedURL, `{"identifier":{"type":"dns","value":"not-an-example.com"}}`, 1, wfe.nonceService))
+	test.AssertEquals(t, responseWriter.Code, http.StatusNotFound)
+
+	_ = features.Set(map[string]bool{"ServeRevokeByName": true})
+	defer features.Reset()
+
+	testCases := []struct {
+		Name         string
+		Payload      string
+		ExpectedCode int
+		ExpectedBody string
+	}{
+		{
+			Name:         "payload isn't valid",
+			Payload:      "foo",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Request payload did not parse as JSON","status":400}`,
+		},
+		{
+			Name:         "IP identifier",
+			Payload:      `{"identifier":{"type":"ip","value":"10.0.0.1"}}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Revocation by name is only supported for dns type identifiers, not \"ip\"","status":400}`,
+		},
+		{
+			Name:         "wildcard identifier",
+			Payload:      `{"identifier":{"type":"dns","value":"*.not-an-example.com"}}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `malformed","detail":"Revocation by name requires a non-wildcard identifier value","status":400}`,
+		},
+		{
+			Name:         "unsupported reason",
+			Payload:      `{"identifier":{"type":"dns","value":"not-an-example.com"},"reason":2}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `badRevocationReason","detail":"unsupported revocation reason code provided: cACompromise (2). Supported reasons: ` + revocation.UserAllowedReasonsMessage + `","status":400}`,
+		},
+		{
+			Name:         "no authorization",
+			Payload:      `{"identifier":{"type":"dns","value":"not-mine.com"}}`,
+			ExpectedCode: http.StatusForbidden,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `unauthorized","detail":"Failed to revoke certificates :: account does not hold a valid authorization for \"not-mine.com\"","status":403}`,
+		},
+		{
+			Name:         "good payload",
+			Payload:      `{"identifier":{"type":"dns","value":"not-an-example.com"},"reason":4}`,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `{"certificates":["http://localhost/acme/cert/` + fmt.Sprintf("%036x", 1) + `","http://localhost/acme/cert/` + fmt.Sprintf("%036x", 2) + `"]}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			responseWriter := httptest.NewRecorder()
+			wfe.RevokeCertificatesByName(ctx, newRequestEvent(), responseWriter,
+				signAndPost(t, targetPath, signedURL, tc.Payload, 1, wfe.nonceService))
+			test.AssertEquals(t, responseWriter.Code, tc.ExpectedCode)
+			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.ExpectedBody)
+		})
+	}
+}
+
+func TestRevokeCertificatesByKey(t *testing.T) {
+	wfe, _ := setupWFE(t)
+
+	k, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	test.AssertNotError(t, err, "ecdsa.GenerateKey failed")
+	makeCSR := func(cn string) []byte {
+		csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+			Subject: pkix.Name{CommonName: cn},
+		}, k)
+		test.AssertNotError(t, err, "x509.CreateCertificateRequest failed")
+		return csr
+	}
+	goodCSR := makeCSR("This key is compromised")
+	makePost := func(body []byte) *http.Request {
+		return &http.Request{
+			Method: "POST",
+			URL:    mustParseURL(revokeKeyPath),
+			Body:   ioutil.NopCloser(bytes.NewReader(body)),
+		}
+	}
+
+	// Without the feature enabled, the endpoint doesn't exist.
+	responseWriter := httptest.NewRecorder()
+	wfe.RevokeCertificatesByKey(ctx, newRequestEvent(), responseWriter, makePost(goodCSR))
+	test.AssertEquals(t, responseWriter.Code, http.StatusNotFound)
+
+	_ = features.Set(map[string]bool{"ServeRevokeByKey": true})
+	defer features.Reset()
+
+	goodResponse := `{"certificates":["http://localhost/acme/cert/` + fmt.Sprintf("%036x", 3) + `"]}`
+	testCases := []struct {
+		Name         string
+		Body         []byte
+		ExpectedCode int
+		ExpectedBody string
+	}{
+		{
+			Name:         "not a CSR",
+			Body:         []byte("foo"),
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `badCSR","detail":"Error parsing certificate request: asn1: structure error: tags don't match (16 vs {class:1 tag:6 length:111 isCompound:true}) {optional:false explicit:false application:false private:false defaultValue:<nil> tag:<nil> stringType:0 timeType:0 set:false omitEmpty:false} certificateRequest @2","status":400}`,
+		},
+		{
+			Name:         "wrong common name",
+			Body:         makeCSR("example.com"),
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `badCSR","detail":"Failed to revoke certificates :: CSR common name must be \"This key is compromised\"","status":400}`,
+		},
+		{
+			Name:         "DER CSR",
+			Body:         goodCSR,
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: goodResponse,
+		},
+		{
+			Name:         "PEM CSR",
+			Body:         pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: goodCSR}),
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: goodResponse,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			responseWriter := httptest.NewRecorder()
+			wfe.RevokeCertificatesByKey(ctx, newRequestEvent(), responseWriter, makePost(tc.Body))
+			test.AssertEquals(t, responseWriter.Code, tc.ExpectedCode)
+			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.ExpectedBody)
+		})
+	}
+}
+
 func TestFinalizeOrder(t *testing.T) {
 	wfe, _ := setupWFE(t)
 	responseWriter := httptest.NewRecorder()
@@ -2582,9 +3118,12 @@ func TestFinalizeOrder(t *testing.T) {
 			ExpectedBody: `{"type":"` + probs.V2ErrorNS + `orderNotReady","detail":"Order's status (\"pending\") is not acceptable for finalization","status":403}`,
 		},
 		{
-			Name:            "Good CSR, Ready Order",
-			Request:         signAndPost(t, "1/8", "http://localhost/1/8", goodCertCSRPayload, 1, wfe.nonceService),
-			ExpectedHeaders: map[string]string{"Location": "http://localhost/acme/order/1/8"},
+			Name:    "Good CSR, Ready Order",
+			Request: signAndPost(t, "1/8", "http://localhost/1/8", goodCertCSRPayload, 1, wfe.nonceService),
+			ExpectedHeaders: map[string]string{
+				"Location":    "http://localhost/acme/order/1/8",
+				"Retry-After": "3",
+			},
 			ExpectedBody: `
 {
   "status": "processing",
@@ -2753,16 +3292,23 @@ func TestGetOrder(t *testing.T) {
 	}
 
 	testCases := []struct {
-		Name     string
-		Request  *http.Request
-		Response string
-		Endpoint string
+		Name       string
+		Request    *http.Request
+		Response   string
+		Endpoint   string
+		RetryAfter string
 	}{
 		{
 			Name:     "Good request",
 			Request:  makeGet("1/1"),
 			Response: `{"status": "valid","expires": "1970-01-01T00:00:00.9466848Z","identifiers":[{"type":"dns", "value":"example.com"}], "authorizations":["http://localhost/acme/authz-v3/1"],"finalize":"http://localhost/acme/finalize/1/1","certificate":"http://localhost/acme/cert/serial"}`,
 		},
+		{
+			Name:       "Processing order",
+			Request:    makePost(1, "1/10", ""),
+			Response:   `{"status": "processing","expires": "1970-01-01T00:00:00.9466848Z","identifiers":[{"type":"dns", "value":"example.com"}], "authorizations":["http://localhost/acme/authz-v3/1"],"finalize":"http://localhost/acme/finalize/1/10"}`,
+			RetryAfter: "3",
+		},
 		{
 			Name:     "404 request",
 			Request:  makeGet("1/2"),
@@ -2835,6 +3381,7 @@ func TestGetOrder(t *testing.T) {
 				wfe.GetOrder(ctx, newRequestEvent(), responseWriter, tc.Request)
 			}
 			test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.Response)
+			test.AssertEquals(t, responseWriter.Header().Get("Retry-After"), tc.RetryAfter)
 		})
 	}
 }
@@ -3228,14 +3775,20 @@ func TestPrepAuthzForDisplay(t *testing.T) {
 		t.Errorf("Authz had a non-nil combinations")
 	}
 
-	// We expect the authz challenge has its URL set and the URI emptied.
+	// We expect the authz challenge has its URL set and the URI emptied, and
+	// only the primary VA's validation records shown.
 	chal := authz.Challenges[0]
 	authz.ID = "12345"
+	authz.Challenges[0].ValidationRecord = []core.ValidationRecord{
+		{Hostname: "example.com"},
+		{Hostname: "example.com", RemoteVA: "va1.boulder:9097", Perspective: "ARIN"},
+	}
 	wfe.prepAuthorizationForDisplay(&http.Request{Host: "localhost"}, authz)
 	chal = authz.Challenges[0]
 	test.AssertEquals(t, chal.URL, "http://localhost/acme/chall-v3/12345/po1V2w")
 	test.AssertEquals(t, chal.URI, "")
 	test.AssertEquals(t, chal.ProvidedKeyAuthorization, "")
+	test.AssertDeepEquals(t, chal.ValidationRecord, []core.ValidationRecord{{Hostname: "example.com"}})
 }
 
 // noSCTMockRA is a mock RA that always returns a `berrors.MissingSCTsError` from `FinalizeOrder`
@@ -3380,7 +3933,7 @@ func TestPrepAccountForDisplay(t *testing.T) {
 	}
 
 	// Prep the account for display.
-	prepAccountForDisplay(acct)
+	prepAccountForDisplay(&http.Request{}, acct)
 
 	// The Agreement should always be cleared.
 	test.AssertEquals(t, acct.Agreement, "")
@@ -3485,3 +4038,364 @@ func TestGetAPIAndMandatoryPOSTAsGET(t *testing.T) {
 	wfe.Certificate(context.Background(), event, resp, req)
 	test.AssertEquals(t, resp.Code, 200)
 }
+
+func TestRenewalInfo(t *testing.T) {
+	wfe, _ := setupWFE(t)
+
+	issuer, err := issuance.LoadCertificate("../test/hierarchy/int-r3.cert.pem")
+	test.AssertNotError(t, err, "failed to load test issuer")
+	keyHash, err := issuerKeyHash(issuer.Certificate)
+	test.AssertNotError(t, err, "failed to compute issuer key hash")
+	hexKeyHash := hex.EncodeToString(keyHash)
+
+	makeGet := func(path string) (*http.Request, *web.RequestEvent) {
+		return &http.Request{URL: &url.URL{Path: path}, Method: "GET"},
+			&web.RequestEvent{Endpoint: renewalInfoPath, Extra: map[string]interface{}{}}
+	}
+
+	// With the feature disabled, the endpoint should not exist.
+	req, event := makeGet(hexKeyHash + "/000000000000000000000000000000000001")
+	responseWriter := httptest.NewRecorder()
+	wfe.RenewalInfo(context.Background(), event, responseWriter, req)
+	test.AssertEquals(t, responseWriter.Code, http.StatusNotFound)
+
+	_ = features.Set(map[string]bool{"ServeRenewalInfo": true})
+	defer features.Reset()
+
+	testCases := []struct {
+		name         string
+		path         string
+		expectedCode int
+	}{
+		{
+			name:         "missing serial",
+			path:         hexKeyHash,
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "malformed serial",
+			path:         hexKeyHash + "/zzz",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "malformed key hash",
+			path:         "zzz/000000000000000000000000000000000001",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "unknown issuer",
+			path:         strings.Repeat("00", 32) + "/000000000000000000000000000000000001",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "unknown serial",
+			path:         hexKeyHash + "/000000000000000000000000000000000002",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "success",
+			path:         hexKeyHash + "/000000000000000000000000000000000001",
+			expectedCode: http.StatusOK,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, event := makeGet(tc.path)
+			responseWriter := httptest.NewRecorder()
+			wfe.RenewalInfo(context.Background(), event, responseWriter, req)
+			test.AssertEquals(t, responseWriter.Code, tc.expectedCode)
+		})
+	}
+
+	req, event = makeGet(hexKeyHash + "/000000000000000000000000000000000001")
+	responseWriter = httptest.NewRecorder()
+	wfe.RenewalInfo(context.Background(), event, responseWriter, req)
+	test.AssertEquals(t, responseWriter.Header().Get("Retry-After"), "21600")
+	test.AssertUnmarshaledEquals(t, responseWriter.Body.String(),
+		`{"suggestedWindow":{"start":"2021-09-01T00:00:00Z","end":"2021-09-02T00:00:00Z"}}`)
+
+	// With the feature enabled, the directory should advertise the endpoint.
+	responseWriter = httptest.NewRecorder()
+	wfe.Directory(context.Background(), newRequestEvent(), responseWriter, &http.Request{
+		Method: http.MethodGet,
+		URL:    mustParseURL(directoryPath),
+		Host:   "localhost:4300",
+	})
+	test.AssertContains(t, responseWriter.Body.String(), `"renewalInfo": "http://localhost:4300/acme/renewal-info/"`)
+}
+
+func TestRateLimits(t *testing.T) {
+	wfe, _ := setupWFE(t)
+	signedURL := "http://localhost" + rateLimitsPath
+
+	// With the feature disabled, the endpoint should not exist.
+	responseWriter := httptest.NewRecorder()
+	wfe.RateLimits(ctx, newRequestEvent(), responseWriter,
+		signAndPost(t, rateLimitsPath, signedURL, "", 1, wfe.nonceService))
+	test.AssertEquals(t, responseWriter.Code, http.StatusNotFound)
+
+	_ = features.Set(map[string]bool{"ServeRateLimitUsage": true})
+	defer features.Reset()
+
+	testCases := []struct {
+		name         string
+		payload      string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "POST-as-GET",
+			payload:      "",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"limits":[{"name":"newOrdersPerAccount","limit":300,"remaining":299,"window":10800,"reset":37}]}`,
+		},
+		{
+			name:         "with identifiers",
+			payload:      `{"identifiers":[{"type":"dns","value":"example.com"}]}`,
+			expectedCode: http.StatusOK,
+			expectedBody: `{"limits":[
+				{"name":"newOrdersPerAccount","limit":300,"remaining":299,"window":10800,"reset":37},
+				{"name":"certificatesPerName","key":"example.com","limit":50,"remaining":0,"window":604800,"retryAfter":3600,"reset":604800}
+			]}`,
+		},
+		{
+			name:         "malformed payload",
+			payload:      "foo",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "unsupported identifier type",
+			payload:      `{"identifiers":[{"type":"fnord","value":"example.com"}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "mismatched identifier type",
+			payload:      `{"identifiers":[{"type":"ip","value":"example.com"}]}`,
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "rate limited",
+			payload:      `{"identifiers":[{"type":"dns","value":"ratelimited.com"}]}`,
+			expectedCode: http.StatusTooManyRequests,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responseWriter := httptest.NewRecorder()
+			wfe.RateLimits(ctx, newRequestEvent(), responseWriter,
+				signAndPost(t, rateLimitsPath, signedURL, tc.payload, 1, wfe.nonceService))
+			test.AssertEquals(t, responseWriter.Code, tc.expectedCode)
+			if tc.expectedBody != "" {
+				test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.expectedBody)
+			}
+		})
+	}
+
+	// A rate limit error from the RA carries its status in the response
+	// headers.
+	responseWriter = httptest.NewRecorder()
+	wfe.RateLimits(ctx, newRequestEvent(), responseWriter,
+		signAndPost(t, rateLimitsPath, signedURL, `{"identifiers":[{"type":"dns","value":"ratelimited.com"}]}`, 1, wfe.nonceService))
+	test.AssertEquals(t, responseWriter.Header().Get("Retry-After"), "3600")
+	test.AssertEquals(t, responseWriter.Header().Get("RateLimit-Limit"), "50")
+	test.AssertEquals(t, responseWriter.Header().Get("RateLimit-Remaining"), "0")
+	test.AssertEquals(t, responseWriter.Header().Get("RateLimit-Reset"), "604800")
+
+	// With the feature enabled, the directory should advertise the endpoint.
+	responseWriter = httptest.NewRecorder()
+	wfe.Directory(context.Background(), newRequestEvent(), responseWriter, &http.Request{
+		Method: http.MethodGet,
+		URL:    mustParseURL(directoryPath),
+		Host:   "localhost:4300",
+	})
+	test.AssertContains(t, responseWriter.Body.String(), `"rateLimits": "http://localhost:4300/acme/rate-limits"`)
+}
+
+func TestAccountLists(t *testing.T) {
+	wfe, _ := setupWFE(t)
+	wfe.accountListPageSize = 2
+
+	key2 := loadKey(t, []byte(test2KeyPrivatePEM))
+	makePost := func(keyID int64, path string) *http.Request {
+		var key interface{}
+		if keyID == 2 {
+			key = key2
+		}
+		_, _, jwsBody := signRequestKeyID(t, keyID, key, fmt.Sprintf("http://localhost/%s", path), "", wfe.nonceService)
+		return makePostRequestWithPath(path, jwsBody)
+	}
+
+	// With the feature disabled, the endpoints should not exist, and the
+	// account object should not refer to them.
+	responseWriter := httptest.NewRecorder()
+	wfe.Orders(ctx, newRequestEvent(), responseWriter, makePost(1, "1"))
+	test.AssertEquals(t, responseWriter.Code, http.StatusNotFound)
+	responseWriter = httptest.NewRecorder()
+	wfe.AccountCertificates(ctx, newRequestEvent(), responseWriter, makePost(1, "1"))
+	test.AssertEquals(t, responseWriter.Code, http.StatusNotFound)
+	responseWriter = httptest.NewRecorder()
+	wfe.Account(ctx, newRequestEvent(), responseWriter, makePost(1, "1"))
+	test.AssertNotContains(t, responseWriter.Body.String(), `"orders"`)
+
+	_ = features.Set(map[string]bool{"ServeAccountOrders": true})
+	defer features.Reset()
+
+	responseWriter = httptest.NewRecorder()
+	wfe.Account(ctx, newRequestEvent(), responseWriter, makePost(1, "1"))
+	test.AssertEquals(t, responseWriter.Code, http.StatusOK)
+	var acct core.Registration
+	err := json.Unmarshal(responseWriter.Body.Bytes(), &acct)
+	test.AssertNotError(t, err, "unmarshalling account")
+	test.AssertEquals(t, acct.Orders, "http://localhost/acme/orders/1")
+	test.AssertEquals(t, acct.Certificates, "http://localhost/acme/acct-certs/1")
+
+	testCases := []struct {
+		name         string
+		handler      func(context.Context, *web.RequestEvent, http.ResponseWriter, *http.Request)
+		keyID        int64
+		path         string
+		expectedCode int
+		expectedBody string
+		expectedLink string
+	}{
+		{
+			name:         "orders, first page",
+			handler:      wfe.Orders,
+			keyID:        1,
+			path:         "1",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"orders":["http://localhost/acme/order/1/1","http://localhost/acme/order/1/2"]}`,
+			expectedLink: `<http://localhost/acme/orders/1/2>;rel="next"`,
+		},
+		{
+			name:         "orders, last page",
+			handler:      wfe.Orders,
+			keyID:        1,
+			path:         "1/4",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"orders":["http://localhost/acme/order/1/5"]}`,
+		},
+		{
+			name:         "orders, no orders",
+			handler:      wfe.Orders,
+			keyID:        2,
+			path:         "2",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"orders":[]}`,
+		},
+		{
+			name:         "orders, another account's list",
+			handler:      wfe.Orders,
+			keyID:        2,
+			path:         "1",
+			expectedCode: http.StatusForbidden,
+		},
+		{
+			name:         "orders, malformed account ID",
+			handler:      wfe.Orders,
+			keyID:        1,
+			path:         "one",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "orders, malformed page",
+			handler:      wfe.Orders,
+			keyID:        1,
+			path:         "1/two",
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "certificates, first page",
+			handler:      wfe.AccountCertificates,
+			keyID:        1,
+			path:         "1",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"certificates":["http://localhost/acme/cert/000000000000000000000000000000000001","http://localhost/acme/cert/000000000000000000000000000000000002"]}`,
+			expectedLink: `<http://localhost/acme/acct-certs/1/2>;rel="next"`,
+		},
+		{
+			name:         "certificates, second page",
+			handler:      wfe.AccountCertificates,
+			keyID:        1,
+			path:         "1/2",
+			expectedCode: http.StatusOK,
+			expectedBody: `{"certificates":["http://localhost/acme/cert/000000000000000000000000000000000003","http://localhost/acme/cert/000000000000000000000000000000000004"]}`,
+			expectedLink: `<http://localhost/acme/acct-certs/1/4>;rel="next"`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			responseWriter := httptest.NewRecorder()
+			tc.handler(ctx, newRequestEvent(), responseWriter, makePost(tc.keyID, tc.path))
+			test.AssertEquals(t, responseWriter.Code, tc.expectedCode)
+			if tc.expectedBody != "" {
+				test.AssertUnmarshaledEquals(t, responseWriter.Body.String(), tc.expectedBody)
+			}
+			test.AssertEquals(t, responseWriter.Header().Get("Link"), tc.expectedLink)
+		})
+	}
+}
+
+// loadTestChain loads the chain in the given files in the same form as the
+// chains given to NewWebFrontEndImpl, and returns it with its issuer's ID.
+func loadTestChain(t *testing.T, files ...string) (issuance.IssuerNameID, []byte) {
+	certs, err := issuance.LoadChain(files)
+	test.AssertNotError(t, err, "Unable to load chain")
+	var buf bytes.Buffer
+	for _, cert := range certs {
+		buf.Write([]byte("\n"))
+		buf.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+	}
+	return certs[0].NameID(), buf.Bytes()
+}
+
+func TestSetCertificateChains(t *testing.T) {
+	wfe, _ := setupWFE(t)
+
+	r3ID, r3Chain := loadTestChain(t, "../test/hierarchy/int-r3.cert.pem", "../test/hierarchy/root-x1.cert.pem")
+	_, r3CrossChain := loadTestChain(t, "../test/hierarchy/int-r3-cross.cert.pem", "../test/hierarchy/root-dst.cert.pem")
+	e1ID, e1Chain := loadTestChain(t, "../test/hierarchy/int-e1.cert.pem", "../test/hierarchy/root-x2.cert.pem")
+
+	// Every issuer must keep at least one chain.
+	err := wfe.SetCertificateChains(map[issuance.IssuerNameID][][]byte{r3ID: {r3Chain}})
+	test.AssertError(t, err, "SetCertificateChains accepted chains missing an issuer")
+
+	// Chains must be for known issuers.
+	err = wfe.SetCertificateChains(map[issuance.IssuerNameID][][]byte{
+		r3ID:  {r3Chain},
+		e1ID:  {e1Chain},
+		12345: {r3Chain},
+	})
+	test.AssertError(t, err, "SetCertificateChains accepted a chain for an unknown issuer")
+
+	// Chains must begin with their issuer's certificate.
+	err = wfe.SetCertificateChains(map[issuance.IssuerNameID][][]byte{
+		r3ID: {e1Chain},
+		e1ID: {e1Chain},
+	})
+	test.AssertError(t, err, "SetCertificateChains accepted a chain for the wrong issuer")
+
+	// Chains must be well-formed.
+	err = wfe.SetCertificateChains(map[issuance.IssuerNameID][][]byte{
+		r3ID: {[]byte("not a chain")},
+		e1ID: {e1Chain},
+	})
+	test.AssertError(t, err, "SetCertificateChains accepted a malformed chain")
+
+	// The rejected chains were never served.
+	chains := wfe.certificateChains.get(r3ID)
+	test.AssertEquals(t, len(chains), 2)
+	test.AssertByteEquals(t, chains[0].pem, r3Chain)
+	test.AssertEquals(t, chains[0].rootSubject, "(TEST) Ineffable Ice X1")
+	test.AssertEquals(t, chains[1].rootSubject, "(TEST) Diaphanous Diamond Root CA X3")
+
+	// Dropping a chain and reordering the rest takes effect immediately.
+	err = wfe.SetCertificateChains(map[issuance.IssuerNameID][][]byte{
+		r3ID: {r3CrossChain},
+		e1ID: {e1Chain},
+	})
+	test.AssertNotError(t, err, "SetCertificateChains failed")
+	chains = wfe.certificateChains.get(r3ID)
+	test.AssertEquals(t, len(chains), 1)
+	test.AssertByteEquals(t, chains[0].pem, r3CrossChain)
+}